@@ -159,6 +159,8 @@ func (e *Engine) Query(
 		return nil, nil, err
 	}
 
+	// The plan is executed with the context it's analyzed with
+	ctx = e.Analyzer.QueryContext(ctx)
 	analyzed, err = e.Analyzer.Analyze(ctx, parsed, nil)
 	if err != nil {
 		return nil, nil, err
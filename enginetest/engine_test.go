@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pmezard/go-difflib/difflib"
 	"github.com/stretchr/testify/assert"
@@ -67,6 +68,10 @@ func TestWarnings(t *testing.T) {
 	})
 }
 
+func TestGenericComparisons(t *testing.T) {
+	enginetest.TestGenericComparisons(t, newDefaultMemoryHarness())
+}
+
 func TestClearWarnings(t *testing.T) {
 	enginetest.TestClearWarnings(t, newDefaultMemoryHarness())
 }
@@ -103,6 +108,24 @@ func TestDescribe(t *testing.T) {
 	})
 }
 
+func TestDescribeCoercions(t *testing.T) {
+	harness := newDefaultMemoryHarness()
+	e := enginetest.NewEngine(t, harness)
+	enginetest.TestQuery(t, harness, e,
+		"EXPLAIN FORMAT=coercions SELECT i, s = 5 FROM mytable "+
+			"WHERE i = '5' OR s = 'first row' OR i > 1.5 OR (i, s) = (1, 'x')",
+		[]sql.Row{
+			{"Project(mytable.i, mytable.s = 5 [compare type: BIGINT, branch: signed])"},
+			{" └─ Filter(" +
+				"mytable.i = \"5\" [compare type: BIGINT, branch: signed] OR " +
+				"mytable.s = \"first row\" [compare type: LONGTEXT, branch: char] OR " +
+				"mytable.i > 1.5 [compare type: DOUBLE, branch: double] OR " +
+				"(mytable.i, mytable.s) = (1, \"x\") [compare type: TUPLE(BIGINT, VARCHAR(20)), branch: tuple])"},
+			{"     └─ Table(mytable)"},
+		},
+	)
+}
+
 func TestUse(t *testing.T) {
 	enginetest.TestUse(t, newDefaultMemoryHarness())
 }
@@ -151,6 +174,154 @@ func TestLocks(t *testing.T) {
 	require.Equal(1, t2.unlocks)
 }
 
+func TestCustomCollation(t *testing.T) {
+	require := require.New(t)
+
+	// Digits sort after letters in this collation
+	collation, err := sql.RegisterCollation("utf8mb4_digits_last", sql.CharacterSet_utf8mb4, func(r rune) int {
+		if r >= '0' && r <= '9' {
+			return 1<<20 + int(r)
+		}
+		return int(r)
+	})
+	require.NoError(err)
+
+	ctx := enginetest.NewContext(newDefaultMemoryHarness()).WithCurrentDB("db")
+	table := memory.NewTable("t", sql.Schema{
+		{Name: "s", Type: sql.MustCreateString(sqltypes.VarChar, 10, collation), Source: "t"},
+	})
+	for _, s := range []string{"9", "a", "1", "9a", "b"} {
+		require.NoError(table.Insert(ctx, sql.NewRow(s)))
+	}
+
+	catalog := sql.NewCatalog()
+	db := memory.NewDatabase("db")
+	db.AddTable("t", table)
+	catalog.AddDatabase(db)
+	engine := sqle.New(catalog, analyzer.NewDefault(catalog), new(sqle.Config))
+
+	testCases := []struct {
+		query    string
+		expected []sql.Row
+	}{
+		{"SELECT s FROM t ORDER BY s", []sql.Row{{"a"}, {"b"}, {"1"}, {"9"}, {"9a"}}},
+		{"SELECT s FROM t WHERE s > '9' ORDER BY s", []sql.Row{{"9a"}}},
+		{"SELECT s FROM t WHERE s < '1' ORDER BY s", []sql.Row{{"a"}, {"b"}}},
+	}
+
+	for _, tt := range testCases {
+		_, iter, err := engine.Query(ctx, tt.query)
+		require.NoError(err)
+
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		require.Equal(tt.expected, rows, tt.query)
+	}
+}
+
+func TestNullComparisonPolicy(t *testing.T) {
+	newEngine := func(t *testing.T, ctx *sql.Context, policy sql.NullComparisonPolicy) *sqle.Engine {
+		table := memory.NewTable("t", sql.Schema{
+			{Name: "a", Type: sql.Int64, Source: "t", Nullable: true},
+			{Name: "b", Type: sql.Int64, Source: "t", Nullable: true},
+		})
+		for _, r := range []sql.Row{{int64(1), int64(1)}, {int64(2), nil}, {nil, nil}} {
+			require.NoError(t, table.Insert(ctx, r))
+		}
+
+		catalog := sql.NewCatalog()
+		db := memory.NewDatabase("db")
+		db.AddTable("t", table)
+		catalog.AddDatabase(db)
+		a := analyzer.NewBuilder(catalog).WithNullComparisonPolicy(policy).Build()
+		return sqle.New(catalog, a, new(sqle.Config))
+	}
+
+	testCases := []struct {
+		query          string
+		strict         []sql.Row
+		nullEqualsNull []sql.Row
+	}{
+		{"SELECT NULL = NULL", []sql.Row{{nil}}, []sql.Row{{true}}},
+		{"SELECT NULL = 1", []sql.Row{{nil}}, []sql.Row{{false}}},
+		{"SELECT a FROM t WHERE a = b ORDER BY a", []sql.Row{{1}}, []sql.Row{{nil}, {1}}},
+		{"SELECT a FROM t WHERE b = NULL ORDER BY a", []sql.Row{}, []sql.Row{{nil}, {2}}},
+		{"SELECT a, a = b FROM t ORDER BY a", []sql.Row{{nil, nil}, {1, true}, {2, nil}}, []sql.Row{{nil, true}, {1, true}, {2, false}}},
+		{"SELECT NULL IN (1, NULL)", []sql.Row{{nil}}, []sql.Row{{true}}},
+		{"SELECT 2 IN (1, NULL)", []sql.Row{{nil}}, []sql.Row{{false}}},
+		{"SELECT a, a IN (1, NULL) FROM t ORDER BY a", []sql.Row{{nil, nil}, {1, true}, {2, nil}}, []sql.Row{{nil, true}, {1, true}, {2, false}}},
+		{"SELECT a FROM t WHERE b IN (SELECT b FROM t) ORDER BY a", []sql.Row{{1}}, []sql.Row{{nil}, {1}, {2}}},
+		{"SELECT a, a IN (SELECT b FROM t) FROM t ORDER BY a", []sql.Row{{nil, nil}, {1, true}, {2, nil}}, []sql.Row{{nil, true}, {1, true}, {2, false}}},
+	}
+
+	for _, policy := range []sql.NullComparisonPolicy{sql.NullComparisonStrict, sql.NullEqualsNull} {
+		t.Run(string(policy), func(t *testing.T) {
+			ctx := enginetest.NewContext(newDefaultMemoryHarness()).WithCurrentDB("db")
+			engine := newEngine(t, ctx, policy)
+			for _, tt := range testCases {
+				t.Run(tt.query, func(t *testing.T) {
+					expected := tt.strict
+					if policy == sql.NullEqualsNull {
+						expected = tt.nullEqualsNull
+					}
+
+					_, iter, err := engine.Query(ctx, tt.query)
+					require.NoError(t, err)
+
+					rows, err := sql.RowIterToRows(iter)
+					require.NoError(t, err)
+					require.Equal(t, enginetest.WidenRows(expected), enginetest.WidenRows(rows), tt.query)
+				})
+			}
+
+			// A NULL value in a unique key never matches another one, whatever the policy
+			for _, query := range []string{
+				"CREATE TABLE u (pk BIGINT PRIMARY KEY, k BIGINT, UNIQUE KEY (k))",
+				"INSERT INTO u VALUES (1, NULL), (2, NULL)",
+				"INSERT INTO u VALUES (2, NULL) ON DUPLICATE KEY UPDATE k = 10",
+			} {
+				_, iter, err := engine.Query(ctx, query)
+				require.NoError(t, err)
+				_, err = sql.RowIterToRows(iter)
+				require.NoError(t, err)
+			}
+			enginetest.TestQueryWithContext(t, ctx, engine, "SELECT pk, k FROM u ORDER BY pk", []sql.Row{{1, nil}, {2, 10}})
+
+			// The policy only lasts for the queries, not for the context they're run with
+			require.Empty(t, ctx.NullComparisons)
+		})
+	}
+}
+
+func TestPredicateCache(t *testing.T) {
+	require := require.New(t)
+	ctx := enginetest.NewContext(newDefaultMemoryHarness()).WithCurrentDB("db")
+
+	table := memory.NewTable("t", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "b", Type: sql.Text, Source: "t"},
+	})
+	for _, r := range []sql.Row{{int64(1), "x"}, {int64(2), "y"}, {nil, "z"}} {
+		require.NoError(table.Insert(ctx, r))
+	}
+
+	catalog := sql.NewCatalog()
+	db := memory.NewDatabase("db")
+	db.AddTable("t", table)
+	catalog.AddDatabase(db)
+	cache := analyzer.NewPredicateCache()
+	e := sqle.New(catalog, analyzer.NewBuilder(catalog).WithPredicateCache(cache).Build(), new(sqle.Config))
+
+	for i := 0; i < 3; i++ {
+		enginetest.TestQueryWithContext(t, ctx, e, "SELECT b FROM t WHERE a > 1", []sql.Row{{"y"}})
+	}
+	require.Equal(1, cache.Compilations())
+
+	enginetest.TestQueryWithContext(t, ctx, e, "ALTER TABLE t ADD COLUMN c INT", []sql.Row{})
+	enginetest.TestQueryWithContext(t, ctx, e, "SELECT b FROM t WHERE a > 1", []sql.Row{{"y"}})
+	require.Equal(2, cache.Compilations())
+}
+
 type mockSpan struct {
 	opentracing.Span
 	finished bool
@@ -160,6 +331,117 @@ func (m *mockSpan) Finish() {
 	m.finished = true
 }
 
+func TestColumnComparator(t *testing.T) {
+	require := require.New(t)
+
+	// Values of this column sort in reverse order
+	reversed := sql.WithComparator(sql.Int64, func(a, b interface{}) (int, error) {
+		return sql.Int64.Compare(b, a)
+	})
+
+	ctx := enginetest.NewContext(newDefaultMemoryHarness()).WithCurrentDB("db")
+	table := memory.NewTable("t", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "t"},
+		{Name: "r", Type: reversed, Source: "t", Nullable: true},
+	})
+	for i := int64(1); i <= 4; i++ {
+		require.NoError(table.Insert(ctx, sql.NewRow(i, i)))
+	}
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(5), nil)))
+
+	catalog := sql.NewCatalog()
+	db := memory.NewDatabase("db")
+	db.AddTable("t", table)
+	catalog.AddDatabase(db)
+	engine := sqle.New(catalog, analyzer.NewDefault(catalog), new(sqle.Config))
+
+	testCases := []struct {
+		query    string
+		expected []sql.Row
+	}{
+		{"SELECT r FROM t ORDER BY r", []sql.Row{{nil}, {int64(4)}, {int64(3)}, {int64(2)}, {int64(1)}}},
+		{"SELECT r FROM t ORDER BY r DESC", []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}, {int64(4)}, {nil}}},
+		{"SELECT r FROM t WHERE r > 2 ORDER BY i", []sql.Row{{int64(1)}}},
+		{"SELECT r FROM t WHERE 2 > r ORDER BY i", []sql.Row{{int64(3)}, {int64(4)}}},
+		{"SELECT r FROM t WHERE r <= '3' ORDER BY i", []sql.Row{{int64(3)}, {int64(4)}}},
+		{"SELECT r FROM t WHERE r = 2", []sql.Row{{int64(2)}}},
+		{"SELECT i FROM t WHERE i > 2 ORDER BY i", []sql.Row{{int64(3)}, {int64(4)}, {int64(5)}}},
+	}
+
+	for _, tt := range testCases {
+		_, iter, err := engine.Query(ctx, tt.query)
+		require.NoError(err)
+
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		require.Equal(tt.expected, rows, tt.query)
+	}
+}
+
+func TestPartialColumnComparator(t *testing.T) {
+	require := require.New(t)
+
+	// Values of this column are ranges like "1-2", one less than another if it ends before the other starts, and
+	// incomparable if they overlap without being equal
+	ranges := sql.WithPartialComparator(sql.LongText, func(a, b interface{}) (int, bool, error) {
+		var aLo, aHi, bLo, bHi int
+		if _, err := fmt.Sscanf(a.(string), "%d-%d", &aLo, &aHi); err != nil {
+			return 0, false, err
+		}
+		if _, err := fmt.Sscanf(b.(string), "%d-%d", &bLo, &bHi); err != nil {
+			return 0, false, err
+		}
+
+		switch {
+		case aLo == bLo && aHi == bHi:
+			return 0, true, nil
+		case aHi < bLo:
+			return -1, true, nil
+		case aLo > bHi:
+			return 1, true, nil
+		default:
+			return 0, false, nil
+		}
+	})
+
+	ctx := enginetest.NewContext(newDefaultMemoryHarness()).WithCurrentDB("db")
+	table := memory.NewTable("t", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "t"},
+		{Name: "r", Type: ranges, Source: "t"},
+	})
+	for i, r := range []string{"1-2", "3-4", "2-3", "5-6"} {
+		require.NoError(table.Insert(ctx, sql.NewRow(int64(i+1), r)))
+	}
+
+	catalog := sql.NewCatalog()
+	db := memory.NewDatabase("db")
+	db.AddTable("t", table)
+	catalog.AddDatabase(db)
+	engine := sqle.New(catalog, analyzer.NewDefault(catalog), new(sqle.Config))
+
+	testCases := []struct {
+		query    string
+		expected []sql.Row
+	}{
+		{"SELECT i, r > '2-3' FROM t ORDER BY i", []sql.Row{{int64(1), nil}, {int64(2), nil}, {int64(3), false}, {int64(4), true}}},
+		{"SELECT i, r <= '2-3' FROM t ORDER BY i", []sql.Row{{int64(1), nil}, {int64(2), nil}, {int64(3), true}, {int64(4), false}}},
+		{"SELECT i, r = '2-3' FROM t ORDER BY i", []sql.Row{{int64(1), false}, {int64(2), false}, {int64(3), true}, {int64(4), false}}},
+		{"SELECT i FROM t WHERE r < '5-6' ORDER BY i", []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}}},
+		{"SELECT i FROM t WHERE NOT (r > '2-3') ORDER BY i", []sql.Row{{int64(3)}}},
+		{"SELECT i FROM t WHERE r IN ('2-3', '9-9')", []sql.Row{{int64(3)}}},
+		{"SELECT i, r BETWEEN '2-3' AND '5-6' FROM t ORDER BY i", []sql.Row{{int64(1), nil}, {int64(2), nil}, {int64(3), true}, {int64(4), true}}},
+	}
+
+	for _, tt := range testCases {
+		_, iter, err := engine.Query(ctx, tt.query)
+		require.NoError(err)
+
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		require.Equal(tt.expected, rows, tt.query)
+	}
+}
+
 func TestRootSpanFinish(t *testing.T) {
 	harness := newDefaultMemoryHarness()
 	e := enginetest.NewEngine(t, harness)
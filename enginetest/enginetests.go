@@ -47,6 +47,33 @@ func TestQueries(t *testing.T, harness Harness) {
 	}
 }
 
+// TestGenericComparisons runs the query tests with and without the specialization of comparisons, and checks that
+// both give the same results.
+func TestGenericComparisons(t *testing.T, harness Harness) {
+	engine := NewEngine(t, harness)
+	createIndexes(t, harness, engine)
+	createForeignKeys(t, harness, engine)
+
+	for _, tt := range QueryTests {
+		t.Run(tt.Query, func(t *testing.T) {
+			ctx := NewContextWithEngine(harness, engine)
+			_, iter, err := engine.Query(ctx, tt.Query)
+			require.NoError(t, err)
+			specialized, err := sql.RowIterToRows(iter)
+			require.NoError(t, err)
+
+			ctx = NewContextWithEngine(harness, engine)
+			ctx.ApplyOpts(sql.WithGenericComparisons())
+			_, iter, err = engine.Query(ctx, tt.Query)
+			require.NoError(t, err)
+			generic, err := sql.RowIterToRows(iter)
+			require.NoError(t, err)
+
+			require.ElementsMatch(t, specialized, generic)
+		})
+	}
+}
+
 // Runs the query tests given after setting up the engine. Useful for testing out a smaller subset of queries during
 // debugging.
 func RunQueryTests(t *testing.T, harness Harness, queries []QueryTest) {
@@ -260,6 +260,50 @@ var QueryTests = []QueryTest{
 		"SELECT i FROM mytable WHERE i <> 2;",
 		[]sql.Row{{int64(1)}, {int64(3)}},
 	},
+	{
+		"SELECT ROW(1, 2) = ROW(1, 2), ROW(1, 2) <> ROW(1, 2), ROW(1, 2) < ROW(1, 3), ROW(1, 2) > ROW(1, 3), ROW(1, 2) <= ROW(1, 2), ROW(1, 2) >= ROW(2, 1)",
+		[]sql.Row{{true, false, true, false, true, false}},
+	},
+	{
+		"SELECT i FROM mytable WHERE ROW(i, s) > ROW(1, 'first row') ORDER BY i",
+		[]sql.Row{{int64(2)}, {int64(3)}},
+	},
+	{
+		"SELECT i, s FROM mytable WHERE (i, s) > (1, 'first row') ORDER BY i, s LIMIT 1",
+		[]sql.Row{{int64(2), "second row"}},
+	},
+	{
+		"SELECT i FROM mytable WHERE (s, i) <= ('SECOND ROW', 2) ORDER BY i",
+		[]sql.Row{{int64(1)}, {int64(2)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE ROW(i, s) = (2, 'second row')",
+		[]sql.Row{{int64(2)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE EXISTS (SELECT 1) ORDER BY i",
+		[]sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE EXISTS (SELECT 1 FROM dual WHERE 1 = 0)",
+		[]sql.Row{},
+	},
+	{
+		"SELECT i FROM mytable WHERE NOT EXISTS (SELECT 1 FROM dual WHERE 1 = 0) ORDER BY i",
+		[]sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
+	{
+		"SELECT EXISTS (SELECT * FROM othertable WHERE i2 > 2), EXISTS (SELECT * FROM othertable WHERE i2 > 3)",
+		[]sql.Row{{true, false}},
+	},
+	{
+		"SELECT i FROM mytable WHERE EXISTS (SELECT i2 FROM othertable WHERE i2 = i + 1) ORDER BY i",
+		[]sql.Row{{int64(1)}, {int64(2)}},
+	},
+	{
+		"SELECT EXISTS (SELECT i FROM emptytable)",
+		[]sql.Row{{false}},
+	},
 	{
 		"SELECT NULL IN (SELECT i FROM emptytable)",
 		[]sql.Row{{false}},
@@ -276,6 +320,22 @@ var QueryTests = []QueryTest{
 		"SELECT NULL NOT IN (SELECT i FROM mytable)",
 		[]sql.Row{{nil}},
 	},
+	{
+		"SELECT NULL <=> NULL, 1 <=> NULL, NULL <=> 1, 1 <=> 1, (1, NULL) <=> (1, NULL)",
+		[]sql.Row{{true, false, false, true, true}},
+	},
+	{
+		"SELECT i FROM niltable WHERE i2 <=> NULL ORDER BY i",
+		[]sql.Row{{int64(1)}, {int64(3)}, {int64(5)}},
+	},
+	{
+		"SELECT i FROM niltable WHERE NOT(i2 <=> 2) ORDER BY i",
+		[]sql.Row{{int64(1)}, {int64(3)}, {int64(4)}, {int64(5)}, {int64(6)}},
+	},
+	{
+		"SELECT a.i, b.i FROM niltable a JOIN niltable b ON a.b <=> b.b WHERE a.i < b.i ORDER BY 1, 2",
+		[]sql.Row{{int64(1), int64(4)}, {int64(2), int64(5)}, {int64(3), int64(6)}},
+	},
 	{
 		"SELECT NULL IN (SELECT i2 FROM niltable)",
 		[]sql.Row{{nil}},
@@ -380,6 +440,38 @@ var QueryTests = []QueryTest{
 		"SELECT i FROM mytable WHERE i = 1 OR i = 3",
 		[]sql.Row{{int64(1)}, {int64(3)}},
 	},
+	{
+		"SELECT i FROM mytable WHERE i IN (1, 2, 3) AND i IN ('2', 3.0, 4) ORDER BY i",
+		[]sql.Row{{int64(2)}, {int64(3)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE i IN (1, NULL) AND i IN (2, 3)",
+		nil,
+	},
+	{
+		"SELECT i FROM mytable WHERE i IN (1) OR i IN (3, 1) ORDER BY i",
+		[]sql.Row{{int64(1)}, {int64(3)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE NOT (i IN (1, 2) OR i IN (2, 4)) ORDER BY i",
+		[]sql.Row{{int64(3)}},
+	},
+	{
+		"SELECT i FROM tabletest WHERE i + 1 > 2 ORDER BY i",
+		[]sql.Row{{int32(2)}, {int32(3)}},
+	},
+	{
+		"SELECT i FROM tabletest WHERE 4 <= i * 2 AND i / 2 < 2 ORDER BY i",
+		[]sql.Row{{int32(2)}, {int32(3)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE i + 1 > 2 ORDER BY i",
+		[]sql.Row{{int64(2)}, {int64(3)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE i IN (1, 2) AND i IN (SELECT i FROM mytable WHERE i > 1)",
+		[]sql.Row{{int64(2)}},
+	},
 	{
 		"SELECT * FROM mytable WHERE i = 1 AND i = 2",
 		nil,
@@ -476,6 +568,22 @@ var QueryTests = []QueryTest{
 		"SELECT * FROM mytable WHERE i = 2 AND s = 'third row'",
 		nil,
 	},
+	{
+		"SELECT i FROM mytable WHERE s = 'FIRST ROW' ORDER BY i;",
+		[]sql.Row{{int64(1)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE BINARY s = 'FIRST ROW' ORDER BY i;",
+		nil,
+	},
+	{
+		"SELECT i FROM mytable WHERE s = BINARY 'FIRST ROW' ORDER BY i;",
+		nil,
+	},
+	{
+		"SELECT i FROM mytable WHERE BINARY s = 'first row' ORDER BY i;",
+		[]sql.Row{{int64(1)}},
+	},
 	{
 		"SELECT i FROM mytable WHERE s = 'first row' ORDER BY i DESC LIMIT 1;",
 		[]sql.Row{{int64(1)}},
@@ -658,6 +766,30 @@ var QueryTests = []QueryTest{
 		"SELECT i FROM mytable WHERE i NOT BETWEEN 1 AND 2",
 		[]sql.Row{{int64(3)}},
 	},
+	{
+		"SELECT i FROM mytable WHERE i BETWEEN 2 AND 1",
+		[]sql.Row{},
+	},
+	{
+		"SELECT i FROM mytable WHERE i NOT BETWEEN 2 AND 1",
+		[]sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE i BETWEEN 2 AND 2",
+		[]sql.Row{{int64(2)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE i >= 2 AND i <= 2.0",
+		[]sql.Row{{int64(2)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE i < 2 OR i > 2 ORDER BY 1",
+		[]sql.Row{{int64(1)}, {int64(3)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE i BETWEEN 0 AND 1 OR i BETWEEN 1 AND 2 ORDER BY 1",
+		[]sql.Row{{int64(1)}, {int64(2)}},
+	},
 	{
 		"SELECT id FROM typestable WHERE ti > '2019-12-31'",
 		[]sql.Row{{int64(1)}},
@@ -1292,6 +1424,10 @@ var QueryTests = []QueryTest{
 	},
 	{
 		"SELECT i FROM mytable UNION SELECT i FROM mytable;",
+		[]sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
+	{
+		"SELECT i FROM mytable UNION ALL SELECT i FROM mytable;",
 		[]sql.Row{{int64(1)}, {int64(2)}, {int64(3)}, {int64(1)}, {int64(2)}, {int64(3)}},
 	},
 	{
@@ -1348,6 +1484,24 @@ var QueryTests = []QueryTest{
 			{"first row"},
 		},
 	},
+	{
+		`SELECT s FROM mytable WHERE s LIKE 'FIRST%'`,
+		[]sql.Row{
+			{"first row"},
+		},
+	},
+	{
+		`SELECT s FROM mytable WHERE s LIKE BINARY 'FIRST%'`,
+		[]sql.Row{},
+	},
+	{
+		`SELECT 'a%c' LIKE 'a|%c' ESCAPE '|', 'abc' LIKE 'a|%c' ESCAPE '|', 'a|c' LIKE 'a||c' ESCAPE '|', 'a_c' NOT LIKE 'a|_c' ESCAPE '|'`,
+		[]sql.Row{{true, false, true, false}},
+	},
+	{
+		`SELECT s FROM mytable WHERE s LIKE '%d!_row' ESCAPE '!'`,
+		[]sql.Row{},
+	},
 	{
 		`SELECT * FROM foo.other_table`,
 		[]sql.Row{
@@ -1394,6 +1548,10 @@ var QueryTests = []QueryTest{
 			{"character_set_connection", sql.Collation_Default.CharacterSet().String()},
 			{"character_set_results", sql.Collation_Default.CharacterSet().String()},
 			{"collation_connection", sql.Collation_Default.String()},
+			{"in_list_hash_threshold", int64(sql.DefaultInListHashThreshold)},
+			{"empty_string_is_null", int8(0)},
+			{"transform_null_equals", int8(0)},
+			{"last_insert_id", uint64(0)},
 		},
 	},
 	{
@@ -1496,6 +1654,35 @@ var QueryTests = []QueryTest{
 			{nil},
 		},
 	},
+	{
+		"SELECT i FROM niltable WHERE i2 IN (SELECT i2 FROM niltable) ORDER BY i",
+		[]sql.Row{
+			{2},
+			{4},
+			{6},
+		},
+	},
+	{
+		"SELECT i FROM niltable WHERE i2 IN (SELECT i2 FROM niltable WHERE i > 2) ORDER BY i",
+		[]sql.Row{
+			{4},
+			{6},
+		},
+	},
+	{
+		"SELECT i, `interval`(i, 1, 2, 3) FROM mytable ORDER BY i",
+		[]sql.Row{
+			{1, 1},
+			{2, 2},
+			{3, 3},
+		},
+	},
+	{
+		"SELECT `interval`(NULL, 1, 2)",
+		[]sql.Row{
+			{-1},
+		},
+	},
 	{
 		`SELECT nullif('abc', NULL)`,
 		[]sql.Row{
@@ -1610,6 +1797,51 @@ var QueryTests = []QueryTest{
 		"SELECT i FROM mytable WHERE NOT NULL <> NULL;",
 		nil,
 	},
+	{
+		"SELECT i FROM niltable WHERE NOT (i2 = 2 AND i < 5) ORDER BY i",
+		[]sql.Row{
+			{int64(4)},
+			{int64(5)},
+			{int64(6)},
+		},
+	},
+	{
+		"SELECT i FROM niltable WHERE NOT (i2 > 3 OR i > 4) ORDER BY i",
+		[]sql.Row{
+			{int64(2)},
+		},
+	},
+	{
+		"SELECT i, s FROM mytable WHERE i = 2 OR i = 3 ORDER BY i DESC, s",
+		[]sql.Row{
+			{int64(3), "third row"},
+			{int64(2), "second row"},
+		},
+	},
+	{
+		"SELECT i2, i FROM niltable WHERE i2 = 4 AND i > 1 ORDER BY i2, i DESC",
+		[]sql.Row{
+			{int64(4), int64(4)},
+		},
+	},
+	{
+		"SELECT i FROM mytable WHERE i <> 1 AND i <> 3",
+		[]sql.Row{
+			{int64(2)},
+		},
+	},
+	{
+		"SELECT i FROM niltable WHERE i2 <> 2 AND i > 1 AND i2 <> 4 ORDER BY i",
+		[]sql.Row{
+			{int64(6)},
+		},
+	},
+	{
+		"SELECT i FROM mytable WHERE NOT (i < 2 OR i > 2)",
+		[]sql.Row{
+			{int64(2)},
+		},
+	},
 	{
 		`SELECT round(15728640/1024/1024)`,
 		[]sql.Row{
@@ -1622,6 +1854,27 @@ var QueryTests = []QueryTest{
 			{int8(15)},
 		},
 	},
+	{
+		`SELECT SIGN(-0.4), SIGN(0), SIGN(0.4), SIGN(NULL)`,
+		[]sql.Row{
+			{int8(-1), int8(0), int8(1), nil},
+		},
+	},
+	{
+		`SELECT SIGN(i - 2), SIGN(i - 2) < 0, SIGN(i - 2) = 1.0 FROM mytable ORDER BY i`,
+		[]sql.Row{
+			{int8(-1), true, false},
+			{int8(0), false, false},
+			{int8(1), false, true},
+		},
+	},
+	{
+		`SELECT i FROM mytable WHERE SIGN(i - 1.5) = '1' ORDER BY i`,
+		[]sql.Row{
+			{int64(2)},
+			{int64(3)},
+		},
+	},
 	{
 		`SELECT CASE i WHEN 1 THEN 'one' WHEN 2 THEN 'two' ELSE 'other' END FROM mytable`,
 		[]sql.Row{
@@ -1897,6 +2150,14 @@ var QueryTests = []QueryTest{
 		`SELECT t.date_col FROM (SELECT CONVERT('2019-06-06 00:00:00', DATETIME) AS date_col) t WHERE t.date_col > '0000-01-01 00:00:00'`,
 		[]sql.Row{{time.Date(2019, time.June, 6, 0, 0, 0, 0, time.UTC)}},
 	},
+	{
+		`SELECT doc->'$.n' = 5, doc->'$.n' = '5', doc->>'$.n' = '5' FROM (SELECT '{"n": 5}' AS doc) t`,
+		[]sql.Row{{true, false, true}},
+	},
+	{
+		`SELECT doc->'$.s' = 5, doc->'$.s' = '5', doc->>'$.s' = 5 FROM (SELECT '{"s": "5"}' AS doc) t`,
+		[]sql.Row{{false, true, true}},
+	},
 	{
 		`SELECT t.date_col FROM (SELECT CONVERT('2019-06-06 00:00:00', DATETIME) as date_col) t GROUP BY t.date_col`,
 		[]sql.Row{{time.Date(2019, time.June, 6, 0, 0, 0, 0, time.UTC)}},
@@ -1973,6 +2234,20 @@ var QueryTests = []QueryTest{
 			{int64(3), nil, nil},
 		},
 	},
+	{
+		"SELECT i, i2, s2 FROM mytable LEFT JOIN othertable ON i = i2 - 1 WHERE s2 <> 'first'",
+		[]sql.Row{
+			{int64(1), int64(2), "second"},
+		},
+	},
+	{
+		"SELECT i, i2, s2 FROM mytable LEFT JOIN othertable ON i = i2 - 1 AND s2 <> 'first'",
+		[]sql.Row{
+			{int64(1), int64(2), "second"},
+			{int64(2), nil, nil},
+			{int64(3), nil, nil},
+		},
+	},
 	{
 		"SELECT i, i2, s2 FROM mytable RIGHT JOIN othertable ON i = i2 - 1",
 		[]sql.Row{
@@ -2069,6 +2344,34 @@ var QueryTests = []QueryTest{
 		`SELECT avg(i) FROM mytable GROUP BY i HAVING avg(i) > 1`,
 		[]sql.Row{{float64(2)}, {float64(3)}},
 	},
+	{
+		`SELECT i FROM mytable WHERE CASE WHEN i > 1 THEN 1 ELSE 0 END = 1 ORDER BY i`,
+		[]sql.Row{{int64(2)}, {int64(3)}},
+	},
+	{
+		`SELECT i FROM niltable WHERE NOT (CASE WHEN i2 > 3 THEN 1 ELSE 0 END = 1) ORDER BY i`,
+		[]sql.Row{{int64(1)}, {int64(2)}, {int64(3)}, {int64(5)}},
+	},
+	{
+		`SELECT SUM(i) FROM mytable HAVING SUM(i) > 5.5`,
+		[]sql.Row{{float64(6)}},
+	},
+	{
+		`SELECT SUM(i) FROM mytable HAVING SUM(i) > 6.5`,
+		[]sql.Row{},
+	},
+	{
+		`SELECT s, SUM(i) FROM mytable GROUP BY s HAVING SUM(i) > 2.5`,
+		[]sql.Row{{"third row", float64(3)}},
+	},
+	{
+		`SELECT AVG(i) FROM mytable HAVING AVG(i) > 1.5`,
+		[]sql.Row{{float64(2)}},
+	},
+	{
+		`SELECT AVG(i) FROM mytable HAVING AVG(i) > 2`,
+		[]sql.Row{},
+	},
 	{
 		`SELECT s AS s, COUNT(*) AS count,  AVG(i) AS ` + "`AVG(i)`" + `
 		FROM  (
@@ -3511,6 +3814,18 @@ type QueryErrorTest struct {
 }
 
 var errorQueries = []QueryErrorTest{
+	{
+		Query:       "SELECT ROW(1, 2) = ROW(1, 2, 3)",
+		ExpectedErr: expression.ErrInvalidOperandColumns,
+	},
+	{
+		Query:       "SELECT i FROM mytable WHERE ROW(i, s) < i",
+		ExpectedErr: expression.ErrInvalidOperandColumns,
+	},
+	{
+		Query:       "SELECT 'a' LIKE 'a' ESCAPE '||'",
+		ExpectedErr: expression.ErrInvalidLikeEscape,
+	},
 	{
 		Query:       "select foo.i from mytable as a",
 		ExpectedErr: sql.ErrTableNotFound,
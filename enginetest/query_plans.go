@@ -528,15 +528,89 @@ var PlanTests = []QueryPlanTest{
 	},
 	{
 		Query: "SELECT pk,pk2 FROM one_pk t1, two_pk t2 WHERE pk=1 AND pk2=1 ORDER BY 1,2",
-		ExpectedPlan: "Sort(t1.pk ASC, t2.pk2 ASC)\n" +
-			" └─ Project(t1.pk, t2.pk2)\n" +
-			"     └─ CrossJoin\n" +
-			"         ├─ TableAlias(t1)\n" +
-			"         │   └─ Indexed table access on index [one_pk.pk]\n" +
-			"         │       └─ Table(one_pk)\n" +
-			"         └─ Filter(t2.pk2 = 1)\n" +
-			"             └─ TableAlias(t2)\n" +
-			"                 └─ Table(two_pk)\n" +
+		ExpectedPlan: "Project(t1.pk, t2.pk2)\n" +
+			" └─ CrossJoin\n" +
+			"     ├─ TableAlias(t1)\n" +
+			"     │   └─ Index-only table access on index [one_pk.pk]\n" +
+			"     │       └─ Table(one_pk)\n" +
+			"     └─ Filter(t2.pk2 = 1)\n" +
+			"         └─ TableAlias(t2)\n" +
+			"             └─ Table(two_pk)\n" +
+			"",
+	},
+	{
+		Query: "SELECT pk FROM one_pk WHERE pk > 1",
+		ExpectedPlan: "Project(one_pk.pk)\n" +
+			" └─ Index-only table access on index [one_pk.pk]\n" +
+			"     └─ Table(one_pk)\n" +
+			"",
+	},
+	{
+		Query: "SELECT pk, c1 FROM one_pk WHERE pk > 1",
+		ExpectedPlan: "Project(one_pk.pk, one_pk.c1)\n" +
+			" └─ Indexed table access on index [one_pk.pk]\n" +
+			"     └─ Table(one_pk)\n" +
+			"",
+	},
+	{
+		Query: "SELECT i, i2 FROM niltable WHERE i2 = 4 ORDER BY i2, i",
+		ExpectedPlan: "Sort(niltable.i ASC)\n" +
+			" └─ Project(niltable.i, niltable.i2)\n" +
+			"     └─ Filter(niltable.i2 = 4)\n" +
+			"         └─ Table(niltable)\n" +
+			"",
+	},
+	{
+		Query: "SELECT i FROM mytable WHERE NOT EXISTS (SELECT 1 FROM othertable WHERE i2 = i)",
+		ExpectedPlan: "Project(mytable.i)\n" +
+			" └─ AntiJoin(othertable.i2 = mytable.i)\n" +
+			"     ├─ Table(mytable)\n" +
+			"     └─ Table(othertable)\n" +
+			"",
+	},
+	{
+		Query: "SELECT i FROM mytable WHERE NOT EXISTS (SELECT * FROM othertable o WHERE o.i2 = mytable.i AND o.s2 <> 'x') AND i > 1",
+		ExpectedPlan: "Project(mytable.i)\n" +
+			" └─ AntiJoin(o.i2 = mytable.i)\n" +
+			"     ├─ Filter(mytable.i > 1)\n" +
+			"     │   └─ Table(mytable)\n" +
+			"     └─ Filter(NOT(o.s2 = \"x\"))\n" +
+			"         └─ TableAlias(o)\n" +
+			"             └─ Table(othertable)\n" +
+			"",
+	},
+	{
+		Query: "SELECT i FROM mytable WHERE NOT EXISTS (SELECT 1 FROM othertable WHERE i2 = i LIMIT 1)",
+		ExpectedPlan: "Project(mytable.i)\n" +
+			" └─ Filter(NOT(EXISTS (Limit(1)\n" +
+			"     └─ Project(1)\n" +
+			"         └─ Filter(othertable.i2 = mytable.i)\n" +
+			"             └─ Table(othertable)\n" +
+			"    )))\n" +
+			"     └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: "SELECT i FROM mytable WHERE i NOT IN (SELECT i2 FROM othertable WHERE 1 = 0)",
+		ExpectedPlan: "Project(mytable.i)\n" +
+			" └─ Table(mytable)\n" +
+			"",
+	},
+	{
+		Query: "SELECT i FROM niltable WHERE i2 IN (SELECT i2 FROM niltable)",
+		ExpectedPlan: "Project(niltable.i)\n" +
+			" └─ Filter(NOT(niltable.i2 IS NULL))\n" +
+			"     └─ Table(niltable)\n" +
+			"",
+	},
+	{
+		Query: "SELECT i FROM niltable WHERE i2 IN (SELECT i2 FROM niltable WHERE i > 2)",
+		ExpectedPlan: "Project(niltable.i)\n" +
+			" └─ Filter(niltable.i2 IN (Project(niltable.i2)\n" +
+			"     └─ Filter(niltable.i > 2)\n" +
+			"         └─ Table(niltable)\n" +
+			"    ))\n" +
+			"     └─ Table(niltable)\n" +
 			"",
 	},
 }
@@ -108,4 +108,753 @@ var ScriptTests = []ScriptTest{
 			{7},
 		},
 	},
+	{
+		Name: "unique keys are matched with their collation",
+		SetUpScript: []string{
+			"CREATE TABLE uk (pk VARCHAR(10) PRIMARY KEY, u VARCHAR(10), n INT)",
+			"CREATE UNIQUE INDEX idx_uk_u ON uk (u)",
+			"INSERT INTO uk VALUES ('a', 'x', 1), ('b', NULL, 2), ('c', NULL, 3)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:       "INSERT INTO uk VALUES ('A', 'y', 4)",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:       "INSERT INTO uk VALUES ('d', 'X', 4)",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:    "INSERT INTO uk VALUES ('d', NULL, 4)",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "INSERT INTO uk VALUES ('A', 'y', 5) ON DUPLICATE KEY UPDATE n = 10",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:    "INSERT INTO uk VALUES ('e', 'X', 6) ON DUPLICATE KEY UPDATE n = n + 1",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query:       "UPDATE uk SET u = 'X' WHERE pk = 'b'",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:    "SELECT * FROM uk ORDER BY pk",
+				Expected: []sql.Row{{"a", "x", int32(11)}, {"b", nil, int32(2)}, {"c", nil, int32(3)}, {"d", nil, int32(4)}},
+			},
+		},
+	},
+	{
+		Name: "IN lists looked up in a hash set",
+		SetUpScript: []string{
+			"CREATE TABLE t (i bigint primary key, s text)",
+			"INSERT INTO t VALUES (1, 'first row'), (2, 'second row'), (3, 'third row')",
+			"SET in_list_hash_threshold = 2",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT @@in_list_hash_threshold",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT i FROM t WHERE i IN (1, '3', 5) ORDER BY i",
+				Expected: []sql.Row{{int64(1)}, {int64(3)}},
+			},
+			{
+				Query:    "SELECT i FROM t WHERE s IN ('first row', 'third row', 'fourth row') ORDER BY i",
+				Expected: []sql.Row{{int64(1)}, {int64(3)}},
+			},
+			{
+				Query:    "SELECT i FROM t WHERE i NOT IN (1, 5, NULL)",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT i FROM t WHERE i NOT IN (1, 5, 6) ORDER BY i",
+				Expected: []sql.Row{{int64(2)}, {int64(3)}},
+			},
+		},
+	},
+	{
+		Name: "TIMESTAMP compared with strings in the session time zone",
+		SetUpScript: []string{
+			"CREATE TABLE ts (pk bigint primary key, t timestamp)",
+			"INSERT INTO ts VALUES (1, '2020-06-01 12:00:00'), (2, '2020-06-01 13:00:00')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM ts WHERE t = '2020-06-01 12:00:00'",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query:    "SET time_zone = '+02:00'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT pk FROM ts WHERE t = '2020-06-01 12:00:00'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM ts WHERE t = '2020-06-01 14:00:00'",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query:    "SELECT pk FROM ts WHERE t > '2020-06-01 14:30:00'",
+				Expected: []sql.Row{{int64(2)}},
+			},
+		},
+	},
+	{
+		Name: "updates and deletes of rows with BLOB and JSON values",
+		SetUpScript: []string{
+			"CREATE TABLE bj (i int primary key, j json, b blob)",
+			`INSERT INTO bj VALUES (1, '{"a": 1}', 'abc'), (2, '[1, 2]', 'def')`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "UPDATE bj SET i = 10 WHERE i = 1",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "DELETE FROM bj WHERE i = 2",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT i, b FROM bj",
+				Expected: []sql.Row{{int32(10), "abc"}},
+			},
+		},
+	},
+	{
+		Name: "warnings for strings truncated to compare them with numbers",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk bigint primary key, i int)",
+			"INSERT INTO t VALUES (1, 12), (2, 0), (3, 5)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM t WHERE i = '12abc'",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query: "SHOW WARNINGS",
+				Expected: []sql.Row{
+					{"Warning", 1292, "Truncated incorrect INTEGER value: '12abc'"},
+					{"Warning", 1292, "Truncated incorrect INTEGER value: '12abc'"},
+					{"Warning", 1292, "Truncated incorrect INTEGER value: '12abc'"},
+				},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE i <> '12.0' ORDER BY pk",
+				Expected: []sql.Row{{int64(2)}, {int64(3)}},
+			},
+		},
+	},
+	{
+		Name: "warnings for nonbinary strings compared with binary strings",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk bigint primary key, b blob)",
+			"INSERT INTO t VALUES (1, 'a'), (2, 'b')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM t WHERE b = 'b'",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				Query: "SHOW WARNINGS",
+				Expected: []sql.Row{
+					{"Warning", 1105, "Comparing a nonbinary string with a binary string as binary strings"},
+					{"Warning", 1105, "Comparing a nonbinary string with a binary string as binary strings"},
+				},
+			},
+		},
+	},
+	{
+		Name: "empty strings taken as NULL in comparisons",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk bigint primary key, s text)",
+			"INSERT INTO t VALUES (1, ''), (2, 'a'), (3, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT '' = ''",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s = ''",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query:    "SET empty_string_is_null = 1",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT '' = ''",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s = ''",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s IS NULL ORDER BY pk",
+				Expected: []sql.Row{{int64(1)}, {int64(3)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s IS NOT NULL",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				// The session is shared with the scripts that follow
+				Query:    "SET empty_string_is_null = 0",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "COUNT(DISTINCT) compares values like comparisons do",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk bigint primary key, s varchar(20) COLLATE utf8mb4_general_ci, b varchar(20) COLLATE utf8mb4_bin, d decimal(10, 3))",
+			"INSERT INTO t VALUES (1, 'foo', 'foo', 1), (2, 'FOO', 'FOO', 1.0), (3, 'foo  ', 'bar', 1.000), (4, 'bar', 'bar', 2.5), (5, NULL, NULL, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT COUNT(DISTINCT s), COUNT(DISTINCT b), COUNT(DISTINCT d) FROM t",
+				Expected: []sql.Row{{int64(2), int64(3), int64(2)}},
+			},
+			{
+				Query:    "SELECT COUNT(DISTINCT s, d), COUNT(DISTINCT b, d) FROM t",
+				Expected: []sql.Row{{int64(2), int64(4)}},
+			},
+		},
+	},
+	{
+		Name: "BINARY(n) values are compared with their 0x00 padding",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk bigint primary key, b binary(3), v varbinary(3))",
+			"INSERT INTO t VALUES (1, 'a', 'a'), (2, 'a ', 'a '), (3, 'ab', 'ab')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT pk, HEX(b), b = 'a', b = 'a\\0\\0', b = 'a ', b = v FROM t ORDER BY pk",
+				Expected: []sql.Row{
+					{int64(1), "610000", false, true, false, false},
+					{int64(2), "612000", false, false, false, false},
+					{int64(3), "616200", false, false, false, false},
+				},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE b = 'a\\0\\0'",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE b = 'a'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE b IN ('a', 'a \\0')",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE b > 'a' AND b < 'a\\0a' ORDER BY pk",
+				Expected: []sql.Row{{int64(1)}},
+			},
+		},
+	},
+	{
+		Name: "strings of other character sets are converted to compare them",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk bigint primary key, l varchar(20) CHARACTER SET latin1, g varchar(20) CHARACTER SET latin1 COLLATE latin1_german1_ci, u varchar(20))",
+			"INSERT INTO t VALUES (1, 'café', 'café', 'CAFÉ'), (2, 'tea', 'tea', 'coffee')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM t WHERE l = 'CAFÉ'",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE l = u",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query:       "SELECT pk FROM t WHERE l = 'caf☕'",
+				ExpectedErr: sql.ErrCollationIllegalMix,
+			},
+			{
+				Query:       "SELECT pk FROM t WHERE l = g",
+				ExpectedErr: sql.ErrCollationIllegalMix,
+			},
+		},
+	},
+	{
+		Name: "SET values are compared with numbers and strings by their bitmask",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk bigint primary key, s SET('a','b','c'))",
+			"INSERT INTO t VALUES (1, 'a'), (2, 'b,a'), (3, 'c'), (4, ''), (5, 'a,b,c')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "SELECT pk, s = 3, s = 'b,a', s > 2 FROM t ORDER BY pk",
+				Expected: []sql.Row{
+					{int64(1), false, false, false},
+					{int64(2), true, true, true},
+					{int64(3), false, false, true},
+					{int64(4), false, false, false},
+					{int64(5), false, false, true},
+				},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s = 7",
+				Expected: []sql.Row{{int64(5)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s = 'c,a,b'",
+				Expected: []sql.Row{{int64(5)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE s < 'b' ORDER BY pk",
+				Expected: []sql.Row{{int64(1)}, {int64(4)}},
+			},
+		},
+	},
+	{
+		Name: "CHAR values are compared without their trailing spaces, unless padded to their full length",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk bigint primary key, c char(5), v varchar(5))",
+			"INSERT INTO t VALUES (1, 'ab ', 'ab '), (2, 'ab', 'ab')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM t WHERE c = 'ab' ORDER BY pk",
+				Expected: []sql.Row{{int64(1)}, {int64(2)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE c = 'ab   '",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE v = 'ab'",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE c LIKE 'ab' ORDER BY pk",
+				Expected: []sql.Row{{int64(1)}, {int64(2)}},
+			},
+			{
+				Query:    "SET sql_mode = 'PAD_CHAR_TO_FULL_LENGTH'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE c = 'ab'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE c = 'ab   ' ORDER BY pk",
+				Expected: []sql.Row{{int64(1)}, {int64(2)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE c LIKE 'ab' ORDER BY pk",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE c LIKE 'ab   ' ORDER BY pk",
+				Expected: []sql.Row{{int64(1)}, {int64(2)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE v = 'ab'",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				// The session is shared with the scripts that follow
+				Query:    "SET sql_mode = ''",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "comparisons with NULL are rewritten to null checks with transform_null_equals",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk BIGINT PRIMARY KEY, v BIGINT)",
+			"INSERT INTO t VALUES (1, NULL), (2, 2)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM t WHERE v = NULL",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE v <> NULL",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SET transform_null_equals = 1",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE v = NULL",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query:    "SELECT pk FROM t WHERE NULL <> v",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				Query:    "SELECT pk, v = NULL FROM t ORDER BY pk",
+				Expected: []sql.Row{{int64(1), true}, {int64(2), false}},
+			},
+			{
+				// The session is shared with the scripts that follow
+				Query:    "SET transform_null_equals = 0",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "comparisons with session values",
+		SetUpScript: []string{
+			"CREATE TABLE t (id BIGINT UNSIGNED PRIMARY KEY, v VARCHAR(20))",
+			"INSERT INTO t VALUES (1, 'a'), (2, 'b'), (3, 'c')",
+			"SET @@last_insert_id = 2",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT LAST_INSERT_ID()",
+				Expected: []sql.Row{{uint64(2)}},
+			},
+			{
+				Query:    "SELECT v FROM t WHERE id = LAST_INSERT_ID()",
+				Expected: []sql.Row{{"b"}},
+			},
+			{
+				Query:    "SELECT v FROM t WHERE LAST_INSERT_ID() < id",
+				Expected: []sql.Row{{"c"}},
+			},
+			{
+				Query:    "SELECT v FROM t WHERE id <> CONNECTION_ID() + 100 ORDER BY id",
+				Expected: []sql.Row{{"a"}, {"b"}, {"c"}},
+			},
+			{
+				// The session is shared with the scripts that follow
+				Query:    "SET @@last_insert_id = 0",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "NOT EXISTS on correlated subqueries with NULL keys",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (id INT PRIMARY KEY, k INT)",
+			"CREATE TABLE t2 (id INT PRIMARY KEY, k INT)",
+			"INSERT INTO t1 VALUES (1, 1), (2, 2), (3, NULL), (4, 4)",
+			"INSERT INTO t2 VALUES (1, 1), (2, NULL), (3, 4)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id FROM t1 WHERE NOT EXISTS (SELECT 1 FROM t2 WHERE t2.k = t1.k) ORDER BY id",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "SELECT id FROM t1 WHERE NOT EXISTS (SELECT 1 FROM t2 WHERE t2.k = t1.k LIMIT 1) ORDER BY id",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				Query:    "SELECT id FROM t1 WHERE NOT EXISTS (SELECT 1 FROM t2 WHERE t2.k = t1.k OR (t2.k IS NULL AND t1.k IS NULL)) ORDER BY id",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT id FROM t1 WHERE NOT EXISTS (SELECT * FROM t2 WHERE t2.k = t1.k AND t2.id > 1) ORDER BY id",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "SELECT id FROM t1 WHERE NOT EXISTS (SELECT 1 FROM t2 WHERE t2.k = t1.k AND t2.id > 10) ORDER BY id",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}},
+			},
+			{
+				Query:    "SELECT t2.id FROM t2 WHERE NOT EXISTS (SELECT 1 FROM t1 WHERE t1.k = t2.k) AND t2.id > 1 ORDER BY t2.id",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "UNION removes rows that compare as equal",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (d DECIMAL(10,2), s VARCHAR(10) COLLATE utf8mb4_0900_ai_ci)",
+			"CREATE TABLE t2 (d DECIMAL(10,1), s VARCHAR(10) COLLATE utf8mb4_0900_ai_ci)",
+			"INSERT INTO t1 VALUES (1.5, 'abc'), (2, 'x'), (NULL, NULL)",
+			"INSERT INTO t2 VALUES (1.5, 'ABC'), (2.5, 'y'), (NULL, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT s FROM t1 UNION SELECT s FROM t2",
+				Expected: []sql.Row{{"abc"}, {"x"}, {nil}, {"y"}},
+			},
+			{
+				Query:    "SELECT s FROM t1 UNION ALL SELECT s FROM t2",
+				Expected: []sql.Row{{"abc"}, {"x"}, {nil}, {"ABC"}, {"y"}, {nil}},
+			},
+			{
+				Query:    "SELECT d FROM t1 UNION SELECT d FROM t2",
+				Expected: []sql.Row{{"1.5000000000"}, {"2.0000000000"}, {nil}, {"2.5000000000"}},
+			},
+			{
+				Query:    "SELECT d, s FROM t1 UNION DISTINCT SELECT d, s FROM t2",
+				Expected: []sql.Row{{"1.5000000000", "abc"}, {"2.0000000000", "x"}, {nil, nil}, {"2.5000000000", "y"}},
+			},
+			{
+				Query:    "SELECT 1.5 UNION SELECT 1.50",
+				Expected: []sql.Row{{1.5}},
+			},
+		},
+	},
+	{
+		Name: "UNION, DISTINCT, GROUP BY and COUNT(DISTINCT) find the same strings equal",
+		SetUpScript: []string{
+			"CREATE TABLE t3 (pk BIGINT PRIMARY KEY, s VARCHAR(10))",
+			"INSERT INTO t3 VALUES (1, 'abc'), (2, 'ABC'), (3, 'abd')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT LOWER(s) FROM (SELECT s FROM t3 UNION SELECT s FROM t3) sq ORDER BY 1",
+				Expected: []sql.Row{{"abc"}, {"abd"}},
+			},
+			{
+				Query:    "SELECT LOWER(s) FROM (SELECT DISTINCT s FROM t3) sq ORDER BY 1",
+				Expected: []sql.Row{{"abc"}, {"abd"}},
+			},
+			{
+				Query:    "SELECT LOWER(s) FROM (SELECT DISTINCT s FROM t3 ORDER BY s) sq ORDER BY 1",
+				Expected: []sql.Row{{"abc"}, {"abd"}},
+			},
+			{
+				Query:    "SELECT LOWER(s), COUNT(*) FROM t3 GROUP BY s ORDER BY 1",
+				Expected: []sql.Row{{"abc", int64(2)}, {"abd", int64(1)}},
+			},
+			{
+				Query:    "SELECT COUNT(DISTINCT s) FROM t3",
+				Expected: []sql.Row{{int64(2)}},
+			},
+		},
+	},
+	{
+		Name: "IN statically empty subqueries",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (i BIGINT PRIMARY KEY, k BIGINT)",
+			"CREATE TABLE t2 (k BIGINT)",
+			"INSERT INTO t1 VALUES (1, 1), (2, NULL)",
+			"INSERT INTO t2 VALUES (1), (NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT i FROM t1 WHERE k IN (SELECT k FROM t2 WHERE 1 = 0)",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT i FROM t1 WHERE k NOT IN (SELECT k FROM t2 WHERE 1 = 0) ORDER BY i",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT i, k IN (SELECT k FROM t2 LIMIT 0) FROM t1 ORDER BY i",
+				Expected: []sql.Row{{1, false}, {2, false}},
+			},
+			{
+				Query:    "SELECT i FROM t1 WHERE k NOT IN (SELECT k FROM t2)",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "temporal columns compared with numbers",
+		SetUpScript: []string{
+			"CREATE TABLE t (id BIGINT PRIMARY KEY, d DATE, dt DATETIME, INDEX d_idx (d), INDEX dt_idx (dt))",
+			"INSERT INTO t VALUES (1, '2020-01-01', '2020-01-01 12:00:00'), (2, '2020-01-02', '2020-01-01 00:00:00'), (3, NULL, NULL)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT id FROM t WHERE d = 20200101",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE dt = 20200101120000",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE dt = 20200101",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE d > 20200101 ORDER BY id",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE 200101 = d",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE d = 20200101120000",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE d BETWEEN 20200101 AND 20200102 ORDER BY id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE d IN (20200102, 20200103)",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "disjunctions of equalities on different indexes",
+		SetUpScript: []string{
+			"CREATE TABLE m (a INT, b INT, c INT, INDEX a_idx (a), INDEX b_idx (b))",
+			"INSERT INTO m VALUES (1, 1, 1), (1, 2, 2), (2, 2, 3), (2, 3, 4), (3, 3, 5), (1, 2, 2)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "EXPLAIN SELECT c FROM m WHERE a = 1 OR b = 2",
+				Expected: []sql.Row{
+					{"Project(m.c)"},
+					{" └─ Filter(m.a = 1 OR m.b = 2)"},
+					{"     └─ IndexMerge(m on a_idx = 1, b_idx = 2)"},
+				},
+			},
+			{
+				Query:    "SELECT c FROM m WHERE a = 1 OR b = 2 ORDER BY c",
+				Expected: []sql.Row{{1}, {2}, {2}, {3}},
+			},
+			{
+				Query:    "SELECT c FROM m WHERE a = 3 OR b = 1 OR a = 2 ORDER BY c",
+				Expected: []sql.Row{{1}, {3}, {4}, {5}},
+			},
+			{
+				Query:    "SELECT c FROM m WHERE (a = 1 OR b = 3) AND c > 1 ORDER BY c",
+				Expected: []sql.Row{{2}, {2}, {4}, {5}},
+			},
+			{
+				Query:    "SELECT c FROM m WHERE a = 1 OR c = 3 ORDER BY c",
+				Expected: []sql.Row{{1}, {2}, {2}, {3}},
+			},
+		},
+	},
+	{
+		Name: "DEFAULT(col) compared against columns",
+		SetUpScript: []string{
+			"CREATE TABLE dt (pk INT PRIMARY KEY, a INT DEFAULT 5, b VARCHAR(10) DEFAULT 'x', c DATETIME DEFAULT CURRENT_TIMESTAMP, d INT DEFAULT (pk + 1), e INT NOT NULL, f INT)",
+			"INSERT INTO dt (pk, a, b, e) VALUES (1, 5, 'x', 1), (2, 6, 'y', 2)",
+			"INSERT INTO dt (pk, a, b, d, e) VALUES (4, 5, 'x', 7, 3)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk, DEFAULT(a), DEFAULT(b), DEFAULT(d), DEFAULT(f) FROM dt ORDER BY pk",
+				Expected: []sql.Row{{1, 5, "x", 2, nil}, {2, 5, "x", 3, nil}, {4, 5, "x", 5, nil}},
+			},
+			{
+				Query:    "SELECT pk FROM dt WHERE a IN (DEFAULT(a)) ORDER BY pk",
+				Expected: []sql.Row{{1}, {4}},
+			},
+			{
+				Query:    "SELECT pk FROM dt WHERE b NOT IN (DEFAULT(b)) ORDER BY pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT pk FROM dt WHERE d IN (DEFAULT(d)) ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "SELECT t.pk FROM dt t WHERE t.d NOT IN (DEFAULT(d)) ORDER BY 1",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "SELECT pk FROM dt WHERE COALESCE(DEFAULT(c)) >= c ORDER BY pk",
+				Expected: []sql.Row{{1}, {2}, {4}},
+			},
+			{
+				Query:       "SELECT pk, DEFAULT(e) FROM dt",
+				ExpectedErr: sql.ErrColumnHasNoDefault,
+			},
+		},
+	},
+	{
+		Name: "strings of a case insensitive collation compared in every way",
+		SetUpScript: []string{
+			"CREATE TABLE ci (pk BIGINT PRIMARY KEY, s VARCHAR(20) COLLATE utf8mb4_general_ci, b VARCHAR(20) COLLATE utf8mb4_bin)",
+			"INSERT INTO ci VALUES (1, 'a', 'a'), (2, 'B', 'B'), (3, 'A', 'A'), (4, 'c', 'c'), (5, 'b ', 'b ')",
+			"SET in_list_hash_threshold = 1",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT pk FROM ci WHERE s = 'b' ORDER BY pk",
+				Expected: []sql.Row{{2}, {5}},
+			},
+			{
+				Query:    "SELECT pk FROM ci WHERE s IN ('b', 'x') ORDER BY pk",
+				Expected: []sql.Row{{2}, {5}},
+			},
+			{
+				Query:    "SELECT pk FROM ci WHERE s IN (SELECT 'B') ORDER BY pk",
+				Expected: []sql.Row{{2}, {5}},
+			},
+			{
+				Query:    "SELECT pk FROM ci WHERE (s, pk) IN (('b', 5), ('x', 1))",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT pk FROM ci ORDER BY s, pk",
+				Expected: []sql.Row{{1}, {3}, {2}, {5}, {4}},
+			},
+			{
+				Query:    "SELECT MIN(s), MAX(s) FROM ci WHERE pk < 4",
+				Expected: []sql.Row{{"a", "B"}},
+			},
+			{
+				Query:    "SELECT pk FROM ci WHERE b = 'b' ORDER BY pk",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT pk FROM ci WHERE b IN ('b', 'x') ORDER BY pk",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT pk FROM ci ORDER BY b, pk",
+				Expected: []sql.Row{{3}, {2}, {1}, {5}, {4}},
+			},
+			{
+				Query:    "SELECT MIN(b), MAX(b) FROM ci WHERE pk < 4",
+				Expected: []sql.Row{{"A", "a"}},
+			},
+			{
+				Query:    "SELECT pk FROM ci WHERE ('b', pk) = (b, pk)",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT pk FROM ci WHERE ('b', pk) IN ((b, pk), ('x', 0))",
+				Expected: []sql.Row{{5}},
+			},
+		},
+	},
+	{
+		Name: "strings of a column compared with the results of a subquery of another collation",
+		SetUpScript: []string{
+			"CREATE TABLE a (pk BIGINT PRIMARY KEY, s VARCHAR(20) COLLATE utf8mb4_bin)",
+			"CREATE TABLE b (pk BIGINT PRIMARY KEY, s VARCHAR(20))",
+			"INSERT INTO a VALUES (1, 'Abc'), (2, 'def')",
+			"INSERT INTO b VALUES (1, 'ABC'), (2, 'def')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "SELECT b.pk FROM b, a WHERE b.s = a.s ORDER BY b.pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT pk FROM b WHERE s IN (SELECT s FROM a) ORDER BY pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT pk FROM a WHERE s IN (SELECT s FROM b) ORDER BY pk",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
 }
@@ -0,0 +1,64 @@
+package bloom
+
+import "math"
+
+// Filter is a bloom filter, a set of hashes that can tell whether a hash is definitely not in the set, using much
+// less memory than the set itself. A hash that may be in the set may also be a false positive, so membership must be
+// checked in some other way when needed.
+type Filter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// New creates a new bloom filter sized for the number of elements given, with the given rate of false positives
+// once it holds all of them.
+func New(elements int, falsePositiveRate float64) *Filter {
+	if elements < 1 {
+		elements = 1
+	}
+
+	size := uint64(math.Ceil(-float64(elements) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if size < 64 {
+		size = 64
+	}
+
+	hashes := uint64(math.Round(float64(size) / float64(elements) * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+
+	return &Filter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// Add adds the hash given to the filter.
+func (f *Filter) Add(hash uint64) {
+	h1, h2 := split(hash)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MayContain returns whether the hash given may have been added to the filter. If it returns false, the hash was
+// definitely not added.
+func (f *Filter) MayContain(hash uint64) bool {
+	h1, h2 := split(hash)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// split derives the two hashes used to compute the bits of a hash from its two halves, as in Kirsch and
+// Mitzenmacher's "Less Hashing, Same Performance". The second one is made odd so that it's never zero.
+func split(hash uint64) (uint64, uint64) {
+	return hash & math.MaxUint32, (hash >> 32) | 1
+}
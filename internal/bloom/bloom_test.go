@@ -0,0 +1,42 @@
+package bloom
+
+import (
+	"hash/crc64"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var table = crc64.MakeTable(crc64.ISO)
+
+func hash(i int) uint64 {
+	return crc64.Checksum([]byte(strconv.Itoa(i)), table)
+}
+
+func TestFilter(t *testing.T) {
+	require := require.New(t)
+
+	const elements = 10000
+	f := New(elements, 0.01)
+	for i := 0; i < elements; i++ {
+		f.Add(hash(i))
+	}
+
+	for i := 0; i < elements; i++ {
+		require.True(f.MayContain(hash(i)))
+	}
+
+	var falsePositives int
+	for i := elements; i < 2*elements; i++ {
+		if f.MayContain(hash(i)) {
+			falsePositives++
+		}
+	}
+	require.Less(falsePositives, elements/20)
+}
+
+func TestFilterEmpty(t *testing.T) {
+	f := New(0, 0.01)
+	require.False(t, f.MayContain(hash(1)))
+}
@@ -61,11 +61,13 @@ func (l *AscendIndexLookup) EvalExpression() sql.Expression {
 			columnExprs = append(columnExprs, ltExpr)
 		case hasGte:
 			columnExprs = append(columnExprs, gtExpr)
-		default:
-			panic("Either Lt or Gte must be set")
 		}
 	}
 
+	// A lookup of no keys is of every key
+	if len(columnExprs) == 0 {
+		return expression.NewLiteral(true, sql.Boolean)
+	}
 	return and(columnExprs...)
 }
 
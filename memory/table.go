@@ -5,6 +5,7 @@ import (
 	"encoding/gob"
 	"fmt"
 	"io"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -378,7 +379,7 @@ func (t *tableEditor) Insert(ctx *sql.Context, row sql.Row) error {
 		return err
 	}
 
-	if err := t.checkUniquenessConstraints(row); err != nil {
+	if err := t.checkUniquenessConstraints(ctx, row, nil); err != nil {
 		return err
 	}
 
@@ -398,6 +399,11 @@ func (t *tableEditor) Delete(ctx *sql.Context, row sql.Row) error {
 		return err
 	}
 
+	var pk *expression.KeyMatcher
+	if pkColIdxes := t.pkColumnIndexes(); len(pkColIdxes) > 0 {
+		pk = expression.NewKeyMatcher(t.table.schema, pkColIdxes)
+	}
+
 	matches := false
 	for partitionIndex, partition := range t.table.partitions {
 		for partitionRowIndex, partitionRow := range partition {
@@ -405,22 +411,20 @@ func (t *tableEditor) Delete(ctx *sql.Context, row sql.Row) error {
 
 			// For DELETE queries, we will have previously selected the row in order to delete it. For REPLACE, we will just
 			// have the row to be replaced, so we need to consider primary key information.
-			pkColIdxes := t.pkColumnIndexes()
-			if len(pkColIdxes) > 0 {
-				if columnsMatch(pkColIdxes, partitionRow, row) {
-					t.table.partitions[partitionIndex] = append(partition[:partitionRowIndex], partition[partitionRowIndex+1:]...)
-					break
+			if pk != nil {
+				pkMatches, err := pk.Matches(ctx, partitionRow, row)
+				if err != nil {
+					return err
 				}
-			}
 
-			// If we had no primary key match (or have no primary key), check each row for a total match
-			for rIndex, val := range row {
-				if val != partitionRow[rIndex] {
-					matches = false
+				if pkMatches {
+					t.table.partitions[partitionIndex] = append(partition[:partitionRowIndex], partition[partitionRowIndex+1:]...)
 					break
 				}
 			}
 
+			// If we had no primary key match (or have no primary key), check each row for a total match
+			matches = rowsAreEqual(row, partitionRow)
 			if matches {
 				t.table.partitions[partitionIndex] = append(partition[:partitionRowIndex], partition[partitionRowIndex+1:]...)
 				break
@@ -446,22 +450,14 @@ func (t *tableEditor) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) e
 		return err
 	}
 
-	if t.pkColsDiffer(oldRow, newRow) {
-		if err := t.checkUniquenessConstraints(newRow); err != nil {
-			return err
-		}
+	if err := t.checkUniquenessConstraints(ctx, newRow, oldRow); err != nil {
+		return err
 	}
 
 	matches := false
 	for partitionIndex, partition := range t.table.partitions {
 		for partitionRowIndex, partitionRow := range partition {
-			matches = true
-			for rIndex, val := range oldRow {
-				if val != partitionRow[rIndex] {
-					matches = false
-					break
-				}
-			}
+			matches = rowsAreEqual(oldRow, partitionRow)
 			if matches {
 				t.table.partitions[partitionIndex][partitionRowIndex] = newRow
 				break
@@ -475,14 +471,41 @@ func (t *tableEditor) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) e
 	return nil
 }
 
-func (t *tableEditor) checkUniquenessConstraints(row sql.Row) error {
-	pkColIdxes := t.pkColumnIndexes()
+// checkUniquenessConstraints returns an error if the row given has the same primary key or unique index key as any
+// other row of the table. Keys are compared the way the unique index on them compares them. When updating a row, the
+// old row is given so that it's not considered a duplicate of its new version.
+func (t *tableEditor) checkUniquenessConstraints(ctx *sql.Context, row, oldRow sql.Row) error {
+	for _, key := range t.uniqueKeys() {
+		// A key with a NULL value is never the same as any other, and a key that isn't updated can only be the same as
+		// the key of the row being updated, so neither needs the rows of the table to be scanned
+		if hasNullValue(key.Columns(), row) || (oldRow != nil && columnsMatch(key.Columns(), oldRow, row)) {
+			continue
+		}
+
+		if oldRow != nil {
+			same, err := key.Matches(ctx, oldRow, row)
+			if err != nil {
+				return err
+			}
+
+			if same {
+				continue
+			}
+		}
 
-	if len(pkColIdxes) > 0 {
 		for _, partition := range t.table.partitions {
 			for _, partitionRow := range partition {
-				if columnsMatch(pkColIdxes, partitionRow, row) {
-					return sql.ErrUniqueKeyViolation.New(pkColIdxes)
+				if oldRow != nil && rowsAreEqual(partitionRow, oldRow) {
+					continue
+				}
+
+				duplicate, err := key.Matches(ctx, partitionRow, row)
+				if err != nil {
+					return err
+				}
+
+				if duplicate {
+					return sql.ErrUniqueKeyViolation.New(key.Columns())
 				}
 			}
 		}
@@ -491,6 +514,44 @@ func (t *tableEditor) checkUniquenessConstraints(row sql.Row) error {
 	return nil
 }
 
+// uniqueKeys returns the matchers of the primary key and the keys of the unique indexes of the table.
+func (t *tableEditor) uniqueKeys() []*expression.KeyMatcher {
+	var keys []*expression.KeyMatcher
+	if pkColIdxes := t.pkColumnIndexes(); len(pkColIdxes) > 0 {
+		keys = append(keys, expression.NewKeyMatcher(t.table.schema, pkColIdxes))
+	}
+
+	indexNames := make([]string, 0, len(t.table.indexes))
+	for name := range t.table.indexes {
+		indexNames = append(indexNames, name)
+	}
+	sort.Strings(indexNames)
+
+	for _, name := range indexNames {
+		index := t.table.indexes[name]
+		exprIndex, ok := index.(ExpressionsIndex)
+		if !ok || !index.IsUnique() {
+			continue
+		}
+
+		var colIdxes []int
+		for _, e := range exprIndex.ColumnExpressions() {
+			gf, ok := e.(*expression.GetField)
+			if !ok {
+				colIdxes = nil
+				break
+			}
+			colIdxes = append(colIdxes, t.table.schema.IndexOf(gf.Name(), t.table.name))
+		}
+
+		if len(colIdxes) > 0 {
+			keys = append(keys, expression.NewKeyMatcher(t.table.schema, colIdxes))
+		}
+	}
+
+	return keys
+}
+
 func (t *tableEditor) pkColumnIndexes() []int {
 	var pkColIdxes []int
 	for _, column := range t.table.schema {
@@ -502,21 +563,42 @@ func (t *tableEditor) pkColumnIndexes() []int {
 	return pkColIdxes
 }
 
-func (t *tableEditor) pkColsDiffer(row, row2 sql.Row) bool {
-	pkColIdxes := t.pkColumnIndexes()
-	return !columnsMatch(pkColIdxes, row, row2)
-}
-
 // Returns whether the values for the columns given match in the two rows provided
 func columnsMatch(colIndexes []int, row sql.Row, row2 sql.Row) bool {
 	for _, i := range colIndexes {
-		if row[i] != row2[i] {
+		if !valuesAreEqual(row[i], row2[i]) {
 			return false
 		}
 	}
 	return true
 }
 
+// hasNullValue returns whether any of the columns given is NULL in the row given.
+func hasNullValue(colIndexes []int, row sql.Row) bool {
+	for _, i := range colIndexes {
+		if row[i] == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// rowsAreEqual returns whether the two rows given have exactly the same values.
+func rowsAreEqual(row, row2 sql.Row) bool {
+	for i := range row {
+		if !valuesAreEqual(row[i], row2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// valuesAreEqual returns whether the two values given are exactly the same. Values such as BLOBs and JSON documents
+// can't be compared with ==, so values are compared deeply.
+func valuesAreEqual(v, v2 interface{}) bool {
+	return reflect.DeepEqual(v, v2)
+}
+
 func (t *Table) AddColumn(ctx *sql.Context, column *sql.Column, order *sql.ColumnOrder) error {
 	newColIdx := t.addColumnToSchema(ctx, column, order)
 	return t.insertValueInRows(ctx, newColIdx, column.Default)
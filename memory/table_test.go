@@ -1,10 +1,12 @@
 package memory
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"testing"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -404,3 +406,68 @@ func TestTableIndexKeyValueIter(t *testing.T) {
 		})
 	}
 }
+
+func TestTableUniqueKeys(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := NewTable("t", sql.Schema{
+		{Name: "pk", Type: sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_0900_ai_ci), Source: "t", PrimaryKey: true},
+		{Name: "u", Type: sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_0900_ai_ci), Source: "t", Nullable: true},
+	})
+	require.NoError(table.CreateIndex(ctx, "u", sql.IndexUsing_Default, sql.IndexConstraint_Unique, []sql.IndexColumn{{Name: "u"}}, ""))
+
+	require.NoError(table.Insert(ctx, sql.NewRow("a", "x")))
+	require.NoError(table.Insert(ctx, sql.NewRow("b", nil)))
+	require.NoError(table.Insert(ctx, sql.NewRow("c", nil)))
+
+	err := table.Insert(ctx, sql.NewRow("A", "y"))
+	require.True(sql.ErrUniqueKeyViolation.Is(err))
+
+	err = table.Insert(ctx, sql.NewRow("d", "X"))
+	require.True(sql.ErrUniqueKeyViolation.Is(err))
+
+	updater := table.Updater(ctx)
+	require.NoError(updater.Update(ctx, sql.NewRow("a", "x"), sql.NewRow("A", "X")))
+	err = updater.Update(ctx, sql.NewRow("b", nil), sql.NewRow("b", "x"))
+	require.True(sql.ErrUniqueKeyViolation.Is(err))
+	require.NoError(updater.Close(ctx))
+
+	require.ElementsMatch([]sql.Row{{"A", "X"}, {"b", nil}, {"c", nil}}, testFlatRows(t, table))
+
+	// Keys that aren't updated, or that have a NULL value, aren't compared with the keys of any row
+	diagnostics := sql.NewDiagnostics().LogNullComparisons()
+	ctx = sql.NewContext(context.Background(), sql.WithDiagnostics(diagnostics))
+	updater = table.Updater(ctx)
+	require.NoError(updater.Update(ctx, sql.NewRow("A", "X"), sql.NewRow("A", "X")))
+	require.NoError(updater.Update(ctx, sql.NewRow("b", nil), sql.NewRow("b", nil)))
+	require.NoError(updater.Close(ctx))
+	require.Empty(diagnostics.Coercions())
+	require.Empty(diagnostics.NullComparisons())
+}
+
+func TestTableBlobAndJSONRows(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := NewTable("t", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "t", PrimaryKey: true},
+		{Name: "j", Type: sql.JSON, Source: "t", Nullable: true},
+		{Name: "b", Type: sql.Blob, Source: "t", Nullable: true},
+	})
+
+	row := sql.NewRow(int64(1), sql.JSON.MustConvert(`{"a": 1}`), []byte("abc"))
+	require.NoError(table.Insert(ctx, row))
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(2), sql.JSON.MustConvert(`[1, 2]`), []byte("def"))))
+
+	updated := sql.NewRow(int64(10), sql.JSON.MustConvert(`{"a": 1}`), []byte("abc"))
+	updater := table.Updater(ctx)
+	require.NoError(updater.Update(ctx, sql.NewRow(int64(1), sql.JSON.MustConvert(`{"a": 1}`), []byte("abc")), updated))
+	require.NoError(updater.Close(ctx))
+
+	deleter := table.Deleter(ctx)
+	require.NoError(deleter.Delete(ctx, sql.NewRow(int64(2), sql.JSON.MustConvert(`[1, 2]`), []byte("def"))))
+	require.NoError(deleter.Close(ctx))
+
+	require.Equal([]sql.Row{updated}, testFlatRows(t, table))
+}
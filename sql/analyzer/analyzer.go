@@ -33,9 +33,13 @@ type Builder struct {
 	postAnalyzeRules    []Rule
 	preValidationRules  []Rule
 	postValidationRules []Rule
+	fixpointBatches     []*Batch
 	catalog             *sql.Catalog
 	debug               bool
 	parallelism         int
+	nullComparisons     sql.NullComparisonPolicy
+	predicates          *PredicateCache
+	predicateStats      *PredicateStatistics
 }
 
 // NewBuilder creates a new Builder from a specific catalog.
@@ -57,6 +61,26 @@ func (ab *Builder) WithParallelism(parallelism int) *Builder {
 	return ab
 }
 
+// WithNullComparisonPolicy sets how the equality comparisons of the queries the Analyzer analyzes treat NULL operands.
+func (ab *Builder) WithNullComparisonPolicy(p sql.NullComparisonPolicy) *Builder {
+	ab.nullComparisons = p
+	return ab
+}
+
+// WithPredicateCache sets the cache of the predicates compiled from the comparisons of the queries the Analyzer
+// analyzes, so that they're compiled once for all the executions of queries of the same shape.
+func (ab *Builder) WithPredicateCache(c *PredicateCache) *Builder {
+	ab.predicates = c
+	return ab
+}
+
+// WithPredicateStatistics sets the statistics the Analyzer collects of the comparisons of the filters of the queries it
+// analyzes, which suggest the indexes the queries could look up.
+func (ab *Builder) WithPredicateStatistics(s *PredicateStatistics) *Builder {
+	ab.predicateStats = s
+	return ab
+}
+
 // AddPreAnalyzeRule adds a new rule to the analyze before the standard analyzer rules.
 func (ab *Builder) AddPreAnalyzeRule(name string, fn RuleFunc) *Builder {
 	ab.preAnalyzeRules = append(ab.preAnalyzeRules, Rule{name, fn})
@@ -71,6 +95,14 @@ func (ab *Builder) AddPostAnalyzeRule(name string, fn RuleFunc) *Builder {
 	return ab
 }
 
+// AddDatabaseRule adds a new rule to the analyzer after standard analyzer rules, like AddPostAnalyzeRule, which only
+// runs for queries that target a database the predicate given matches. See DatabaseRule.
+func (ab *Builder) AddDatabaseRule(name string, fn RuleFunc, matches DatabasePredicate) *Builder {
+	ab.postAnalyzeRules = append(ab.postAnalyzeRules, Rule{name, DatabaseRule(fn, matches)})
+
+	return ab
+}
+
 // AddPreValidationRule adds a new rule to the analyzer before standard validation rules.
 func (ab *Builder) AddPreValidationRule(name string, fn RuleFunc) *Builder {
 	ab.preValidationRules = append(ab.preValidationRules, Rule{name, fn})
@@ -85,6 +117,20 @@ func (ab *Builder) AddPostValidationRule(name string, fn RuleFunc) *Builder {
 	return ab
 }
 
+// AddFixpointBatch adds a new batch of rules to the analyzer after the post analyzer rules, which are executed until
+// the done function given returns true for the node, or up to maxIter times. When done doesn't return true within
+// maxIter iterations, the analysis goes on with the node of the last iteration, like it does for any other batch.
+func (ab *Builder) AddFixpointBatch(desc string, rules []Rule, done func(sql.Node) bool, maxIter int) *Builder {
+	ab.fixpointBatches = append(ab.fixpointBatches, &Batch{
+		Desc:       desc,
+		Iterations: maxIter,
+		Rules:      rules,
+		Done:       done,
+	})
+
+	return ab
+}
+
 func init() {
 	logrus.SetFormatter(simpleLogFormatter{})
 }
@@ -118,6 +164,9 @@ func (ab *Builder) Build() *Analyzer {
 			Iterations: maxAnalysisIterations,
 			Rules:      ab.postAnalyzeRules,
 		},
+	}
+	batches = append(batches, ab.fixpointBatches...)
+	batches = append(batches, []*Batch{
 		&Batch{
 			Desc:       "pre-validation",
 			Iterations: 1,
@@ -138,14 +187,17 @@ func (ab *Builder) Build() *Analyzer {
 			Iterations: 1,
 			Rules:      OnceAfterAll,
 		},
-	}
+	}...)
 
 	return &Analyzer{
-		Debug:        debug || ab.debug,
-		contextStack: make([]string, 0),
-		Batches:      batches,
-		Catalog:      ab.catalog,
-		Parallelism:  ab.parallelism,
+		Debug:           debug || ab.debug,
+		contextStack:    make([]string, 0),
+		Batches:         batches,
+		Catalog:         ab.catalog,
+		Parallelism:     ab.parallelism,
+		NullComparisons: ab.nullComparisons,
+		Predicates:      ab.predicates,
+		PredicateStats:  ab.predicateStats,
 	}
 }
 
@@ -156,6 +208,9 @@ type Analyzer struct {
 	Debug bool
 	// Whether to output the query plan at each step of the analyzer
 	Verbose bool
+	// Whether to log only the expressions that changed, rather than the whole plan, when a rule only modified
+	// expressions. Only has an effect in verbose debug mode.
+	ExpressionDiff bool
 	// A stack of debugger context. See PushDebugContext, PopDebugContext
 	contextStack []string
 	Parallelism  int
@@ -163,6 +218,15 @@ type Analyzer struct {
 	Batches []*Batch
 	// Catalog of databases and registered functions.
 	Catalog *sql.Catalog
+	// NullComparisons is how the equality comparisons of the queries analyzed treat NULL operands, which is set in
+	// the context QueryContext returns for a query, unless it's empty.
+	NullComparisons sql.NullComparisonPolicy
+	// Predicates is the cache of the predicates compiled from the comparisons of the filters of the queries
+	// analyzed, if any.
+	Predicates *PredicateCache
+	// PredicateStats are the statistics of the comparisons of the filters of the queries analyzed, if they're
+	// collected.
+	PredicateStats *PredicateStatistics
 }
 
 // NewDefault creates a default Analyzer instance with all default Rules and configuration.
@@ -223,9 +287,17 @@ func (a *Analyzer) LogNode(n sql.Node) {
 
 // LogDiff logs the diff between the query plans after a transformation rules has been applied.
 // Only can print a diff when the string representations of the nodes differ, which isn't always the case.
+// If ExpressionDiff is set and the rule only modified expressions, only the changed expressions are logged.
 func (a *Analyzer) LogDiff(prev, next sql.Node) {
 	if a.Debug && a.Verbose {
 		if !reflect.DeepEqual(next, prev) {
+			if a.ExpressionDiff {
+				if diff, ok := expressionDiff(prev, next); ok {
+					a.Log(diff)
+					return
+				}
+			}
+
 			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
 				A:        difflib.SplitLines(sql.DebugString(prev)),
 				B:        difflib.SplitLines(sql.DebugString(next)),
@@ -259,13 +331,30 @@ func (a *Analyzer) PopDebugContext() {
 	}
 }
 
+// QueryContext returns the context a query is analyzed and executed with, which is a copy of the context given with the
+// options of the analyzer that change how the query is evaluated, such as its NullComparisons policy. The context given
+// is left as it is, so the options only last for the query.
+func (a *Analyzer) QueryContext(ctx *sql.Context) *sql.Context {
+	if a.NullComparisons == "" {
+		return ctx
+	}
+
+	ctx = ctx.WithContext(ctx.Context)
+	ctx.ApplyOpts(sql.WithNullComparisonPolicy(a.NullComparisons))
+	return ctx
+}
+
 // Analyze applies the transformation rules to the node given. In the case of an error, the last successfully
-// transformed node is returned along with the error.
+// transformed node is returned along with the error. The node is analyzed with the QueryContext of the context given,
+// which the plan must be executed with too.
 func (a *Analyzer) Analyze(ctx *sql.Context, n sql.Node, scope *Scope) (sql.Node, error) {
+	ctx = a.QueryContext(ctx)
 	span, ctx := ctx.Span("analyze", opentracing.Tags{
 		"plan": n.String(),
 	})
 
+	ctx = withQueryDatabases(ctx, a, n)
+
 	var err error
 	a.Log("starting analysis of node of type: %T", n)
 	for _, batch := range a.Batches {
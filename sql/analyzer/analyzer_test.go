@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/memory"
@@ -231,6 +232,55 @@ func TestMaxIterations(t *testing.T) {
 	require.Equal(1000, count)
 }
 
+func TestAddFixpointBatch(t *testing.T) {
+	table := memory.NewTable("mytable", sql.Schema{{Name: "i", Type: sql.Int32, Source: "mytable"}})
+	db := memory.NewDatabase("mydb")
+	db.AddTable("mytable", table)
+	catalog := sql.NewCatalog()
+	catalog.AddDatabase(db)
+
+	// limit wraps the table in a LIMIT 1, and then raises the limit by one each time
+	count := 0
+	limit := Rule{"limit", func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+		count++
+		if l, ok := n.(*plan.Limit); ok {
+			return plan.NewLimit(l.Limit+1, l.Child), nil
+		}
+		return plan.NewLimit(1, n), nil
+	}}
+	limitedTo := func(limit int64) func(sql.Node) bool {
+		return func(n sql.Node) bool {
+			l, ok := n.(*plan.Limit)
+			return ok && l.Limit >= limit
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		done     func(sql.Node) bool
+		expected sql.Node
+		count    int
+	}{
+		{"done after some iterations", limitedTo(3), plan.NewLimit(3, plan.NewResolvedTable(table)), 3},
+		{"done right away", func(sql.Node) bool { return true }, plan.NewResolvedTable(table), 0},
+		{"never done", func(sql.Node) bool { return false }, plan.NewLimit(5, plan.NewResolvedTable(table)), 5},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			count = 0
+			a := withoutProcessTracking(NewBuilder(catalog).AddFixpointBatch("limit", []Rule{limit}, tt.done, 5).Build())
+
+			ctx := sql.NewContext(context.Background(), sql.WithIndexRegistry(sql.NewIndexRegistry()), sql.WithViewRegistry(sql.NewViewRegistry())).WithCurrentDB("mydb")
+			analyzed, err := a.Analyze(ctx, plan.NewUnresolvedTable("mytable", ""), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, analyzed)
+			require.Equal(tt.count, count)
+		})
+	}
+}
+
 func TestAddRule(t *testing.T) {
 	require := require.New(t)
 
@@ -434,3 +484,138 @@ func TestReorderProjectionUnresolvedChild(t *testing.T) {
 	require.NoError(err)
 	require.True(result.Resolved())
 }
+
+func TestAnalyzeSpans(t *testing.T) {
+	require := require.New(t)
+
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{{Name: "i", Type: sql.Int64, Source: "t"}}))
+	limited := plan.NewLimit(1, table)
+	keep := Rule{"keep", func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+		return n, nil
+	}}
+	limit := Rule{"limit", func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+		span, _ := ctx.Span("limit")
+		defer span.Finish()
+		if _, ok := n.(*plan.Limit); ok {
+			return n, nil
+		}
+		return limited, nil
+	}}
+
+	a := &Analyzer{Batches: []*Batch{
+		{Desc: "once", Iterations: 1, Rules: []Rule{keep}},
+		{Desc: "fixed-point", Iterations: 5, Rules: []Rule{keep, limit}},
+	}}
+
+	tracer := mocktracer.New()
+	ctx := sql.NewContext(context.Background(), sql.WithTracer(tracer))
+	analyzed, err := a.Analyze(ctx, table, nil)
+	require.NoError(err)
+	require.Equal(limited, analyzed)
+
+	spans := tracer.FinishedSpans()
+	byID := make(map[int]*mocktracer.MockSpan)
+	for _, s := range spans {
+		byID[s.SpanContext.SpanID] = s
+	}
+
+	var structure []string
+	for _, s := range spans {
+		path := s.OperationName
+		for p := byID[s.ParentID]; p != nil; p = byID[p.ParentID] {
+			path = p.OperationName + "/" + path
+		}
+		for _, tag := range []string{"batch", "rule", "iterations", "changed"} {
+			if v := s.Tag(tag); v != nil {
+				path += fmt.Sprintf(" %s=%v", tag, v)
+			}
+		}
+		structure = append(structure, path)
+	}
+
+	require.Equal([]string{
+		"analyze/analyze.batch/analyze.rule rule=keep changed=false",
+		"analyze/analyze.batch batch=once iterations=1 changed=false",
+		"analyze/analyze.batch/analyze.rule rule=keep changed=false",
+		"analyze/analyze.batch/analyze.rule/limit",
+		"analyze/analyze.batch/analyze.rule rule=limit changed=true",
+		"analyze/analyze.batch/analyze.rule rule=keep changed=false",
+		"analyze/analyze.batch/analyze.rule/limit",
+		"analyze/analyze.batch/analyze.rule rule=limit changed=false",
+		"analyze/analyze.batch batch=fixed-point iterations=2 changed=true",
+		"analyze",
+	}, structure)
+}
+
+func TestAddDatabaseRule(t *testing.T) {
+	catalog := sql.NewCatalog()
+	for _, name := range []string{"tenant", "other"} {
+		db := memory.NewDatabase(name)
+		db.AddTable("t", memory.NewTable("t", sql.Schema{{Name: "i", Type: sql.Int32, Source: "t"}}))
+		catalog.AddDatabase(db)
+	}
+
+	count := 0
+	a := withoutProcessTracking(NewBuilder(catalog).AddDatabaseRule("tenant_rule",
+		func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+			count++
+			return n, nil
+		},
+		func(db sql.Database) bool {
+			return db.Name() == "tenant"
+		},
+	).Build())
+
+	testCases := []struct {
+		name      string
+		currentDB string
+		node      sql.Node
+		fires     bool
+	}{
+		{
+			name:      "table of the current database",
+			currentDB: "tenant",
+			node:      plan.NewUnresolvedTable("t", ""),
+			fires:     true,
+		},
+		{
+			name:      "table of another current database",
+			currentDB: "other",
+			node:      plan.NewUnresolvedTable("t", ""),
+			fires:     false,
+		},
+		{
+			name:      "table qualified with the database",
+			currentDB: "other",
+			node:      plan.NewUnresolvedTable("t", "tenant"),
+			fires:     true,
+		},
+		{
+			name:      "table of the database in a subquery",
+			currentDB: "other",
+			node: plan.NewFilter(
+				plan.NewExistsSubquery(plan.NewSubquery(plan.NewUnresolvedTable("t", "tenant"), "select * from tenant.t")),
+				plan.NewUnresolvedTable("t", ""),
+			),
+			fires: true,
+		},
+		{
+			name:      "statement on the database",
+			currentDB: "other",
+			node:      plan.NewShowTables(sql.UnresolvedDatabase("tenant"), false, nil),
+			fires:     true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			count = 0
+
+			ctx := sql.NewContext(context.Background(), sql.WithIndexRegistry(sql.NewIndexRegistry()), sql.WithViewRegistry(sql.NewViewRegistry())).WithCurrentDB(tt.currentDB)
+			_, err := a.Analyze(ctx, tt.node, nil)
+			require.NoError(err)
+			require.Equal(tt.fires, count > 0)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// convertNotExistsToAntiJoins replaces the NOT EXISTS conditions of a filter on correlated subqueries, such as
+// NOT EXISTS (SELECT 1 FROM t2 WHERE t2.x = t1.y), with an anti join of the child of the filter with the table of the
+// subquery, which reads the table once rather than once per row. The correlated conditions of the subquery become the
+// condition of the join, and the rest of them filter the table. Subqueries with anything that depends on the rows
+// being read together, such as aggregates or a LIMIT, or which read from more than one table, are left as they are.
+//
+// Rows of the subquery are those of its table prepended with the outer row, just like the rows the condition of an
+// anti join is evaluated over, so the correlated conditions keep their field indexes. Nested queries are left alone,
+// since the outer row of a nested query also holds the rows of the scopes around it.
+func convertNotExistsToAntiJoins(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("convert_not_exists_to_anti_joins")
+	defer span.Finish()
+
+	if !n.Resolved() || len(scope.InnerToOuter()) > 0 {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		scopeLen := len(filter.Child.Schema())
+		type antiJoin struct {
+			right sql.Node
+			cond  sql.Expression
+		}
+
+		var joins []antiJoin
+		var rest []sql.Expression
+		for _, e := range splitConjunction(filter.Expression) {
+			right, cond, ok, err := antiJoinOperands(e, scopeLen)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				rest = append(rest, e)
+				continue
+			}
+
+			a.Log("converted %s to an anti join", e)
+			joins = append(joins, antiJoin{right, cond})
+		}
+
+		if len(joins) == 0 {
+			return node, nil
+		}
+
+		child := filter.Child
+		if len(rest) > 0 {
+			child = plan.NewFilter(expression.JoinAnd(rest...), child)
+		}
+
+		for _, j := range joins {
+			child = plan.NewAntiJoin(child, j.right, j.cond)
+		}
+
+		return child, nil
+	})
+}
+
+// antiJoinOperands returns the right side and condition of the anti join equivalent to the condition given, if it's
+// a NOT EXISTS on a correlated subquery that can be converted, that is, one that filters a single table with
+// conditions of which some reference the outer row given its length, and which doesn't aggregate or limit the rows
+// of the table.
+func antiJoinOperands(e sql.Expression, scopeLen int) (sql.Node, sql.Expression, bool, error) {
+	not, ok := e.(*expression.Not)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	exists, ok := not.Child.(*plan.ExistsSubquery)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	subquery, ok := exists.Child.(*plan.Subquery)
+	if !ok || !subquery.Resolved() {
+		return nil, nil, false, nil
+	}
+
+	// Neither the projected values nor the order or uniqueness of the rows change whether there are any
+	query := subquery.Query
+	for {
+		switch n := query.(type) {
+		case *plan.Project, *plan.Distinct, *plan.OrderedDistinct, *plan.Sort, *plan.QueryProcess:
+			query = n.Children()[0]
+			continue
+		}
+		break
+	}
+
+	filter, ok := query.(*plan.Filter)
+	if !ok || !isAntiJoinSource(filter.Child, scopeLen) {
+		return nil, nil, false, nil
+	}
+
+	var correlated, uncorrelated []sql.Expression
+	for _, e := range splitConjunction(filter.Expression) {
+		if containsSubquery(e) {
+			return nil, nil, false, nil
+		}
+
+		if referencesOuterRow(e, scopeLen) {
+			correlated = append(correlated, e)
+		} else {
+			uncorrelated = append(uncorrelated, e)
+		}
+	}
+
+	if len(correlated) == 0 {
+		return nil, nil, false, nil
+	}
+
+	// The rows of the table are no longer prepended with the outer row
+	right, err := plan.TransformExpressionsUp(filter.Child, shiftFieldIndexes(-scopeLen))
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if len(uncorrelated) > 0 {
+		cond, err := expression.TransformUp(expression.JoinAnd(uncorrelated...), shiftFieldIndexes(-scopeLen))
+		if err != nil {
+			return nil, nil, false, err
+		}
+		right = plan.NewFilter(cond, right)
+	}
+
+	return right, expression.JoinAnd(correlated...), true, nil
+}
+
+// isAntiJoinSource returns whether the rows of the node given can be read on their own as the right side of an anti
+// join, which is the case of a table, possibly aliased and filtered, without any reference to the outer row.
+func isAntiJoinSource(n sql.Node, scopeLen int) bool {
+	switch n := n.(type) {
+	case *plan.ResolvedTable:
+		return true
+	case *plan.TableAlias:
+		return isAntiJoinSource(n.Child, scopeLen)
+	case *plan.Filter:
+		return !containsSubquery(n.Expression) && !referencesOuterRow(n.Expression, scopeLen) &&
+			isAntiJoinSource(n.Child, scopeLen)
+	default:
+		return false
+	}
+}
+
+// referencesOuterRow returns whether the expression given references any column of the outer row, given its length.
+func referencesOuterRow(e sql.Expression, scopeLen int) bool {
+	var found bool
+	sql.Inspect(e, func(e sql.Expression) bool {
+		if gf, ok := e.(*expression.GetField); ok && gf.Index() < scopeLen {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// shiftFieldIndexes returns a transformation that adds the offset given to the index of every field.
+func shiftFieldIndexes(offset int) sql.TransformExprFunc {
+	return func(e sql.Expression) (sql.Expression, error) {
+		gf, ok := e.(*expression.GetField)
+		if !ok {
+			return e, nil
+		}
+		return gf.WithIndex(gf.Index() + offset), nil
+	}
+}
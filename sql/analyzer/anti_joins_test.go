@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestConvertNotExistsToAntiJoins(t *testing.T) {
+	newTable := func(name string) *plan.ResolvedTable {
+		return plan.NewResolvedTable(memory.NewTable(name, sql.Schema{
+			{Name: "id", Type: sql.Int64, Source: name},
+			{Name: "k", Type: sql.Int64, Source: name, Nullable: true},
+		}))
+	}
+	t1, t2 := newTable("t1"), newTable("t2")
+
+	// Fields of the outer table, and of the table of the subquery, whose rows are prepended with the outer row
+	t1ID := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "id", false)
+	t1K := expression.NewGetFieldWithTable(1, sql.Int64, "t1", "k", true)
+	t2ID := expression.NewGetFieldWithTable(2, sql.Int64, "t2", "id", false)
+	t2K := expression.NewGetFieldWithTable(3, sql.Int64, "t2", "k", true)
+	one := expression.NewLiteral(int8(1), sql.Int8)
+
+	notExists := func(query sql.Node) sql.Expression {
+		return expression.NewNot(plan.NewExistsSubquery(plan.NewSubquery(query, "select 1")))
+	}
+	selectOne := func(child sql.Node) sql.Node {
+		return plan.NewProject([]sql.Expression{one}, child)
+	}
+	keyEquals := expression.NewEquals(t2K, t1K)
+
+	correlated := notExists(selectOne(plan.NewFilter(keyEquals, t2)))
+	limited := notExists(plan.NewLimit(1, selectOne(plan.NewFilter(keyEquals, t2))))
+	aggregated := notExists(plan.NewGroupBy(
+		[]sql.Expression{aggregation.NewCount(expression.NewStar())},
+		nil,
+		plan.NewFilter(keyEquals, t2),
+	))
+	uncorrelated := notExists(selectOne(plan.NewFilter(expression.NewEquals(t2K, one), t2)))
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "correlated subquery",
+			node:     plan.NewFilter(correlated, t1),
+			expected: plan.NewAntiJoin(t1, t2, keyEquals),
+		},
+		{
+			name: "uncorrelated conditions of the subquery filter its table",
+			node: plan.NewFilter(
+				notExists(selectOne(plan.NewFilter(
+					expression.NewAnd(keyEquals, expression.NewGreaterThan(t2ID, one)),
+					t2,
+				))),
+				t1,
+			),
+			expected: plan.NewAntiJoin(
+				t1,
+				plan.NewFilter(
+					expression.NewGreaterThan(expression.NewGetFieldWithTable(0, sql.Int64, "t2", "id", false), one),
+					t2,
+				),
+				keyEquals,
+			),
+		},
+		{
+			name: "other conditions filter the outer table",
+			node: plan.NewFilter(
+				expression.NewAnd(correlated, expression.NewGreaterThan(t1ID, one)),
+				t1,
+			),
+			expected: plan.NewAntiJoin(
+				plan.NewFilter(expression.NewGreaterThan(t1ID, one), t1),
+				t2,
+				keyEquals,
+			),
+		},
+		{
+			name:     "subquery with a limit",
+			node:     plan.NewFilter(limited, t1),
+			expected: plan.NewFilter(limited, t1),
+		},
+		{
+			name:     "subquery with an aggregate",
+			node:     plan.NewFilter(aggregated, t1),
+			expected: plan.NewFilter(aggregated, t1),
+		},
+		{
+			name:     "uncorrelated subquery",
+			node:     plan.NewFilter(uncorrelated, t1),
+			expected: plan.NewFilter(uncorrelated, t1),
+		},
+		{
+			name: "EXISTS",
+			node: plan.NewFilter(
+				plan.NewExistsSubquery(plan.NewSubquery(selectOne(plan.NewFilter(keyEquals, t2)), "select 1")),
+				t1,
+			),
+			expected: plan.NewFilter(
+				plan.NewExistsSubquery(plan.NewSubquery(selectOne(plan.NewFilter(keyEquals, t2)), "select 1")),
+				t1,
+			),
+		},
+	}
+
+	runTestCases(t, nil, tests, NewDefault(nil), getRule("convert_not_exists_to_anti_joins"))
+}
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strconv"
 
+	opentracing "github.com/opentracing/opentracing-go"
+
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
@@ -25,56 +27,115 @@ type Batch struct {
 	Desc       string
 	Iterations int
 	Rules      []Rule
+	// Done, if set, is whether the rules are done with the node, in which case the rules are executed until Done
+	// returns true for the node, rather than until the node no longer changes.
+	Done func(sql.Node) bool
 }
 
 // Eval executes the rules of the batch. On any error, the partially transformed node is returned along with the error.
 // If the batch's max number of iterations is reached without achieving stabilization (batch evaluation no longer
-// changes the node), then this method returns ErrMaxAnalysisIters.
+// changes the node), then this method returns ErrMaxAnalysisIters. The evaluation is traced in a span of its own,
+// tagged with the batch, the number of iterations and whether the node changed.
 func (b *Batch) Eval(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	if b.Iterations == 0 {
 		return n, nil
 	}
 
+	span, ctx := ctx.Span("analyze.batch", opentracing.Tags{
+		"batch": b.Desc,
+	})
+	defer span.Finish()
+
+	cur, iterations, err := b.eval(ctx, a, n, scope)
+	span.SetTag("iterations", iterations)
+	if isTraced(span) {
+		span.SetTag("changed", !nodesEqual(n, cur))
+	}
+
+	return cur, err
+}
+
+// eval executes the rules of the batch until the node stabilizes, and returns the number of times they were executed.
+func (b *Batch) eval(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, int, error) {
+	if b.Done != nil {
+		return b.evalUntilDone(ctx, a, n, scope)
+	}
+
 	prev := n
 	a.PushDebugContext("0")
 	cur, err := b.evalOnce(ctx, a, n, scope)
 	a.PopDebugContext()
 	if err != nil {
-		return cur, err
+		return cur, 1, err
 	}
 
 	if b.Iterations == 1 {
-		return cur, nil
+		return cur, 1, nil
 	}
 
-	for i := 1; !nodesEqual(prev, cur); {
+	i := 1
+	for !nodesEqual(prev, cur) {
 		prev = cur
 		a.PushDebugContext(strconv.Itoa(i))
 		cur, err = b.evalOnce(ctx, a, cur, scope)
 		a.PopDebugContext()
 		if err != nil {
-			return cur, err
+			return cur, i + 1, err
 		}
 
 		i++
 		if i >= b.Iterations {
-			return cur, ErrMaxAnalysisIters.New(b.Iterations)
+			return cur, i, ErrMaxAnalysisIters.New(b.Iterations)
 		}
 	}
 
-	return cur, nil
+	return cur, i, nil
+}
+
+// evalUntilDone executes the rules of the batch until the Done function of the batch returns true for the node, and
+// returns the number of times they were executed. The rules aren't executed at all for a node that's done already.
+func (b *Batch) evalUntilDone(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, int, error) {
+	i := 0
+	for ; !b.Done(n); i++ {
+		if i >= b.Iterations {
+			return n, i, ErrMaxAnalysisIters.New(b.Iterations)
+		}
+
+		var err error
+		a.PushDebugContext(strconv.Itoa(i))
+		n, err = b.evalOnce(ctx, a, n, scope)
+		a.PopDebugContext()
+		if err != nil {
+			return n, i + 1, err
+		}
+	}
+
+	return n, i, nil
 }
 
 // evalOnce returns the result of evaluating a batch of rules on the node given. In the result of an error, the result
 // of the last successful transformation is returned along with the error. If no transformation was successful, the
-// input node is returned as-is.
+// input node is returned as-is. Each rule is traced in a span tagged with its name and whether it changed the node.
+// The rules that specialize comparisons are skipped when the context disables their specialization.
 func (b *Batch) evalOnce(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	prev := n
 	for _, rule := range b.Rules {
+		if ctx.GenericComparisons && specializingRules[rule.Name] {
+			a.Log("Skipping rule %s, since comparisons are evaluated generically", rule.Name)
+			continue
+		}
+
 		var err error
 		a.Log("Evaluating rule %s", rule.Name)
 		a.PushDebugContext(rule.Name)
-		next, err := rule.Apply(ctx, a, prev, scope)
+		span, ruleCtx := ctx.Span("analyze.rule", opentracing.Tags{
+			"rule": rule.Name,
+		})
+		next, err := rule.Apply(ruleCtx, a, prev, scope)
+		if isTraced(span) {
+			span.SetTag("changed", next != nil && !nodesEqual(prev, next))
+		}
+		span.Finish()
 		if next != nil {
 			a.LogDiff(prev, next)
 			prev = next
@@ -92,6 +153,13 @@ func (b *Batch) evalOnce(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope
 	return prev, nil
 }
 
+// isTraced returns whether the span given is recorded by a tracer, so that the tags that are costly to compute, such
+// as whether a rule changed the node, are only computed when someone will look at them.
+func isTraced(span opentracing.Span) bool {
+	_, noop := span.Tracer().(opentracing.NoopTracer)
+	return !noop
+}
+
 func nodesEqual(a, b sql.Node) bool {
 	if e, ok := a.(equaler); ok {
 		return e.Equal(b)
@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestBatchSkipsSpecializingRules(t *testing.T) {
+	var applied []string
+	var rules []Rule
+	for _, name := range []string{"resolve_columns", "hash_in_lists", "merge_or_ranges", "cache_compiled_predicates"} {
+		name := name
+		rules = append(rules, Rule{name, func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+			applied = append(applied, name)
+			return n, nil
+		}})
+	}
+	batch := &Batch{Desc: "test", Iterations: 1, Rules: rules}
+
+	_, err := batch.Eval(sql.NewEmptyContext(), NewDefault(nil), plan.EmptyTable, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"resolve_columns", "hash_in_lists", "merge_or_ranges", "cache_compiled_predicates"}, applied)
+
+	applied = nil
+	ctx := sql.NewContext(context.Background(), sql.WithGenericComparisons())
+	_, err = batch.Eval(ctx, NewDefault(nil), plan.EmptyTable, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"resolve_columns"}, applied)
+}
+
+func TestSpecializingRulesExist(t *testing.T) {
+	names := make(map[string]bool)
+	for _, rules := range [][]Rule{OnceBeforeDefault, DefaultRules, OnceAfterDefault, OnceAfterAll} {
+		for _, rule := range rules {
+			names[rule.Name] = true
+		}
+	}
+
+	for name := range specializingRules {
+		require.True(t, names[name], name)
+	}
+}
@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// foldBooleanCase replaces the comparisons of filters and HAVING clauses that turn a condition into 1 or 0 with a CASE
+// expression and check the result, such as CASE WHEN cond THEN 1 ELSE 0 END = 1 or CASE WHEN cond THEN 1 ELSE 0 END <> 0,
+// with the condition itself. Since such a CASE is 0 when the condition is NULL, the condition is replaced by
+// cond IS TRUE unless it can never be NULL.
+func foldBooleanCase(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fold_boolean_case")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		switch node := node.(type) {
+		case *plan.Filter:
+			e, err := expression.TransformUp(node.Expression, foldBooleanCaseComparison)
+			if err != nil {
+				return nil, err
+			}
+			return plan.NewFilter(e, node.Child), nil
+		case *plan.Having:
+			e, err := expression.TransformUp(node.Cond, foldBooleanCaseComparison)
+			if err != nil {
+				return nil, err
+			}
+			return plan.NewHaving(e, node.Child), nil
+		default:
+			return node, nil
+		}
+	})
+}
+
+func foldBooleanCaseComparison(e sql.Expression) (sql.Expression, error) {
+	var cond sql.Expression
+	switch e := e.(type) {
+	case *expression.Equals:
+		cond = booleanCaseComparedTo(e, 1)
+	case *expression.Not:
+		if eq, ok := e.Child.(*expression.Equals); ok {
+			cond = booleanCaseComparedTo(eq, 0)
+		}
+	}
+
+	if cond == nil {
+		return e, nil
+	}
+
+	if cond.Type() == sql.Boolean && !cond.IsNullable() {
+		return cond, nil
+	}
+
+	return expression.NewIsTrue(cond), nil
+}
+
+// booleanCaseComparedTo returns the condition of the CASE WHEN cond THEN 1 ELSE 0 END expression compared by the
+// equality given with the value given, or nil if it isn't such a comparison.
+func booleanCaseComparedTo(eq *expression.Equals, value int64) sql.Expression {
+	c, ok := eq.Left().(*expression.Case)
+	other := eq.Right()
+	if !ok {
+		c, ok = eq.Right().(*expression.Case)
+		other = eq.Left()
+	}
+
+	if !ok || !isNumberLiteral(other, value) {
+		return nil
+	}
+
+	if c.Expr != nil || len(c.Branches) != 1 || !isNumberLiteral(c.Branches[0].Value, 1) || !isNumberLiteral(c.Else, 0) {
+		return nil
+	}
+
+	return c.Branches[0].Cond
+}
+
+// isNumberLiteral returns whether the expression given is a literal number equal to the integer given.
+func isNumberLiteral(e sql.Expression, value int64) bool {
+	lit, ok := e.(*expression.Literal)
+	if !ok || lit.Value() == nil || !sql.IsNumber(lit.Type()) {
+		return false
+	}
+
+	cmp, err := lit.Type().Compare(lit.Value(), value)
+	return err == nil && cmp == 0
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestFoldBooleanCase(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t", Nullable: false},
+		{Name: "y", Type: sql.Int64, Source: "t", Nullable: true},
+	}))
+
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", false)
+	y := expression.NewGetFieldWithTable(1, sql.Int64, "t", "y", true)
+	zero := expression.NewLiteral(int8(0), sql.Int8)
+	one := expression.NewLiteral(int8(1), sql.Int8)
+	two := expression.NewLiteral(int8(2), sql.Int8)
+	five := expression.NewLiteral(int64(5), sql.Int64)
+	booleanCase := func(cond sql.Expression) sql.Expression {
+		return expression.NewCase(nil, []expression.CaseBranch{{Cond: cond, Value: one}}, zero)
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "compared to 1",
+			node:     plan.NewFilter(expression.NewEquals(booleanCase(expression.NewGreaterThan(x, five)), one), table),
+			expected: plan.NewFilter(expression.NewGreaterThan(x, five), table),
+		},
+		{
+			name:     "1 compared to it",
+			node:     plan.NewFilter(expression.NewEquals(one, booleanCase(expression.NewGreaterThan(x, five))), table),
+			expected: plan.NewFilter(expression.NewGreaterThan(x, five), table),
+		},
+		{
+			name: "not equal to 0",
+			node: plan.NewFilter(
+				expression.NewNot(expression.NewEquals(booleanCase(expression.NewGreaterThan(x, five)), zero)),
+				table,
+			),
+			expected: plan.NewFilter(expression.NewGreaterThan(x, five), table),
+		},
+		{
+			name:     "nullable condition",
+			node:     plan.NewFilter(expression.NewEquals(booleanCase(expression.NewGreaterThan(y, five)), one), table),
+			expected: plan.NewFilter(expression.NewIsTrue(expression.NewGreaterThan(y, five)), table),
+		},
+		{
+			name: "inside conjunction",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(booleanCase(expression.NewGreaterThan(x, five)), one),
+					expression.NewEquals(y, five),
+				),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewGreaterThan(x, five),
+					expression.NewEquals(y, five),
+				),
+				table,
+			),
+		},
+		{
+			name: "compared to 0",
+			node: plan.NewFilter(expression.NewEquals(booleanCase(expression.NewGreaterThan(x, five)), zero), table),
+		},
+		{
+			name: "compared to 2",
+			node: plan.NewFilter(expression.NewEquals(booleanCase(expression.NewGreaterThan(x, five)), two), table),
+		},
+		{
+			name: "without else",
+			node: plan.NewFilter(
+				expression.NewEquals(
+					expression.NewCase(nil, []expression.CaseBranch{{Cond: expression.NewGreaterThan(x, five), Value: one}}, nil),
+					one,
+				),
+				table,
+			),
+		},
+		{
+			name: "other values",
+			node: plan.NewFilter(
+				expression.NewEquals(
+					expression.NewCase(nil, []expression.CaseBranch{{Cond: expression.NewGreaterThan(x, five), Value: one}}, two),
+					one,
+				),
+				table,
+			),
+		},
+		{
+			name: "several branches",
+			node: plan.NewFilter(
+				expression.NewEquals(
+					expression.NewCase(nil, []expression.CaseBranch{
+						{Cond: expression.NewGreaterThan(x, five), Value: one},
+						{Cond: expression.NewGreaterThan(y, five), Value: one},
+					}, zero),
+					one,
+				),
+				table,
+			),
+		},
+		{
+			name: "case with an operand",
+			node: plan.NewFilter(
+				expression.NewEquals(
+					expression.NewCase(x, []expression.CaseBranch{{Cond: five, Value: one}}, zero),
+					one,
+				),
+				table,
+			),
+		},
+		{
+			name: "string values",
+			node: plan.NewFilter(
+				expression.NewEquals(
+					expression.NewCase(nil, []expression.CaseBranch{{
+						Cond:  expression.NewGreaterThan(x, five),
+						Value: expression.NewLiteral("1", sql.LongText),
+					}}, expression.NewLiteral("0", sql.LongText)),
+					one,
+				),
+				table,
+			),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("fold_boolean_case"))
+}
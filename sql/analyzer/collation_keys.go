@@ -0,0 +1,119 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// precomputeCollationKeys computes the sort keys of the string columns with a custom collation that a query both
+// filters by comparing them with literals and sorts by, such as in SELECT * FROM t WHERE s > 'm' ORDER BY s, once for
+// each row, so that the filter and the sort both compare the keys instead of the weights of the characters of the
+// strings, which the collation would otherwise look up for every comparison. The keys are computed by a projection
+// below the filter, which the comparisons of the filter, as expression.CollationKeyComparison, and the sort then read,
+// and another projection above the sort leaves them out of the rows returned. Comparisons whose values aren't compared
+// as they are, such as the values with trailing spaces of a PAD SPACE collation, are still compared as strings.
+func precomputeCollationKeys(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("precompute_collation_keys")
+	defer span.Finish()
+
+	// Diagnostics record the coercions of the comparisons evaluated, which the comparisons of keys skip
+	if !n.Resolved() || ctx.Diagnostics != nil {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		sort, ok := node.(*plan.Sort)
+		if !ok {
+			return node, nil
+		}
+
+		filter, ok := sort.Child.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		schema := filter.Child.Schema()
+		keys := make(map[int]*expression.GetField)
+		var keyExprs []sql.Expression
+		var predicates []sql.Expression
+		rewritten := false
+		for _, p := range splitConjunction(filter.Expression) {
+			c, ok := p.(expression.Comparer)
+			if !ok {
+				predicates = append(predicates, p)
+				continue
+			}
+
+			column, ok := sortedCollationColumn(sort, c)
+			if !ok {
+				predicates = append(predicates, p)
+				continue
+			}
+
+			key, ok := keys[column.Index()]
+			if !ok {
+				key = expression.NewGetField(len(schema)+len(keyExprs), sql.LongBlob, column.Name()+"_collation_key", column.IsNullable())
+			}
+
+			kc, ok := expression.NewCollationKeyComparison(c, key)
+			if !ok {
+				predicates = append(predicates, p)
+				continue
+			}
+
+			if _, ok := keys[column.Index()]; !ok {
+				keys[column.Index()] = key
+				keyExprs = append(keyExprs, expression.NewAlias(key.Name(), expression.NewCollationKey(column)))
+			}
+			predicates = append(predicates, kc)
+			rewritten = true
+		}
+
+		if !rewritten {
+			return node, nil
+		}
+
+		columns := make([]sql.Expression, len(schema))
+		for i, col := range schema {
+			columns[i] = expression.NewGetFieldWithTable(i, col.Type, col.Source, col.Name, col.Nullable)
+		}
+
+		fields := make([]plan.SortField, len(sort.SortFields))
+		for i, f := range sort.SortFields {
+			fields[i] = f
+			if gf, ok := f.Column.(*expression.GetField); ok && keys[gf.Index()] != nil {
+				fields[i].Column = keys[gf.Index()]
+			}
+		}
+
+		a.Log("precomputing the collation keys of %d columns sorted and filtered by %s", len(keyExprs), filter.Expression)
+		keyed := plan.NewProject(append(columns, keyExprs...), filter.Child)
+		return plan.NewProject(columns, plan.NewSort(fields, plan.NewFilter(expression.JoinAnd(predicates...), keyed))), nil
+	})
+}
+
+// sortedCollationColumn returns the column of a custom collation the comparison given compares with a literal, if the
+// sort given sorts by it.
+func sortedCollationColumn(sort *plan.Sort, c expression.Comparer) (*expression.GetField, bool) {
+	column, ok := c.Left().(*expression.GetField)
+	if !ok {
+		column, ok = c.Right().(*expression.GetField)
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	st, ok := column.Type().(sql.StringType)
+	if !ok || !st.Collation().IsCustom() {
+		return nil, false
+	}
+
+	for _, f := range sort.SortFields {
+		if gf, ok := f.Column.(*expression.GetField); ok && gf.Index() == column.Index() {
+			return column, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// registerDigitsLastCollation registers a case insensitive custom collation that orders digits after letters.
+func registerDigitsLastCollation(t testing.TB) sql.Collation {
+	c, err := sql.RegisterCollation("utf8mb4_test_keys_digits_last_ci", sql.CharacterSet_utf8mb4, func(r rune) int {
+		if unicode.IsDigit(r) {
+			return 1<<20 + int(r)
+		}
+		return int(unicode.ToLower(r))
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestPrecomputeCollationKeys(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	custom := sql.MustCreateString(sqltypes.VarChar, 20, registerDigitsLastCollation(t))
+	table := memory.NewTable("t", sql.Schema{
+		{Name: "s", Type: custom, Source: "t", Nullable: true},
+		{Name: "i", Type: sql.Int64, Source: "t"},
+		{Name: "b", Type: sql.Text, Source: "t"},
+	})
+	for i, s := range []interface{}{"a", "B", "b ", "9", "1a", "", nil, "Ab", "m", "M ", "z9", "é", "b", "A", "ab"} {
+		require.NoError(table.Insert(ctx, sql.NewRow(s, int64(i), fmt.Sprint(s))))
+	}
+	rt := plan.NewResolvedTable(table)
+
+	s := expression.NewGetFieldWithTable(0, custom, "t", "s", true)
+	i := expression.NewGetFieldWithTable(1, sql.Int64, "t", "i", false)
+	b := expression.NewGetFieldWithTable(2, sql.Text, "t", "b", false)
+	str := func(v string) sql.Expression {
+		return expression.NewLiteral(v, sql.LongText)
+	}
+	ascending := []plan.SortField{{Column: s, Order: plan.Ascending}}
+	descending := []plan.SortField{{Column: s, Order: plan.Descending}, {Column: i, Order: plan.Ascending}}
+
+	key := expression.NewGetField(3, sql.LongBlob, "s_collation_key", true)
+	columns := []sql.Expression{s, i, b}
+	keyed := plan.NewProject(append(columns, expression.NewAlias("s_collation_key", expression.NewCollationKey(s))), rt)
+	keyComparison := func(c expression.Comparer) sql.Expression {
+		kc, ok := expression.NewCollationKeyComparison(c, key)
+		require.True(ok)
+		return kc
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name: "s > 'b' and i < 12 order by s",
+			node: plan.NewSort(ascending, plan.NewFilter(
+				expression.NewAnd(expression.NewGreaterThan(s, str("b")), expression.NewLessThan(i, expression.NewLiteral(int64(12), sql.Int64))),
+				rt,
+			)),
+			expected: plan.NewProject(columns, plan.NewSort([]plan.SortField{{Column: key, Order: plan.Ascending}}, plan.NewFilter(
+				expression.NewAnd(keyComparison(expression.NewGreaterThan(s, str("b"))), expression.NewLessThan(i, expression.NewLiteral(int64(12), sql.Int64))),
+				keyed,
+			))),
+		},
+		{
+			name: "'m' >= s and s <> 'a' order by s desc, i",
+			node: plan.NewSort(descending, plan.NewFilter(
+				expression.NewAnd(expression.NewGreaterThanOrEqual(str("m"), s), expression.NewNot(expression.NewEquals(s, str("a")))),
+				rt,
+			)),
+			expected: plan.NewProject(columns, plan.NewSort([]plan.SortField{{Column: key, Order: plan.Descending}, descending[1]}, plan.NewFilter(
+				expression.NewAnd(keyComparison(expression.NewGreaterThanOrEqual(str("m"), s)), expression.NewNot(expression.NewEquals(s, str("a")))),
+				keyed,
+			))),
+		},
+		{
+			name: "s = 'B ' order by s",
+			node: plan.NewSort(ascending, plan.NewFilter(expression.NewEquals(s, str("B ")), rt)),
+			expected: plan.NewProject(columns, plan.NewSort([]plan.SortField{{Column: key, Order: plan.Ascending}},
+				plan.NewFilter(keyComparison(expression.NewEquals(s, str("B "))), keyed))),
+		},
+		{
+			name: "s > 'b' order by i",
+			node: plan.NewSort([]plan.SortField{{Column: i}}, plan.NewFilter(expression.NewGreaterThan(s, str("b")), rt)),
+		},
+		{
+			name: "s > b order by s",
+			node: plan.NewSort(ascending, plan.NewFilter(expression.NewGreaterThan(s, b), rt)),
+		},
+		{
+			name: "b > 'b' order by b",
+			node: plan.NewSort([]plan.SortField{{Column: b}}, plan.NewFilter(expression.NewGreaterThan(b, str("b")), rt)),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("precompute_collation_keys"))
+
+	// The keys are compared instead of the strings for the values compared as they are, and the strings for the rest
+	for _, tt := range tests[:3] {
+		naive, err := sql.NodeToRows(ctx, tt.node)
+		require.NoError(err)
+		keyed, err := sql.NodeToRows(ctx, tt.expected)
+		require.NoError(err)
+		require.NotEmpty(naive, tt.name)
+		require.Equal(naive, keyed, tt.name)
+	}
+}
+
+func BenchmarkPrecomputeCollationKeys(b *testing.B) {
+	ctx := sql.NewEmptyContext()
+	custom := sql.MustCreateString(sqltypes.VarChar, 40, registerDigitsLastCollation(b))
+	table := memory.NewTable("t", sql.Schema{{Name: "s", Type: custom, Source: "t"}})
+	for i := 0; i < 10000; i++ {
+		if err := table.Insert(ctx, sql.NewRow(fmt.Sprintf("row %x %d", i*7919%10007, i))); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	s := expression.NewGetFieldWithTable(0, custom, "t", "s", false)
+	naive := plan.NewSort(
+		[]plan.SortField{{Column: s, Order: plan.Ascending}},
+		plan.NewFilter(expression.NewGreaterThan(s, expression.NewLiteral("row 4", sql.LongText)), plan.NewResolvedTable(table)),
+	)
+	keyed, err := precomputeCollationKeys(ctx, NewDefault(nil), naive, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, bb := range []struct {
+		name string
+		node sql.Node
+	}{
+		{"naive", naive},
+		{"collation keys", keyed},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if _, err := sql.NodeToRows(ctx, bb.node); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// removeConstantSortFields removes from sorts the columns that are constant in the rows they sort, because they're
+// filtered by an equality with a constant, so WHERE a = 5 ORDER BY a, b becomes WHERE a = 5 ORDER BY b. A sort left
+// without any field is removed. Only the conjuncts of a filter right below the sort, or below projections of it, are
+// considered. Columns of text types are left alone, since the rows a string equality matches, such as strings that only
+// differ in case, aren't necessarily equal when sorted.
+func removeConstantSortFields(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("remove_constant_sort_fields")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		sort, ok := node.(*plan.Sort)
+		if !ok {
+			return node, nil
+		}
+
+		filter, ok := filterBelowProjections(sort.Child)
+		if !ok {
+			return node, nil
+		}
+
+		predicates := splitConjunction(filter.Expression)
+		var fields []plan.SortField
+		for _, f := range sort.SortFields {
+			if isConstantColumn(f.Column, predicates) {
+				a.Log("removed constant sort field %s", f.Column)
+				continue
+			}
+			fields = append(fields, f)
+		}
+
+		if len(fields) == len(sort.SortFields) {
+			return node, nil
+		}
+
+		if len(fields) == 0 {
+			return sort.Child, nil
+		}
+
+		return plan.NewSort(fields, sort.Child), nil
+	})
+}
+
+// filterBelowProjections returns the filter that is the node given, or is right below the projections it's made of.
+func filterBelowProjections(n sql.Node) (*plan.Filter, bool) {
+	for {
+		switch node := n.(type) {
+		case *plan.Filter:
+			return node, true
+		case *plan.Project:
+			n = node.Child
+		default:
+			return nil, false
+		}
+	}
+}
+
+// isConstantColumn returns whether the expression given is a column that isn't text and that one of the predicates
+// given checks for equality with a deterministic constant.
+func isConstantColumn(e sql.Expression, predicates []sql.Expression) bool {
+	gf, ok := e.(*expression.GetField)
+	if !ok || sql.IsText(gf.Type()) {
+		return false
+	}
+
+	value, ok := columnEqualities(gf.Table(), predicates)[strings.ToLower(gf.Name())]
+	return ok && isDeterministic(value)
+}
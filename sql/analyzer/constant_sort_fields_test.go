@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestRemoveConstantSortFields(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t"},
+		{Name: "b", Type: sql.Int64, Source: "t"},
+		{Name: "s", Type: sql.Text, Source: "t"},
+	}))
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t", "b", false)
+	s := expression.NewGetFieldWithTable(2, sql.Text, "t", "s", false)
+	five := expression.NewLiteral(int64(5), sql.Int64)
+	aIsFive := expression.NewEquals(a, five)
+	byA := plan.SortField{Column: a, Order: plan.Ascending}
+	byB := plan.SortField{Column: b, Order: plan.Descending}
+	byS := plan.SortField{Column: s, Order: plan.Ascending}
+	rand, err := function.NewRand()
+	require.NoError(t, err)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "order by a, b where a = 5",
+			node:     plan.NewSort([]plan.SortField{byA, byB}, plan.NewFilter(aIsFive, table)),
+			expected: plan.NewSort([]plan.SortField{byB}, plan.NewFilter(aIsFive, table)),
+		},
+		{
+			name: "order by b, a where 5 = a and b > 5",
+			node: plan.NewSort(
+				[]plan.SortField{byB, byA},
+				plan.NewProject([]sql.Expression{a, b}, plan.NewFilter(expression.NewAnd(expression.NewEquals(five, a), expression.NewGreaterThan(b, five)), table)),
+			),
+			expected: plan.NewSort(
+				[]plan.SortField{byB},
+				plan.NewProject([]sql.Expression{a, b}, plan.NewFilter(expression.NewAnd(expression.NewEquals(five, a), expression.NewGreaterThan(b, five)), table)),
+			),
+		},
+		{
+			name:     "order by a where a = 5",
+			node:     plan.NewSort([]plan.SortField{byA}, plan.NewFilter(aIsFive, table)),
+			expected: plan.NewFilter(aIsFive, table),
+		},
+		{
+			name: "order by a, b where a = 5 or b = 5",
+			node: plan.NewSort([]plan.SortField{byA, byB}, plan.NewFilter(expression.NewOr(aIsFive, expression.NewEquals(b, five)), table)),
+		},
+		{
+			name: "order by a, b where a > 5",
+			node: plan.NewSort([]plan.SortField{byA, byB}, plan.NewFilter(expression.NewGreaterThan(a, five), table)),
+		},
+		{
+			name: "order by a, b where a = b",
+			node: plan.NewSort([]plan.SortField{byA, byB}, plan.NewFilter(expression.NewEquals(a, b), table)),
+		},
+		{
+			name: "order by a, b where a = rand()",
+			node: plan.NewSort([]plan.SortField{byA, byB}, plan.NewFilter(expression.NewEquals(a, rand), table)),
+		},
+		{
+			name: "order by s, b where s = 'a'",
+			node: plan.NewSort([]plan.SortField{byS, byB}, plan.NewFilter(expression.NewEquals(s, expression.NewLiteral("a", sql.LongText)), table)),
+		},
+		{
+			name: "order by a, b without a filter",
+			node: plan.NewSort([]plan.SortField{byA, byB}, table),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("remove_constant_sort_fields"))
+}
@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// DatabasePredicate is whether a rule added with AddDatabaseRule applies to the queries of the database given.
+type DatabasePredicate func(db sql.Database) bool
+
+// queryDatabasesKey is the key of the databases targeted by the query being analyzed in the context of the analysis.
+type queryDatabasesKey struct{}
+
+// DatabaseRule returns a rule function that applies the function given only to queries that target a database that
+// the predicate given matches, such as a rule specific to the schema of some of the databases of a server with many
+// tenants. Nodes of any other query are returned unchanged.
+func DatabaseRule(fn RuleFunc, matches DatabasePredicate) RuleFunc {
+	return func(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+		for _, db := range queryDatabases(ctx) {
+			if matches(db) {
+				return fn(ctx, a, n, scope)
+			}
+		}
+
+		return n, nil
+	}
+}
+
+// queryDatabases returns the databases targeted by the query being analyzed in the context given.
+func queryDatabases(ctx *sql.Context) []sql.Database {
+	dbs, _ := ctx.Value(queryDatabasesKey{}).([]sql.Database)
+	return dbs
+}
+
+// withQueryDatabases returns the context given with the databases targeted by the node given added to those of the
+// query being analyzed, if any. A database is targeted when the node, or any of its subqueries, reads from a table of
+// it, or is a statement on it, such as SHOW TABLES. Tables without a database are those of the current database.
+// Databases are looked up in the catalog of the analyzer when they aren't resolved yet, and those that don't exist
+// are left out.
+func withQueryDatabases(ctx *sql.Context, a *Analyzer, n sql.Node) *sql.Context {
+	dbs := queryDatabases(ctx)
+	seen := make(map[string]bool, len(dbs))
+	for _, db := range dbs {
+		seen[db.Name()] = true
+	}
+
+	add := func(db sql.Database) {
+		if db == nil || seen[db.Name()] {
+			return
+		}
+
+		if _, ok := db.(sql.UnresolvedDatabase); ok {
+			if a.Catalog == nil {
+				return
+			}
+
+			name := db.Name()
+			if name == "" {
+				name = ctx.GetCurrentDatabase()
+			}
+
+			resolved, err := a.Catalog.Database(name)
+			if err != nil {
+				return
+			}
+			db = resolved
+		}
+
+		seen[db.Name()] = true
+		dbs = append(dbs, db)
+	}
+
+	var inspect func(n sql.Node)
+	inspect = func(n sql.Node) {
+		plan.Inspect(n, func(n sql.Node) bool {
+			switch n := n.(type) {
+			case *plan.UnresolvedTable:
+				add(sql.UnresolvedDatabase(n.Database))
+			case sql.Databaser:
+				add(n.Database())
+			}
+			return true
+		})
+
+		plan.InspectExpressions(n, func(e sql.Expression) bool {
+			if s, ok := e.(*plan.Subquery); ok {
+				inspect(s.Query)
+			}
+			return true
+		})
+	}
+	inspect(n)
+
+	if len(dbs) == 0 {
+		return ctx
+	}
+
+	return ctx.WithContext(context.WithValue(ctx.Context, queryDatabasesKey{}, dbs))
+}
@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// expressionDiff returns a description of the expressions that changed between the two plans given, if the only
+// difference between them is in the expressions of their nodes. Otherwise, it returns false, and the difference needs
+// to be described at the node level.
+func expressionDiff(prev, next sql.Node) (string, bool) {
+	var changes []string
+	reverted, ok := revertExpressions(prev, next, &changes)
+	if !ok || len(changes) == 0 {
+		return "", false
+	}
+
+	if sql.DebugString(reverted) != sql.DebugString(prev) {
+		return "", false
+	}
+
+	return strings.Join(changes, ""), true
+}
+
+// revertExpressions returns the next node with the expressions of the prev node, recording every expression that
+// differs between both in changes. The nodes must have the same shape, otherwise it returns false.
+func revertExpressions(prev, next sql.Node, changes *[]string) (sql.Node, bool) {
+	if reflect.TypeOf(prev) != reflect.TypeOf(next) {
+		return nil, false
+	}
+
+	prevChildren, nextChildren := prev.Children(), next.Children()
+	if len(prevChildren) != len(nextChildren) {
+		return nil, false
+	}
+
+	var err error
+	if len(nextChildren) > 0 {
+		children := make([]sql.Node, len(nextChildren))
+		for i := range nextChildren {
+			var ok bool
+			children[i], ok = revertExpressions(prevChildren[i], nextChildren[i], changes)
+			if !ok {
+				return nil, false
+			}
+		}
+
+		next, err = next.WithChildren(children...)
+		if err != nil {
+			return nil, false
+		}
+	}
+
+	pe, ok := prev.(sql.Expressioner)
+	if !ok {
+		return next, true
+	}
+
+	prevExprs, nextExprs := pe.Expressions(), next.(sql.Expressioner).Expressions()
+	if len(prevExprs) != len(nextExprs) {
+		return nil, false
+	}
+
+	for i := range prevExprs {
+		before, after := sql.DebugString(prevExprs[i]), sql.DebugString(nextExprs[i])
+		if before != after {
+			*changes = append(*changes, fmt.Sprintf("%T:\n- %s\n+ %s\n", prev, before, after))
+		}
+	}
+
+	next, err = next.(sql.Expressioner).WithExpressions(prevExprs...)
+	if err != nil {
+		return nil, false
+	}
+
+	return next, true
+}
@@ -0,0 +1,45 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestExpressionDiff(t *testing.T) {
+	require := require.New(t)
+
+	table := plan.NewResolvedTable(memory.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+	}))
+	i := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+
+	prev := plan.NewProject(
+		[]sql.Expression{i},
+		plan.NewFilter(expression.NewEquals(i, expression.NewPlus(one, one)), table),
+	)
+	folded := plan.NewProject(
+		[]sql.Expression{i},
+		plan.NewFilter(expression.NewEquals(i, two), table),
+	)
+
+	diff, ok := expressionDiff(prev, folded)
+	require.True(ok)
+	require.Equal("*plan.Filter:\n"+
+		"- [mytable.i, idx=0, type=BIGINT, nullable=false] = 1 (BIGINT) + 1 (BIGINT)\n"+
+		"+ [mytable.i, idx=0, type=BIGINT, nullable=false] = 2 (BIGINT)\n", diff)
+
+	reshaped := plan.NewFilter(expression.NewEquals(i, two), table)
+	_, ok = expressionDiff(prev, reshaped)
+	require.False(ok)
+
+	_, ok = expressionDiff(prev, prev)
+	require.False(ok)
+}
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql/plan"
 
@@ -78,6 +79,34 @@ func exprToTableFilters(expr sql.Expression) (filtersByTable, error) {
 	return filtersByTable, nil
 }
 
+// SplitConjunctionByTable splits the expression given at AND into the predicates on a single table of the schema given,
+// which can be pushed down to that table, grouped by table name, and the rest, such as the conditions that join tables,
+// the ones on tables outside the schema and those on no table at all. Table names are matched case-insensitively and
+// the predicates are keyed by the names the schema uses.
+func SplitConjunctionByTable(expr sql.Expression, schema sql.Schema) (map[string][]sql.Expression, []sql.Expression) {
+	tables := make(map[string]string)
+	for _, col := range schema {
+		if col.Source != "" {
+			tables[strings.ToLower(col.Source)] = col.Source
+		}
+	}
+
+	byTable := make(map[string][]sql.Expression)
+	var residual []sql.Expression
+	for _, e := range splitConjunction(expr) {
+		referenced := findTables(e)
+		if len(referenced) == 1 {
+			if table, ok := tables[strings.ToLower(referenced[0])]; ok {
+				byTable[table] = append(byTable[table], e)
+				continue
+			}
+		}
+		residual = append(residual, e)
+	}
+
+	return byTable, residual
+}
+
 type filterSet struct {
 	filtersByTable      filtersByTable
 	handledFilters      []sql.Expression
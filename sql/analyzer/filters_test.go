@@ -193,3 +193,37 @@ func TestExprToTableFilters(t *testing.T) {
 	))
 	assert.NoError(t, err)
 }
+
+func TestSplitConjunctionByTable(t *testing.T) {
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t1"},
+		{Name: "b", Type: sql.Int64, Source: "t2"},
+		{Name: "c", Type: sql.Int64, Source: "T3"},
+	}
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t2", "b", false)
+	c := expression.NewGetFieldWithTable(2, sql.Int64, "t3", "c", false)
+	outer := expression.NewGetFieldWithTable(0, sql.Int64, "t4", "d", false)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+
+	aIsOne := expression.NewEquals(a, one)
+	aBelowB := expression.NewLessThan(a, b)
+	bIsNull := expression.NewIsNull(b)
+	cAboveOne := expression.NewGreaterThan(c, one)
+	cOrA := expression.NewOr(expression.NewEquals(c, one), aIsOne)
+	cIsOuter := expression.NewEquals(c, outer)
+	oneIsOne := expression.NewEquals(one, one)
+
+	byTable, residual := SplitConjunctionByTable(
+		expression.JoinAnd(aIsOne, aBelowB, bIsNull, cAboveOne, cOrA, expression.NewAnd(cIsOuter, oneIsOne)),
+		schema,
+	)
+
+	require.Equal(t, map[string][]sql.Expression{
+		"t1": {aIsOne},
+		"t2": {bIsNull},
+		"T3": {cAboveOne},
+	}, byTable)
+	require.Equal(t, []sql.Expression{aBelowB, cOrA, cIsOuter, oneIsOne}, residual)
+}
@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// foldEmptyInLists replaces the IN expressions whose right operand is known to be empty before running them with false,
+// and the NOT IN expressions with true. An empty set never contains the left operand, even if it's NULL, so the result
+// isn't NULL either. The right operand is known to be empty when it's a tuple without elements, or a subquery that
+// isEmptyQuery proves never returns any row, such as x IN (SELECT i FROM t WHERE 1 = 0).
+func foldEmptyInLists(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fold_empty_in_lists")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		if isEmptyInList(e) {
+			a.Log("folding %s to false", e)
+			return expression.NewLiteral(false, sql.Boolean), nil
+		}
+
+		// Expressions are transformed bottom up, so the IN of a NOT IN has already been folded by now
+		if not, ok := e.(*expression.Not); ok && isFalse(not.Child) {
+			return expression.NewLiteral(true, sql.Boolean), nil
+		}
+
+		return e, nil
+	})
+}
+
+// isEmptyInList returns whether the expression given is an IN expression whose right operand is known to be empty.
+func isEmptyInList(e sql.Expression) bool {
+	switch e := e.(type) {
+	case *expression.InTuple:
+		tuple, ok := e.Right().(expression.Tuple)
+		return ok && len(tuple) == 0
+	case *plan.InSubquery:
+		subquery, ok := e.Right.(*plan.Subquery)
+		return ok && subquery.Resolved() && isEmptyQuery(subquery.Query)
+	default:
+		return false
+	}
+}
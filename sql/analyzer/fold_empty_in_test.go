@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestFoldEmptyInLists(t *testing.T) {
+	newTable := func(name string) *plan.ResolvedTable {
+		return plan.NewResolvedTable(memory.NewTable(name, sql.Schema{
+			{Name: "i", Type: sql.Int64, Source: name},
+		}))
+	}
+	t1, t2 := newTable("t1"), newTable("t2")
+	i := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "i", false)
+	j := expression.NewGetFieldWithTable(0, sql.Int64, "t2", "i", false)
+	one := expression.NewLiteral(int8(1), sql.Int8)
+
+	subquery := func(query sql.Node) sql.Expression {
+		return plan.NewSubquery(query, "select i from t2")
+	}
+	selectJ := func(child sql.Node) sql.Node {
+		return plan.NewProject([]sql.Expression{j}, child)
+	}
+	filter := func(e sql.Expression) sql.Node {
+		return plan.NewFilter(e, t1)
+	}
+	trueLit := expression.NewLiteral(true, sql.Boolean)
+	falseLit := expression.NewLiteral(false, sql.Boolean)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "empty tuple",
+			node:     filter(expression.NewInTuple(i, expression.NewTuple())),
+			expected: filter(falseLit),
+		},
+		{
+			name:     "not in empty tuple",
+			node:     filter(expression.NewNotInTuple(i, expression.NewTuple())),
+			expected: filter(trueLit),
+		},
+		{
+			name:     "subquery filtered with a false condition",
+			node:     filter(plan.NewInSubquery(i, subquery(selectJ(plan.NewFilter(falseLit, t2))))),
+			expected: filter(falseLit),
+		},
+		{
+			name:     "not in subquery of an empty table",
+			node:     filter(plan.NewNotInSubquery(i, subquery(selectJ(plan.EmptyTable)))),
+			expected: filter(trueLit),
+		},
+		{
+			name:     "subquery limited to no rows",
+			node:     filter(plan.NewInSubquery(i, subquery(plan.NewLimit(0, selectJ(t2))))),
+			expected: filter(falseLit),
+		},
+		{
+			name: "in list of a projection",
+			node: plan.NewProject(
+				[]sql.Expression{plan.NewInSubquery(i, subquery(selectJ(plan.EmptyTable)))},
+				t1,
+			),
+			expected: plan.NewProject([]sql.Expression{falseLit}, t1),
+		},
+		{
+			name:     "subquery that may have rows",
+			node:     filter(plan.NewInSubquery(i, subquery(selectJ(t2)))),
+			expected: filter(plan.NewInSubquery(i, subquery(selectJ(t2)))),
+		},
+		{
+			name:     "non-empty tuple",
+			node:     filter(expression.NewInTuple(i, expression.NewTuple(one))),
+			expected: filter(expression.NewInTuple(i, expression.NewTuple(one))),
+		},
+	}
+
+	runTestCases(t, nil, tests, NewDefault(nil), getRule("fold_empty_in_lists"))
+}
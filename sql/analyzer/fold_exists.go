@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// foldExistsSubqueries replaces the EXISTS subqueries whose results are known to be empty or not before running them
+// with a constant, such as EXISTS (SELECT 1), which is always true, or EXISTS (SELECT 1 FROM t WHERE 1 = 0), which is
+// always false. Only subqueries whose number of rows doesn't depend on any table or outer column are folded, so the
+// rest are left to be run as usual.
+func foldExistsSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fold_exists_subqueries")
+	defer span.Finish()
+
+	return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		exists, ok := e.(*plan.ExistsSubquery)
+		if !ok || !exists.Resolved() {
+			return e, nil
+		}
+
+		subquery, ok := exists.Child.(*plan.Subquery)
+		if !ok {
+			return e, nil
+		}
+
+		if isEmptyQuery(subquery.Query) {
+			a.Log("folding %s to false", exists)
+			return expression.NewLiteral(false, sql.Boolean), nil
+		}
+
+		if hasRowsQuery(subquery.Query) {
+			a.Log("folding %s to true", exists)
+			return expression.NewLiteral(true, sql.Boolean), nil
+		}
+
+		return e, nil
+	})
+}
+
+// isEmptyQuery returns whether the query given can be proven to never return any row, because it's filtered with a
+// constant false condition, which eval_filter replaces with an empty table, or limited to zero rows.
+func isEmptyQuery(n sql.Node) bool {
+	switch n := n.(type) {
+	case *plan.Filter:
+		return isFalse(n.Expression) || isEmptyQuery(n.Child)
+	case *plan.Limit:
+		return n.Limit == 0 || isEmptyQuery(n.Child)
+	case *plan.Project, *plan.Sort, *plan.Distinct, *plan.OrderedDistinct, *plan.Offset, *plan.SubqueryAlias,
+		*plan.Exchange, *plan.QueryProcess:
+		return isEmptyQuery(n.Children()[0])
+	default:
+		return n == plan.EmptyTable
+	}
+}
+
+// hasRowsQuery returns whether the query given can be proven to always return at least one row, because all its rows
+// come from the dual table or a list of values, and they're filtered only with constant true conditions.
+func hasRowsQuery(n sql.Node) bool {
+	switch n := n.(type) {
+	case *plan.Filter:
+		return isTrue(n.Expression) && hasRowsQuery(n.Child)
+	case *plan.Limit:
+		return n.Limit > 0 && hasRowsQuery(n.Child)
+	case *plan.Project, *plan.Sort, *plan.Distinct, *plan.OrderedDistinct, *plan.SubqueryAlias, *plan.Exchange,
+		*plan.QueryProcess:
+		return hasRowsQuery(n.Children()[0])
+	case *plan.Values:
+		return len(n.ExpressionTuples) > 0
+	case *plan.ResolvedTable:
+		return isDualTable(n.Table)
+	default:
+		return false
+	}
+}
+
+// isDualTable returns whether the table given is the dual table, which has a single row, or a wrapper of it.
+func isDualTable(t sql.Table) bool {
+	for {
+		if t == dualTable {
+			return true
+		}
+
+		wrapper, ok := t.(sql.TableWrapper)
+		if !ok {
+			return false
+		}
+		t = wrapper.Underlying()
+	}
+}
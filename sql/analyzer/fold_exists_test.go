@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestFoldExistsSubqueries(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "t"},
+	}))
+	i := expression.NewGetFieldWithTable(0, sql.Int64, "t", "i", false)
+	one := expression.NewLiteral(int8(1), sql.Int8)
+	dual := plan.NewResolvedTable(dualTable)
+
+	exists := func(query sql.Node) sql.Expression {
+		return plan.NewExistsSubquery(plan.NewSubquery(query, "select 1"))
+	}
+	filter := func(e sql.Expression) sql.Node {
+		return plan.NewFilter(e, table)
+	}
+	selectOne := func(child sql.Node) sql.Node {
+		return plan.NewProject([]sql.Expression{one}, child)
+	}
+	trueLit := expression.NewLiteral(true, sql.Boolean)
+	falseLit := expression.NewLiteral(false, sql.Boolean)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "select without tables",
+			node:     filter(exists(selectOne(dual))),
+			expected: filter(trueLit),
+		},
+		{
+			name:     "select without tables filtered with a true condition",
+			node:     filter(exists(selectOne(plan.NewFilter(trueLit, dual)))),
+			expected: filter(trueLit),
+		},
+		{
+			name:     "select of values",
+			node:     filter(exists(plan.NewValues([][]sql.Expression{{one}}))),
+			expected: filter(trueLit),
+		},
+		{
+			name:     "select from an empty table",
+			node:     filter(exists(selectOne(plan.EmptyTable))),
+			expected: filter(falseLit),
+		},
+		{
+			name:     "select filtered with a false condition",
+			node:     filter(exists(selectOne(plan.NewFilter(falseLit, table)))),
+			expected: filter(falseLit),
+		},
+		{
+			name:     "select limited to no rows",
+			node:     filter(exists(plan.NewLimit(0, selectOne(table)))),
+			expected: filter(falseLit),
+		},
+		{
+			name:     "not exists",
+			node:     filter(expression.NewNot(exists(selectOne(plan.EmptyTable)))),
+			expected: filter(expression.NewNot(falseLit)),
+		},
+		{
+			name: "select from a table",
+			node: filter(exists(selectOne(table))),
+		},
+		{
+			name: "select without tables filtered with a column",
+			node: filter(exists(selectOne(plan.NewFilter(expression.NewEquals(i, one), dual)))),
+		},
+		{
+			name: "select from a table limited to one row",
+			node: filter(exists(plan.NewLimit(1, selectOne(table)))),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("fold_exists_subqueries"))
+}
@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// foldNullChecks replaces the IS NULL and IS NOT NULL checks of filters and HAVING clauses with a constant when the
+// expression checked is known to never be NULL, such as a NOT NULL column or COUNT(*), or to always be NULL. Strings
+// aren't known to never be NULL when the session takes empty strings as NULL.
+func foldNullChecks(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fold_null_checks")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	emptyStringIsNull := sql.EmptyStringIsNull(ctx)
+	fold := func(e sql.Expression) (sql.Expression, error) {
+		return foldNullCheck(e, emptyStringIsNull)
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		switch node := node.(type) {
+		case *plan.Filter:
+			e, err := expression.TransformUp(node.Expression, fold)
+			if err != nil {
+				return nil, err
+			}
+			return plan.NewFilter(e, node.Child), nil
+		case *plan.Having:
+			e, err := expression.TransformUp(node.Cond, fold)
+			if err != nil {
+				return nil, err
+			}
+			return plan.NewHaving(e, node.Child), nil
+		default:
+			return node, nil
+		}
+	})
+}
+
+func foldNullCheck(e sql.Expression, emptyStringIsNull bool) (sql.Expression, error) {
+	switch e := e.(type) {
+	case *expression.IsNull:
+		if isNeverNull(e.Child) && !(emptyStringIsNull && sql.IsText(e.Child.Type())) {
+			return expression.NewLiteral(false, sql.Boolean), nil
+		}
+
+		if isAlwaysNull(e.Child) {
+			return expression.NewLiteral(true, sql.Boolean), nil
+		}
+
+		return e, nil
+	case *expression.Not:
+		// IS NOT NULL is the negation of IS NULL, which may have been folded already
+		if lit, ok := e.Child.(*expression.Literal); ok {
+			if b, ok := lit.Value().(bool); ok {
+				return expression.NewLiteral(!b, sql.Boolean), nil
+			}
+		}
+
+		return e, nil
+	default:
+		return e, nil
+	}
+}
+
+// isNeverNull returns whether the expression given can be proven to never evaluate to NULL.
+func isNeverNull(e sql.Expression) bool {
+	switch e := e.(type) {
+	case *expression.Literal:
+		return e.Value() != nil
+	case *expression.GetField:
+		return !e.IsNullable()
+	case *aggregation.Count, *aggregation.CountDistinct, *expression.IsNull:
+		return true
+	case *expression.Alias:
+		return isNeverNull(e.Child)
+	default:
+		return false
+	}
+}
+
+// isAlwaysNull returns whether the expression given can be proven to always evaluate to NULL.
+func isAlwaysNull(e sql.Expression) bool {
+	switch e := e.(type) {
+	case *expression.Literal:
+		return e.Value() == nil
+	case *expression.Arithmetic:
+		return isAlwaysNull(e.Left) || isAlwaysNull(e.Right)
+	case *expression.Alias:
+		return isAlwaysNull(e.Child)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestFoldNullChecks(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t", Nullable: false},
+		{Name: "y", Type: sql.Int64, Source: "t", Nullable: true},
+	}))
+
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", false)
+	y := expression.NewGetFieldWithTable(1, sql.Int64, "t", "y", true)
+	null := expression.NewLiteral(nil, sql.Null)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	trueLit := expression.NewLiteral(true, sql.Boolean)
+	falseLit := expression.NewLiteral(false, sql.Boolean)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "not null column is null",
+			node:     plan.NewFilter(expression.NewIsNull(x), table),
+			expected: plan.NewFilter(falseLit, table),
+		},
+		{
+			name:     "not null column is not null",
+			node:     plan.NewFilter(expression.NewNot(expression.NewIsNull(x)), table),
+			expected: plan.NewFilter(trueLit, table),
+		},
+		{
+			name: "nullable column is null",
+			node: plan.NewFilter(expression.NewIsNull(y), table),
+		},
+		{
+			name: "nullable column is not null",
+			node: plan.NewFilter(expression.NewNot(expression.NewIsNull(y)), table),
+		},
+		{
+			name:     "literal is null",
+			node:     plan.NewFilter(expression.NewIsNull(one), table),
+			expected: plan.NewFilter(falseLit, table),
+		},
+		{
+			name:     "null literal is null",
+			node:     plan.NewFilter(expression.NewIsNull(null), table),
+			expected: plan.NewFilter(trueLit, table),
+		},
+		{
+			name:     "arithmetic on null is not null",
+			node:     plan.NewFilter(expression.NewNot(expression.NewIsNull(expression.NewPlus(y, null))), table),
+			expected: plan.NewFilter(falseLit, table),
+		},
+		{
+			name: "null checks in conjunction",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewNot(expression.NewIsNull(x)),
+					expression.NewIsNull(y),
+				),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(trueLit, expression.NewIsNull(y)),
+				table,
+			),
+		},
+		{
+			name: "count is null in having",
+			node: plan.NewHaving(
+				expression.NewIsNull(aggregation.NewCount(y)),
+				plan.NewGroupBy(
+					[]sql.Expression{aggregation.NewCount(y)},
+					[]sql.Expression{x},
+					table,
+				),
+			),
+			expected: plan.NewHaving(
+				falseLit,
+				plan.NewGroupBy(
+					[]sql.Expression{aggregation.NewCount(y)},
+					[]sql.Expression{x},
+					table,
+				),
+			),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("fold_null_checks"))
+}
+
+func TestFoldNullChecksEmptyStringIsNull(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t", Nullable: false},
+		{Name: "s", Type: sql.Text, Source: "t", Nullable: false},
+	}))
+
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", false)
+	s := expression.NewGetFieldWithTable(1, sql.Text, "t", "s", false)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "not null column is null",
+			node:     plan.NewFilter(expression.NewIsNull(x), table),
+			expected: plan.NewFilter(expression.NewLiteral(false, sql.Boolean), table),
+		},
+		{
+			name: "not null string column is null",
+			node: plan.NewFilter(expression.NewIsNull(s), table),
+		},
+		{
+			name: "string literal is null",
+			node: plan.NewFilter(expression.NewIsNull(expression.NewLiteral("", sql.LongText)), table),
+		},
+	}
+
+	ctx := sql.NewEmptyContext()
+	require.NoError(t, ctx.Set(ctx, sql.EmptyStringIsNullSessionVar, sql.Int8, int8(1)))
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("fold_null_checks"))
+}
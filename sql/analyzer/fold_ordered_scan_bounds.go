@@ -0,0 +1,213 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// foldOrderedScanBounds folds the comparisons of the columns of a plan.OrderedIndexScan with constants in the filter
+// right above it into the bounds of the range of the scan, so that it starts and stops reading rows at the tightest
+// bounds the filter allows. The comparisons the range of the scan then implies are removed from the filter, which is
+// removed altogether if none are left. Only the comparisons of the key of the index the scan is ordered by are folded,
+// if their constants are values of the type of the columns, so that the index looks them up exactly. A comparison is
+// only removed if the range has a lower bound, since NULL values are before any other in the order of the index.
+func foldOrderedScanBounds(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fold_ordered_scan_bounds")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		scan, ok := filter.Child.(*plan.OrderedIndexScan)
+		if !ok {
+			return node, nil
+		}
+
+		columns, ok := scanColumns(scan)
+		if !ok {
+			return node, nil
+		}
+
+		predicates := splitConjunction(filter.Expression)
+		lower, upper := scan.Lower, scan.Upper
+		for _, p := range predicates {
+			c, ok := scanKeyComparison(columns, p)
+			if !ok || !isExactBound(ctx, columns, c.Right()) {
+				continue
+			}
+
+			isLower, isUpper, inclusive := boundSides(c)
+			// A bound on the excluded side of the range must exclude its constant
+			if isLower && (!scan.Descending || !inclusive) && (lower == nil || evalsToTrue(ctx, expression.NewGreaterThan(c.Right(), lower))) {
+				lower = c.Right()
+			}
+			if isUpper && (scan.Descending || !inclusive) && (upper == nil || evalsToTrue(ctx, expression.NewLessThan(c.Right(), upper))) {
+				upper = c.Right()
+			}
+		}
+
+		var residual []sql.Expression
+		for _, p := range predicates {
+			if !impliedByScanRange(ctx, columns, p, scan.Descending, lower, upper) {
+				residual = append(residual, p)
+			}
+		}
+
+		if lower == scan.Lower && upper == scan.Upper && len(residual) == len(predicates) {
+			return node, nil
+		}
+
+		a.Log("folded the bounds of filter %s into the scan of table %s", filter.Expression, scan.Name())
+		newScan, err := plan.NewOrderedIndexScan(scan.ResolvedTable, scan.Index, scan.Descending, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(residual) == 0 {
+			return newScan, nil
+		}
+		return plan.NewFilter(expression.JoinAnd(residual...), newScan), nil
+	})
+}
+
+// scanColumns returns the columns of the table of the scan given that the index it's ordered by is of, or false if
+// they aren't all columns of the table.
+func scanColumns(scan *plan.OrderedIndexScan) ([]*expression.GetField, bool) {
+	schema := scan.Schema()
+	names := indexColumnNames(scan.Index)
+	columns := make([]*expression.GetField, len(names))
+	for i, name := range names {
+		idx := schema.IndexOf(name, scan.Name())
+		if idx < 0 {
+			return nil, false
+		}
+
+		col := schema[idx]
+		columns[i] = expression.NewGetFieldWithTable(idx, col.Type, col.Source, col.Name, col.Nullable)
+	}
+	return columns, true
+}
+
+// scanKeyComparison returns the predicate given as a comparison of the key of an index of the columns given with a
+// constant that can bound the range of an ordered scan of it, with the key on the left, or false if it isn't one.
+func scanKeyComparison(columns []*expression.GetField, p sql.Expression) (expression.Comparer, bool) {
+	c, ok := p.(expression.Comparer)
+	if !ok {
+		return nil, false
+	}
+
+	switch c.(type) {
+	case *expression.Equals, *expression.GreaterThan, *expression.GreaterThanOrEqual, *expression.LessThan,
+		*expression.LessThanOrEqual:
+	default:
+		return nil, false
+	}
+
+	if isScanKey(columns, c.Right()) {
+		_, _, c = swapTermsOfExpression(c)
+	}
+
+	if !isScanKey(columns, c.Left()) || !isScanBound(columns, c.Right()) {
+		return nil, false
+	}
+	return c, true
+}
+
+// boundSides returns whether the comparison given of a key with a constant sets a lower bound of the key, an upper
+// bound, or both, and whether the constant is included.
+func boundSides(c expression.Comparer) (isLower, isUpper, inclusive bool) {
+	switch c.(type) {
+	case *expression.Equals:
+		return true, true, true
+	case *expression.GreaterThan:
+		return true, false, false
+	case *expression.GreaterThanOrEqual:
+		return true, false, true
+	case *expression.LessThan:
+		return false, true, false
+	default:
+		return false, true, true
+	}
+}
+
+// impliedByScanRange returns whether every row of the range of an ordered scan of an index of the columns given,
+// between the bounds given, matches the predicate given. The lower bound of an ascending scan and the upper bound of a
+// descending one are included in the range, and the other bounds aren't.
+func impliedByScanRange(ctx *sql.Context, columns []*expression.GetField, p sql.Expression, descending bool, lower, upper sql.Expression) bool {
+	// Without a lower bound, the range includes the NULL values no comparison matches
+	if lower == nil || !isExactBound(ctx, columns, lower) {
+		return false
+	}
+
+	c, ok := scanKeyComparison(columns, p)
+	if !ok || !isExactBound(ctx, columns, c.Right()) {
+		return false
+	}
+
+	value := c.Right()
+	switch c.(type) {
+	case *expression.GreaterThan:
+		if descending {
+			return evalsToTrue(ctx, expression.NewGreaterThanOrEqual(lower, value))
+		}
+		return evalsToTrue(ctx, expression.NewGreaterThan(lower, value))
+	case *expression.GreaterThanOrEqual:
+		return evalsToTrue(ctx, expression.NewGreaterThanOrEqual(lower, value))
+	}
+
+	if upper == nil || !isExactBound(ctx, columns, upper) {
+		return false
+	}
+
+	switch c.(type) {
+	case *expression.LessThan:
+		if descending {
+			return evalsToTrue(ctx, expression.NewLessThan(upper, value))
+		}
+		return evalsToTrue(ctx, expression.NewLessThanOrEqual(upper, value))
+	case *expression.LessThanOrEqual:
+		return evalsToTrue(ctx, expression.NewLessThanOrEqual(upper, value))
+	default:
+		return false
+	}
+}
+
+// isExactBound returns whether the bound given of a range of an index of the columns given is a value of the type of
+// the column, or a tuple of them for several columns, which converts to the type without changing.
+func isExactBound(ctx *sql.Context, columns []*expression.GetField, bound sql.Expression) bool {
+	values := []sql.Expression{bound}
+	if tuple, ok := bound.(expression.Tuple); ok && len(columns) > 1 {
+		values = tuple
+	}
+
+	if len(values) != len(columns) {
+		return false
+	}
+
+	for i, v := range values {
+		value, err := v.Eval(ctx, nil)
+		if err != nil || value == nil {
+			return false
+		}
+
+		converted, err := columns[i].Type().Convert(value)
+		if err != nil || !evalsToTrue(ctx, expression.NewEquals(expression.NewLiteral(converted, columns[i].Type()), v)) {
+			return false
+		}
+	}
+	return true
+}
+
+// evalsToTrue returns whether the constant predicate given evaluates to true.
+func evalsToTrue(ctx *sql.Context, e sql.Expression) bool {
+	v, err := e.Eval(ctx, nil)
+	return err == nil && v == true
+}
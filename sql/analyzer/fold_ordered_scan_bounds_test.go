@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestFoldOrderedScanBounds(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable", Nullable: true},
+		{Name: "b", Type: sql.Int64, Source: "mytable"},
+	}
+	ctx := sql.NewEmptyContext()
+	table := &countingTable{Table: memory.NewTable("mytable", schema), read: new(int)}
+	require.NoError(table.CreateIndex(ctx, "a_idx", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "a"}}, ""))
+	require.NoError(table.Insert(ctx, sql.NewRow(nil, int64(0))))
+	for a := int64(1); a <= 100; a++ {
+		require.NoError(table.Insert(ctx, sql.NewRow(a, a%10)))
+	}
+
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	rt := plan.NewResolvedTable(table)
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", true)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", false)
+	lit := func(v int64) sql.Expression {
+		return expression.NewLiteral(v, sql.Int64)
+	}
+
+	scan := func(descending bool, lower, upper sql.Expression) *plan.OrderedIndexScan {
+		s, err := plan.NewOrderedIndexScan(rt, indexes[0], descending, lower, upper)
+		require.NoError(err)
+		return s
+	}
+
+	and := expression.JoinAnd
+	tests := []analyzerFnTestCase{
+		{
+			name:     "a >= 10 and a < 20 over [10, 20)",
+			node:     plan.NewFilter(and(expression.NewGreaterThanOrEqual(a, lit(10)), expression.NewLessThan(a, lit(20))), scan(false, lit(10), lit(20))),
+			expected: scan(false, lit(10), lit(20)),
+		},
+		{
+			name: "a > 10 and a >= 15 and a < 30 and b > 3 over [10, +inf)",
+			node: plan.NewFilter(and(
+				expression.NewGreaterThan(a, lit(10)),
+				expression.NewGreaterThanOrEqual(a, lit(15)),
+				expression.NewLessThan(a, lit(30)),
+				expression.NewGreaterThan(b, lit(3)),
+			), scan(false, lit(10), nil)),
+			expected: plan.NewFilter(expression.NewGreaterThan(b, lit(3)), scan(false, lit(15), lit(30))),
+		},
+		{
+			name: "a > 10 and 20 >= a over (10, +inf] descending",
+			node: plan.NewFilter(and(expression.NewGreaterThan(a, lit(10)), expression.NewGreaterThanOrEqual(lit(20), a)),
+				scan(true, lit(10), nil)),
+			expected: scan(true, lit(10), lit(20)),
+		},
+		{
+			name: "a >= 10 and a <= 20 over [10, +inf)",
+			node: plan.NewFilter(and(expression.NewGreaterThanOrEqual(a, lit(10)), expression.NewLessThanOrEqual(a, lit(20))),
+				scan(false, lit(10), nil)),
+			expected: plan.NewFilter(expression.NewLessThanOrEqual(a, lit(20)), scan(false, lit(10), nil)),
+		},
+		{
+			name: "a < 20 over [-inf, 20)",
+			node: plan.NewFilter(expression.NewLessThan(a, lit(20)), scan(false, nil, lit(20))),
+		},
+		{
+			name: "b > 5 over [10, +inf)",
+			node: plan.NewFilter(expression.NewGreaterThan(b, lit(5)), scan(false, lit(10), nil)),
+		},
+		{
+			name: "a >= 10.5 over [10, +inf)",
+			node: plan.NewFilter(expression.NewGreaterThanOrEqual(a, expression.NewLiteral(10.5, sql.Float64)), scan(false, lit(10), nil)),
+		},
+		{
+			name: "a > 10 over [10, +inf)",
+			node: plan.NewFilter(expression.NewGreaterThan(a, lit(10)), scan(false, lit(10), nil)),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("fold_ordered_scan_bounds"))
+
+	*table.read = 0
+	rows, err := sql.NodeToRows(ctx, plan.NewLimit(2, tests[1].expected))
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(15), int64(5)}, {int64(16), int64(6)}}, rows)
+	require.Equal(2, *table.read)
+}
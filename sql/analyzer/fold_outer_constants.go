@@ -0,0 +1,206 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// foldOuterConstants replaces the references to outer scope columns in the subqueries of a filter with the constant
+// those columns are known to hold, when the filter also requires them to be equal to that constant. Rows where the
+// column has any other value are discarded by the filter anyway, so the substitution doesn't change the result of the
+// filter. Comparisons in the subquery that become constant after the substitution are then folded, which can allow
+// further simplification, such as caching the results of subqueries that no longer depend on the outer scope. Folding
+// never removes an operand of a conjunction or disjunction that would have been evaluated, and so could have failed,
+// before the one that became constant.
+func foldOuterConstants(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fold_outer_constants")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		constants := outerConstants(ctx, filter.Expression)
+		if len(constants) == 0 {
+			return node, nil
+		}
+
+		scopeLen := len(scope.newScope(filter).Schema())
+		e, err := expression.TransformUp(filter.Expression, func(e sql.Expression) (sql.Expression, error) {
+			s, ok := e.(*plan.Subquery)
+			if !ok || !s.Resolved() {
+				return e, nil
+			}
+
+			var replaced bool
+			query, err := plan.TransformExpressionsUp(s.Query, func(e sql.Expression) (sql.Expression, error) {
+				gf, ok := e.(*expression.GetField)
+				if !ok || gf.Index() >= scopeLen {
+					return e, nil
+				}
+
+				if lit, ok := constants[gf.Index()]; ok {
+					replaced = true
+					return lit, nil
+				}
+
+				return e, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if !replaced {
+				return e, nil
+			}
+
+			a.Log("replaced outer scope columns with constants in subquery %s", s.QueryString)
+			query, err = foldConstantFilters(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+
+			return s.WithQuery(query), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// outerConstants returns the constants that the columns of the filter expression given are required to be equal to,
+// indexed by the field index of the column. Only numeric columns are considered, since other types may compare equal
+// to values that aren't identical to them, such as strings in case insensitive collations.
+func outerConstants(ctx *sql.Context, e sql.Expression) map[int]*expression.Literal {
+	constants := make(map[int]*expression.Literal)
+	for _, c := range splitConjunction(e) {
+		eq, ok := c.(*expression.Equals)
+		if !ok {
+			continue
+		}
+
+		left, right := eq.Left(), eq.Right()
+		if _, ok := left.(*expression.Literal); ok {
+			left, right = right, left
+		}
+
+		gf, ok := left.(*expression.GetField)
+		if !ok || !sql.IsNumber(gf.Type()) {
+			continue
+		}
+
+		lit, ok := right.(*expression.Literal)
+		if !ok || lit.Value() == nil || !sql.IsNumber(lit.Type()) {
+			continue
+		}
+
+		// The constant must be representable in the column's type without losing precision
+		val, err := gf.Type().Convert(lit.Value())
+		if err != nil {
+			continue
+		}
+
+		converted := expression.NewLiteral(val, gf.Type())
+		same, err := expression.NewEquals(converted, lit).Eval(ctx, nil)
+		if err != nil || same != true {
+			continue
+		}
+
+		constants[gf.Index()] = converted
+	}
+
+	return constants
+}
+
+// foldConstantFilters folds the constant expressions of the filters of the node given, like evalFilter does, but
+// keeping the order of evaluation of conjunctions and disjunctions: an operand evaluated before a constant that
+// decides the result on its own is only removed if it can't fail to evaluate.
+func foldConstantFilters(ctx *sql.Context, node sql.Node) (sql.Node, error) {
+	return plan.TransformUp(node, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		e, err := expression.TransformUp(filter.Expression, func(e sql.Expression) (sql.Expression, error) {
+			switch e := e.(type) {
+			case *expression.And:
+				switch {
+				case isFalse(e.Left), isTrue(e.Right):
+					return e.Left, nil
+				case isTrue(e.Left):
+					return e.Right, nil
+				case isFalse(e.Right) && cannotFail(e.Left):
+					return e.Right, nil
+				}
+				return e, nil
+			case *expression.Or:
+				switch {
+				case isTrue(e.Left), isFalse(e.Right):
+					return e.Left, nil
+				case isFalse(e.Left):
+					return e.Right, nil
+				case isTrue(e.Right) && cannotFail(e.Left):
+					return e.Right, nil
+				}
+				return e, nil
+			case *expression.Literal, expression.Tuple, *expression.Interval:
+				return e, nil
+			default:
+				if !isEvaluable(e) {
+					return e, nil
+				}
+
+				val, err := e.Eval(ctx, nil)
+				if err != nil {
+					return e, nil
+				}
+				return expression.NewLiteral(val, e.Type()), nil
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if isFalse(e) {
+			return plan.EmptyTable, nil
+		}
+
+		if isTrue(e) {
+			return filter.Child, nil
+		}
+
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// cannotFail returns whether the expression given always evaluates without error: it's made only of columns,
+// literals, logical operators, NULL checks and comparisons of numbers.
+func cannotFail(e sql.Expression) bool {
+	result := true
+	sql.Inspect(e, func(e sql.Expression) bool {
+		switch e := e.(type) {
+		case nil, *expression.GetField, *expression.Literal, *expression.And, *expression.Or, *expression.Not,
+			*expression.IsNull:
+		case *expression.Equals, *expression.LessThan, *expression.GreaterThan, *expression.LessThanOrEqual,
+			*expression.GreaterThanOrEqual:
+			c := e.(expression.Comparer)
+			if !sql.IsNumber(c.Left().Type()) || !sql.IsNumber(c.Right().Type()) {
+				result = false
+			}
+		default:
+			result = false
+		}
+		return result
+	})
+	return result
+}
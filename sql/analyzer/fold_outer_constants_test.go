@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestFoldOuterConstants(t *testing.T) {
+	outer := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t"},
+		{Name: "y", Type: sql.Int64, Source: "t"},
+	}))
+	swedish := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_latin1_swedish_ci)
+	german := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_latin1_german1_ci)
+	inner := plan.NewResolvedTable(memory.NewTable("s", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "s"},
+		{Name: "b", Type: sql.Int64, Source: "s"},
+		{Name: "c", Type: swedish, Source: "s"},
+		{Name: "d", Type: german, Source: "s"},
+	}))
+
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", false)
+	y := expression.NewGetFieldWithTable(1, sql.Int64, "t", "y", false)
+	a := expression.NewGetFieldWithTable(2, sql.Int64, "s", "a", false)
+	b := expression.NewGetFieldWithTable(3, sql.Int64, "s", "b", false)
+	c := expression.NewGetFieldWithTable(4, swedish, "s", "c", false)
+	d := expression.NewGetFieldWithTable(5, german, "s", "d", false)
+	five := expression.NewLiteral(int64(5), sql.Int64)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	// Comparing strings of collations that can't be aggregated fails when it's evaluated
+	failing := expression.NewEquals(c, d)
+
+	// SELECT * FROM t WHERE t.x = 5 AND t.y = (SELECT s.a FROM s WHERE s.b = t.x AND t.x > 1)
+	subquery := func(filter sql.Expression) *plan.Subquery {
+		return plan.NewSubquery(plan.NewProject([]sql.Expression{a}, plan.NewFilter(filter, inner)), "")
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name: "outer column fixed by the filter",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, five),
+					expression.NewEquals(y, subquery(expression.NewAnd(
+						expression.NewEquals(b, x),
+						expression.NewGreaterThan(x, expression.NewLiteral(int64(1), sql.Int64)),
+					))),
+				),
+				outer,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, five),
+					expression.NewEquals(y, subquery(expression.NewEquals(b, five))),
+				),
+				outer,
+			),
+		},
+		{
+			name: "comparison folds to false",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(five, x),
+					expression.NewEquals(y, subquery(expression.NewLessThan(x, expression.NewLiteral(int64(1), sql.Int64)))),
+				),
+				outer,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(five, x),
+					expression.NewEquals(y, plan.NewSubquery(plan.NewProject([]sql.Expression{a}, plan.EmptyTable), "")),
+				),
+				outer,
+			),
+		},
+		{
+			name: "false comparison after a conjunct that can fail",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, five),
+					expression.NewEquals(y, subquery(expression.NewAnd(failing, expression.NewLessThan(x, one)))),
+				),
+				outer,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, five),
+					expression.NewEquals(y, subquery(expression.NewAnd(failing, expression.NewLiteral(false, sql.Boolean)))),
+				),
+				outer,
+			),
+		},
+		{
+			name: "false comparison before a conjunct that can fail",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, five),
+					expression.NewEquals(y, subquery(expression.NewAnd(expression.NewLessThan(x, one), failing))),
+				),
+				outer,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, five),
+					expression.NewEquals(y, plan.NewSubquery(plan.NewProject([]sql.Expression{a}, plan.EmptyTable), "")),
+				),
+				outer,
+			),
+		},
+		{
+			name: "false comparison after a conjunct that can't fail",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, five),
+					expression.NewEquals(y, subquery(expression.NewAnd(expression.NewEquals(a, b), expression.NewLessThan(x, one)))),
+				),
+				outer,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, five),
+					expression.NewEquals(y, plan.NewSubquery(plan.NewProject([]sql.Expression{a}, plan.EmptyTable), "")),
+				),
+				outer,
+			),
+		},
+		{
+			name: "outer column not fixed",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewGreaterThan(x, five),
+					expression.NewEquals(y, subquery(expression.NewEquals(b, x))),
+				),
+				outer,
+			),
+		},
+		{
+			name: "constant not representable in the column type",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(x, expression.NewLiteral(5.5, sql.Float64)),
+					expression.NewEquals(y, subquery(expression.NewEquals(b, x))),
+				),
+				outer,
+			),
+		},
+	}
+
+	runTestCases(t, nil, tests, NewDefault(nil), getRule("fold_outer_constants"))
+}
@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// sessionValueFunctions are the names of the functions without arguments whose value only depends on the session.
+var sessionValueFunctions = map[string]bool{
+	"connection_id":  true,
+	"current_user":   true,
+	"last_insert_id": true,
+	"user":           true,
+}
+
+// foldSessionValues replaces the operands of comparisons whose value only depends on the session, such as
+// LAST_INSERT_ID(), DATABASE() or a system or user variable, with a literal of their value, so that they're evaluated
+// once per query rather than once per row. Their values don't change while the query runs, and the literal keeps the
+// type of the operand, so the comparison compares them the same way.
+func foldSessionValues(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fold_session_values")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		c, ok := e.(expression.Comparer)
+		if !ok {
+			return e, nil
+		}
+
+		children := c.Children()
+		var folded bool
+		for i, child := range children {
+			if !isSessionValue(child) {
+				continue
+			}
+
+			v, err := child.Eval(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			a.Log("folded session value %s into %v", child, v)
+			children[i] = expression.NewLiteral(v, child.Type())
+			folded = true
+		}
+
+		if !folded {
+			return e, nil
+		}
+
+		return c.WithChildren(children...)
+	})
+}
+
+// isSessionValue returns whether the expression given only depends on the session it's evaluated in.
+func isSessionValue(e sql.Expression) bool {
+	switch e := e.(type) {
+	case *expression.SystemVar, *expression.UserVar, *function.Database:
+		return true
+	case sql.NoArgFunc:
+		return sessionValueFunctions[e.Name]
+	default:
+		return false
+	}
+}
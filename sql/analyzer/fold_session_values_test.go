@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestFoldSessionValues(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "id", Type: sql.Uint64, Source: "t"},
+	}))
+
+	id := expression.NewGetFieldWithTable(0, sql.Uint64, "t", "id", false)
+	var evaluations int
+	lastInsertID := sql.NoArgFunc{Name: "last_insert_id", SQLType: sql.Uint64, Logic: func(ctx *sql.Context, _ sql.Row) (interface{}, error) {
+		evaluations++
+		return uint64(7), nil
+	}}
+	limit := expression.NewSystemVar("sql_select_limit", sql.Int32)
+	seven := expression.NewLiteral(uint64(7), sql.Uint64)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "id = LAST_INSERT_ID()",
+			node:     plan.NewFilter(expression.NewEquals(id, lastInsertID), table),
+			expected: plan.NewFilter(expression.NewEquals(id, seven), table),
+		},
+		{
+			name: "LAST_INSERT_ID() < id and @@sql_select_limit > id",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewLessThan(lastInsertID, id),
+					expression.NewGreaterThan(limit, id),
+				),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewLessThan(seven, id),
+					expression.NewGreaterThan(expression.NewLiteral(int32(5), sql.Int32), id),
+				),
+				table,
+			),
+		},
+		{
+			name: "projected @@sql_select_limit",
+			node: plan.NewProject([]sql.Expression{limit}, table),
+		},
+		{
+			name: "@@sql_select_limit in arithmetic",
+			node: plan.NewFilter(expression.NewEquals(id, expression.NewPlus(limit, seven)), table),
+		},
+	}
+
+	ctx := sql.NewEmptyContext()
+	require.NoError(t, ctx.Set(context.Background(), "sql_select_limit", sql.Int32, int32(5)))
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("fold_session_values"))
+
+	// The value is evaluated once for the whole query, rather than once per row
+	evaluations = 0
+	node, err := getRule("fold_session_values").Apply(ctx, NewDefault(nil), plan.NewFilter(expression.NewEquals(id, lastInsertID), table), nil)
+	require.NoError(t, err)
+	for _, row := range []sql.Row{{uint64(1)}, {uint64(7)}, {uint64(9)}} {
+		_, err := node.(*plan.Filter).Expression.Eval(ctx, row)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, evaluations)
+}
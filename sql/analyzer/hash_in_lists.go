@@ -0,0 +1,67 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// hashInLists replaces the IN lists of literals with more elements than the in_list_hash_threshold session variable
+// with HashInTuple expressions, which look up the left operand in a hash set of the elements instead of comparing it
+// with each of them. Shorter lists are left to be compared one by one, which is cheaper than hashing for few elements.
+// It runs after the rules that look for InTuple expressions to push down filters, since they don't know about hashed
+// lists.
+func hashInLists(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("hash_in_lists")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	threshold := inListHashThreshold(ctx)
+	return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		in, ok := e.(*expression.InTuple)
+		if !ok {
+			return e, nil
+		}
+
+		tuple, ok := in.Right().(expression.Tuple)
+		if !ok || len(tuple) <= threshold || !expression.IsHashableInType(in.Left().Type().Promote()) {
+			return e, nil
+		}
+
+		for _, el := range tuple {
+			if _, ok := el.(*expression.Literal); !ok {
+				return e, nil
+			}
+		}
+
+		hashed, err := expression.NewHashInTuple(in.Left(), in.Right())
+		if err != nil {
+			// An element that can't be converted to the type of the left operand only fails the comparison if it's
+			// reached, so the list is compared one by one.
+			a.Log("not hashing IN list on %s: %s", in.Left(), err)
+			return e, nil
+		}
+
+		a.Log("hashing IN list of %d elements on %s", len(tuple), in.Left())
+		return hashed, nil
+	})
+}
+
+// inListHashThreshold returns the value of the in_list_hash_threshold session variable, or its default value if the
+// session doesn't have a valid one.
+func inListHashThreshold(ctx *sql.Context) int {
+	_, v := ctx.Get(sql.InListHashThresholdSessionVar)
+	if v == nil {
+		return sql.DefaultInListHashThreshold
+	}
+
+	threshold, err := sql.Int64.Convert(v)
+	if err != nil {
+		return sql.DefaultInListHashThreshold
+	}
+
+	return int(threshold.(int64))
+}
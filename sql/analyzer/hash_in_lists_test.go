@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestHashInLists(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t"},
+		{Name: "f", Type: sql.Float64, Source: "t"},
+	}))
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", true)
+	f := expression.NewGetFieldWithTable(1, sql.Float64, "t", "f", true)
+
+	list := func(n int) expression.Tuple {
+		elems := make(expression.Tuple, n)
+		for i := range elems {
+			elems[i] = expression.NewLiteral(int64(i), sql.Int64)
+		}
+		return elems
+	}
+
+	// strategy returns the strategy the rule chose for the IN expression of the filter given
+	strategy := func(t *testing.T, ctx *sql.Context, filter sql.Expression) sql.Expression {
+		result, err := hashInLists(ctx, NewDefault(nil), plan.NewFilter(filter, table), nil)
+		require.NoError(t, err)
+		return result.(*plan.Filter).Expression
+	}
+
+	defaultThreshold := sql.DefaultInListHashThreshold
+	tests := []struct {
+		name      string
+		threshold interface{}
+		size      int
+		hashed    bool
+	}{
+		{"just below the default threshold", nil, defaultThreshold, false},
+		{"just above the default threshold", nil, defaultThreshold + 1, true},
+		{"just below a lower threshold", int8(5), 5, false},
+		{"just above a lower threshold", int8(5), 6, true},
+		{"just below a higher threshold", int64(100), 100, false},
+		{"just above a higher threshold", int64(100), 101, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			if tt.threshold != nil {
+				require.NoError(ctx.Set(ctx, sql.InListHashThresholdSessionVar, sql.Int64, tt.threshold))
+			}
+
+			in := expression.NewInTuple(x, list(tt.size))
+			result := strategy(t, ctx, in)
+			if tt.hashed {
+				require.IsType(&expression.HashInTuple{}, result)
+				require.Equal(in.String(), result.String())
+			} else {
+				require.Equal(in, result)
+			}
+		})
+	}
+
+	t.Run("list with a non literal element", func(t *testing.T) {
+		in := expression.NewInTuple(x, append(list(defaultThreshold+1), x))
+		require.Equal(t, in, strategy(t, sql.NewEmptyContext(), in))
+	})
+
+	t.Run("unhashable type", func(t *testing.T) {
+		in := expression.NewInTuple(f, list(defaultThreshold+1))
+		require.Equal(t, in, strategy(t, sql.NewEmptyContext(), in))
+	})
+}
@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// simplifyImpossibleBetween replaces the BETWEEN predicates of filters whose bounds are constants that can't be
+// satisfied by any value, because the lower bound is greater than the upper bound, with a false literal. Predicates
+// with a NULL bound are replaced with a NULL literal instead. Neither of them can ever be true, so once replaced
+// eval_filter can turn the filter into an empty result. Only predicates that are combined with AND and OR are
+// replaced, since for those a NULL or false result is discarded the same way, which is not the case inside a NOT.
+func simplifyImpossibleBetween(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("simplify_impossible_between")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		e, err := simplifyBetweenPredicates(ctx, a, filter.Expression)
+		if err != nil {
+			return nil, err
+		}
+
+		if e == filter.Expression {
+			return node, nil
+		}
+
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// simplifyBetweenPredicates replaces the impossible BETWEEN predicates of the filter expression given, descending
+// only into AND and OR expressions.
+func simplifyBetweenPredicates(ctx *sql.Context, a *Analyzer, e sql.Expression) (sql.Expression, error) {
+	switch e := e.(type) {
+	case *expression.And, *expression.Or:
+		children := e.Children()
+		newChildren := make([]sql.Expression, len(children))
+		var changed bool
+		for i, child := range children {
+			newChild, err := simplifyBetweenPredicates(ctx, a, child)
+			if err != nil {
+				return nil, err
+			}
+			newChildren[i] = newChild
+			changed = changed || newChild != child
+		}
+
+		if !changed {
+			return e, nil
+		}
+
+		return e.WithChildren(newChildren...)
+	case *expression.Between:
+		if !isEvaluable(e.Lower) || !isEvaluable(e.Upper) {
+			return e, nil
+		}
+
+		lower, err := e.Lower.Eval(ctx, nil)
+		if err != nil {
+			return e, nil
+		}
+
+		upper, err := e.Upper.Eval(ctx, nil)
+		if err != nil {
+			return e, nil
+		}
+
+		if lower == nil || upper == nil {
+			a.Log("replacing BETWEEN with a NULL bound: %s", e)
+			return expression.NewLiteral(nil, sql.Boolean), nil
+		}
+
+		// The bounds are compared the same way BETWEEN compares them with the value
+		typ := e.Val.Type().Promote()
+		lower, err = typ.Convert(lower)
+		if err != nil {
+			return e, nil
+		}
+
+		upper, err = typ.Convert(upper)
+		if err != nil {
+			return e, nil
+		}
+
+		cmp, err := typ.Compare(lower, upper)
+		if err != nil {
+			return e, nil
+		}
+
+		if cmp > 0 {
+			a.Log("replacing BETWEEN with impossible bounds: %s", e)
+			return expression.NewLiteral(false, sql.Boolean), nil
+		}
+
+		return e, nil
+	default:
+		return e, nil
+	}
+}
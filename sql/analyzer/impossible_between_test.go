@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestSimplifyImpossibleBetween(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t"},
+		{Name: "s", Type: sql.Text, Source: "t"},
+	}))
+
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", false)
+	s := expression.NewGetFieldWithTable(1, sql.Text, "t", "s", false)
+	falseLit := expression.NewLiteral(false, sql.Boolean)
+	nullLit := expression.NewLiteral(nil, sql.Boolean)
+
+	tests := []analyzerFnTestCase{
+		{
+			name: "lower bound greater than upper bound",
+			node: plan.NewFilter(
+				expression.NewBetween(x, expression.NewLiteral(int64(10), sql.Int64), expression.NewLiteral(int64(5), sql.Int64)),
+				table,
+			),
+			expected: plan.NewFilter(falseLit, table),
+		},
+		{
+			name: "bounds coerced to the type of the value",
+			node: plan.NewFilter(
+				expression.NewBetween(x, expression.NewLiteral("10", sql.LongText), expression.NewLiteral("5", sql.LongText)),
+				table,
+			),
+			expected: plan.NewFilter(falseLit, table),
+		},
+		{
+			name: "string bounds compared as strings",
+			node: plan.NewFilter(
+				expression.NewBetween(s, expression.NewLiteral("10", sql.LongText), expression.NewLiteral("5", sql.LongText)),
+				table,
+			),
+		},
+		{
+			name: "equal bounds",
+			node: plan.NewFilter(
+				expression.NewBetween(x, expression.NewLiteral(int64(5), sql.Int64), expression.NewLiteral(int64(5), sql.Int64)),
+				table,
+			),
+		},
+		{
+			name: "null bound",
+			node: plan.NewFilter(
+				expression.NewBetween(x, expression.NewLiteral(nil, sql.Null), expression.NewLiteral(int64(5), sql.Int64)),
+				table,
+			),
+			expected: plan.NewFilter(nullLit, table),
+		},
+		{
+			name: "inside conjunction",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(s, expression.NewLiteral("a", sql.LongText)),
+					expression.NewBetween(x, expression.NewLiteral(int64(10), sql.Int64), expression.NewLiteral(int64(5), sql.Int64)),
+				),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(s, expression.NewLiteral("a", sql.LongText)),
+					falseLit,
+				),
+				table,
+			),
+		},
+		{
+			name: "negated predicate is left alone",
+			node: plan.NewFilter(
+				expression.NewNot(
+					expression.NewBetween(x, expression.NewLiteral(int64(10), sql.Int64), expression.NewLiteral(int64(5), sql.Int64)),
+				),
+				table,
+			),
+		},
+		{
+			name: "non-constant bound",
+			node: plan.NewFilter(
+				expression.NewBetween(expression.NewLiteral(int64(5), sql.Int64), x, expression.NewLiteral(int64(1), sql.Int64)),
+				table,
+			),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("simplify_impossible_between"))
+}
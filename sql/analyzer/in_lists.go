@@ -0,0 +1,232 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// mergeInLists merges the IN lists of literals that filters check the same column against. Disjunctions of them are
+// replaced with the union of their lists, so x IN (1, 2) OR x IN (2, 3) becomes x IN (1, 2, 3), and the conjuncts of
+// a filter with the intersection of their lists, so x IN (1, 2, 3) AND x IN (2, 3, 4) becomes x IN (2, 3). The
+// elements of the lists are compared as values of the type of the column, the same way IN compares them.
+func mergeInLists(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("merge_in_lists")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		e, err := expression.TransformUp(filter.Expression, func(e sql.Expression) (sql.Expression, error) {
+			if _, ok := e.(*expression.Or); !ok {
+				return e, nil
+			}
+
+			disjuncts, changed, err := mergeInListsOnColumns(a, splitDisjunction(e), true)
+			if err != nil || !changed {
+				return e, err
+			}
+
+			return expression.JoinOr(disjuncts...), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// The intersection of lists with NULL elements isn't NULL for the same values the conjunction of them is, so
+		// only the conjuncts of the filter itself are merged, where a NULL result filters out a row just like false.
+		conjuncts, changed, err := mergeInListsOnColumns(a, splitConjunction(e), false)
+		if err != nil {
+			return nil, err
+		}
+
+		if changed {
+			e = expression.JoinAnd(conjuncts...)
+		}
+
+		if e == filter.Expression {
+			return node, nil
+		}
+
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// mergeInListsOnColumns replaces the literal IN lists on the same column among the expressions given with the union of
+// them, or their intersection if union is false. The merged list takes the place of the first one on the column. It
+// returns whether any lists were merged.
+func mergeInListsOnColumns(a *Analyzer, exprs []sql.Expression, union bool) ([]sql.Expression, bool, error) {
+	lists := make([]*literalInList, len(exprs))
+	counts := make(map[string]int)
+	for i, e := range exprs {
+		if l, ok := newLiteralInList(e); ok {
+			lists[i] = l
+			counts[l.column.String()]++
+		}
+	}
+
+	var result []sql.Expression
+	merged := make(map[string]*literalInList)
+	positions := make(map[string]int)
+	for i, e := range exprs {
+		l := lists[i]
+		if l == nil || counts[l.column.String()] < 2 {
+			result = append(result, e)
+			continue
+		}
+
+		key := l.column.String()
+		m, ok := merged[key]
+		if !ok {
+			merged[key] = l
+			positions[key] = len(result)
+			result = append(result, e)
+			continue
+		}
+
+		var err error
+		if union {
+			err = m.union(l)
+		} else {
+			err = m.intersect(l)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	for key, m := range merged {
+		a.Log("merged %d IN lists on %s", counts[key], key)
+		result[positions[key]] = m.expression()
+	}
+
+	return result, len(merged) > 0, nil
+}
+
+// literalInList is an IN expression that checks a column against a list of literals.
+type literalInList struct {
+	column *expression.GetField
+	typ    sql.Type
+	elems  []sql.Expression
+	values []interface{}
+	// null is a NULL element of the list, if it has any
+	null sql.Expression
+}
+
+// newLiteralInList returns the literal IN list of the expression given, if it's one whose elements can all be
+// converted to the type they are compared as.
+func newLiteralInList(e sql.Expression) (*literalInList, bool) {
+	in, ok := e.(*expression.InTuple)
+	if !ok {
+		return nil, false
+	}
+
+	column, ok := in.Left().(*expression.GetField)
+	if !ok {
+		return nil, false
+	}
+
+	tuple, ok := in.Right().(expression.Tuple)
+	if !ok {
+		return nil, false
+	}
+
+	l := &literalInList{column: column, typ: column.Type().Promote()}
+	for _, el := range tuple {
+		lit, ok := el.(*expression.Literal)
+		if !ok {
+			return nil, false
+		}
+
+		if lit.Value() == nil {
+			l.null = lit
+			continue
+		}
+
+		v, err := l.typ.Convert(lit.Value())
+		if err != nil {
+			return nil, false
+		}
+
+		l.elems = append(l.elems, lit)
+		l.values = append(l.values, v)
+	}
+
+	return l, true
+}
+
+// union adds the elements of the list given that this list doesn't have.
+func (l *literalInList) union(other *literalInList) error {
+	for i, v := range other.values {
+		ok, err := l.contains(v)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			l.elems = append(l.elems, other.elems[i])
+			l.values = append(l.values, v)
+		}
+	}
+
+	if l.null == nil {
+		l.null = other.null
+	}
+
+	return nil
+}
+
+// intersect removes the elements of this list that the list given doesn't have, along with any NULL element.
+func (l *literalInList) intersect(other *literalInList) error {
+	var elems []sql.Expression
+	var values []interface{}
+	for i, v := range l.values {
+		ok, err := other.contains(v)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			elems = append(elems, l.elems[i])
+			values = append(values, v)
+		}
+	}
+
+	l.elems, l.values, l.null = elems, values, nil
+	return nil
+}
+
+func (l *literalInList) contains(v interface{}) (bool, error) {
+	for _, value := range l.values {
+		cmp, err := l.typ.Compare(v, value)
+		if err != nil {
+			return false, err
+		}
+
+		if cmp == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// expression returns the IN expression for this list, or false if it has no elements.
+func (l *literalInList) expression() sql.Expression {
+	elems := l.elems
+	if l.null != nil {
+		elems = append(elems, l.null)
+	}
+
+	if len(elems) == 0 {
+		return expression.NewLiteral(false, sql.Boolean)
+	}
+
+	return expression.NewInTuple(l.column, expression.NewTuple(elems...))
+}
@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestMergeInLists(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t"},
+		{Name: "y", Type: sql.Int64, Source: "t"},
+	}))
+
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", true)
+	y := expression.NewGetFieldWithTable(1, sql.Int64, "t", "y", true)
+	lit := func(v int64) sql.Expression {
+		return expression.NewLiteral(v, sql.Int64)
+	}
+	in := func(col sql.Expression, elems ...sql.Expression) sql.Expression {
+		return expression.NewInTuple(col, expression.NewTuple(elems...))
+	}
+	null := expression.NewLiteral(nil, sql.Null)
+
+	subquery := plan.NewSubquery(plan.NewProject([]sql.Expression{lit(2)}, plan.EmptyTable), "")
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "intersection of conjuncts",
+			node:     plan.NewFilter(expression.NewAnd(in(x, lit(1), lit(2), lit(3)), in(x, lit(2), lit(3), lit(4))), table),
+			expected: plan.NewFilter(in(x, lit(2), lit(3)), table),
+		},
+		{
+			name: "intersection of conjuncts among others",
+			node: plan.NewFilter(
+				expression.JoinAnd(
+					in(x, lit(1), lit(2)),
+					expression.NewEquals(y, lit(5)),
+					in(x, lit(2), lit(3)),
+					in(y, lit(5), lit(6)),
+				),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.JoinAnd(
+					in(x, lit(2)),
+					expression.NewEquals(y, lit(5)),
+					in(y, lit(5), lit(6)),
+				),
+				table,
+			),
+		},
+		{
+			name: "intersection with coerced elements",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					in(x, lit(1), lit(2), lit(3)),
+					in(x, expression.NewLiteral("2", sql.LongText), expression.NewLiteral(3.0, sql.Float64)),
+				),
+				table,
+			),
+			expected: plan.NewFilter(in(x, lit(2), lit(3)), table),
+		},
+		{
+			name:     "empty intersection",
+			node:     plan.NewFilter(expression.NewAnd(in(x, lit(1)), in(x, lit(2))), table),
+			expected: plan.NewFilter(expression.NewLiteral(false, sql.Boolean), table),
+		},
+		{
+			name:     "intersection drops NULL elements",
+			node:     plan.NewFilter(expression.NewAnd(in(x, lit(1), null), in(x, lit(1), lit(2), null)), table),
+			expected: plan.NewFilter(in(x, lit(1)), table),
+		},
+		{
+			name: "conjunction below NOT is left as is",
+			node: plan.NewFilter(expression.NewNot(expression.NewAnd(in(x, lit(1), null), in(x, lit(2)))), table),
+		},
+		{
+			name:     "union of disjuncts",
+			node:     plan.NewFilter(expression.NewOr(in(x, lit(1), lit(2)), in(x, lit(2), lit(3))), table),
+			expected: plan.NewFilter(in(x, lit(1), lit(2), lit(3)), table),
+		},
+		{
+			name: "union of disjuncts with NULL elements",
+			node: plan.NewFilter(
+				expression.NewOr(in(x, lit(1), null), in(x, expression.NewLiteral("1", sql.LongText), lit(2), null)),
+				table,
+			),
+			expected: plan.NewFilter(in(x, lit(1), lit(2), null), table),
+		},
+		{
+			name: "union below NOT",
+			node: plan.NewFilter(
+				expression.NewNot(expression.NewOr(in(x, lit(1)), in(x, lit(2)))),
+				table,
+			),
+			expected: plan.NewFilter(expression.NewNot(in(x, lit(1), lit(2))), table),
+		},
+		{
+			name: "union of disjuncts inside a conjunct",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewOr(in(x, lit(1)), in(x, lit(2))),
+					in(x, lit(2), lit(3)),
+				),
+				table,
+			),
+			expected: plan.NewFilter(in(x, lit(2)), table),
+		},
+		{
+			name: "lists on different columns",
+			node: plan.NewFilter(expression.NewOr(in(x, lit(1), lit(2)), in(y, lit(2), lit(3))), table),
+		},
+		{
+			name: "list with a non literal element",
+			node: plan.NewFilter(expression.NewAnd(in(x, lit(1), y), in(x, lit(1), lit(2))), table),
+		},
+		{
+			name: "subquery",
+			node: plan.NewFilter(
+				expression.NewAnd(in(x, lit(1), lit(2)), plan.NewInSubquery(x, subquery)),
+				table,
+			),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("merge_in_lists"))
+}
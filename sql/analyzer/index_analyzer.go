@@ -130,7 +130,7 @@ func (r *indexAnalyzer) ExpressionsWithIndexes(db string, exprs ...sql.Expressio
 							continue
 						}
 
-						if ie == e.String() {
+						if sql.SameIndexExpression(ie, e.String()) {
 							used[i] = true
 							found = true
 							matched = append(matched, e)
@@ -182,7 +182,7 @@ func isSublist(a, b []string) bool {
 				continue
 			}
 
-			if va == vb {
+			if sql.SameIndexExpression(va, vb) {
 				visited[j] = true
 				found = true
 				break
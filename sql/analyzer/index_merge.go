@@ -0,0 +1,151 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// indexMerge replaces a table filtered by a disjunction of equalities on columns of different indexes, such as
+// a = 1 OR b = 2, with a plan.IndexMerge of those indexes, which looks up each of them and returns the union of the
+// rows found. Every equality of the disjunction must be on a column that is the only column of an index, otherwise the
+// table is scanned as usual. Only tables implementing sql.IndexedTable that are filtered directly are considered, and
+// only when no index can be looked up with the rest of the filter as usual. The filter is kept on top of the merge.
+func indexMerge(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("index_merge")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		rt, ok := filter.Child.(*plan.ResolvedTable)
+		if !ok {
+			return node, nil
+		}
+
+		table, ok := rt.Table.(sql.IndexedTable)
+		if !ok {
+			return node, nil
+		}
+
+		indexes, err := table.GetIndexes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		conjuncts := splitConjunction(filter.Expression)
+		values := columnEqualities(table.Name(), conjuncts)
+		for _, idx := range indexes {
+			if _, ok := values[indexColumnNames(idx)[0]]; ok {
+				return node, nil
+			}
+		}
+
+		for _, c := range conjuncts {
+			merged, ok := mergedIndexes(table.Name(), indexes, splitDisjunction(c))
+			if !ok {
+				continue
+			}
+
+			a.Log("merging the lookups of %d indexes of table %s", len(merged.indexes), table.Name())
+			scan, err := plan.NewIndexMerge(rt, merged.indexes, merged.keys, merged.conditions)
+			if err != nil {
+				return nil, err
+			}
+
+			return plan.NewFilter(filter.Expression, scan), nil
+		}
+
+		return node, nil
+	})
+}
+
+// indexMergeLookups are the lookups of the indexes of a plan.IndexMerge.
+type indexMergeLookups struct {
+	indexes    []sql.Index
+	keys       []sql.Expression
+	conditions []sql.Expression
+}
+
+// mergedIndexes returns the lookups of the indexes of the table given that together find the rows matching any of the
+// disjuncts given, or false if there are none. Each disjunct must be an equality on the column of a single column
+// index, and more than one index must be looked up, since a disjunction of equalities on a single index is looked up
+// as usual.
+func mergedIndexes(table string, indexes []sql.Index, disjuncts []sql.Expression) (indexMergeLookups, bool) {
+	var lookups indexMergeLookups
+	if len(disjuncts) < 2 {
+		return lookups, false
+	}
+
+	distinct := make(map[string]bool)
+	for _, d := range disjuncts {
+		values := columnEqualities(table, []sql.Expression{d})
+		if len(values) != 1 {
+			return lookups, false
+		}
+
+		var idx sql.Index
+		var key sql.Expression
+		for col, value := range values {
+			idx = singleColumnIndex(indexes, col)
+			key = value
+		}
+
+		if idx == nil || !canLookUpEquality(d.(*expression.Equals), key) {
+			return lookups, false
+		}
+
+		distinct[idx.ID()] = true
+		lookups.indexes = append(lookups.indexes, idx)
+		lookups.keys = append(lookups.keys, key)
+		lookups.conditions = append(lookups.conditions, d)
+	}
+
+	return lookups, len(distinct) > 1
+}
+
+// singleColumnIndex returns the index of the indexes given whose only column is the one with the lower case name given,
+// if any.
+func singleColumnIndex(indexes []sql.Index, column string) sql.Index {
+	for _, idx := range indexes {
+		columns := indexColumnNames(idx)
+		if len(columns) == 1 && columns[0] == column {
+			return idx
+		}
+	}
+	return nil
+}
+
+// canLookUpEquality returns whether the rows matching the equality given of a column with the value given can be looked
+// up in an index of the column with the value, which is the case when the value is compared as a value of the type of
+// the column.
+func canLookUpEquality(eq *expression.Equals, value sql.Expression) bool {
+	column := eq.Left()
+	if column == value {
+		column = eq.Right()
+	}
+
+	return comparesAsColumnType(column, value)
+}
+
+// comparesAsColumnType returns whether a comparison of the column given with the value given compares the value as a
+// value of the type of the column, so that it can be looked up in an index of the column.
+func comparesAsColumnType(column, value sql.Expression) bool {
+	if isBinaryComparisonOfText(column, value) || isNumericComparisonOfTemporal(column, value) {
+		return false
+	}
+
+	colType, valueType := column.Type(), value.Type()
+	if sql.IsText(colType) || sql.IsText(valueType) {
+		return sql.IsText(colType) && sql.IsText(valueType)
+	}
+
+	return !sql.IsNumber(colType) || sql.IsNumber(valueType)
+}
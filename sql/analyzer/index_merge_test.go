@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestIndexMerge(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable"},
+		{Name: "b", Type: sql.Int64, Source: "mytable"},
+		{Name: "c", Type: sql.Int64, Source: "mytable"},
+	}
+	ctx := sql.NewEmptyContext()
+	table := memory.NewTable("mytable", schema)
+	for _, col := range []string{"a", "b"} {
+		require.NoError(table.CreateIndex(ctx, col+"_idx", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{
+			{Name: col},
+		}, ""))
+	}
+	for a := int64(1); a <= 3; a++ {
+		for b := int64(1); b <= 3; b++ {
+			require.NoError(table.Insert(ctx, sql.NewRow(a, b, a*b)))
+		}
+	}
+	unindexed := memory.NewTable("mytable", schema)
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", false)
+	c := expression.NewGetFieldWithTable(2, sql.Int64, "mytable", "c", false)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+	aIsOne := expression.NewEquals(a, one)
+	bIsTwo := expression.NewEquals(b, two)
+	aOrB := expression.NewOr(aIsOne, bIsTwo)
+
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	rt := plan.NewResolvedTable(table)
+	merge, err := plan.NewIndexMerge(rt, indexes, []sql.Expression{one, two}, []sql.Expression{aIsOne, bIsTwo})
+	require.NoError(err)
+
+	cIsTwo := expression.NewGreaterThan(c, two)
+	tests := []analyzerFnTestCase{
+		{
+			name:     "a = 1 or b = 2",
+			node:     plan.NewFilter(aOrB, rt),
+			expected: plan.NewFilter(aOrB, merge),
+		},
+		{
+			name:     "(a = 1 or b = 2) and c > 2",
+			node:     plan.NewFilter(expression.NewAnd(aOrB, cIsTwo), rt),
+			expected: plan.NewFilter(expression.NewAnd(aOrB, cIsTwo), merge),
+		},
+		{
+			name: "a = 1 or a = 2",
+			node: plan.NewFilter(expression.NewOr(aIsOne, expression.NewEquals(a, two)), rt),
+		},
+		{
+			name: "a = 1 or c = 2",
+			node: plan.NewFilter(expression.NewOr(aIsOne, expression.NewEquals(c, two)), rt),
+		},
+		{
+			name: "a = 1 or b > 2",
+			node: plan.NewFilter(expression.NewOr(aIsOne, expression.NewGreaterThan(b, two)), rt),
+		},
+		{
+			name: "(a = 1 or b = 2) and a = 2",
+			node: plan.NewFilter(expression.NewAnd(aOrB, expression.NewEquals(a, two)), rt),
+		},
+		{
+			name: "no indexes",
+			node: plan.NewFilter(aOrB, plan.NewResolvedTable(unindexed)),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("index_merge"))
+
+	// The row matching both equalities is returned once
+	rows, err := sql.NodeToRows(ctx, merge)
+	require.NoError(err)
+	require.ElementsMatch([]sql.Row{
+		sql.NewRow(int64(1), int64(1), int64(1)),
+		sql.NewRow(int64(1), int64(2), int64(2)),
+		sql.NewRow(int64(1), int64(3), int64(3)),
+		sql.NewRow(int64(2), int64(2), int64(4)),
+		sql.NewRow(int64(3), int64(2), int64(6)),
+	}, rows)
+}
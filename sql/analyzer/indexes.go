@@ -142,7 +142,7 @@ func getIndexes(
 		// Take the index of a SOMETHING IN SOMETHING expression only if:
 		// the right branch is evaluable and the indexlookup supports set
 		// operations.
-		if !isEvaluable(e.Left()) && isEvaluable(e.Right()) {
+		if !isEvaluable(e.Left()) && isEvaluable(e.Right()) && !isNumericComparisonOfTemporal(e.Left(), e.Right()) {
 			idx := ia.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(exprAliases, tableAliases, e.Left())...)
 			if idx != nil {
 				value, err := e.Right().Eval(sql.NewEmptyContext(), nil)
@@ -237,6 +237,42 @@ func getIndexes(
 				}
 			}
 		}
+	case *expression.RangeSet:
+		if isEvaluable(e.Column) {
+			return result, nil
+		}
+
+		idx := ia.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(exprAliases, tableAliases, e.Column)...)
+		if idx == nil {
+			return result, nil
+		}
+
+		var lookup sql.IndexLookup
+		for _, r := range e.Ranges {
+			rangeLookup, err := rangeIndexLookup(idx, e.Column, r)
+			if err != nil || rangeLookup == nil {
+				return result, err
+			}
+
+			if lookup == nil {
+				lookup = rangeLookup
+				continue
+			}
+
+			// All the ranges must be looked up with the index, or none of them
+			if !canMergeIndexes(lookup, rangeLookup) {
+				return nil, nil
+			}
+
+			lookup = lookup.(sql.MergeableIndexLookup).Union(rangeLookup)
+		}
+
+		if lookup != nil {
+			result[idx.Table()] = &indexLookup{
+				indexes: []sql.Index{idx},
+				lookup:  lookup,
+			}
+		}
 	case *expression.And:
 		exprs := splitConjunction(e)
 
@@ -286,7 +322,7 @@ func indexHasExpression(indexLookups indexLookupsByTable, expr sql.Expression) b
 	for _, indexLookup := range indexLookups {
 		for _, idx := range indexLookup.indexes {
 			for _, exprStr := range idx.Expressions() {
-				if exprStr == getField.String() {
+				if sql.SameIndexExpression(exprStr, getField.String()) {
 					return true
 				}
 			}
@@ -321,6 +357,78 @@ func betweenIndexLookup(index sql.Index, upper, lower []interface{}) (sql.IndexL
 	return nil, nil
 }
 
+// rangeIndexLookup returns the index lookup for the values of the range given of the column of the index given, or
+// nil if the index can't look them up.
+func rangeIndexLookup(idx sql.Index, column sql.Expression, r expression.Range) (sql.IndexLookup, error) {
+	if r.Lower != nil && r.Upper != nil && r.Lower.Inclusive && r.Upper.Inclusive {
+		lower, err := r.Lower.Value.Eval(sql.NewEmptyContext(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		upper, err := r.Upper.Value.Eval(sql.NewEmptyContext(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		equal, err := expression.NewEquals(r.Lower.Value, r.Upper.Value).Eval(sql.NewEmptyContext(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// The range lookups of an index exclude one of their ends, so a single value is looked up by itself
+		if equal == true {
+			return idx.Get(lower)
+		}
+
+		return betweenIndexLookup(idx, []interface{}{upper}, []interface{}{lower})
+	}
+
+	var bounds []expression.Comparer
+	if r.Lower != nil {
+		if r.Lower.Inclusive {
+			bounds = append(bounds, expression.NewGreaterThanOrEqual(column, r.Lower.Value))
+		} else {
+			bounds = append(bounds, expression.NewGreaterThan(column, r.Lower.Value))
+		}
+	}
+
+	if r.Upper != nil {
+		if r.Upper.Inclusive {
+			bounds = append(bounds, expression.NewLessThanOrEqual(column, r.Upper.Value))
+		} else {
+			bounds = append(bounds, expression.NewLessThan(column, r.Upper.Value))
+		}
+	}
+
+	// An unbounded range matches every value that isn't NULL, which no index lookup does
+	var lookup sql.IndexLookup
+	for _, bound := range bounds {
+		value, err := bound.Right().Eval(sql.NewEmptyContext(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		boundLookup, err := comparisonIndexLookup(bound, idx, value)
+		if err != nil || boundLookup == nil {
+			return nil, err
+		}
+
+		if lookup == nil {
+			lookup = boundLookup
+			continue
+		}
+
+		if !canMergeIndexes(lookup, boundLookup) {
+			return nil, nil
+		}
+
+		lookup = lookup.(sql.MergeableIndexLookup).Intersection(boundLookup)
+	}
+
+	return lookup, nil
+}
+
 // getComparisonIndex returns the index and index lookup for the given
 // comparison if any index can be found.
 // It works for the following comparisons: eq, lt, gt, gte and lte.
@@ -340,7 +448,8 @@ func getComparisonIndex(
 		left, right, e = swapTermsOfExpression(e)
 	}
 
-	if !isEvaluable(left) && isEvaluable(right) {
+	if !isEvaluable(left) && isEvaluable(right) && !isBinaryComparisonOfText(left, right) &&
+		!isNumericComparisonOfTemporal(left, right) {
 		idx := ia.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(exprAliases, tableAliases, left)...)
 		if idx != nil {
 			value, err := right.Eval(sql.NewEmptyContext(), nil)
@@ -474,7 +583,7 @@ func getNegatedIndexes(
 		// Take the index of a SOMETHING IN SOMETHING expression only if:
 		// the right branch is evaluable and the indexlookup supports set
 		// operations.
-		if !isEvaluable(e.Left()) && isEvaluable(e.Right()) {
+		if !isEvaluable(e.Left()) && isEvaluable(e.Right()) && !isNumericComparisonOfTemporal(e.Left(), e.Right()) {
 			idx := ia.IndexByExpression(ctx, ctx.GetCurrentDatabase(), normalizeExpressions(exprAliases, tableAliases, e.Left())...)
 			if idx != nil {
 				nidx, ok := idx.(sql.NegateIndex)
@@ -747,7 +856,7 @@ type columnExpr struct {
 
 func findColumn(cols []columnExpr, column string) *columnExpr {
 	for _, col := range cols {
-		if col.col.String() == column {
+		if sql.SameIndexExpression(col.col.String(), column) {
 			return &col
 		}
 	}
@@ -793,7 +902,7 @@ func extractColumnExpr(e sql.Expression) (string, *columnExpr) {
 			left, right, e = swapTermsOfExpression(cmp)
 		}
 
-		if !isEvaluable(right) {
+		if !isEvaluable(right) || isBinaryComparisonOfText(left, right) || isNumericComparisonOfTemporal(left, right) {
 			return "", nil
 		}
 
@@ -851,6 +960,34 @@ func isEvaluable(e sql.Expression) bool {
 	return !containsColumns(e) && !containsSubquery(e)
 }
 
+// isBinaryComparisonOfText returns whether comparing the text expression given with the value given is a binary
+// comparison, as it is when the value is a binary string, such as one cast with BINARY. An index on the expression
+// matches values with the collation of the expression instead, so it can't be used to look up the rows that match.
+func isBinaryComparisonOfText(e, value sql.Expression) bool {
+	return sql.IsTextOnly(e.Type()) && sql.IsBlob(value.Type())
+}
+
+// isNumericComparisonOfTemporal returns whether comparing the temporal expression given with the value given compares
+// them chronologically with the value read as a date and time, as it is when the value is a number, or a tuple with
+// any number. An index on the expression can't look up the number itself, so it can't be used to look up the rows
+// that match.
+func isNumericComparisonOfTemporal(e, value sql.Expression) bool {
+	if !sql.IsTime(e.Type()) {
+		return false
+	}
+
+	if sql.IsTuple(value.Type()) {
+		for _, typ := range sql.TupleTypes(value.Type()) {
+			if sql.IsNumber(typ) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return sql.IsNumber(value.Type())
+}
+
 func canMergeIndexLookups(leftIndexes, rightIndexes indexLookupsByTable) bool {
 	for table, leftIdx := range leftIndexes {
 		if rightIdx, ok := rightIndexes[table]; ok {
@@ -9,6 +9,7 @@ import (
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
@@ -909,6 +910,98 @@ func TestGetIndexes(t *testing.T) {
 			},
 			true,
 		},
+		{
+			&expression.RangeSet{
+				Column: col(0, "t1", "bar"),
+				Ranges: []expression.Range{
+					{Upper: &expression.RangeBound{Value: lit(5)}},
+					{Lower: &expression.RangeBound{Value: lit(100)}},
+				},
+			},
+			indexLookupsByTable{
+				"t1": &indexLookup{
+					unionLookup("t1", "bar", 0,
+						&memory.AscendIndexLookup{
+							Lt:    []interface{}{int64(5)},
+							Index: mergeableIndex("t1", "bar", 0),
+						},
+						&memory.DescendIndexLookup{
+							Gt:    []interface{}{int64(100)},
+							Index: mergeableIndex("t1", "bar", 0),
+						},
+					),
+					[]sql.Index{indexes[0]},
+				},
+			},
+			true,
+		},
+		{
+			&expression.RangeSet{
+				Column: col(0, "t1", "bar"),
+				Ranges: []expression.Range{
+					{Lower: &expression.RangeBound{Value: lit(1), Inclusive: true}, Upper: &expression.RangeBound{Value: lit(3), Inclusive: true}},
+					{Lower: &expression.RangeBound{Value: lit(5)}, Upper: &expression.RangeBound{Value: lit(10)}},
+				},
+			},
+			indexLookupsByTable{
+				"t1": &indexLookup{
+					unionLookup("t1", "bar", 0,
+						&memory.AscendIndexLookup{
+							Gte:   []interface{}{int64(1)},
+							Lt:    []interface{}{int64(3)},
+							Index: mergeableIndex("t1", "bar", 0),
+						},
+						&memory.DescendIndexLookup{
+							Gt:    []interface{}{int64(1)},
+							Lte:   []interface{}{int64(3)},
+							Index: mergeableIndex("t1", "bar", 0),
+						},
+						intersectionLookup("t1", "bar", 0,
+							&memory.DescendIndexLookup{
+								Gt:    []interface{}{int64(5)},
+								Index: mergeableIndex("t1", "bar", 0),
+							},
+							&memory.AscendIndexLookup{
+								Lt:    []interface{}{int64(10)},
+								Index: mergeableIndex("t1", "bar", 0),
+							},
+						),
+					),
+					[]sql.Index{indexes[0]},
+				},
+			},
+			true,
+		},
+		{
+			&expression.RangeSet{
+				Column: col(0, "t1", "bar"),
+				Ranges: []expression.Range{
+					{Lower: &expression.RangeBound{Value: lit(1), Inclusive: true}, Upper: &expression.RangeBound{Value: lit(1), Inclusive: true}},
+					{Lower: &expression.RangeBound{Value: lit(2), Inclusive: true}},
+				},
+			},
+			indexLookupsByTable{
+				"t1": &indexLookup{
+					unionLookup("t1", "bar", 0,
+						mergeableIndexLookup("t1", "bar", 0, int64(1)),
+						&memory.AscendIndexLookup{
+							Gte:   []interface{}{int64(2)},
+							Index: mergeableIndex("t1", "bar", 0),
+						},
+					),
+					[]sql.Index{indexes[0]},
+				},
+			},
+			true,
+		},
+		{
+			&expression.RangeSet{
+				Column: col(0, "t1", "bar"),
+				Ranges: []expression.Range{{}},
+			},
+			indexLookupsByTable{},
+			true,
+		},
 	}
 
 	catalog := sql.NewCatalog()
@@ -943,6 +1036,99 @@ func TestGetIndexes(t *testing.T) {
 	}
 }
 
+// declaredIndex is an index whose expressions are declared as written, rather than as the String method of its
+// expressions gives them.
+type declaredIndex struct {
+	*memory.MergeableIndex
+	exprs []string
+}
+
+func (i *declaredIndex) Expressions() []string { return i.exprs }
+
+func TestGetFunctionalIndexes(t *testing.T) {
+	name := expression.NewGetFieldWithTable(0, sql.LongText, "t1", "name", false)
+	upperName := function.NewUpper(name)
+	lowerName := function.NewLower(expression.NewGetFieldWithTable(0, sql.LongText, "t2", "name", false))
+	x := expression.NewLiteral("X", sql.LongText)
+
+	upperIndex := &memory.MergeableIndex{
+		TableName: "t1",
+		Exprs:     []sql.Expression{upperName},
+	}
+	lowerIndex := &declaredIndex{
+		MergeableIndex: &memory.MergeableIndex{
+			TableName: "t2",
+			Exprs:     []sql.Expression{lowerName},
+		},
+		exprs: []string{"lower( `t2`.`NAME` )"},
+	}
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		expected indexLookupsByTable
+	}{
+		{
+			"UPPER(t1.name) = 'X'",
+			eq(upperName, x),
+			indexLookupsByTable{
+				"t1": &indexLookup{
+					&memory.MergeableIndexLookup{Key: []interface{}{"X"}, Index: upperIndex},
+					[]sql.Index{upperIndex},
+				},
+			},
+		},
+		{
+			"'X' = UPPER(t1.name)",
+			eq(x, upperName),
+			indexLookupsByTable{
+				"t1": &indexLookup{
+					&memory.MergeableIndexLookup{Key: []interface{}{"X"}, Index: upperIndex},
+					[]sql.Index{upperIndex},
+				},
+			},
+		},
+		{
+			"t1.name = 'X'",
+			eq(name, x),
+			indexLookupsByTable{},
+		},
+		{
+			"LOWER(t2.name) = 'X'",
+			eq(lowerName, x),
+			indexLookupsByTable{
+				"t2": &indexLookup{
+					&memory.MergeableIndexLookup{Key: []interface{}{"X"}, Index: lowerIndex.MergeableIndex},
+					[]sql.Index{lowerIndex},
+				},
+			},
+		},
+	}
+
+	idxReg := sql.NewIndexRegistry()
+	for _, idx := range []sql.DriverIndex{upperIndex, lowerIndex} {
+		done, ready, err := idxReg.AddIndex(idx)
+		require.NoError(t, err)
+		close(done)
+		<-ready
+	}
+
+	a := NewDefault(sql.NewCatalog())
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewContext(context.Background(), sql.WithIndexRegistry(idxReg))
+			ia, err := getIndexesForNode(ctx, a, nil)
+			require.NoError(err)
+
+			result, err := getIndexes(ctx, a, ia, tt.expr, nil, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
 func TestGetMultiColumnIndexes(t *testing.T) {
 	require := require.New(t)
 
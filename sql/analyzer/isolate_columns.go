@@ -0,0 +1,352 @@
+package analyzer
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+var (
+	minInt64 = big.NewInt(math.MinInt64)
+	maxInt64 = big.NewInt(math.MaxInt64)
+)
+
+// isolateComparisonColumns rewrites comparisons of an arithmetic operation of a column and a constant against another
+// constant so that the column is compared by itself, which lets indexes on it be used. For example, x + 5 > 10 is
+// rewritten to x > 5, and x * -2 <= 8 to x >= -4. Only integer columns and literals are rewritten, and only when no
+// value of the column can make the operation overflow, so that the rewritten comparison gives the same results as the
+// original one for every value of the column.
+func isolateComparisonColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("isolate_comparison_columns")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		e, err := expression.TransformUp(filter.Expression, func(e sql.Expression) (sql.Expression, error) {
+			isolated, ok := isolateComparisonColumn(e)
+			if !ok {
+				return e, nil
+			}
+
+			a.Log("rewriting %s as %s", e, isolated)
+			return isolated, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if e == filter.Expression {
+			return node, nil
+		}
+
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// isolateComparisonColumn returns the comparison of the column alone equivalent to the expression given, if it's a
+// comparison of an arithmetic operation of an integer column and an integer literal against another integer literal.
+func isolateComparisonColumn(e sql.Expression) (sql.Expression, bool) {
+	var op expression.ComparisonOperator
+	var left, right sql.Expression
+	switch e := e.(type) {
+	case *expression.Equals:
+		op, left, right = expression.OpEquals, e.Left(), e.Right()
+	case *expression.GreaterThan:
+		op, left, right = expression.OpGreaterThan, e.Left(), e.Right()
+	case *expression.LessThan:
+		op, left, right = expression.OpLessThan, e.Left(), e.Right()
+	case *expression.GreaterThanOrEqual:
+		op, left, right = expression.OpGreaterThanOrEqual, e.Left(), e.Right()
+	case *expression.LessThanOrEqual:
+		op, left, right = expression.OpLessThanOrEqual, e.Left(), e.Right()
+	default:
+		return nil, false
+	}
+
+	// c < x + 5 is the same as x + 5 > c
+	arithmetic, ok := left.(*expression.Arithmetic)
+	if !ok {
+		arithmetic, ok = right.(*expression.Arithmetic)
+		if !ok {
+			return nil, false
+		}
+		op, right = flipComparisonOperator(op), left
+	}
+
+	value, ok := integerLiteral(right)
+	if !ok || value.Cmp(maxInt64) > 0 {
+		return nil, false
+	}
+
+	column, constant, ok := columnArithmeticOperands(arithmetic)
+	if !ok || !arithmeticCantOverflow(arithmetic, column.Type(), constant) {
+		return nil, false
+	}
+
+	op, value, ok = invertArithmetic(arithmetic.Op, constant, op, value)
+	if !ok || value.Cmp(minInt64) < 0 || value.Cmp(maxInt64) > 0 {
+		return nil, false
+	}
+
+	return newComparison(op, column, expression.NewLiteral(value.Int64(), sql.Int64)), true
+}
+
+// columnArithmeticOperands returns the column and the constant of an arithmetic operation of an integer column and
+// an integer literal that can be inverted to isolate the column. Only addition and multiplication can have the column
+// on either side.
+func columnArithmeticOperands(a *expression.Arithmetic) (*expression.GetField, *big.Int, bool) {
+	column, ok := a.Left.(*expression.GetField)
+	constant, isConstant := integerLiteral(a.Right)
+	if !ok || !isConstant {
+		if a.Op != sqlparser.PlusStr && a.Op != sqlparser.MultStr {
+			return nil, nil, false
+		}
+
+		column, ok = a.Right.(*expression.GetField)
+		constant, isConstant = integerLiteral(a.Left)
+		if !ok || !isConstant {
+			return nil, nil, false
+		}
+	}
+
+	if _, _, ok := integerTypeRange(column.Type()); !ok {
+		return nil, nil, false
+	}
+
+	return column, constant, true
+}
+
+// arithmeticCantOverflow returns whether the arithmetic operation given of a column of the type given and a constant
+// gives the exact result for any value of the column. The operation is evaluated with the type of the arithmetic
+// expression, so all of the values involved must be within its range. They must also be within the range of a signed
+// integer, so that comparing them as signed integers is exact too.
+func arithmeticCantOverflow(a *expression.Arithmetic, columnType sql.Type, constant *big.Int) bool {
+	resultMin, resultMax, ok := integerTypeRange(a.Type())
+	if !ok {
+		return false
+	}
+	if resultMin.Cmp(minInt64) < 0 {
+		resultMin = minInt64
+	}
+	if resultMax.Cmp(maxInt64) > 0 {
+		resultMax = maxInt64
+	}
+
+	within := func(v *big.Int) bool {
+		return v.Cmp(resultMin) >= 0 && v.Cmp(resultMax) <= 0
+	}
+
+	columnMin, columnMax, _ := integerTypeRange(columnType)
+	if !within(columnMin) || !within(columnMax) || !within(constant) {
+		return false
+	}
+
+	// Every operation is monotonic on the column, so its results are between the results for the bounds of the column
+	for _, v := range []*big.Int{columnMin, columnMax} {
+		result := new(big.Int)
+		switch a.Op {
+		case sqlparser.PlusStr:
+			result.Add(v, constant)
+		case sqlparser.MinusStr:
+			result.Sub(v, constant)
+		case sqlparser.MultStr:
+			result.Mul(v, constant)
+		case sqlparser.DivStr:
+			if constant.Sign() == 0 {
+				return false
+			}
+			result.Quo(v, constant)
+		default:
+			return false
+		}
+
+		if !within(result) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// invertArithmetic returns the comparison of a column equivalent to comparing the result of the arithmetic operation
+// given of it and a constant against a value, as the operator and the value to compare the column with. Division is
+// the integer division of the operands truncated towards zero, which is how Arithmetic divides integers.
+func invertArithmetic(arithmeticOp string, constant *big.Int, op expression.ComparisonOperator, value *big.Int) (expression.ComparisonOperator, *big.Int, bool) {
+	switch arithmeticOp {
+	case sqlparser.PlusStr:
+		return op, new(big.Int).Sub(value, constant), true
+	case sqlparser.MinusStr:
+		return op, new(big.Int).Add(value, constant), true
+	}
+
+	if constant.Sign() == 0 {
+		return op, nil, false
+	}
+
+	// x * -c OP v is the same as x * c OP' -v, where OP' is OP with the sides swapped, and the same goes for the
+	// truncated division
+	if constant.Sign() < 0 {
+		constant = new(big.Int).Neg(constant)
+		value = new(big.Int).Neg(value)
+		op = flipComparisonOperator(op)
+	}
+
+	one := big.NewInt(1)
+	switch arithmeticOp {
+	case sqlparser.MultStr:
+		switch op {
+		case expression.OpEquals:
+			quotient, remainder := new(big.Int).QuoRem(value, constant, new(big.Int))
+			if remainder.Sign() != 0 {
+				return op, nil, false
+			}
+			return op, quotient, true
+		case expression.OpGreaterThan, expression.OpLessThanOrEqual:
+			return op, floorDiv(value, constant), true
+		default:
+			return op, ceilDiv(value, constant), true
+		}
+	case sqlparser.DivStr:
+		switch op {
+		case expression.OpGreaterThan, expression.OpLessThanOrEqual:
+			next := minTruncatedDividend(new(big.Int).Add(value, one), constant)
+			return op, next.Sub(next, one), true
+		case expression.OpGreaterThanOrEqual, expression.OpLessThan:
+			return op, minTruncatedDividend(value, constant), true
+		}
+	}
+
+	return op, nil, false
+}
+
+// minTruncatedDividend returns the minimum integer whose quotient truncated towards zero when divided by the positive
+// divisor given is at least the quotient given.
+func minTruncatedDividend(quotient, divisor *big.Int) *big.Int {
+	if quotient.Sign() > 0 {
+		return new(big.Int).Mul(quotient, divisor)
+	}
+
+	// Negative dividends are truncated up, so the quotient of one more than the previous multiple is the same
+	result := new(big.Int).Sub(quotient, big.NewInt(1))
+	result.Mul(result, divisor)
+	return result.Add(result, big.NewInt(1))
+}
+
+// floorDiv returns the quotient of the two integers given rounded down, for a positive divisor.
+func floorDiv(dividend, divisor *big.Int) *big.Int {
+	// Euclidean division rounds down with a positive divisor
+	return new(big.Int).Div(dividend, divisor)
+}
+
+// ceilDiv returns the quotient of the two integers given rounded up, for a positive divisor.
+func ceilDiv(dividend, divisor *big.Int) *big.Int {
+	result := floorDiv(new(big.Int).Neg(dividend), divisor)
+	return result.Neg(result)
+}
+
+// integerLiteral returns the value of the expression given if it's an integer literal that isn't NULL.
+func integerLiteral(e sql.Expression) (*big.Int, bool) {
+	lit, ok := e.(*expression.Literal)
+	if !ok || !sql.IsInteger(lit.Type()) {
+		return nil, false
+	}
+
+	switch v := lit.Value().(type) {
+	case int8:
+		return big.NewInt(int64(v)), true
+	case int16:
+		return big.NewInt(int64(v)), true
+	case int32:
+		return big.NewInt(int64(v)), true
+	case int64:
+		return big.NewInt(v), true
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint64:
+		return new(big.Int).SetUint64(v), true
+	default:
+		return nil, false
+	}
+}
+
+// integerTypeRange returns the minimum and maximum values of the integer type given.
+func integerTypeRange(t sql.Type) (*big.Int, *big.Int, bool) {
+	if !sql.IsInteger(t) {
+		return nil, nil, false
+	}
+
+	switch t.Type() {
+	case sqltypes.Int8:
+		return big.NewInt(math.MinInt8), big.NewInt(math.MaxInt8), true
+	case sqltypes.Int16:
+		return big.NewInt(math.MinInt16), big.NewInt(math.MaxInt16), true
+	case sqltypes.Int24:
+		return big.NewInt(-1 << 23), big.NewInt(1<<23 - 1), true
+	case sqltypes.Int32:
+		return big.NewInt(math.MinInt32), big.NewInt(math.MaxInt32), true
+	case sqltypes.Int64:
+		return big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64), true
+	case sqltypes.Uint8:
+		return big.NewInt(0), big.NewInt(math.MaxUint8), true
+	case sqltypes.Uint16:
+		return big.NewInt(0), big.NewInt(math.MaxUint16), true
+	case sqltypes.Uint24:
+		return big.NewInt(0), big.NewInt(1<<24 - 1), true
+	case sqltypes.Uint32:
+		return big.NewInt(0), big.NewInt(math.MaxUint32), true
+	case sqltypes.Uint64:
+		return big.NewInt(0), new(big.Int).SetUint64(math.MaxUint64), true
+	default:
+		return nil, nil, false
+	}
+}
+
+// flipComparisonOperator returns the operator that compares the same way as the one given with its operands swapped.
+func flipComparisonOperator(op expression.ComparisonOperator) expression.ComparisonOperator {
+	switch op {
+	case expression.OpGreaterThan:
+		return expression.OpLessThan
+	case expression.OpLessThan:
+		return expression.OpGreaterThan
+	case expression.OpGreaterThanOrEqual:
+		return expression.OpLessThanOrEqual
+	case expression.OpLessThanOrEqual:
+		return expression.OpGreaterThanOrEqual
+	default:
+		return op
+	}
+}
+
+// newComparison returns the comparison of the two expressions given with the operator given.
+func newComparison(op expression.ComparisonOperator, left, right sql.Expression) sql.Expression {
+	switch op {
+	case expression.OpGreaterThan:
+		return expression.NewGreaterThan(left, right)
+	case expression.OpLessThan:
+		return expression.NewLessThan(left, right)
+	case expression.OpGreaterThanOrEqual:
+		return expression.NewGreaterThanOrEqual(left, right)
+	case expression.OpLessThanOrEqual:
+		return expression.NewLessThanOrEqual(left, right)
+	default:
+		return expression.NewEquals(left, right)
+	}
+}
@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestIsolateComparisonColumns(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "i", Type: sql.Int32, Source: "t"},
+		{Name: "b", Type: sql.Int64, Source: "t"},
+		{Name: "u", Type: sql.Uint8, Source: "t"},
+		{Name: "f", Type: sql.Float64, Source: "t"},
+	}))
+
+	i := expression.NewGetFieldWithTable(0, sql.Int32, "t", "i", true)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t", "b", true)
+	u := expression.NewGetFieldWithTable(2, sql.Uint8, "t", "u", true)
+	f := expression.NewGetFieldWithTable(3, sql.Float64, "t", "f", true)
+	lit := func(v int8) sql.Expression {
+		return expression.NewLiteral(v, sql.Int8)
+	}
+	result := func(v int64) sql.Expression {
+		return expression.NewLiteral(v, sql.Int64)
+	}
+	filter := func(e sql.Expression) sql.Node {
+		return plan.NewFilter(e, table)
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "i + 5 > 10",
+			node:     filter(expression.NewGreaterThan(expression.NewPlus(i, lit(5)), lit(10))),
+			expected: filter(expression.NewGreaterThan(i, result(5))),
+		},
+		{
+			name:     "5 + i = 10",
+			node:     filter(expression.NewEquals(expression.NewPlus(lit(5), i), lit(10))),
+			expected: filter(expression.NewEquals(i, result(5))),
+		},
+		{
+			name:     "10 <= i - 3",
+			node:     filter(expression.NewLessThanOrEqual(lit(10), expression.NewMinus(i, lit(3)))),
+			expected: filter(expression.NewGreaterThanOrEqual(i, result(13))),
+		},
+		{
+			name:     "u - 3 < 10",
+			node:     filter(expression.NewLessThan(expression.NewMinus(u, lit(3)), lit(10))),
+			expected: filter(expression.NewLessThan(u, result(13))),
+		},
+		{
+			name:     "i * 3 > 10",
+			node:     filter(expression.NewGreaterThan(expression.NewMult(i, lit(3)), lit(10))),
+			expected: filter(expression.NewGreaterThan(i, result(3))),
+		},
+		{
+			name:     "i * 3 >= 10",
+			node:     filter(expression.NewGreaterThanOrEqual(expression.NewMult(i, lit(3)), lit(10))),
+			expected: filter(expression.NewGreaterThanOrEqual(i, result(4))),
+		},
+		{
+			name:     "i * 3 < -10",
+			node:     filter(expression.NewLessThan(expression.NewMult(i, lit(3)), lit(-10))),
+			expected: filter(expression.NewLessThan(i, result(-3))),
+		},
+		{
+			name:     "i * -2 <= 8",
+			node:     filter(expression.NewLessThanOrEqual(expression.NewMult(i, lit(-2)), lit(8))),
+			expected: filter(expression.NewGreaterThanOrEqual(i, result(-4))),
+		},
+		{
+			name:     "i * 3 = 12",
+			node:     filter(expression.NewEquals(expression.NewMult(i, lit(3)), lit(12))),
+			expected: filter(expression.NewEquals(i, result(4))),
+		},
+		{
+			name: "i * 3 = 10",
+			node: filter(expression.NewEquals(expression.NewMult(i, lit(3)), lit(10))),
+		},
+		{
+			name: "i * 0 > 10",
+			node: filter(expression.NewGreaterThan(expression.NewMult(i, lit(0)), lit(10))),
+		},
+		{
+			name:     "i / 2 > 3",
+			node:     filter(expression.NewGreaterThan(expression.NewDiv(i, lit(2)), lit(3))),
+			expected: filter(expression.NewGreaterThan(i, result(7))),
+		},
+		{
+			name:     "i / 2 >= -1",
+			node:     filter(expression.NewGreaterThanOrEqual(expression.NewDiv(i, lit(2)), lit(-1))),
+			expected: filter(expression.NewGreaterThanOrEqual(i, result(-3))),
+		},
+		{
+			name:     "i / -2 < 1",
+			node:     filter(expression.NewLessThan(expression.NewDiv(i, lit(-2)), lit(1))),
+			expected: filter(expression.NewGreaterThan(i, result(-2))),
+		},
+		{
+			name: "i / 2 = 3",
+			node: filter(expression.NewEquals(expression.NewDiv(i, lit(2)), lit(3))),
+		},
+		{
+			name: "10 - i > 3",
+			node: filter(expression.NewGreaterThan(expression.NewMinus(lit(10), i), lit(3))),
+		},
+		{
+			name: "b + 5 > 10 may overflow",
+			node: filter(expression.NewGreaterThan(expression.NewPlus(b, lit(5)), lit(10))),
+		},
+		{
+			name: "u - 3 < 10 may overflow when unsigned",
+			node: filter(expression.NewLessThan(
+				expression.NewMinus(u, expression.NewLiteral(uint8(3), sql.Uint8)),
+				expression.NewLiteral(uint8(10), sql.Uint8),
+			)),
+		},
+		{
+			name: "f + 5 > 10",
+			node: filter(expression.NewGreaterThan(expression.NewPlus(f, lit(5)), lit(10))),
+		},
+		{
+			name: "i + 0.5 > 10",
+			node: filter(expression.NewGreaterThan(expression.NewPlus(i, expression.NewLiteral(0.5, sql.Float64)), lit(10))),
+		},
+		{
+			name: "i + 5 > b",
+			node: filter(expression.NewGreaterThan(expression.NewPlus(i, lit(5)), b)),
+		},
+		{
+			name: "i + 5 > 10 below a conjunction",
+			node: filter(expression.NewAnd(
+				expression.NewGreaterThan(expression.NewPlus(i, lit(5)), lit(10)),
+				expression.NewLessThan(expression.NewPlus(i, lit(5)), lit(20)),
+			)),
+			expected: filter(expression.NewAnd(
+				expression.NewGreaterThan(i, result(5)),
+				expression.NewLessThan(i, result(15)),
+			)),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("isolate_comparison_columns"))
+}
+
+// TestIsolateComparisonColumnsResults checks that the rewritten comparisons give the same results as the original ones
+// for every value of a column.
+func TestIsolateComparisonColumnsResults(t *testing.T) {
+	column := expression.NewGetField(0, sql.Int8, "c", true)
+	ops := []func(left, right sql.Expression) sql.Expression{
+		func(l, r sql.Expression) sql.Expression { return expression.NewEquals(l, r) },
+		func(l, r sql.Expression) sql.Expression { return expression.NewGreaterThan(l, r) },
+		func(l, r sql.Expression) sql.Expression { return expression.NewLessThan(l, r) },
+		func(l, r sql.Expression) sql.Expression { return expression.NewGreaterThanOrEqual(l, r) },
+		func(l, r sql.Expression) sql.Expression { return expression.NewLessThanOrEqual(l, r) },
+	}
+	arithmetics := []func(left, right sql.Expression) *expression.Arithmetic{
+		expression.NewPlus, expression.NewMinus, expression.NewMult, expression.NewDiv,
+	}
+
+	ctx := sql.NewEmptyContext()
+	for _, op := range ops {
+		for _, arithmetic := range arithmetics {
+			for _, constant := range []int64{-3, -2, -1, 1, 2, 3} {
+				for value := int64(-7); value <= 7; value++ {
+					e := op(arithmetic(column, expression.NewLiteral(constant, sql.Int64)), expression.NewLiteral(value, sql.Int64))
+					isolated, ok := isolateComparisonColumn(e)
+					if !ok {
+						continue
+					}
+
+					for c := int64(-20); c <= 20; c++ {
+						row := sql.NewRow(int8(c))
+						expected, err := e.Eval(ctx, row)
+						require.NoError(t, err)
+						actual, err := isolated.Eval(ctx, row)
+						require.NoError(t, err)
+						require.Equal(t, expected, actual, "%s and %s with %d", e, isolated, c)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// pruneListPartitions replaces a LIST partitioned table filtered by an equality or IN list of constants on its
+// partition column with a plan.PartitionPrunedTable that only reads the partitions whose lists have any of the
+// constants. Only tables implementing sql.ListPartitionedTable that are filtered directly are considered. The filter is
+// kept on top of the pruned table.
+func pruneListPartitions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("prune_list_partitions")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		rt, ok := filter.Child.(*plan.ResolvedTable)
+		if !ok {
+			return node, nil
+		}
+
+		table, ok := rt.Table.(sql.ListPartitionedTable)
+		if !ok {
+			return node, nil
+		}
+
+		idx := table.Schema().IndexOf(table.PartitionColumn(), table.Name())
+		if idx < 0 {
+			return node, nil
+		}
+		typ := table.Schema()[idx].Type.Promote()
+
+		values, ok, err := partitionColumnValues(ctx, table.Name(), table.PartitionColumn(), typ, splitConjunction(filter.Expression))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return node, nil
+		}
+
+		partitions, err := table.ListPartitions(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var pruned []sql.Partition
+		for _, p := range partitions {
+			ok, err := containsAny(typ, p.Values, values)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				pruned = append(pruned, p.Partition)
+			}
+		}
+
+		if len(pruned) == len(partitions) {
+			return node, nil
+		}
+
+		a.Log("pruned table %s to %d of its %d partitions", table.Name(), len(pruned), len(partitions))
+		return plan.NewFilter(filter.Expression, plan.NewPartitionPrunedTable(rt, pruned)), nil
+	})
+}
+
+// partitionColumnValues returns the values, converted to the type given, that the column given of the table given must
+// be equal to for all the predicates given to match, or false if they don't restrict the column to a list of values.
+// Equalities and IN lists comparing the column with text when it isn't text, or the other way around, are left out,
+// since the values are then compared as numbers.
+func partitionColumnValues(ctx *sql.Context, table, column string, typ sql.Type, predicates []sql.Expression) ([]interface{}, bool, error) {
+	var list *literalInList
+	for _, p := range predicates {
+		l, ok := newLiteralInList(p)
+		if !ok || !strings.EqualFold(l.column.Table(), table) || !strings.EqualFold(l.column.Name(), column) {
+			continue
+		}
+
+		sameKind := true
+		for _, el := range l.elems {
+			sameKind = sameKind && sql.IsText(el.Type()) == sql.IsText(typ)
+		}
+		if !sameKind {
+			continue
+		}
+
+		if list == nil {
+			list = l
+		} else if err := list.intersect(l); err != nil {
+			return nil, false, err
+		}
+	}
+
+	e, ok := columnEqualities(table, predicates)[strings.ToLower(column)]
+	if !ok || sql.IsText(e.Type()) != sql.IsText(typ) {
+		if list == nil {
+			return nil, false, nil
+		}
+		return list.values, true, nil
+	}
+
+	v, err := e.Eval(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// No row is equal to NULL
+	if v == nil {
+		return nil, true, nil
+	}
+
+	v, err = typ.Convert(v)
+	if err != nil {
+		if list == nil {
+			return nil, false, nil
+		}
+		return list.values, true, nil
+	}
+
+	if list != nil {
+		ok, err := list.contains(v)
+		if err != nil || !ok {
+			return nil, true, err
+		}
+	}
+
+	return []interface{}{v}, true, nil
+}
+
+// containsAny returns whether any of the values given are in the list given, compared as the type given.
+func containsAny(typ sql.Type, list, values []interface{}) (bool, error) {
+	for _, l := range list {
+		if l == nil {
+			continue
+		}
+
+		for _, v := range values {
+			cmp, err := typ.Compare(l, v)
+			if err != nil {
+				return false, err
+			}
+
+			if cmp == 0 {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,123 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// listTable is a table partitioned by the values of its region column.
+type listTable struct {
+	*memory.Table
+	partitions []sql.ListPartition
+}
+
+var _ sql.ListPartitionedTable = (*listTable)(nil)
+
+func (t *listTable) PartitionColumn() string {
+	return "region"
+}
+
+func (t *listTable) ListPartitions(*sql.Context) ([]sql.ListPartition, error) {
+	return t.partitions, nil
+}
+
+type listPartition string
+
+func (p listPartition) Key() []byte {
+	return []byte(p)
+}
+
+func TestPruneListPartitions(t *testing.T) {
+	eu, us, asia := listPartition("p_eu"), listPartition("p_us"), listPartition("p_asia")
+	table := &listTable{
+		Table: memory.NewTable("sales", sql.Schema{
+			{Name: "id", Type: sql.Int64, Source: "sales"},
+			{Name: "region", Type: sql.Text, Source: "sales", Nullable: true},
+		}),
+		partitions: []sql.ListPartition{
+			{Partition: eu, Values: []interface{}{"EU", "UK"}},
+			{Partition: us, Values: []interface{}{"US", nil}},
+			{Partition: asia, Values: []interface{}{"JP", "CN"}},
+		},
+	}
+	rt := plan.NewResolvedTable(table)
+
+	id := expression.NewGetFieldWithTable(0, sql.Int64, "sales", "id", false)
+	region := expression.NewGetFieldWithTable(1, sql.Text, "sales", "region", true)
+	str := func(s string) sql.Expression {
+		return expression.NewLiteral(s, sql.LongText)
+	}
+	in := func(values ...sql.Expression) sql.Expression {
+		return expression.NewInTuple(region, expression.NewTuple(values...))
+	}
+	pruned := func(e sql.Expression, partitions ...sql.Partition) sql.Node {
+		return plan.NewFilter(e, plan.NewPartitionPrunedTable(rt, partitions))
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "region = 'EU'",
+			node:     plan.NewFilter(expression.NewEquals(region, str("EU")), rt),
+			expected: pruned(expression.NewEquals(region, str("EU")), eu),
+		},
+		{
+			name:     "'UK' = region",
+			node:     plan.NewFilter(expression.NewEquals(str("UK"), region), rt),
+			expected: pruned(expression.NewEquals(str("UK"), region), eu),
+		},
+		{
+			name:     "region IN ('EU', 'US')",
+			node:     plan.NewFilter(in(str("EU"), str("US")), rt),
+			expected: pruned(in(str("EU"), str("US")), eu, us),
+		},
+		{
+			name:     "region IN ('EU', 'UK', NULL)",
+			node:     plan.NewFilter(in(str("EU"), str("UK"), expression.NewLiteral(nil, sql.Null)), rt),
+			expected: pruned(in(str("EU"), str("UK"), expression.NewLiteral(nil, sql.Null)), eu),
+		},
+		{
+			name: "region IN ('EU', 'US') and region = 'US'",
+			node: plan.NewFilter(expression.NewAnd(in(str("EU"), str("US")), expression.NewEquals(region, str("US"))), rt),
+			expected: pruned(
+				expression.NewAnd(in(str("EU"), str("US")), expression.NewEquals(region, str("US"))),
+				us,
+			),
+		},
+		{
+			name:     "region IN ('EU', 'US') and id > 5",
+			node:     plan.NewFilter(expression.NewAnd(in(str("EU"), str("US")), expression.NewGreaterThan(id, expression.NewLiteral(int64(5), sql.Int64))), rt),
+			expected: pruned(expression.NewAnd(in(str("EU"), str("US")), expression.NewGreaterThan(id, expression.NewLiteral(int64(5), sql.Int64))), eu, us),
+		},
+		{
+			name:     "region = 'FR'",
+			node:     plan.NewFilter(expression.NewEquals(region, str("FR")), rt),
+			expected: pruned(expression.NewEquals(region, str("FR"))),
+		},
+		{
+			name: "region IN ('EU', 'JP', 'US')",
+			node: plan.NewFilter(in(str("EU"), str("JP"), str("US")), rt),
+		},
+		{
+			name: "region = 1",
+			node: plan.NewFilter(expression.NewEquals(region, expression.NewLiteral(int64(1), sql.Int64)), rt),
+		},
+		{
+			name: "region > 'EU'",
+			node: plan.NewFilter(expression.NewGreaterThan(region, str("EU")), rt),
+		},
+		{
+			name: "id = 1",
+			node: plan.NewFilter(expression.NewEquals(id, expression.NewLiteral(int64(1), sql.Int64)), rt),
+		},
+		{
+			name: "region = 'EU' or region = 'US'",
+			node: plan.NewFilter(expression.NewOr(expression.NewEquals(region, str("EU")), expression.NewEquals(region, str("US"))), rt),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("prune_list_partitions"))
+}
@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// memoizeInSubqueries memoizes the results of the correlated subqueries of IN expressions by the values of the columns
+// of the outer row they depend on, so that each of them only runs once for each distinct combination of the values
+// instead of once for each outer row. A subquery is only memoized if the statistics of the tables of the columns
+// estimate there are no more distinct combinations of their values than plan.SubqueryMemoMaxEntries, which bounds the
+// memory the memo takes up, and if it doesn't depend on anything else that can change from one row to the next.
+func memoizeInSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("memoize_in_subqueries")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformExpressionsUpWithNode(n, func(node sql.Node, e sql.Expression) (sql.Expression, error) {
+		in, ok := e.(*plan.InSubquery)
+		if !ok {
+			return e, nil
+		}
+
+		s, ok := in.Right.(*plan.Subquery)
+		if !ok || !s.Resolved() || !s.IsNonDeterministic() || s.MemoizedBy() != nil {
+			return e, nil
+		}
+
+		outerScope := scope.newScope(node)
+		outer := outerScope.Schema()
+		correlation, ok := subqueryCorrelation(s, len(outer))
+		if !ok || len(correlation) == 0 {
+			return e, nil
+		}
+
+		combinations, ok, err := correlationCardinality(ctx, outerScope.InnerToOuter(), outer, correlation)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok || combinations > plan.SubqueryMemoMaxEntries {
+			return e, nil
+		}
+
+		a.Log("memoizing the results of subquery %s by the outer columns %v", s, correlation)
+		return in.WithChildren(in.Left, s.WithMemoizedResults(correlation))
+	})
+}
+
+// subqueryCorrelation returns the sorted indexes of the columns of the outer row the subquery given depends on, for an
+// outer row of the length given, or false if it depends on anything else that can change from one row to the next.
+func subqueryCorrelation(s *plan.Subquery, outerLen int) ([]int, bool) {
+	seen := make(map[int]bool)
+	var correlation []int
+	deterministic := true
+	plan.InspectExpressions(s.Query, func(e sql.Expression) bool {
+		if nd, ok := e.(sql.NonDeterministicExpression); ok && nd.IsNonDeterministic() {
+			deterministic = false
+			return false
+		}
+
+		if gf, ok := e.(*expression.GetField); ok && gf.Index() < outerLen && !seen[gf.Index()] {
+			seen[gf.Index()] = true
+			correlation = append(correlation, gf.Index())
+		}
+		return true
+	})
+
+	if !deterministic {
+		return nil, false
+	}
+
+	sort.Ints(correlation)
+	return correlation, true
+}
+
+// correlationCardinality returns the number of distinct combinations of the values of the columns given of the
+// schema of the outer row given that the statistics of their tables, found in the scope nodes given, estimate there
+// are, or false if any of them has no statistics. Counting stops once there are more than plan.SubqueryMemoMaxEntries.
+func correlationCardinality(ctx *sql.Context, nodes []sql.Node, outer sql.Schema, correlation []int) (int, bool, error) {
+	combinations := 1
+	for _, idx := range correlation {
+		col := outer[idx]
+		table := statisticsTableNamed(nodes, col.Source)
+		if table == nil {
+			return 0, false, nil
+		}
+
+		values, ok, err := table.DistinctValues(ctx, strings.ToLower(col.Name))
+		if err != nil || !ok {
+			return 0, false, err
+		}
+
+		combinations *= len(values)
+		if combinations > plan.SubqueryMemoMaxEntries {
+			break
+		}
+	}
+
+	return combinations, true, nil
+}
+
+// statisticsTableNamed returns the table with statistics with the name or alias given among the nodes given, or nil if
+// there's none.
+func statisticsTableNamed(nodes []sql.Node, name string) sql.StatisticsTable {
+	var table sql.StatisticsTable
+	for _, n := range nodes {
+		plan.Inspect(n, func(n sql.Node) bool {
+			if table != nil {
+				return false
+			}
+
+			switch n := n.(type) {
+			case *plan.TableAlias:
+				// The columns of an aliased table are from the alias, not the table
+				if rt, ok := n.Child.(*plan.ResolvedTable); ok && strings.EqualFold(n.Name(), name) {
+					table, _ = rt.Table.(sql.StatisticsTable)
+				}
+				return false
+			case *plan.ResolvedTable:
+				if strings.EqualFold(n.Name(), name) {
+					table, _ = n.Table.(sql.StatisticsTable)
+				}
+				return false
+			default:
+				return true
+			}
+		})
+	}
+	return table
+}
@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestMemoizeInSubqueries(t *testing.T) {
+	outerSchema := func(source string) sql.Schema {
+		return sql.Schema{
+			{Name: "g", Type: sql.Int64, Source: source},
+			{Name: "v", Type: sql.Int64, Source: source},
+		}
+	}
+	fewValues := &statsTable{
+		Table:    memory.NewTable("outer", outerSchema("outer")),
+		distinct: map[string][]interface{}{"g": {int64(0), int64(1), int64(2)}},
+	}
+	manyValues := &statsTable{Table: fewValues.Table, distinct: map[string][]interface{}{"g": make([]interface{}, plan.SubqueryMemoMaxEntries+1)}}
+	noStats := &statsTable{Table: fewValues.Table}
+	aliased := plan.NewTableAlias("o", plan.NewResolvedTable(&statsTable{
+		Table:    memory.NewTable("outer", outerSchema("o")),
+		distinct: fewValues.distinct,
+	}))
+	inner := memory.NewTable("inner", sql.Schema{
+		{Name: "g", Type: sql.Int64, Source: "inner"},
+		{Name: "v", Type: sql.Int64, Source: "inner"},
+	})
+
+	// SELECT v FROM inner WHERE inner.g = outer.g
+	correlated := plan.NewSubquery(plan.NewProject([]sql.Expression{
+		expression.NewGetFieldWithTable(3, sql.Int64, "inner", "v", false),
+	}, plan.NewFilter(
+		expression.NewEquals(
+			expression.NewGetFieldWithTable(0, sql.Int64, "outer", "g", false),
+			expression.NewGetFieldWithTable(2, sql.Int64, "inner", "g", false),
+		),
+		plan.NewResolvedTable(inner),
+	)), "")
+	uncorrelated := plan.NewSubquery(plan.NewProject([]sql.Expression{
+		expression.NewGetFieldWithTable(3, sql.Int64, "inner", "v", false),
+	}, plan.NewResolvedTable(inner)), "").WithCachedResults()
+
+	v := expression.NewGetFieldWithTable(1, sql.Int64, "outer", "v", false)
+	filter := func(subquery *plan.Subquery, table sql.Node) sql.Node {
+		return plan.NewFilter(plan.NewInSubquery(v, subquery), table)
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "few distinct correlation values",
+			node:     filter(correlated, plan.NewResolvedTable(fewValues)),
+			expected: filter(correlated.WithMemoizedResults([]int{0}), plan.NewResolvedTable(fewValues)),
+		},
+		{
+			name:     "aliased table",
+			node:     filter(correlated, aliased),
+			expected: filter(correlated.WithMemoizedResults([]int{0}), aliased),
+		},
+		{
+			name: "many distinct correlation values",
+			node: filter(correlated, plan.NewResolvedTable(manyValues)),
+		},
+		{
+			name: "no statistics",
+			node: filter(correlated, plan.NewResolvedTable(noStats)),
+		},
+		{
+			name: "uncorrelated subquery",
+			node: filter(uncorrelated, plan.NewResolvedTable(fewValues)),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("memoize_in_subqueries"))
+}
@@ -39,8 +39,13 @@ func mergeUnionSchemas(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 				hasdiff = true
 
 				// TODO: Principled type coercion...
-				les[i] = expression.NewConvert(les[i], expression.ConvertToChar)
-				res[i] = expression.NewConvert(res[i], expression.ConvertToChar)
+				// Decimals of different scales are kept as decimals, so that they're compared as numbers
+				convertTo := expression.ConvertToChar
+				if sql.IsDecimal(ls[i].Type) && sql.IsDecimal(rs[i].Type) {
+					convertTo = expression.ConvertToDecimal
+				}
+				les[i] = expression.NewConvert(les[i], convertTo)
+				res[i] = expression.NewConvert(res[i], convertTo)
 
 				// Preserve schema names across the conversion.
 				les[i] = expression.NewAlias(ls[i].Name, les[i])
@@ -108,6 +108,42 @@ func TestMergeUnionSchemas(t *testing.T) {
 			),
 			nil,
 		},
+		{
+			"Mismatched Decimals Coerced to Decimals",
+			plan.NewDistinctUnion(
+				plan.NewProject(
+					[]sql.Expression{expression.NewLiteral("1.50", sql.MustCreateDecimalType(10, 2))},
+					plan.NewResolvedTable(dualTable),
+				),
+				plan.NewProject(
+					[]sql.Expression{expression.NewLiteral("1.5", sql.MustCreateDecimalType(10, 1))},
+					plan.NewResolvedTable(dualTable),
+				),
+			),
+			plan.NewDistinctUnion(
+				plan.NewProject(
+					[]sql.Expression{
+						expression.NewAlias(`"1.50"`, expression.NewConvert(
+							expression.NewGetField(0, sql.MustCreateDecimalType(10, 2), `"1.50"`, false), "decimal")),
+					},
+					plan.NewProject(
+						[]sql.Expression{expression.NewLiteral("1.50", sql.MustCreateDecimalType(10, 2))},
+						plan.NewResolvedTable(dualTable),
+					),
+				),
+				plan.NewProject(
+					[]sql.Expression{
+						expression.NewAlias(`"1.5"`, expression.NewConvert(
+							expression.NewGetField(0, sql.MustCreateDecimalType(10, 1), `"1.5"`, false), "decimal")),
+					},
+					plan.NewProject(
+						[]sql.Expression{expression.NewLiteral("1.5", sql.MustCreateDecimalType(10, 1))},
+						plan.NewResolvedTable(dualTable),
+					),
+				),
+			),
+			nil,
+		},
 	}
 	for _, c := range testCases {
 		t.Run(c.name, func(t *testing.T) {
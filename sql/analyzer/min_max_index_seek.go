@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// minMaxIndexSeek replaces the table aggregated by the MIN or MAX of one of its columns without grouping, such as in
+// SELECT MIN(x) FROM t, with the first row of a plan.OrderedIndexScan of a sql.OrderedIndex of the column, ascending
+// for MIN and descending for MAX, so that only the row with the value aggregated is read instead of the whole table.
+// The NULL values the aggregation ignores are filtered out of the scan, and so are the rows not matching the filter of
+// the table, if it's filtered, whose comparisons of the column with constants bound the range of the scan.
+func minMaxIndexSeek(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("min_max_index_seek")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		groupBy, ok := node.(*plan.GroupBy)
+		if !ok || len(groupBy.GroupByExprs) != 0 || len(groupBy.SelectedExprs) != 1 {
+			return node, nil
+		}
+
+		column, descending, ok := minMaxColumn(groupBy.SelectedExprs[0])
+		if !ok {
+			return node, nil
+		}
+
+		var predicates []sql.Expression
+		child := groupBy.Child
+		if filter, ok := child.(*plan.Filter); ok {
+			predicates, child = splitConjunction(filter.Expression), filter.Child
+		}
+
+		rt, ok := child.(*plan.ResolvedTable)
+		if !ok || !strings.EqualFold(column.Table(), rt.Name()) {
+			return node, nil
+		}
+
+		idx, err := orderedColumnsIndex(ctx, rt, []string{strings.ToLower(column.Name())}, descending)
+		if err != nil {
+			return nil, err
+		}
+
+		if idx == nil {
+			return node, nil
+		}
+
+		lower, upper := scanBounds([]*expression.GetField{column}, predicates, descending)
+		scan, err := plan.NewOrderedIndexScan(rt, idx, descending, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+
+		if column.IsNullable() {
+			predicates = append(predicates, expression.NewNot(expression.NewIsNull(column)))
+		}
+
+		var seek sql.Node = scan
+		if len(predicates) > 0 {
+			seek = plan.NewFilter(expression.JoinAnd(predicates...), scan)
+		}
+
+		a.Log("seeking the %s of column %s in index %s", groupBy.SelectedExprs[0], column, idx.ID())
+		return groupBy.WithChildren(plan.NewLimit(1, seek))
+	})
+}
+
+// minMaxColumn returns the column the aggregation given is the MIN or MAX of, and whether it's MAX, whose value is the
+// first of a descending scan of the column, or false if it isn't the MIN or MAX of a column.
+func minMaxColumn(e sql.Expression) (*expression.GetField, bool, bool) {
+	if alias, ok := e.(*expression.Alias); ok {
+		e = alias.Child
+	}
+
+	var isMax bool
+	switch e.(type) {
+	case *aggregation.Min:
+	case *aggregation.Max:
+		isMax = true
+	default:
+		return nil, false, false
+	}
+
+	column, ok := e.Children()[0].(*expression.GetField)
+	return column, isMax, ok
+}
@@ -0,0 +1,124 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestMinMaxIndexSeek(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable"},
+		{Name: "b", Type: sql.Int64, Source: "mytable", Nullable: true},
+		{Name: "c", Type: sql.Int64, Source: "mytable"},
+	}
+	ctx := sql.NewEmptyContext()
+
+	// The rows of a countingTable are read in the order they're inserted, which is the order of its ascending scans, so
+	// the descending scans of MAX are tested with a table of the same rows inserted in the opposite order.
+	newTable := func(descending bool) (*countingTable, *plan.ResolvedTable, []sql.Index) {
+		table := &countingTable{Table: memory.NewTable("mytable", schema), read: new(int)}
+		require.NoError(table.CreateIndex(ctx, "a_idx", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "a"}}, ""))
+		require.NoError(table.CreateIndex(ctx, "b_idx", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "b"}}, ""))
+		for i := int64(1); i <= 100; i++ {
+			a := i
+			if descending {
+				a = 101 - i
+			}
+			require.NoError(table.Insert(ctx, sql.NewRow(a, a, a%10)))
+		}
+
+		indexes, err := table.GetIndexes(ctx)
+		require.NoError(err)
+		return table, plan.NewResolvedTable(table), indexes
+	}
+	ascTable, ascRt, ascIndexes := newTable(false)
+	descTable, descRt, descIndexes := newTable(true)
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", true)
+	c := expression.NewGetFieldWithTable(2, sql.Int64, "mytable", "c", false)
+	ten := expression.NewLiteral(int64(10), sql.Int64)
+	aGreaterThanTen := expression.NewGreaterThan(a, ten)
+	minA := []sql.Expression{expression.NewAlias("min(a)", aggregation.NewMin(a))}
+	maxA := []sql.Expression{aggregation.NewMax(a)}
+
+	scan := func(rt *plan.ResolvedTable, idx sql.Index, descending bool, lower, upper sql.Expression) *plan.OrderedIndexScan {
+		s, err := plan.NewOrderedIndexScan(rt, idx, descending, lower, upper)
+		require.NoError(err)
+		return s
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "min(a)",
+			node:     plan.NewGroupBy(minA, nil, ascRt),
+			expected: plan.NewGroupBy(minA, nil, plan.NewLimit(1, scan(ascRt, ascIndexes[0], false, nil, nil))),
+		},
+		{
+			name:     "max(a)",
+			node:     plan.NewGroupBy(maxA, nil, descRt),
+			expected: plan.NewGroupBy(maxA, nil, plan.NewLimit(1, scan(descRt, descIndexes[0], true, nil, nil))),
+		},
+		{
+			name: "min(a) where a > 10",
+			node: plan.NewGroupBy(minA, nil, plan.NewFilter(aGreaterThanTen, ascRt)),
+			expected: plan.NewGroupBy(minA, nil, plan.NewLimit(1,
+				plan.NewFilter(aGreaterThanTen, scan(ascRt, ascIndexes[0], false, ten, nil)))),
+		},
+		{
+			name: "min(b)",
+			node: plan.NewGroupBy([]sql.Expression{aggregation.NewMin(b)}, nil, ascRt),
+			expected: plan.NewGroupBy([]sql.Expression{aggregation.NewMin(b)}, nil, plan.NewLimit(1,
+				plan.NewFilter(expression.NewNot(expression.NewIsNull(b)), scan(ascRt, ascIndexes[1], false, nil, nil)))),
+		},
+		{
+			name: "min(c)",
+			node: plan.NewGroupBy([]sql.Expression{aggregation.NewMin(c)}, nil, ascRt),
+		},
+		{
+			name: "min(a) group by c",
+			node: plan.NewGroupBy(minA, []sql.Expression{c}, ascRt),
+		},
+		{
+			name: "min(a), max(a)",
+			node: plan.NewGroupBy(append(minA, maxA...), nil, ascRt),
+		},
+		{
+			name: "min(a + 1)",
+			node: plan.NewGroupBy([]sql.Expression{aggregation.NewMin(expression.NewArithmetic(a, ten, "+"))}, nil, ascRt),
+		},
+		{
+			name: "memory table",
+			node: plan.NewGroupBy(minA, nil, plan.NewResolvedTable(ascTable.Table)),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("min_max_index_seek"))
+
+	for _, tt := range []struct {
+		table    *countingTable
+		node     sql.Node
+		expected []sql.Row
+		read     int
+	}{
+		{ascTable, tests[0].expected, []sql.Row{{int64(1)}}, 1},
+		{descTable, tests[1].expected, []sql.Row{{int64(100)}}, 1},
+		{ascTable, tests[2].expected, []sql.Row{{int64(11)}}, 2},
+		{ascTable, tests[0].node, []sql.Row{{int64(1)}}, 100},
+	} {
+		*tt.table.read = 0
+		rows, err := sql.NodeToRows(ctx, tt.node)
+		require.NoError(err)
+		require.Equal(tt.expected, rows)
+		require.Equal(tt.read, *tt.table.read)
+	}
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// pushDownNegations pushes the NOTs of filters and HAVING clauses down through the ANDs and ORs they negate, using De
+// Morgan's laws, so that NOT (a = 1 AND b < 2) becomes NOT(a = 1) OR b >= 2. Negated comparisons that have an
+// inverse operator are replaced by it, which exposes them to the rules that use comparisons to look up indexes. Both
+// laws and the inverse operators keep the results of three-valued logic, so NULLs are kept where they were.
+func pushDownNegations(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("push_down_negations")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		switch node := node.(type) {
+		case *plan.Filter:
+			e, err := expression.TransformUp(node.Expression, pushDownNegation)
+			if err != nil {
+				return nil, err
+			}
+			return plan.NewFilter(e, node.Child), nil
+		case *plan.Having:
+			e, err := expression.TransformUp(node.Cond, pushDownNegation)
+			if err != nil {
+				return nil, err
+			}
+			return plan.NewHaving(e, node.Child), nil
+		default:
+			return node, nil
+		}
+	})
+}
+
+func pushDownNegation(e sql.Expression) (sql.Expression, error) {
+	not, ok := e.(*expression.Not)
+	if !ok {
+		return e, nil
+	}
+	return negate(not.Child), nil
+}
+
+// negate returns an expression equivalent to NOT e, with the NOT pushed down as far as it can go.
+func negate(e sql.Expression) sql.Expression {
+	switch e := e.(type) {
+	case *expression.And:
+		return expression.NewOr(negate(e.Left), negate(e.Right))
+	case *expression.Or:
+		return expression.NewAnd(negate(e.Left), negate(e.Right))
+	case expression.Negatable:
+		if negated, ok := e.Negate(); ok {
+			return negated
+		}
+	}
+	return expression.NewNot(e)
+}
@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestPushDownNegations(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "b", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "f", Type: sql.Float64, Source: "t", Nullable: true},
+		{Name: "s", Type: sql.Text, Source: "t", Nullable: true},
+	}))
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", true)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t", "b", true)
+	f := expression.NewGetFieldWithTable(2, sql.Float64, "t", "f", true)
+	s := expression.NewGetFieldWithTable(3, sql.Text, "t", "s", true)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+
+	tests := []analyzerFnTestCase{
+		{
+			name: "not (a = 1 and b < 2)",
+			node: plan.NewFilter(
+				expression.NewNot(expression.NewAnd(expression.NewEquals(a, one), expression.NewLessThan(b, two))),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewOr(expression.NewNot(expression.NewEquals(a, one)), expression.NewGreaterThanOrEqual(b, two)),
+				table,
+			),
+		},
+		{
+			name: "not (a > 1 or b <= 2)",
+			node: plan.NewFilter(
+				expression.NewNot(expression.NewOr(expression.NewGreaterThan(a, one), expression.NewLessThanOrEqual(b, two))),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(expression.NewLessThanOrEqual(a, one), expression.NewGreaterThan(b, two)),
+				table,
+			),
+		},
+		{
+			name: "nested",
+			node: plan.NewFilter(
+				expression.NewNot(expression.NewAnd(
+					expression.NewGreaterThanOrEqual(a, one),
+					expression.NewOr(expression.NewLessThan(b, two), expression.NewIsNull(a)),
+				)),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewOr(
+					expression.NewLessThan(a, one),
+					expression.NewAnd(expression.NewGreaterThanOrEqual(b, two), expression.NewNot(expression.NewIsNull(a))),
+				),
+				table,
+			),
+		},
+		{
+			name: "having",
+			node: plan.NewHaving(
+				expression.NewNot(expression.NewAnd(expression.NewGreaterThan(a, one), expression.NewGreaterThan(b, two))),
+				table,
+			),
+			expected: plan.NewHaving(
+				expression.NewOr(expression.NewLessThanOrEqual(a, one), expression.NewLessThanOrEqual(b, two)),
+				table,
+			),
+		},
+		{
+			name: "float, which can be NaN",
+			node: plan.NewFilter(expression.NewNot(expression.NewGreaterThan(f, one)), table),
+		},
+		{
+			name: "text compared with a number, which can be parsed as NaN",
+			node: plan.NewFilter(expression.NewNot(expression.NewGreaterThan(s, one)), table),
+		},
+		{
+			name: "not a = 1",
+			node: plan.NewFilter(expression.NewNot(expression.NewEquals(a, one)), table),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("push_down_negations"))
+}
+
+func TestPushDownNegationsThreeValuedLogic(t *testing.T) {
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", true)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t", "b", true)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+
+	conditions := []sql.Expression{
+		expression.NewAnd(expression.NewEquals(a, one), expression.NewLessThan(b, two)),
+		expression.NewOr(expression.NewGreaterThan(a, one), expression.NewLessThanOrEqual(b, two)),
+		expression.NewAnd(expression.NewGreaterThanOrEqual(a, one), expression.NewOr(expression.NewLessThan(b, two), expression.NewIsNull(a))),
+	}
+
+	values := []interface{}{nil, int64(0), int64(1), int64(2), int64(3)}
+	ctx := sql.NewEmptyContext()
+	for _, cond := range conditions {
+		negated, err := pushDownNegation(expression.NewNot(cond))
+		require.NoError(t, err)
+
+		for _, av := range values {
+			for _, bv := range values {
+				row := sql.NewRow(av, bv)
+				expected, err := expression.NewNot(cond).Eval(ctx, row)
+				require.NoError(t, err)
+
+				actual, err := negated.Eval(ctx, row)
+				require.NoError(t, err)
+				require.Equal(t, expected, actual, "%s for a = %v, b = %v", negated, av, bv)
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// foldInequalitiesToNotIn replaces the inequalities between the same expression and literals among the conjuncts of
+// filters with a NOT IN list of the literals, so x <> 1 AND x <> 2 becomes x NOT IN (1, 2). Only deterministic
+// expressions are considered, and only literals that IN compares with them as the inequalities do, which are those of
+// the same type, or integers compared with integers of the same signedness. Strings aren't folded, since IN doesn't
+// follow the collation of the expression like the inequalities do.
+func foldInequalitiesToNotIn(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fold_inequalities_to_not_in")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		conjuncts := splitConjunction(filter.Expression)
+		operands := make([]sql.Expression, len(conjuncts))
+		counts := make(map[string]int)
+		for i, e := range conjuncts {
+			if operand, _, ok := literalInequality(e); ok {
+				operands[i] = operand
+				counts[operand.String()]++
+			}
+		}
+
+		var result []sql.Expression
+		lists := make(map[string][]sql.Expression)
+		positions := make(map[string]int)
+		for i, e := range conjuncts {
+			operand := operands[i]
+			if operand == nil || counts[operand.String()] < 2 {
+				result = append(result, e)
+				continue
+			}
+
+			key := operand.String()
+			if _, ok := lists[key]; !ok {
+				positions[key] = len(result)
+				result = append(result, e)
+			}
+
+			_, lit, _ := literalInequality(e)
+			lists[key] = append(lists[key], lit)
+		}
+
+		if len(lists) == 0 {
+			return node, nil
+		}
+
+		for key, lits := range lists {
+			a.Log("folded %d inequalities on %s into NOT IN", len(lits), key)
+			operand, _, _ := literalInequality(result[positions[key]])
+			result[positions[key]] = expression.NewNotInTuple(operand, expression.NewTuple(lits...))
+		}
+
+		return plan.NewFilter(expression.JoinAnd(result...), filter.Child), nil
+	})
+}
+
+// literalInequality returns the operands of the inequality given, NOT(x = lit) or NOT(lit = x), if x is deterministic
+// and lit a literal that IN compares with it the same way the equality does.
+func literalInequality(e sql.Expression) (sql.Expression, *expression.Literal, bool) {
+	not, ok := e.(*expression.Not)
+	if !ok {
+		return nil, nil, false
+	}
+
+	eq, ok := not.Child.(*expression.Equals)
+	if !ok {
+		return nil, nil, false
+	}
+
+	operand, right := eq.Left(), eq.Right()
+	lit, ok := right.(*expression.Literal)
+	if !ok {
+		operand, right = right, operand
+		lit, ok = right.(*expression.Literal)
+	}
+
+	if !ok || isEvaluable(operand) || !isDeterministic(operand) {
+		return nil, nil, false
+	}
+
+	if lit.Value() == nil {
+		return operand, lit, true
+	}
+
+	typ, litType := operand.Type().Promote(), lit.Type().Promote()
+	if sql.IsText(typ) || sql.IsTuple(typ) || sql.IsJSON(typ) || sql.HasComparator(typ) {
+		return nil, nil, false
+	}
+
+	sameKind := sql.IsInteger(typ) && sql.IsInteger(litType) && sql.IsSigned(typ) == sql.IsSigned(litType)
+	if typ != litType && !sameKind {
+		return nil, nil, false
+	}
+
+	if _, err := typ.Convert(lit.Value()); err != nil {
+		return nil, nil, false
+	}
+
+	return operand, lit, true
+}
+
+// isDeterministic returns whether the expression given always evaluates to the same value for the same row.
+func isDeterministic(e sql.Expression) bool {
+	deterministic := true
+	sql.Inspect(e, func(e sql.Expression) bool {
+		if nd, ok := e.(sql.NonDeterministicExpression); ok && nd.IsNonDeterministic() {
+			deterministic = false
+		}
+		return deterministic
+	})
+	return deterministic
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestFoldInequalitiesToNotIn(t *testing.T) {
+	require := require.New(t)
+
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "b", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "s", Type: sql.Text, Source: "t", Nullable: true},
+	}))
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", true)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t", "b", true)
+	s := expression.NewGetFieldWithTable(2, sql.Text, "t", "s", true)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int8(2), sql.Int8)
+	null := expression.NewLiteral(nil, sql.Null)
+	notEquals := func(left, right sql.Expression) sql.Expression {
+		return expression.NewNot(expression.NewEquals(left, right))
+	}
+	rand, err := function.NewRand()
+	require.NoError(err)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "a <> 1 and a <> 2",
+			node:     plan.NewFilter(expression.NewAnd(notEquals(a, one), notEquals(a, two)), table),
+			expected: plan.NewFilter(expression.NewNotInTuple(a, expression.NewTuple(one, two)), table),
+		},
+		{
+			name: "1 <> a and b > 1 and a <> 2",
+			node: plan.NewFilter(
+				expression.JoinAnd(notEquals(one, a), expression.NewGreaterThan(b, one), notEquals(a, two)),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(expression.NewNotInTuple(a, expression.NewTuple(one, two)), expression.NewGreaterThan(b, one)),
+				table,
+			),
+		},
+		{
+			name:     "a <> 1 and a <> NULL",
+			node:     plan.NewFilter(expression.NewAnd(notEquals(a, one), notEquals(a, null)), table),
+			expected: plan.NewFilter(expression.NewNotInTuple(a, expression.NewTuple(one, null)), table),
+		},
+		{
+			name: "a <> 1 and b <> 2",
+			node: plan.NewFilter(expression.NewAnd(notEquals(a, one), notEquals(b, two)), table),
+		},
+		{
+			name: "a <> 1",
+			node: plan.NewFilter(notEquals(a, one), table),
+		},
+		{
+			name: "a <> 1 or a <> 2",
+			node: plan.NewFilter(expression.NewOr(notEquals(a, one), notEquals(a, two)), table),
+		},
+		{
+			name: "a <> 1 and a <> '2'",
+			node: plan.NewFilter(expression.NewAnd(notEquals(a, one), notEquals(a, expression.NewLiteral("2", sql.LongText))), table),
+		},
+		{
+			name: "a <> 1 and a <> 2.5",
+			node: plan.NewFilter(expression.NewAnd(notEquals(a, one), notEquals(a, expression.NewLiteral(2.5, sql.Float64))), table),
+		},
+		{
+			name: "s <> 'a' and s <> 'b'",
+			node: plan.NewFilter(
+				expression.NewAnd(notEquals(s, expression.NewLiteral("a", sql.LongText)), notEquals(s, expression.NewLiteral("b", sql.LongText))),
+				table,
+			),
+		},
+		{
+			name: "rand() <> 1 and rand() <> 2",
+			node: plan.NewFilter(expression.NewAnd(notEquals(rand, one), notEquals(rand, two)), table),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("fold_inequalities_to_not_in"))
+
+	// The NOT IN list is NULL for the same rows the inequalities are
+	conjunction := expression.JoinAnd(notEquals(a, one), notEquals(a, two), notEquals(a, null))
+	notIn := expression.NewNotInTuple(a, expression.NewTuple(one, two, null))
+	for _, v := range []interface{}{nil, int64(1), int64(2), int64(3)} {
+		expected, err := conjunction.Eval(sql.NewEmptyContext(), sql.NewRow(v))
+		require.NoError(err)
+		actual, err := notIn.Eval(sql.NewEmptyContext(), sql.NewRow(v))
+		require.NoError(err)
+		require.Equal(expected, actual, "a = %v", v)
+	}
+}
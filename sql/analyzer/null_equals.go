@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// nullEqualsWarning is the message of the warning added for every comparison with a NULL literal using = or <>.
+const nullEqualsWarning = "comparison %s with NULL always yields NULL; use IS NULL or IS NOT NULL instead"
+
+// transformNullEquals handles the comparisons of an expression with a NULL literal using = or <>, such as col = NULL,
+// which are always NULL, even when the expression is NULL, unlike what is often intended. By default they're left as
+// they are, and a warning is added to the context for each of them. When the session has the transform_null_equals
+// variable set, they're rewritten to col IS NULL and col IS NOT NULL instead, since <> is the negation of =. They're
+// rewritten the same way when the context has the sql.NullEqualsNull policy, under which that's what they mean.
+func transformNullEquals(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("transform_null_equals")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	transform := sql.TransformNullEquals(ctx) || sql.NullEqualsNullPolicy(ctx)
+	return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		operand, ok := nullEqualsOperand(e)
+		if !ok {
+			return e, nil
+		}
+
+		if !transform {
+			warnOnce(ctx, nullEqualsWarning, e)
+			return e, nil
+		}
+
+		a.Log("rewrote %s to IS NULL", e)
+		return expression.NewIsNull(operand), nil
+	})
+}
+
+// nullEqualsOperand returns the operand of the equality given that is compared with a NULL literal, if the equality is
+// such a comparison. Tuples aren't considered, since they can't be checked with IS NULL.
+func nullEqualsOperand(e sql.Expression) (sql.Expression, bool) {
+	eq, ok := e.(*expression.Equals)
+	if !ok {
+		return nil, false
+	}
+
+	operand, right := eq.Left(), eq.Right()
+	if !isNullLiteral(right) {
+		operand, right = right, operand
+	}
+
+	if !isNullLiteral(right) || sql.IsTuple(operand.Type()) {
+		return nil, false
+	}
+
+	return operand, true
+}
+
+// isNullLiteral returns whether the expression given is the NULL literal.
+func isNullLiteral(e sql.Expression) bool {
+	lit, ok := e.(*expression.Literal)
+	return ok && lit.Value() == nil
+}
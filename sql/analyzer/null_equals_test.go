@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestTransformNullEquals(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "b", Type: sql.Int64, Source: "t", Nullable: true},
+	}))
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", true)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t", "b", true)
+	null := expression.NewLiteral(nil, sql.Null)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected sql.Node
+		warnings int
+	}{
+		{
+			"a = NULL",
+			plan.NewFilter(expression.NewEquals(a, null), table),
+			plan.NewFilter(expression.NewIsNull(a), table),
+			1,
+		},
+		{
+			"NULL <> a and b = 1",
+			plan.NewFilter(expression.NewAnd(expression.NewNot(expression.NewEquals(null, a)), expression.NewEquals(b, one)), table),
+			plan.NewFilter(expression.NewAnd(expression.NewNot(expression.NewIsNull(a)), expression.NewEquals(b, one)), table),
+			1,
+		},
+		{
+			"a = b",
+			plan.NewFilter(expression.NewEquals(a, b), table),
+			plan.NewFilter(expression.NewEquals(a, b), table),
+			0,
+		},
+		{
+			"a > NULL",
+			plan.NewFilter(expression.NewGreaterThan(a, null), table),
+			plan.NewFilter(expression.NewGreaterThan(a, null), table),
+			0,
+		},
+		{
+			"a IS NULL",
+			plan.NewFilter(expression.NewIsNull(a), table),
+			plan.NewFilter(expression.NewIsNull(a), table),
+			0,
+		},
+	}
+
+	rule := getRule("transform_null_equals")
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			result, err := rule.Apply(ctx, NewDefault(nil), tt.node, nil)
+			require.NoError(err)
+			require.Equal(tt.node, result)
+			require.Len(ctx.Warnings(), tt.warnings)
+
+			ctx = sql.NewEmptyContext()
+			require.NoError(ctx.Set(context.Background(), sql.TransformNullEqualsSessionVar, sql.Int8, int8(1)))
+			result, err = rule.Apply(ctx, NewDefault(nil), tt.node, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+			require.Empty(ctx.Warnings())
+		})
+	}
+}
@@ -186,7 +186,7 @@ func createPrimaryTableExpr(
 IndexExpressions:
 	for i, idxExpr := range idx.Expressions() {
 		for j := range primaryTableEqualityExprs {
-			if idxExpr == normalizeExpression(exprAliases, tableAliases, primaryTableEqualityExprs[j].comparand).String() {
+			if sql.SameIndexExpression(idxExpr, normalizeExpression(exprAliases, tableAliases, primaryTableEqualityExprs[j].comparand).String()) {
 				keyExprs[i] = primaryTableEqualityExprs[j].colExpr
 				continue IndexExpressions
 			}
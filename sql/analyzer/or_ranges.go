@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// mergeOrRanges replaces the disjuncts of filters that compare the same column against constants, such as
+// x < 5 OR x > 100 or x BETWEEN 1 AND 3 OR x BETWEEN 2 AND 10, with a single expression.RangeSet holding the merged
+// ranges of values they accept. An index on the column can then be used for all of them with a single lookup.
+// Disjunctions that are only made of equalities are left as they are, since they are already handled as well as they
+// can be.
+func mergeOrRanges(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("merge_or_ranges")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		e, err := mergeRangeDisjuncts(a, filter.Expression)
+		if err != nil {
+			return nil, err
+		}
+
+		if e == filter.Expression {
+			return node, nil
+		}
+
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// mergeRangeDisjuncts merges the ranges of the disjunctions in the expression given, descending into AND and OR
+// expressions.
+func mergeRangeDisjuncts(a *Analyzer, e sql.Expression) (sql.Expression, error) {
+	switch e := e.(type) {
+	case *expression.And:
+		left, err := mergeRangeDisjuncts(a, e.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := mergeRangeDisjuncts(a, e.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		if left == e.Left && right == e.Right {
+			return e, nil
+		}
+
+		return expression.NewAnd(left, right), nil
+	case *expression.Or:
+		disjuncts := splitDisjunction(e)
+
+		// Group the ranges of the disjuncts by the column they are on
+		keys := make([]string, len(disjuncts))
+		columns := make(map[string]*expression.GetField)
+		ranges := make(map[string][]expression.Range)
+		notPoints := make(map[string]bool)
+		for i, d := range disjuncts {
+			column, r, ok := expression.ExpressionRange(d)
+			if !ok {
+				continue
+			}
+
+			key := column.String()
+			keys[i] = key
+			columns[key] = column
+			ranges[key] = append(ranges[key], r)
+			if _, ok := d.(*expression.Equals); !ok {
+				notPoints[key] = true
+			}
+		}
+
+		var result []sql.Expression
+		var changed bool
+		merged := make(map[string]bool)
+		for i, d := range disjuncts {
+			key := keys[i]
+			if key == "" {
+				newDisjunct, err := mergeRangeDisjuncts(a, d)
+				if err != nil {
+					return nil, err
+				}
+
+				changed = changed || newDisjunct != d
+				result = append(result, newDisjunct)
+				continue
+			}
+
+			if len(ranges[key]) < 2 || !notPoints[key] {
+				result = append(result, d)
+				continue
+			}
+
+			// The merged ranges take the place of the first disjunct on the column
+			if merged[key] {
+				continue
+			}
+			merged[key] = true
+
+			rangeSet, err := expression.NewRangeSet(columns[key], ranges[key]...)
+			if err != nil {
+				return nil, err
+			}
+
+			a.Log("merged %d disjuncts on %s into %s", len(ranges[key]), key, rangeSet)
+			result = append(result, rangeSet)
+			changed = true
+		}
+
+		if !changed {
+			return e, nil
+		}
+
+		return expression.JoinOr(result...), nil
+	default:
+		return e, nil
+	}
+}
+
+// splitDisjunction breaks OR expressions into their left and right parts, recursively
+func splitDisjunction(expr sql.Expression) []sql.Expression {
+	or, ok := expr.(*expression.Or)
+	if !ok {
+		return []sql.Expression{expr}
+	}
+
+	return append(
+		splitDisjunction(or.Left),
+		splitDisjunction(or.Right)...,
+	)
+}
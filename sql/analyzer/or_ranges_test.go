@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestMergeOrRanges(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t"},
+		{Name: "y", Type: sql.Int64, Source: "t"},
+	}))
+
+	x := gf(0, "t", "x")
+	y := gf(1, "t", "y")
+
+	rangeSet := func(ranges ...expression.Range) sql.Expression {
+		rs, err := expression.NewRangeSet(x, ranges...)
+		require.NoError(t, err)
+		return rs
+	}
+
+	tests := []analyzerFnTestCase{
+		{
+			name: "disjoint ranges",
+			node: plan.NewFilter(or(lt(x, lit(5)), gt(x, lit(100))), table),
+			expected: plan.NewFilter(rangeSet(
+				expression.Range{Upper: &expression.RangeBound{Value: lit(5)}},
+				expression.Range{Lower: &expression.RangeBound{Value: lit(100)}},
+			), table),
+		},
+		{
+			name: "overlapping between ranges",
+			node: plan.NewFilter(or(
+				expression.NewBetween(x, lit(1), lit(3)),
+				expression.NewBetween(x, lit(2), lit(10)),
+			), table),
+			expected: plan.NewFilter(rangeSet(
+				expression.Range{
+					Lower: &expression.RangeBound{Value: lit(1), Inclusive: true},
+					Upper: &expression.RangeBound{Value: lit(10), Inclusive: true},
+				},
+			), table),
+		},
+		{
+			name: "different columns",
+			node: plan.NewFilter(or(lt(x, lit(5)), gt(y, lit(100))), table),
+		},
+		{
+			name: "only equalities",
+			node: plan.NewFilter(or(eq(x, lit(5)), eq(x, lit(100))), table),
+		},
+		{
+			name: "ranges among other disjuncts",
+			node: plan.NewFilter(
+				expression.JoinOr(eq(y, lit(1)), lt(x, lit(5)), gt(y, lit(3)), gte(x, lit(5))),
+				table,
+			),
+			expected: plan.NewFilter(
+				or(
+					&expression.RangeSet{Column: y, Ranges: []expression.Range{
+						{
+							Lower: &expression.RangeBound{Value: lit(1), Inclusive: true},
+							Upper: &expression.RangeBound{Value: lit(1), Inclusive: true},
+						},
+						{Lower: &expression.RangeBound{Value: lit(3)}},
+					}},
+					rangeSet(expression.Range{}),
+				),
+				table,
+			),
+		},
+		{
+			name: "disjunction inside a conjunction",
+			node: plan.NewFilter(and(eq(y, lit(1)), or(lt(x, lit(5)), gt(x, lit(100)))), table),
+			expected: plan.NewFilter(and(eq(y, lit(1)), rangeSet(
+				expression.Range{Upper: &expression.RangeBound{Value: lit(5)}},
+				expression.Range{Lower: &expression.RangeBound{Value: lit(100)}},
+			)), table),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("merge_or_ranges"))
+}
@@ -0,0 +1,258 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// orderedIndexScan replaces a table sorted by its columns and limited to a number of rows, such as in
+// SELECT * FROM t WHERE x > 10 ORDER BY x LIMIT 1, with a plan.OrderedIndexScan of an index of the columns whose
+// lookups return the rows in order, which makes the sort unnecessary, so the limit stops reading the table as soon as
+// it has enough rows. The table must be filtered directly by a comparison of the columns with a constant the range of
+// the scan is bounded by, which leaves out the NULL values the sort would return first, and the index must be a
+// sql.OrderedIndex of the columns sorted by, in the same order. The columns of a sort by several of them, as in keyset
+// pagination with WHERE (a, b) > (1, 2) ORDER BY a, b LIMIT 10, are compared as a tuple. The filter is kept on top of
+// the scan.
+func orderedIndexScan(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("ordered_index_scan")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		limit, ok := node.(*plan.Limit)
+		if !ok {
+			return node, nil
+		}
+
+		child, ok, err := withSortScannedInOrder(ctx, a, limit.Child)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return node, nil
+		}
+		return limit.WithChildren(child)
+	})
+}
+
+// withSortScannedInOrder returns the node given with the sort it returns the rows of replaced by an ordered scan of
+// an index, or false if there's none or it can't be replaced. Only projections and offsets can be between the node and
+// the sort, since they return the first rows of the sort without reading the rest.
+func withSortScannedInOrder(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, bool, error) {
+	switch n := n.(type) {
+	case *plan.Project, *plan.Offset:
+		child, ok, err := withSortScannedInOrder(ctx, a, n.Children()[0])
+		if err != nil || !ok {
+			return n, false, err
+		}
+
+		node, err := n.WithChildren(child)
+		if err != nil {
+			return nil, false, err
+		}
+		return node, true, nil
+	case *plan.Sort:
+		return sortScannedInOrder(ctx, a, n)
+	default:
+		return n, false, nil
+	}
+}
+
+// sortScannedInOrder returns the filter the sort given sorts the rows of, over an ordered scan of an index of the
+// columns sorted by, or false if it can't be scanned in order.
+func sortScannedInOrder(ctx *sql.Context, a *Analyzer, sort *plan.Sort) (sql.Node, bool, error) {
+	filter, ok := sort.Child.(*plan.Filter)
+	if !ok {
+		return sort, false, nil
+	}
+
+	rt, ok := filter.Child.(*plan.ResolvedTable)
+	if !ok {
+		return sort, false, nil
+	}
+
+	// All the columns must be sorted in the same direction, which is the order of the scan
+	descending := sort.SortFields[0].Order == plan.Descending
+	columns := make([]*expression.GetField, len(sort.SortFields))
+	names := make([]string, len(sort.SortFields))
+	for i, f := range sort.SortFields {
+		column, ok := f.Column.(*expression.GetField)
+		if !ok || !strings.EqualFold(column.Table(), rt.Name()) || (f.Order == plan.Descending) != descending {
+			return sort, false, nil
+		}
+		columns[i], names[i] = column, strings.ToLower(column.Name())
+	}
+
+	idx, err := orderedColumnsIndex(ctx, rt, names, descending)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if idx == nil {
+		return sort, false, nil
+	}
+
+	lower, upper := scanBounds(columns, splitConjunction(filter.Expression), descending)
+	if lower == nil && upper == nil {
+		return sort, false, nil
+	}
+
+	a.Log("scanning table %s in the order of index %s", rt.Name(), idx.ID())
+	scan, err := plan.NewOrderedIndexScan(rt, idx, descending, lower, upper)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return plan.NewFilter(filter.Expression, scan), true, nil
+}
+
+// orderedColumnsIndex returns the sql.OrderedIndex of the table given of exactly the columns given, in the same order,
+// that can be scanned in the order given, or nil if there is none.
+func orderedColumnsIndex(ctx *sql.Context, rt *plan.ResolvedTable, columns []string, descending bool) (sql.OrderedIndex, error) {
+	table, ok := rt.Table.(sql.IndexedTable)
+	if !ok {
+		return nil, nil
+	}
+
+	indexes, err := table.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, ok := columnsIndex(indexes, columns).(sql.OrderedIndex)
+	if !ok || !idx.IsOrdered() {
+		return nil, nil
+	}
+
+	if _, ok := idx.(sql.AscendIndex); !ok && !descending {
+		return nil, nil
+	}
+	if _, ok := idx.(sql.DescendIndex); !ok && descending {
+		return nil, nil
+	}
+	return idx, nil
+}
+
+// columnsIndex returns the index of the indexes given of exactly the columns given, in the same order, or nil if there
+// is none.
+func columnsIndex(indexes []sql.Index, columns []string) sql.Index {
+	for _, idx := range indexes {
+		indexColumns := indexColumnNames(idx)
+		if len(indexColumns) != len(columns) {
+			continue
+		}
+
+		matches := true
+		for i, c := range indexColumns {
+			if c != columns[i] {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return idx
+		}
+	}
+	return nil
+}
+
+// scanBounds returns the bounds of the range of an ordered scan of an index of the columns given that includes all
+// the keys matching the predicates given, or nil for the bounds they don't set. The key of an index of a column is
+// compared with a value, and the key of an index of several columns is compared as a tuple with a tuple of values. The
+// lower bound of an ascending scan and the upper bound of a descending one are included in the range, and the other
+// bounds aren't, so a comparison that could match its constant only bounds the range on the included side.
+func scanBounds(columns []*expression.GetField, predicates []sql.Expression, descending bool) (lower, upper sql.Expression) {
+	for _, p := range predicates {
+		c, ok := p.(expression.Comparer)
+		if !ok {
+			continue
+		}
+
+		left, right := c.Left(), c.Right()
+		flipped := false
+		if isScanKey(columns, right) {
+			left, right, flipped = right, left, true
+		}
+
+		if !isScanKey(columns, left) || !isScanBound(columns, right) {
+			continue
+		}
+
+		var isLower, isUpper, inclusive bool
+		switch c.(type) {
+		case *expression.Equals:
+			isLower, isUpper, inclusive = !descending, descending, true
+		case *expression.GreaterThan:
+			isLower, isUpper = !flipped, flipped
+		case *expression.GreaterThanOrEqual:
+			isLower, isUpper, inclusive = !flipped, flipped, true
+		case *expression.LessThan:
+			isLower, isUpper = flipped, !flipped
+		case *expression.LessThanOrEqual:
+			isLower, isUpper, inclusive = flipped, !flipped, true
+		}
+
+		// A bound on the excluded side of the range must exclude its constant
+		if isLower && lower == nil && (!descending || !inclusive) {
+			lower = right
+		}
+		if isUpper && upper == nil && (descending || !inclusive) {
+			upper = right
+		}
+	}
+
+	return lower, upper
+}
+
+// isScanKey returns whether the expression given is the key of an index of the columns given: the column itself, or a
+// tuple of the columns in the order of the index.
+func isScanKey(columns []*expression.GetField, e sql.Expression) bool {
+	if len(columns) == 1 {
+		gf, ok := e.(*expression.GetField)
+		return ok && gf.Index() == columns[0].Index()
+	}
+
+	tuple, ok := e.(expression.Tuple)
+	if !ok || len(tuple) != len(columns) {
+		return false
+	}
+
+	for i, e := range tuple {
+		gf, ok := e.(*expression.GetField)
+		if !ok || gf.Index() != columns[i].Index() {
+			return false
+		}
+	}
+	return true
+}
+
+// isScanBound returns whether the expression given can bound the range of an ordered scan of an index of the columns
+// given, which is a constant compared as a value of the type of the column, or a tuple of them for several columns.
+// The values of a tuple must be literals other than NULL, since a tuple comparison with a NULL value can still match
+// the rows the value of an earlier column decides, so it can't bound the range.
+func isScanBound(columns []*expression.GetField, e sql.Expression) bool {
+	if len(columns) == 1 {
+		return isEvaluable(e) && comparesAsColumnType(columns[0], e)
+	}
+
+	tuple, ok := e.(expression.Tuple)
+	if !ok || len(tuple) != len(columns) {
+		return false
+	}
+
+	for i, e := range tuple {
+		l, ok := e.(*expression.Literal)
+		if !ok || l.Value() == nil || !comparesAsColumnType(columns[i], l) {
+			return false
+		}
+	}
+	return true
+}
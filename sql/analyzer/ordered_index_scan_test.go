@@ -0,0 +1,337 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// countingTable is a table of a single partition, whose rows are inserted in the order of its indexes, that counts the
+// rows read from it.
+type countingTable struct {
+	*memory.Table
+	read *int
+}
+
+var _ sql.IndexedTable = (*countingTable)(nil)
+
+func (t *countingTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	indexes, err := t.Table.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, idx := range indexes {
+		if len(idx.Expressions()) > 1 {
+			indexes[i] = tupleOrderedIndex{orderedIndex{idx.(*memory.UnmergeableIndex)}}
+			continue
+		}
+		indexes[i] = orderedIndex{idx.(*memory.UnmergeableIndex)}
+	}
+	return indexes, nil
+}
+
+func (t *countingTable) WithIndexLookup(lookup sql.IndexLookup) sql.Table {
+	return &countingTable{t.Table.WithIndexLookup(lookup).(*memory.Table), t.read}
+}
+
+func (t *countingTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	iter, err := t.Table.PartitionRows(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	return &countingIter{iter, t.read}, nil
+}
+
+type countingIter struct {
+	sql.RowIter
+	read *int
+}
+
+func (i *countingIter) Next() (sql.Row, error) {
+	row, err := i.RowIter.Next()
+	if err == nil {
+		*i.read++
+	}
+	return row, err
+}
+
+// orderedIndex is an index of a countingTable, whose ascending lookups return the rows in order.
+type orderedIndex struct {
+	*memory.UnmergeableIndex
+}
+
+func (orderedIndex) IsOrdered() bool { return true }
+
+// tupleOrderedIndex is an index of several columns of a countingTable, whose lookups compare the keys with their bounds
+// as tuples, instead of column by column like the indexes of a memory.Table do.
+type tupleOrderedIndex struct {
+	orderedIndex
+}
+
+func (i tupleOrderedIndex) AscendGreaterOrEqual(keys ...interface{}) (sql.IndexLookup, error) {
+	return i.lookup(expression.NewGreaterThanOrEqual(i.key(), i.bound(keys))), nil
+}
+
+func (i tupleOrderedIndex) AscendLessThan(keys ...interface{}) (sql.IndexLookup, error) {
+	return i.lookup(expression.NewLessThan(i.key(), i.bound(keys))), nil
+}
+
+func (i tupleOrderedIndex) AscendRange(greaterOrEqual, lessThan []interface{}) (sql.IndexLookup, error) {
+	return i.lookup(expression.NewAnd(
+		expression.NewGreaterThanOrEqual(i.key(), i.bound(greaterOrEqual)),
+		expression.NewLessThan(i.key(), i.bound(lessThan)),
+	)), nil
+}
+
+func (i tupleOrderedIndex) DescendGreater(keys ...interface{}) (sql.IndexLookup, error) {
+	return i.lookup(expression.NewGreaterThan(i.key(), i.bound(keys))), nil
+}
+
+func (i tupleOrderedIndex) DescendLessOrEqual(keys ...interface{}) (sql.IndexLookup, error) {
+	return i.lookup(expression.NewLessThanOrEqual(i.key(), i.bound(keys))), nil
+}
+
+func (i tupleOrderedIndex) DescendRange(lessOrEqual, greaterThan []interface{}) (sql.IndexLookup, error) {
+	return i.lookup(expression.NewAnd(
+		expression.NewLessThanOrEqual(i.key(), i.bound(lessOrEqual)),
+		expression.NewGreaterThan(i.key(), i.bound(greaterThan)),
+	)), nil
+}
+
+func (i tupleOrderedIndex) key() sql.Expression {
+	return expression.NewTuple(i.ColumnExpressions()...)
+}
+
+func (i tupleOrderedIndex) bound(keys []interface{}) sql.Expression {
+	values := make([]sql.Expression, len(keys))
+	for j, e := range i.ColumnExpressions() {
+		values[j] = expression.NewLiteral(keys[j], e.Type())
+	}
+	return expression.NewTuple(values...)
+}
+
+func (i tupleOrderedIndex) lookup(match sql.Expression) sql.IndexLookup {
+	return &memory.MergedIndexLookup{Unions: []sql.IndexLookup{tupleLookup{match}}, Index: i.UnmergeableIndex}
+}
+
+// tupleLookup is a lookup of a tupleOrderedIndex of the rows matching its expression.
+type tupleLookup struct {
+	match sql.Expression
+}
+
+func (l tupleLookup) EvalExpression() sql.Expression { return l.match }
+func (l tupleLookup) String() string                 { return l.match.String() }
+
+func TestOrderedIndexScan(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable", Nullable: true},
+		{Name: "b", Type: sql.Int64, Source: "mytable"},
+	}
+	ctx := sql.NewEmptyContext()
+	table := &countingTable{Table: memory.NewTable("mytable", schema), read: new(int)}
+	require.NoError(table.CreateIndex(ctx, "a_idx", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "a"}}, ""))
+	require.NoError(table.Insert(ctx, sql.NewRow(nil, int64(0))))
+	for a := int64(1); a <= 100; a++ {
+		require.NoError(table.Insert(ctx, sql.NewRow(a, a%10)))
+	}
+
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	rt := plan.NewResolvedTable(table)
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", true)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", false)
+	ten := expression.NewLiteral(int64(10), sql.Int64)
+	twenty := expression.NewLiteral(int64(20), sql.Int64)
+	aGreaterThanTen := expression.NewGreaterThan(a, ten)
+	ascending := []plan.SortField{{Column: a, Order: plan.Ascending}}
+	descending := []plan.SortField{{Column: a, Order: plan.Descending}}
+
+	scan := func(descending bool, lower, upper sql.Expression) *plan.OrderedIndexScan {
+		s, err := plan.NewOrderedIndexScan(rt, indexes[0], descending, lower, upper)
+		require.NoError(err)
+		return s
+	}
+
+	bounded := expression.NewAnd(expression.NewGreaterThanOrEqual(a, ten), expression.NewLessThan(a, twenty))
+	tests := []analyzerFnTestCase{
+		{
+			name:     "a > 10 order by a limit 1",
+			node:     plan.NewLimit(1, plan.NewSort(ascending, plan.NewFilter(aGreaterThanTen, rt))),
+			expected: plan.NewLimit(1, plan.NewFilter(aGreaterThanTen, scan(false, ten, nil))),
+		},
+		{
+			name: "a >= 10 and a < 20 order by a limit 1 offset 2",
+			node: plan.NewLimit(1, plan.NewOffset(2, plan.NewProject([]sql.Expression{b},
+				plan.NewSort(ascending, plan.NewFilter(bounded, rt))))),
+			expected: plan.NewLimit(1, plan.NewOffset(2, plan.NewProject([]sql.Expression{b},
+				plan.NewFilter(bounded, scan(false, ten, twenty))))),
+		},
+		{
+			name:     "a > 10 order by a desc limit 1",
+			node:     plan.NewLimit(1, plan.NewSort(descending, plan.NewFilter(aGreaterThanTen, rt))),
+			expected: plan.NewLimit(1, plan.NewFilter(aGreaterThanTen, scan(true, ten, nil))),
+		},
+		{
+			name:     "20 >= a order by a desc limit 1",
+			node:     plan.NewLimit(1, plan.NewSort(descending, plan.NewFilter(expression.NewGreaterThanOrEqual(twenty, a), rt))),
+			expected: plan.NewLimit(1, plan.NewFilter(expression.NewGreaterThanOrEqual(twenty, a), scan(true, nil, twenty))),
+		},
+		{
+			name: "a <= 20 order by a limit 1",
+			node: plan.NewLimit(1, plan.NewSort(ascending, plan.NewFilter(expression.NewLessThanOrEqual(a, twenty), rt))),
+		},
+		{
+			name: "a > 10 order by a",
+			node: plan.NewSort(ascending, plan.NewFilter(aGreaterThanTen, rt)),
+		},
+		{
+			name: "b > 10 order by a limit 1",
+			node: plan.NewLimit(1, plan.NewSort(ascending, plan.NewFilter(expression.NewGreaterThan(b, ten), rt))),
+		},
+		{
+			name: "a > 10 order by b limit 1",
+			node: plan.NewLimit(1, plan.NewSort([]plan.SortField{{Column: b}}, plan.NewFilter(aGreaterThanTen, rt))),
+		},
+		{
+			name: "a > 10 order by a, b limit 1",
+			node: plan.NewLimit(1, plan.NewSort(append(ascending, plan.SortField{Column: b}), plan.NewFilter(aGreaterThanTen, rt))),
+		},
+		{
+			name: "memory table",
+			node: plan.NewLimit(1, plan.NewSort(ascending, plan.NewFilter(aGreaterThanTen, plan.NewResolvedTable(table.Table)))),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("ordered_index_scan"))
+
+	for _, tt := range []struct {
+		node     sql.Node
+		expected []sql.Row
+		read     int
+	}{
+		{tests[0].expected, []sql.Row{{int64(11), int64(1)}}, 2},
+		{tests[1].expected, []sql.Row{{int64(2)}}, 3},
+		{tests[0].node, []sql.Row{{int64(11), int64(1)}}, 101},
+	} {
+		*table.read = 0
+		rows, err := sql.NodeToRows(ctx, tt.node)
+		require.NoError(err)
+		require.Equal(tt.expected, rows)
+		require.Equal(tt.read, *table.read)
+	}
+}
+
+func TestOrderedIndexScanTuples(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable"},
+		{Name: "b", Type: sql.Int64, Source: "mytable"},
+		{Name: "c", Type: sql.Int64, Source: "mytable"},
+	}
+	ctx := sql.NewEmptyContext()
+	table := &countingTable{Table: memory.NewTable("mytable", schema), read: new(int)}
+	require.NoError(table.CreateIndex(ctx, "ab_idx", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "a"}, {Name: "b"}}, ""))
+	for a := int64(1); a <= 10; a++ {
+		for b := int64(1); b <= 10; b++ {
+			require.NoError(table.Insert(ctx, sql.NewRow(a, b, a*10+b)))
+		}
+	}
+
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	rt := plan.NewResolvedTable(table)
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", false)
+	c := expression.NewGetFieldWithTable(2, sql.Int64, "mytable", "c", false)
+	key := expression.NewTuple(a, b)
+	tuple := func(values ...interface{}) expression.Tuple {
+		t := make(expression.Tuple, len(values))
+		for i, v := range values {
+			t[i] = expression.NewLiteral(v, sql.Int64)
+		}
+		return t
+	}
+	ascending := []plan.SortField{{Column: a, Order: plan.Ascending}, {Column: b, Order: plan.Ascending}}
+	descending := []plan.SortField{{Column: a, Order: plan.Descending}, {Column: b, Order: plan.Descending}}
+
+	scan := func(descending bool, lower, upper sql.Expression) *plan.OrderedIndexScan {
+		s, err := plan.NewOrderedIndexScan(rt, indexes[0], descending, lower, upper)
+		require.NoError(err)
+		return s
+	}
+
+	afterPage := expression.NewGreaterThan(key, tuple(int64(3), int64(8)))
+	beforePage := expression.NewLessThan(key, tuple(int64(3), int64(2)))
+	tests := []analyzerFnTestCase{
+		{
+			name:     "(a, b) > (3, 8) order by a, b limit 3",
+			node:     plan.NewLimit(3, plan.NewSort(ascending, plan.NewFilter(afterPage, rt))),
+			expected: plan.NewLimit(3, plan.NewFilter(afterPage, scan(false, tuple(int64(3), int64(8)), nil))),
+		},
+		{
+			name:     "(a, b) < (3, 2) order by a desc, b desc limit 3",
+			node:     plan.NewLimit(3, plan.NewSort(descending, plan.NewFilter(beforePage, rt))),
+			expected: plan.NewLimit(3, plan.NewFilter(beforePage, scan(true, nil, tuple(int64(3), int64(2))))),
+		},
+		{
+			name: "(3, 8) < (a, b) and (a, b) < (5, 1) order by a, b limit 3",
+			node: plan.NewLimit(3, plan.NewProject([]sql.Expression{c}, plan.NewSort(ascending, plan.NewFilter(
+				expression.NewAnd(expression.NewLessThan(tuple(int64(3), int64(8)), key), expression.NewLessThan(key, tuple(int64(5), int64(1)))),
+				rt,
+			)))),
+			expected: plan.NewLimit(3, plan.NewProject([]sql.Expression{c}, plan.NewFilter(
+				expression.NewAnd(expression.NewLessThan(tuple(int64(3), int64(8)), key), expression.NewLessThan(key, tuple(int64(5), int64(1)))),
+				scan(false, tuple(int64(3), int64(8)), tuple(int64(5), int64(1))),
+			))),
+		},
+		{
+			name: "(a, b) > (3, 8) order by a, b desc limit 3",
+			node: plan.NewLimit(3, plan.NewSort([]plan.SortField{ascending[0], descending[1]}, plan.NewFilter(afterPage, rt))),
+		},
+		{
+			name: "(b, a) > (8, 3) order by b, a limit 3",
+			node: plan.NewLimit(3, plan.NewSort([]plan.SortField{ascending[1], ascending[0]},
+				plan.NewFilter(expression.NewGreaterThan(expression.NewTuple(b, a), tuple(int64(8), int64(3))), rt))),
+		},
+		{
+			name: "a > 3 order by a, b limit 3",
+			node: plan.NewLimit(3, plan.NewSort(ascending, plan.NewFilter(expression.NewGreaterThan(a, expression.NewLiteral(int64(3), sql.Int64)), rt))),
+		},
+		{
+			name: "(a, b) > (3, NULL) order by a, b limit 3",
+			node: plan.NewLimit(3, plan.NewSort(ascending, plan.NewFilter(
+				expression.NewGreaterThan(key, expression.NewTuple(expression.NewLiteral(int64(3), sql.Int64), expression.NewLiteral(nil, sql.Null))),
+				rt,
+			))),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("ordered_index_scan"))
+
+	for _, tt := range []struct {
+		node     sql.Node
+		expected []sql.Row
+		read     int
+	}{
+		{tests[0].expected, []sql.Row{{int64(3), int64(9), int64(39)}, {int64(3), int64(10), int64(40)}, {int64(4), int64(1), int64(41)}}, 4},
+		{tests[2].expected, []sql.Row{{int64(39)}, {int64(40)}, {int64(41)}}, 4},
+		{tests[0].node, []sql.Row{{int64(3), int64(9), int64(39)}, {int64(3), int64(10), int64(40)}, {int64(4), int64(1), int64(41)}}, 100},
+	} {
+		*table.read = 0
+		rows, err := sql.NodeToRows(ctx, tt.node)
+		require.NoError(err)
+		require.Equal(tt.expected, rows)
+		require.Equal(tt.read, *table.read)
+	}
+}
@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// nullRejectingWarning is the message of the warning added for every filter predicate that discards the rows an outer
+// join extends with NULLs.
+const nullRejectingWarning = "predicate %s discards the rows of the outer join with no match in %s, which makes it an inner join; move it to the ON clause to keep them"
+
+// warnNullRejectingFilters adds a warning to the context for every filter predicate that compares a column of the
+// side of an outer join below it that is NULL for the rows with no match, such as t2.x > 5 in
+// t1 LEFT JOIN t2 ON ... WHERE t2.x > 5. Since such a comparison is never true for NULL, the rows the outer join
+// adds are filtered out, which is rarely intended. It's purely advisory: the node is always returned unchanged.
+func warnNullRejectingFilters(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("warn_null_rejecting_filters")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	plan.Inspect(n, func(node sql.Node) bool {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return true
+		}
+
+		nullable := nullExtendedSources(filter.Child)
+		if len(nullable) == 0 {
+			return true
+		}
+
+		for _, p := range splitConjunction(filter.Expression) {
+			if !isNullRejecting(p) {
+				continue
+			}
+
+			for _, source := range expressionSources(p) {
+				if nullable[source] {
+					ctx.Warn(0, nullRejectingWarning, p, source)
+					break
+				}
+			}
+		}
+
+		return true
+	})
+
+	return n, nil
+}
+
+// nullExtendedSources returns the sources of the columns of the node given that are NULL for the rows of an outer
+// join in it with no match on that side. The columns of subqueries are never included.
+func nullExtendedSources(n sql.Node) map[string]bool {
+	sources := make(map[string]bool)
+	plan.Inspect(n, func(node sql.Node) bool {
+		switch node := node.(type) {
+		case *plan.LeftJoin:
+			for _, s := range nodeSources(node.Right) {
+				sources[s] = true
+			}
+		case *plan.RightJoin:
+			for _, s := range nodeSources(node.Left) {
+				sources[s] = true
+			}
+		case *plan.SubqueryAlias:
+			return false
+		}
+		return true
+	})
+	return sources
+}
+
+// isNullRejecting returns whether the predicate given is never true when its operands are NULL. Only comparisons are
+// considered, since they are the predicates most likely to unintentionally filter out rows extended with NULLs. A <=>
+// comparison is true for NULLs compared with NULLs, and its negation for NULLs compared with other values.
+func isNullRejecting(e sql.Expression) bool {
+	switch e := e.(type) {
+	case *expression.NullSafeEquals:
+		return false
+	case expression.Comparer, *expression.Between, *expression.Like:
+		return true
+	case *expression.Not:
+		_, ok := e.Child.(expression.Comparer)
+		_, nullSafe := e.Child.(*expression.NullSafeEquals)
+		return ok && !nullSafe
+	default:
+		return false
+	}
+}
@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestWarnNullRejectingFilters(t *testing.T) {
+	t1 := plan.NewResolvedTable(memory.NewTable("t1", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t1"},
+	}))
+	t2 := plan.NewResolvedTable(memory.NewTable("t2", sql.Schema{
+		{Name: "b", Type: sql.Int64, Source: "t2"},
+	}))
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t2", "b", true)
+	five := expression.NewLiteral(int64(5), sql.Int64)
+	cond := expression.NewEquals(a, b)
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		warnings int
+	}{
+		{
+			"comparison on the right of a left join",
+			plan.NewFilter(expression.NewGreaterThan(b, five), plan.NewLeftJoin(t1, t2, cond)),
+			1,
+		},
+		{
+			"comparison on the left of a left join",
+			plan.NewFilter(expression.NewGreaterThan(a, five), plan.NewLeftJoin(t1, t2, cond)),
+			0,
+		},
+		{
+			"comparison on the left of a right join",
+			plan.NewFilter(expression.NewEquals(a, five), plan.NewRightJoin(t1, t2, cond)),
+			1,
+		},
+		{
+			"null check on the right of a left join",
+			plan.NewFilter(expression.NewIsNull(b), plan.NewLeftJoin(t1, t2, cond)),
+			0,
+		},
+		{
+			"null-safe comparison on the right of a left join",
+			plan.NewFilter(
+				expression.NewAnd(
+					expression.NewNullSafeEquals(b, expression.NewLiteral(nil, sql.Null)),
+					expression.NewNot(expression.NewNullSafeEquals(b, five)),
+				),
+				plan.NewLeftJoin(t1, t2, cond),
+			),
+			0,
+		},
+		{
+			"disjunction with a null check",
+			plan.NewFilter(
+				expression.NewOr(expression.NewIsNull(b), expression.NewGreaterThan(b, five)),
+				plan.NewLeftJoin(t1, t2, cond),
+			),
+			0,
+		},
+		{
+			"comparisons in conjunction",
+			plan.NewFilter(
+				expression.NewAnd(
+					expression.NewGreaterThan(a, five),
+					expression.NewNot(expression.NewEquals(b, five)),
+				),
+				plan.NewLeftJoin(t1, t2, cond),
+			),
+			1,
+		},
+		{
+			"comparison on an inner join",
+			plan.NewFilter(expression.NewGreaterThan(b, five), plan.NewInnerJoin(t1, t2, cond)),
+			0,
+		},
+	}
+
+	rule := getRule("warn_null_rejecting_filters")
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			result, err := rule.Apply(ctx, NewDefault(nil), tt.node, nil)
+			require.NoError(err)
+			require.Equal(tt.node, result)
+			require.Len(ctx.Warnings(), tt.warnings)
+		})
+	}
+}
+
+func TestPushdownFiltersKeepsOuterJoinFilters(t *testing.T) {
+	t1 := memory.NewPushdownTable("t1", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t1"},
+	})
+	t2 := memory.NewPushdownTable("t2", sql.Schema{
+		{Name: "b", Type: sql.Int64, Source: "t2"},
+	})
+
+	db := memory.NewDatabase("mydb")
+	db.AddTable("t1", t1)
+	db.AddTable("t2", t2)
+
+	catalog := sql.NewCatalog()
+	catalog.AddDatabase(db)
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t2", "b", true)
+
+	// Pushing the filter down to t2 would keep the rows of t1 with no match for it, instead of discarding them
+	tests := []analyzerFnTestCase{
+		{
+			name: "filter on the right of a left join",
+			node: plan.NewProject(
+				[]sql.Expression{a, b},
+				plan.NewFilter(
+					expression.NewGreaterThan(b, expression.NewLiteral(int64(5), sql.Int64)),
+					plan.NewLeftJoin(
+						plan.NewResolvedTable(t1),
+						plan.NewResolvedTable(t2),
+						expression.NewEquals(a, b),
+					),
+				),
+			),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(catalog), getRule("pushdown_filters"))
+}
@@ -95,6 +95,14 @@ func isParallelizable(node sql.Node) bool {
 			*plan.Project,
 			*plan.TableAlias,
 			*plan.Exchange:
+		case *plan.UniqueKeyLookup:
+			// A single row is read, so there are no partitions to read in parallel.
+			ok = false
+			return false
+		case *plan.OrderedIndexScan:
+			// The rows are read in order, which reading the partitions in parallel would lose.
+			ok = false
+			return false
 		case sql.Table:
 			lastWasTable = true
 			tableSeen = true
@@ -161,6 +161,14 @@ func TestIsParallelizable(t *testing.T) {
 			),
 			false,
 		},
+		{
+			"unique key lookup",
+			plan.NewFilter(
+				expression.NewLiteral(1, sql.Int64),
+				&plan.UniqueKeyLookup{ResolvedTable: plan.NewResolvedTable(table)},
+			),
+			false,
+		},
 	}
 
 	for _, tt := range testCases {
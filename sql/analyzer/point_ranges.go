@@ -0,0 +1,148 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// simplifyPointRanges rewrites the ranges of filters whose lower and upper bounds are the same constant into an
+// equality, which allows indexes to be used for a point lookup instead of a range scan. Both x BETWEEN c AND c and
+// x >= c AND x <= c are rewritten to x = c, as long as the two constants compare equal.
+func simplifyPointRanges(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("simplify_point_ranges")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		e, err := expression.TransformUp(filter.Expression, func(e sql.Expression) (sql.Expression, error) {
+			between, ok := e.(*expression.Between)
+			if !ok || !isEvaluable(between.Lower) || !isEvaluable(between.Upper) {
+				return e, nil
+			}
+
+			if !equalConstants(ctx, between.Val.Type(), between.Lower, between.Upper) {
+				return e, nil
+			}
+
+			a.Log("rewriting %s as an equality", between)
+			return expression.NewEquals(between.Val, between.Lower), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		conjuncts := splitConjunction(e)
+		var changed bool
+		for i := 0; i < len(conjuncts); i++ {
+			lower, ok := pointRangeBound(conjuncts[i], true)
+			if !ok {
+				continue
+			}
+
+			for j := 0; j < len(conjuncts); j++ {
+				upper, ok := pointRangeBound(conjuncts[j], false)
+				if !ok || lower.column.String() != upper.column.String() {
+					continue
+				}
+
+				if !equalConstants(ctx, lower.column.Type(), lower.value, upper.value) {
+					continue
+				}
+
+				a.Log("rewriting %s AND %s as an equality", conjuncts[i], conjuncts[j])
+				conjuncts[i] = expression.NewEquals(lower.column, lower.value)
+				conjuncts = append(conjuncts[:j], conjuncts[j+1:]...)
+				if j < i {
+					i--
+				}
+				changed = true
+				break
+			}
+		}
+
+		if changed {
+			e = expression.JoinAnd(conjuncts...)
+		}
+
+		if e == filter.Expression {
+			return node, nil
+		}
+
+		return plan.NewFilter(e, filter.Child), nil
+	})
+}
+
+// pointRangeColumnBound is an inclusive bound on the values of a column, given by a comparison against a constant.
+type pointRangeColumnBound struct {
+	column *expression.GetField
+	value  sql.Expression
+}
+
+// pointRangeBound returns the inclusive lower bound, or upper bound if lower is false, of the column the expression
+// given compares against a constant, if it's such a comparison.
+func pointRangeBound(e sql.Expression, lower bool) (*pointRangeColumnBound, bool) {
+	var left, right sql.Expression
+	switch e := e.(type) {
+	case *expression.GreaterThanOrEqual:
+		left, right = e.Left(), e.Right()
+	case *expression.LessThanOrEqual:
+		left, right = e.Right(), e.Left()
+	default:
+		return nil, false
+	}
+
+	// left >= right is a lower bound if the column is on the left, and an upper bound otherwise
+	if !lower {
+		left, right = right, left
+	}
+
+	column, ok := left.(*expression.GetField)
+	if !ok || !isEvaluable(right) {
+		return nil, false
+	}
+
+	return &pointRangeColumnBound{column, right}, true
+}
+
+// equalConstants returns whether the two constant expressions given are equal when compared the same way a comparison
+// of them would, and also when compared as values of the type given. The latter ensures they are equal for columns of
+// that type, which may compare differently than the constants themselves, such as strings with a _bin collation. Any
+// NULL values or errors while evaluating them make them not equal.
+func equalConstants(ctx *sql.Context, typ sql.Type, left, right sql.Expression) bool {
+	equal, err := expression.NewEquals(left, right).Eval(ctx, nil)
+	if err != nil || equal != true {
+		return false
+	}
+
+	l, err := left.Eval(ctx, nil)
+	if err != nil {
+		return false
+	}
+
+	r, err := right.Eval(ctx, nil)
+	if err != nil {
+		return false
+	}
+
+	l, err = typ.Convert(l)
+	if err != nil {
+		return false
+	}
+
+	r, err = typ.Convert(r)
+	if err != nil {
+		return false
+	}
+
+	cmp, err := typ.Compare(l, r)
+	return err == nil && cmp == 0
+}
@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestSimplifyPointRanges(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("t", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t"},
+		{Name: "y", Type: sql.Int64, Source: "t"},
+		{Name: "s", Type: sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin), Source: "t"},
+	}))
+
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", false)
+	y := expression.NewGetFieldWithTable(1, sql.Int64, "t", "y", false)
+	s := expression.NewGetFieldWithTable(2, sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin), "t", "s", false)
+	five := expression.NewLiteral(int64(5), sql.Int64)
+	six := expression.NewLiteral(int64(6), sql.Int64)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "between with equal bounds",
+			node:     plan.NewFilter(expression.NewBetween(x, five, five), table),
+			expected: plan.NewFilter(expression.NewEquals(x, five), table),
+		},
+		{
+			name: "between with bounds equal after coercion",
+			node: plan.NewFilter(
+				expression.NewBetween(x, five, expression.NewLiteral("5", sql.LongText)),
+				table,
+			),
+			expected: plan.NewFilter(expression.NewEquals(x, five), table),
+		},
+		{
+			name: "between with unequal bounds",
+			node: plan.NewFilter(expression.NewBetween(x, five, six), table),
+		},
+		{
+			name: "range with equal bounds",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewGreaterThanOrEqual(x, five),
+					expression.NewLessThanOrEqual(x, five),
+				),
+				table,
+			),
+			expected: plan.NewFilter(expression.NewEquals(x, five), table),
+		},
+		{
+			name: "range with flipped comparisons",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewGreaterThanOrEqual(five, x),
+					expression.NewLessThanOrEqual(five, x),
+				),
+				table,
+			),
+			expected: plan.NewFilter(expression.NewEquals(x, five), table),
+		},
+		{
+			name: "range among other conditions",
+			node: plan.NewFilter(
+				expression.JoinAnd(
+					expression.NewLessThanOrEqual(x, five),
+					expression.NewEquals(y, six),
+					expression.NewGreaterThanOrEqual(x, expression.NewLiteral(float64(5), sql.Float64)),
+				),
+				table,
+			),
+			expected: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(y, six),
+					expression.NewEquals(x, expression.NewLiteral(float64(5), sql.Float64)),
+				),
+				table,
+			),
+		},
+		{
+			name: "range with unequal bounds",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewGreaterThanOrEqual(x, five),
+					expression.NewLessThanOrEqual(x, six),
+				),
+				table,
+			),
+		},
+		{
+			name: "bounds equal only case insensitively",
+			node: plan.NewFilter(
+				expression.NewBetween(s, expression.NewLiteral("a", sql.LongText), expression.NewLiteral("A", sql.LongText)),
+				table,
+			),
+		},
+		{
+			name: "bounds on different columns",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewGreaterThanOrEqual(x, five),
+					expression.NewLessThanOrEqual(y, five),
+				),
+				table,
+			),
+		},
+		{
+			name: "exclusive bounds",
+			node: plan.NewFilter(
+				expression.NewAnd(
+					expression.NewGreaterThan(x, five),
+					expression.NewLessThanOrEqual(x, five),
+				),
+				table,
+			),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("simplify_point_ranges"))
+}
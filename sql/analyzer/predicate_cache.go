@@ -0,0 +1,243 @@
+package analyzer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// PredicateCache holds the predicates compiled from the comparisons of the filters of the queries an Analyzer
+// analyzes, so that running a query of the same shape again reuses them instead of compiling them again. Predicates
+// are keyed by the shape of their comparison, which includes the types of the columns compared, and by the version of
+// the schema, which is bumped by every DDL statement analyzed. It's safe for concurrent use.
+type PredicateCache struct {
+	mu           sync.Mutex
+	version      uint64
+	predicates   map[predicateKey]func(sql.Row) (bool, error)
+	compilations int
+}
+
+// predicateKey is the key of a compiled predicate in a PredicateCache.
+type predicateKey struct {
+	version uint64
+	shape   string
+}
+
+// NewPredicateCache returns a new empty PredicateCache.
+func NewPredicateCache() *PredicateCache {
+	return &PredicateCache{predicates: make(map[predicateKey]func(sql.Row) (bool, error))}
+}
+
+// Predicate returns the predicate compiled from the comparison given, compiling it if there isn't one for its shape
+// with the current schema version yet.
+func (c *PredicateCache) Predicate(cmp expression.Comparer) (func(sql.Row) (bool, error), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := predicateKey{c.version, comparisonShape(cmp)}
+	if p, ok := c.predicates[key]; ok {
+		return p, nil
+	}
+
+	p, err := expression.CompilePredicate(cmp)
+	if err != nil {
+		return nil, err
+	}
+
+	c.compilations++
+	c.predicates[key] = p
+	return p, nil
+}
+
+// Invalidate drops all the predicates of the cache and bumps the schema version, so that the comparisons of the
+// queries analyzed afterwards are compiled again.
+func (c *PredicateCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	c.predicates = make(map[predicateKey]func(sql.Row) (bool, error))
+}
+
+// Compilations returns how many predicates the cache has compiled since it was created.
+func (c *PredicateCache) Compilations() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.compilations
+}
+
+// comparisonShape returns the canonical description of a comparison whose operands are columns and literals, which is
+// the same for every comparison evaluated the same way: the operator, and the index and type of each column or the
+// value and type of each literal.
+func comparisonShape(c expression.Comparer) string {
+	return fmt.Sprintf("%T(%s, %s)", c, operandShape(c.Left()), operandShape(c.Right()))
+}
+
+func operandShape(e sql.Expression) string {
+	if l, ok := e.(*expression.Literal); ok {
+		return fmt.Sprintf("%T %#v (%s)", l.Value(), l.Value(), l.Type())
+	}
+	return sql.DebugString(e)
+}
+
+// invalidateCompiledPredicates invalidates the PredicateCache of the analyzer, if it has one, when the node given is a
+// DDL statement, which can change the types the cached predicates were compiled for. It's a rule of its own, apart
+// from cache_compiled_predicates, so that the cache is invalidated even when compiled predicates aren't used.
+func invalidateCompiledPredicates(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("invalidate_compiled_predicates")
+	defer span.Finish()
+
+	if a.Predicates != nil && isDDL(n) {
+		a.Log("invalidating compiled predicates")
+		a.Predicates.Invalidate()
+	}
+	return n, nil
+}
+
+// cacheCompiledPredicates replaces the comparisons of the filters of the node given by the predicates the
+// PredicateCache of the analyzer compiled from them, if it has one. Only the comparisons that the filter is a
+// conjunction of are replaced, since a predicate is false instead of NULL when an operand is NULL, and only those of
+// columns and literals whose values are compared as they would be with the context of the query. DDL statements are
+// left to invalidate_compiled_predicates.
+func cacheCompiledPredicates(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("cache_compiled_predicates")
+	defer span.Finish()
+
+	if a.Predicates == nil || isDDL(n) {
+		return n, nil
+	}
+
+	// Compiled predicates don't log the comparisons that evaluate to NULL
+	if !n.Resolved() || ctx.DecimalRounding != nil || sql.NullEqualsNullPolicy(ctx) ||
+		ctx.Diagnostics.LogsNullComparisons() {
+		return n, nil
+	}
+
+	if _, ok := n.(*plan.DescribeQuery); ok {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		filter, ok := n.(*plan.Filter)
+		if !ok {
+			return n, nil
+		}
+
+		cond, err := compileConjuncts(ctx, a, filter.Expression)
+		if err != nil {
+			return nil, err
+		}
+
+		if cond == filter.Expression {
+			return n, nil
+		}
+		return plan.NewFilter(cond, filter.Child), nil
+	})
+}
+
+// compileConjuncts returns the condition given with the comparisons it's a conjunction of replaced by their compiled
+// predicates, or the same condition if none of them can be.
+func compileConjuncts(ctx *sql.Context, a *Analyzer, e sql.Expression) (sql.Expression, error) {
+	switch e := e.(type) {
+	case *expression.And:
+		left, err := compileConjuncts(ctx, a, e.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := compileConjuncts(ctx, a, e.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		if left == e.Left && right == e.Right {
+			return e, nil
+		}
+		return expression.NewAnd(left, right), nil
+	case *expression.Equals, *expression.LessThan, *expression.GreaterThan,
+		*expression.LessThanOrEqual, *expression.GreaterThanOrEqual:
+		cmp := e.(expression.Comparer)
+		if !compilesExactly(ctx, cmp) {
+			return e, nil
+		}
+
+		predicate, err := a.Predicates.Predicate(cmp)
+		if err != nil {
+			return nil, err
+		}
+		return &compiledComparison{cmp, predicate}, nil
+	default:
+		return e, nil
+	}
+}
+
+// compilesExactly returns whether the predicate compiled from the comparison given evaluates it like the comparison
+// does with the context given, which has neither the session nor the warnings of the query.
+func compilesExactly(ctx *sql.Context, c expression.Comparer) bool {
+	for _, operand := range []sql.Expression{c.Left(), c.Right()} {
+		switch operand.(type) {
+		case *expression.GetField, *expression.Literal:
+		default:
+			return false
+		}
+	}
+
+	cd, ok := c.(expression.CoercionDescriber)
+	if !ok {
+		return false
+	}
+
+	_, branch, err := cd.Coercion(ctx)
+	if err != nil {
+		return false
+	}
+
+	switch branch {
+	case expression.CoercionBranchComparator, expression.CoercionBranchOperandType:
+		return true
+	case expression.ConvertToSigned, expression.ConvertToUnsigned, expression.ConvertToDecimal,
+		expression.ConvertToDouble:
+		// Strings read as numbers warn when they aren't numbers, which the predicate can't do
+		return !sql.IsText(c.Left().Type()) && !sql.IsText(c.Right().Type())
+	default:
+		return false
+	}
+}
+
+// isDDL returns whether the node given, or any of its children, changes the schema of a table.
+func isDDL(n sql.Node) bool {
+	var ddl bool
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n.(type) {
+		case *plan.CreateTable, *plan.DropTable, *plan.RenameTable, *plan.AddColumn, *plan.DropColumn,
+			*plan.RenameColumn, *plan.ModifyColumn, *plan.CreateIndex, *plan.DropIndex, *plan.AlterIndex,
+			*plan.CreateForeignKey, *plan.DropForeignKey:
+			ddl = true
+		}
+		return !ddl
+	})
+	return ddl
+}
+
+// compiledComparison is a comparison of a filter evaluated with the predicate compiled from it.
+type compiledComparison struct {
+	expression.Comparer
+	predicate func(sql.Row) (bool, error)
+}
+
+// Eval implements the sql.Expression interface.
+func (c *compiledComparison) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return c.predicate(row)
+}
+
+// WithChildren implements the sql.Expression interface. The comparison with the children given isn't compiled.
+func (c *compiledComparison) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return c.Comparer.WithChildren(children...)
+}
+
+func (c *compiledComparison) DebugString() string {
+	return sql.DebugString(c.Comparer)
+}
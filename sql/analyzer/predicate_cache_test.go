@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestCacheCompiledPredicates(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := memory.NewTable("t", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "s", Type: sql.Text, Source: "t"},
+	})
+	for _, r := range []sql.Row{
+		sql.NewRow(int64(1), "a"),
+		sql.NewRow(int64(2), "b"),
+		sql.NewRow(nil, "c"),
+		sql.NewRow(int64(3), "d"),
+	} {
+		require.NoError(table.Insert(ctx, r))
+	}
+	db := memory.NewDatabase("mydb")
+	db.AddTable("t", table)
+
+	cache := NewPredicateCache()
+	a := NewBuilder(nil).WithPredicateCache(cache).Build()
+	rule := *getRuleFrom(OnceAfterAll, "cache_compiled_predicates")
+
+	// A new plan of the same query for every execution, as when parsing it again
+	query := func() sql.Node {
+		return plan.NewFilter(
+			expression.NewAnd(
+				expression.NewGreaterThan(
+					expression.NewGetFieldWithTable(0, sql.Int64, "t", "i", true),
+					expression.NewLiteral(int8(1), sql.Int8),
+				),
+				expression.NewNot(expression.NewEquals(
+					expression.NewGetFieldWithTable(1, sql.Text, "t", "s", false),
+					expression.NewLiteral("d", sql.LongText),
+				)),
+			),
+			plan.NewResolvedTable(table),
+		)
+	}
+
+	run := func() []sql.Row {
+		n, err := rule.Apply(ctx, a, query(), nil)
+		require.NoError(err)
+
+		iter, err := n.RowIter(ctx, nil)
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		return rows
+	}
+
+	for i := 0; i < 3; i++ {
+		require.Equal([]sql.Row{{int64(2), "b"}}, run())
+	}
+	// Only the comparison of numbers is compiled: the other one isn't a conjunct of the filter
+	require.Equal(1, cache.Compilations())
+
+	_, err := getRuleFrom(OnceAfterAll, "invalidate_compiled_predicates").Apply(ctx, a, plan.NewDropTable(db, false, "other"), nil)
+	require.NoError(err)
+
+	require.Equal([]sql.Row{{int64(2), "b"}}, run())
+	require.Equal(2, cache.Compilations())
+
+	// Without a cache, comparisons are left as they are
+	n, err := rule.Apply(ctx, NewDefault(nil), query(), nil)
+	require.NoError(err)
+	require.Equal(query(), n)
+}
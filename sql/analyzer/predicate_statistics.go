@@ -0,0 +1,320 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// PredicateStatistics collects, across the queries an Analyzer analyzes, which columns of which tables the filters of
+// the queries compare with constants, and with which operators, to suggest the indexes the queries could look up.
+// It's purely observational: collecting the statistics doesn't change how queries are executed. It's safe for
+// concurrent use.
+type PredicateStatistics struct {
+	mu          sync.Mutex
+	columns     map[columnKey]*ColumnPredicates
+	suggestions map[string]*IndexSuggestion
+}
+
+// columnKey is the key of the statistics of a column in a PredicateStatistics.
+type columnKey struct {
+	table  string
+	column string
+}
+
+// ColumnPredicates is how many times the filters of the queries analyzed compared a column with constants.
+type ColumnPredicates struct {
+	Table  string
+	Column string
+	// Equality is how many times the column was compared with = or IN.
+	Equality int
+	// Range is how many times the column was compared with <, >, <= or >=, a BETWEEN counting as two comparisons.
+	Range int
+}
+
+// IndexSuggestion is an index a table has no index for that the filters of the queries analyzed could look up.
+type IndexSuggestion struct {
+	Table string
+	// Columns are the lowercase names of the columns of the index, with those compared for equality first, by name,
+	// followed by the first column compared by range, if any.
+	Columns []string
+	// Filters is how many filters of the queries analyzed could look up the index.
+	Filters int
+}
+
+// NewPredicateStatistics returns a new empty PredicateStatistics.
+func NewPredicateStatistics() *PredicateStatistics {
+	return &PredicateStatistics{
+		columns:     make(map[columnKey]*ColumnPredicates),
+		suggestions: make(map[string]*IndexSuggestion),
+	}
+}
+
+// Columns returns the statistics of the columns compared by the filters of the queries analyzed, sorted by table and
+// column.
+func (s *PredicateStatistics) Columns() []ColumnPredicates {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	columns := make([]ColumnPredicates, 0, len(s.columns))
+	for _, c := range s.columns {
+		columns = append(columns, *c)
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		if columns[i].Table != columns[j].Table {
+			return columns[i].Table < columns[j].Table
+		}
+		return columns[i].Column < columns[j].Column
+	})
+	return columns
+}
+
+// Suggestions returns the indexes suggested for the filters of the queries analyzed, sorted by how many filters could
+// look them up, most first.
+func (s *PredicateStatistics) Suggestions() []IndexSuggestion {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	suggestions := make([]IndexSuggestion, 0, len(s.suggestions))
+	for _, suggestion := range s.suggestions {
+		suggestion := *suggestion
+		suggestion.Columns = append([]string(nil), suggestion.Columns...)
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Filters != suggestions[j].Filters {
+			return suggestions[i].Filters > suggestions[j].Filters
+		}
+		return suggestionKey(suggestions[i].Table, suggestions[i].Columns) < suggestionKey(suggestions[j].Table, suggestions[j].Columns)
+	})
+	return suggestions
+}
+
+// record adds the predicates given of a filter of the table given, whose indexes are the ones given, to the statistics.
+func (s *PredicateStatistics) record(table string, predicates []sql.PushablePredicate, indexes []sql.Index) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var equalities []string
+	var ranged string
+	isEquality := make(map[string]bool)
+	for _, p := range predicates {
+		key := columnKey{strings.ToLower(table), strings.ToLower(p.Column)}
+		c, ok := s.columns[key]
+		if !ok {
+			c = &ColumnPredicates{Table: table, Column: p.Column}
+			s.columns[key] = c
+		}
+
+		switch p.Operator {
+		case sql.PredicateEquals, sql.PredicateIn:
+			c.Equality++
+			if !isEquality[key.column] {
+				isEquality[key.column] = true
+				equalities = append(equalities, key.column)
+			}
+		default:
+			c.Range++
+			if ranged == "" {
+				ranged = key.column
+			}
+		}
+	}
+
+	sort.Strings(equalities)
+	columns := equalities
+	if ranged != "" && !isEquality[ranged] {
+		columns = append(columns, ranged)
+	}
+
+	if len(columns) == 0 || isIndexedPrefix(indexes, len(equalities), columns) {
+		return
+	}
+
+	key := suggestionKey(table, columns)
+	suggestion, ok := s.suggestions[key]
+	if !ok {
+		suggestion = &IndexSuggestion{Table: table, Columns: columns}
+		s.suggestions[key] = suggestion
+	}
+	suggestion.Filters++
+}
+
+// suggestionKey returns the key of the suggestion of an index of the table and columns given.
+func suggestionKey(table string, columns []string) string {
+	return strings.ToLower(table) + "(" + strings.Join(columns, ", ") + ")"
+}
+
+// isIndexedPrefix returns whether any of the indexes given starts with the lowercase columns given, the first of which,
+// as many as given, are compared for equality, and so can be in any order.
+func isIndexedPrefix(indexes []sql.Index, equalities int, columns []string) bool {
+	for _, idx := range indexes {
+		indexColumns := indexColumnNames(idx)
+		if len(indexColumns) < len(columns) {
+			continue
+		}
+
+		prefix := append([]string(nil), indexColumns[:len(columns)]...)
+		sort.Strings(prefix[:equalities])
+		matches := true
+		for i, c := range columns {
+			if prefix[i] != c {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// collectPredicateStatistics adds the comparisons of columns with constants of the filters of the query given, and of
+// its subqueries, to the PredicateStatistics of the analyzer, if it has them, using the same comparisons that could be
+// pushed down to a storage engine. Subquery expressions are counted with the query they belong to, not when they're
+// analyzed on their own, which can happen more than once. The node is always returned unchanged.
+func collectPredicateStatistics(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("collect_predicate_statistics")
+	defer span.Finish()
+
+	if a.PredicateStats == nil || !n.Resolved() || len(scope.InnerToOuter()) > 0 {
+		return n, nil
+	}
+
+	if _, ok := n.(*plan.DescribeQuery); ok {
+		return n, nil
+	}
+
+	return n, recordFilterPredicates(ctx, a.PredicateStats, n)
+}
+
+// recordFilterPredicates adds the comparisons of the filters of the node given, and of its subqueries, to the
+// statistics given.
+func recordFilterPredicates(ctx *sql.Context, stats *PredicateStatistics, n sql.Node) error {
+	tables := resolvedTablesByName(n)
+
+	var err error
+	plan.Inspect(n, func(node sql.Node) bool {
+		if err != nil {
+			return false
+		}
+
+		// Derived tables are counted when they're analyzed on their own
+		if _, ok := node.(*plan.SubqueryAlias); ok {
+			return false
+		}
+
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return true
+		}
+
+		byTable := make(map[string][]sql.Expression)
+		var names []string
+		for _, e := range splitConjunction(filter.Expression) {
+			if name, ok := conjunctTable(e); ok && tables[name] != nil {
+				if _, ok := byTable[name]; !ok {
+					names = append(names, name)
+				}
+				byTable[name] = append(byTable[name], e)
+			}
+		}
+
+		for _, name := range names {
+			rt := tables[name]
+			predicates, _ := ExtractSargable(expression.JoinAnd(byTable[name]...), schemaColumnNames(rt.Schema()))
+			if len(predicates) == 0 {
+				continue
+			}
+
+			var indexes []sql.Index
+			if table, ok := rt.Table.(sql.IndexedTable); ok {
+				indexes, err = table.GetIndexes(ctx)
+				if err != nil {
+					return false
+				}
+			}
+
+			stats.record(rt.Name(), predicates, indexes)
+		}
+		return true
+	})
+
+	if err != nil {
+		return err
+	}
+
+	plan.InspectExpressions(n, func(e sql.Expression) bool {
+		if err != nil {
+			return false
+		}
+
+		if s, ok := e.(*plan.Subquery); ok {
+			err = recordFilterPredicates(ctx, stats, s.Query)
+			return false
+		}
+		return true
+	})
+	return err
+}
+
+// resolvedTablesByName returns the tables of the node given keyed by their lowercase names, or by their aliases for the
+// aliased ones. The tables of subqueries aren't included.
+func resolvedTablesByName(n sql.Node) map[string]*plan.ResolvedTable {
+	tables := make(map[string]*plan.ResolvedTable)
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.TableAlias:
+			if rt, ok := n.Child.(*plan.ResolvedTable); ok {
+				tables[strings.ToLower(n.Name())] = rt
+			}
+			return false
+		case *plan.ResolvedTable:
+			tables[strings.ToLower(n.Name())] = n
+			return false
+		case *plan.SubqueryAlias:
+			return false
+		default:
+			return true
+		}
+	})
+	return tables
+}
+
+// conjunctTable returns the lowercase name of the table all the columns of the expression given are of, or false if
+// there are none or they're of more than one table.
+func conjunctTable(e sql.Expression) (string, bool) {
+	var table string
+	ok := true
+	sql.Inspect(e, func(e sql.Expression) bool {
+		switch e := e.(type) {
+		case *plan.Subquery:
+			ok = false
+		case *expression.GetField:
+			name := strings.ToLower(e.Table())
+			if table != "" && table != name {
+				ok = false
+			}
+			table = name
+		}
+		return ok
+	})
+	return table, ok && table != ""
+}
+
+// schemaColumnNames returns the names of the columns of the schema given.
+func schemaColumnNames(schema sql.Schema) []string {
+	names := make([]string, len(schema))
+	for i, col := range schema {
+		names[i] = col.Name
+	}
+	return names
+}
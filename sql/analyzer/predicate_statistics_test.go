@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+)
+
+func TestPredicateStatistics(t *testing.T) {
+	require := require.New(t)
+
+	ctx := sql.NewContext(context.Background(), sql.WithIndexRegistry(sql.NewIndexRegistry()), sql.WithViewRegistry(sql.NewViewRegistry())).WithCurrentDB("mydb")
+	t1 := memory.NewTable("t", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t"},
+		{Name: "b", Type: sql.Int64, Source: "t"},
+		{Name: "c", Type: sql.Int64, Source: "t"},
+		{Name: "d", Type: sql.Int64, Source: "t"},
+	})
+	require.NoError(t1.CreateIndex(ctx, "d_idx", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{{Name: "d"}}, ""))
+	t2 := memory.NewTable("u", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "u"},
+		{Name: "y", Type: sql.Int64, Source: "u"},
+	})
+	db := memory.NewDatabase("mydb")
+	db.AddTable("t", t1)
+	db.AddTable("u", t2)
+	catalog := sql.NewCatalog()
+	catalog.AddDatabase(db)
+
+	stats := NewPredicateStatistics()
+	a := withoutProcessTracking(NewBuilder(catalog).WithPredicateStatistics(stats).Build())
+	for _, query := range []string{
+		"SELECT * FROM t WHERE a = 1 AND b > 5",
+		"SELECT * FROM t WHERE b < 10 AND a IN (2, 3)",
+		"SELECT * FROM t AS t2 WHERE c BETWEEN 1 AND 3",
+		"SELECT * FROM t WHERE d = 4",
+		"SELECT * FROM u WHERE x IN (SELECT a FROM t WHERE c = 5)",
+		"SELECT * FROM t JOIN u ON t.a = u.x WHERE u.y = 2 AND t.a + 1 = 3",
+		"SELECT * FROM t WHERE a = b",
+	} {
+		n, err := parse.Parse(ctx, query)
+		require.NoError(err)
+		_, err = a.Analyze(ctx, n, nil)
+		require.NoError(err, query)
+	}
+
+	require.Equal([]IndexSuggestion{
+		{Table: "t", Columns: []string{"a", "b"}, Filters: 2},
+		{Table: "t", Columns: []string{"c"}, Filters: 2},
+		{Table: "u", Columns: []string{"y"}, Filters: 1},
+	}, stats.Suggestions())
+
+	require.Equal([]ColumnPredicates{
+		{Table: "t", Column: "a", Equality: 2},
+		{Table: "t", Column: "b", Range: 2},
+		{Table: "t", Column: "c", Equality: 1, Range: 2},
+		{Table: "t", Column: "d", Equality: 1},
+		{Table: "u", Column: "y", Equality: 1},
+	}, stats.Columns())
+}
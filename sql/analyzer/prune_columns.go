@@ -137,6 +137,12 @@ func findUsedColumns(columns usedColumns, n sql.Node) {
 		case *plan.SubqueryAlias:
 			// TODO: inspect subquery for references to outer scope nodes
 			return false
+		case *plan.Union:
+			// The rows of both sides are returned, but the schema of a union only has the columns of the left side
+			for _, col := range append(n.Left.Schema(), n.Right.Schema()...) {
+				columns.add(col.Source, col.Name)
+			}
+			return true
 		}
 
 		exp, ok := n.(sql.Expressioner)
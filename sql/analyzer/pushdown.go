@@ -43,8 +43,19 @@ func pushdownFilters(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 	}
 
 	filters := newFilterSet(filtersByTable, exprAliases, tableAliases)
+	// The fields of a table the query uses are those it refers to, and those of its result, which nodes such as joins pass
+	// on without referring to them. The fields of an alias are also those of the table it's an alias of.
+	fields := getFieldsByTable(ctx, n)
+	for _, col := range n.Schema() {
+		fields.add(col.Source, col.Name)
+	}
+	for alias, fs := range fields {
+		if table, ok := tableAliases[strings.ToLower(alias)]; ok {
+			fields.addAll(fieldsByTable{table.Name(): fs})
+		}
+	}
 
-	return transformPushdownFilters(a, n, filters, indexes, exprAliases, tableAliases)
+	return transformPushdownFilters(a, n, filters, indexes, fields, exprAliases, tableAliases)
 }
 
 // pushdownProjections attempts to push projections down to individual tables that implement sql.ProjectTable
@@ -116,7 +127,7 @@ func canDoPushdown(n sql.Node, scope *Scope, a *Analyzer) bool {
 	return true
 }
 
-func transformPushdownFilters(a *Analyzer, n sql.Node, filters *filterSet, indexes indexLookupsByTable, exprAliases ExprAliases, tableAliases TableAliases) (sql.Node, error) {
+func transformPushdownFilters(a *Analyzer, n sql.Node, filters *filterSet, indexes indexLookupsByTable, fields fieldsByTable, exprAliases ExprAliases, tableAliases TableAliases) (sql.Node, error) {
 	node, err := plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
 		switch node := node.(type) {
 		case *plan.Filter:
@@ -126,13 +137,13 @@ func transformPushdownFilters(a *Analyzer, n sql.Node, filters *filterSet, index
 			}
 			return FixFieldIndexesForExpressions(n)
 		case *plan.TableAlias:
-			table, err := pushdownFiltersToTable(a, node, filters, indexes, exprAliases, tableAliases)
+			table, err := pushdownFiltersToTable(a, node, filters, indexes, fields, exprAliases, tableAliases)
 			if err != nil {
 				return nil, err
 			}
 			return FixFieldIndexesForExpressions(table)
 		case *plan.ResolvedTable:
-			table, err := pushdownFiltersToTable(a, node, filters, indexes, exprAliases, tableAliases)
+			table, err := pushdownFiltersToTable(a, node, filters, indexes, fields, exprAliases, tableAliases)
 			if err != nil {
 				return nil, err
 			}
@@ -184,12 +195,15 @@ type NameableNode interface {
 	sql.Node
 }
 
-// pushdownFiltersToTable attempts to push filters to tables that can accept them
+// pushdownFiltersToTable attempts to push filters to tables that can accept them. An index lookup on a single index
+// that covers all the fields of the table used in the query is marked as an index-only access, since the query can be
+// answered from the index alone.
 func pushdownFiltersToTable(
 	a *Analyzer,
 	tableNode NameableNode,
 	filters *filterSet,
 	indexes map[string]*indexLookup,
+	fields fieldsByTable,
 	exprAliases ExprAliases,
 	tableAliases TableAliases,
 ) (sql.Node, error) {
@@ -212,8 +226,12 @@ func pushdownFiltersToTable(
 			if len(indexStrs) > 1 {
 				indexNoun = "indexes"
 			}
+			access := "Indexed table access"
+			if len(indexLookup.indexes) == 1 && isCoveringIndex(indexLookup.indexes[0], fields[tableNode.Name()]) {
+				access = "Index-only table access"
+			}
 			newTableNode = plan.NewDecoratedNode(
-				fmt.Sprintf("Indexed table access on %s %s", indexNoun, strings.Join(indexStrs, ", ")),
+				fmt.Sprintf("%s on %s %s", access, indexNoun, strings.Join(indexStrs, ", ")),
 				newTableNode)
 			a.Log("table %q transformed with pushdown of index", tableNode.Name())
 
@@ -294,6 +312,17 @@ func formatIndexDecoratorString(indexLookup *indexLookup) []string {
 	return indexStrs
 }
 
+// isCoveringIndex returns whether the index given has all the fields given among its columns.
+func isCoveringIndex(idx sql.Index, fields []string) bool {
+	columns := indexColumnNames(idx)
+	for _, f := range fields {
+		if !stringContains(columns, strings.ToLower(f)) {
+			return false
+		}
+	}
+	return true
+}
+
 func pushdownProjectionsToTable(
 	a *Analyzer,
 	tableNode NameableNode,
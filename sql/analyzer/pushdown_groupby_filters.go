@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// pushdownGroupByFilters moves the parts of filters and HAVING clauses on top of a GroupBy that only reference
+// grouping keys below the GroupBy, so that fewer rows need to be aggregated. Conditions that reference aggregates stay
+// where they are. Filters on top of a Distinct are always moved below it, since filtering and removing duplicates can
+// be done in any order.
+func pushdownGroupByFilters(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("pushdown_groupby_filters")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	scopeLen := len(scope.Schema())
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		var cond sql.Expression
+		var child sql.Node
+		switch node := node.(type) {
+		case *plan.Filter:
+			if distinct, ok := node.Child.(*plan.Distinct); ok {
+				a.Log("pushing filter below distinct")
+				return plan.NewDistinct(plan.NewFilter(node.Expression, distinct.Child)), nil
+			}
+			cond, child = node.Expression, node.Child
+		case *plan.Having:
+			cond, child = node.Cond, node.Child
+		default:
+			return node, nil
+		}
+
+		groupBy, ok := child.(*plan.GroupBy)
+		if !ok {
+			return node, nil
+		}
+
+		var pushed, kept []sql.Expression
+		for _, e := range splitConjunction(cond) {
+			if pushable, ok := groupingKeyExpression(groupBy, scopeLen, e); ok {
+				pushed = append(pushed, pushable)
+			} else {
+				kept = append(kept, e)
+			}
+		}
+
+		if len(pushed) == 0 {
+			return node, nil
+		}
+
+		a.Log("pushing %d filter conditions below group by", len(pushed))
+		newGroupBy, err := groupBy.WithChildren(plan.NewFilter(expression.JoinAnd(pushed...), groupBy.Child))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(kept) == 0 {
+			return newGroupBy, nil
+		}
+
+		if _, ok := node.(*plan.Having); ok {
+			return plan.NewHaving(expression.JoinAnd(kept...), newGroupBy), nil
+		}
+		return plan.NewFilter(expression.JoinAnd(kept...), newGroupBy), nil
+	})
+}
+
+// groupingKeyExpression returns the expression given rewritten in terms of the child of the GroupBy given, if it only
+// references grouping keys of that GroupBy. Otherwise, it returns false.
+func groupingKeyExpression(groupBy *plan.GroupBy, scopeLen int, e sql.Expression) (sql.Expression, bool) {
+	pushable := true
+	sql.Inspect(e, func(e sql.Expression) bool {
+		switch e := e.(type) {
+		case *plan.Subquery, sql.Aggregation:
+			pushable = false
+		case sql.NonDeterministicExpression:
+			if e.IsNonDeterministic() {
+				pushable = false
+			}
+		}
+		return pushable
+	})
+
+	if !pushable {
+		return nil, false
+	}
+
+	rewritten, err := expression.TransformUp(e, func(e sql.Expression) (sql.Expression, error) {
+		gf, ok := e.(*expression.GetField)
+		// Fields of the outer scope are the same on both sides of the GroupBy
+		if !ok || gf.Index() < scopeLen {
+			return e, nil
+		}
+
+		idx := gf.Index() - scopeLen
+		if idx >= len(groupBy.SelectedExprs) {
+			pushable = false
+			return e, nil
+		}
+
+		selected := groupBy.SelectedExprs[idx]
+		if alias, ok := selected.(*expression.Alias); ok {
+			selected = alias.Child
+		}
+
+		if !isGroupingKey(groupBy, selected) {
+			pushable = false
+			return e, nil
+		}
+
+		return selected, nil
+	})
+	if err != nil || !pushable {
+		return nil, false
+	}
+
+	return rewritten, true
+}
+
+// isGroupingKey returns whether the expression given has the same value for every row of a group of the GroupBy given,
+// which is the case for its grouping expressions and any expressions of their columns.
+func isGroupingKey(groupBy *plan.GroupBy, e sql.Expression) bool {
+	for _, g := range groupBy.GroupByExprs {
+		if alias, ok := g.(*expression.Alias); ok {
+			g = alias.Child
+		}
+		if g.String() == e.String() {
+			return true
+		}
+	}
+
+	if nd, ok := e.(sql.NonDeterministicExpression); ok && nd.IsNonDeterministic() {
+		return false
+	}
+
+	switch e.(type) {
+	case *expression.GetField, sql.Aggregation, *plan.Subquery:
+		return false
+	default:
+		for _, child := range e.Children() {
+			if !isGroupingKey(groupBy, child) {
+				return false
+			}
+		}
+		return true
+	}
+}
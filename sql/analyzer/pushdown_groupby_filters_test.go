@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestPushdownGroupByFilters(t *testing.T) {
+	table := plan.NewResolvedTable(memory.NewTable("mytable", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable"},
+		{Name: "b", Type: sql.Int64, Source: "mytable"},
+	}))
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", false)
+
+	// SELECT a, COUNT(*) AS c, b + 1 AS d FROM mytable GROUP BY a, b
+	groupBy := func(child sql.Node) *plan.GroupBy {
+		return plan.NewGroupBy(
+			[]sql.Expression{
+				a,
+				expression.NewAlias("c", aggregation.NewCount(expression.NewStar())),
+				expression.NewAlias("d", expression.NewPlus(b, lit(1))),
+			},
+			[]sql.Expression{a, b},
+			child,
+		)
+	}
+
+	groupedA := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", false)
+	count := expression.NewGetField(1, sql.Int64, "c", false)
+	d := expression.NewGetField(2, sql.Int64, "d", false)
+
+	tests := []analyzerFnTestCase{
+		{
+			name: "filter on a grouping key is pushed",
+			node: plan.NewHaving(gt(groupedA, lit(1)), groupBy(table)),
+			expected: groupBy(
+				plan.NewFilter(gt(a, lit(1)), table),
+			),
+		},
+		{
+			name: "filter on an aggregate is kept as having",
+			node: plan.NewHaving(gt(count, lit(1)), groupBy(table)),
+		},
+		{
+			name: "conjunction is split",
+			node: plan.NewHaving(
+				and(gt(count, lit(1)), gt(groupedA, lit(1))),
+				groupBy(table),
+			),
+			expected: plan.NewHaving(
+				gt(count, lit(1)),
+				groupBy(plan.NewFilter(gt(a, lit(1)), table)),
+			),
+		},
+		{
+			name: "filter on an expression of grouping keys",
+			node: plan.NewFilter(gt(d, lit(1)), groupBy(table)),
+			expected: groupBy(
+				plan.NewFilter(gt(expression.NewPlus(b, lit(1)), lit(1)), table),
+			),
+		},
+		{
+			name: "filter below distinct",
+			node: plan.NewFilter(gt(a, lit(1)), plan.NewDistinct(table)),
+			expected: plan.NewDistinct(
+				plan.NewFilter(gt(a, lit(1)), table),
+			),
+		},
+	}
+
+	runTestCases(t, nil, tests, NewDefault(nil), getRule("pushdown_groupby_filters"))
+}
@@ -638,7 +638,7 @@ func TestPushdownIndex(t *testing.T) {
 							mustIndexLookup(idxTable1F.Get(3.14))),
 						),
 					),
-					plan.NewDecoratedNode("Indexed table access on index [mytable2.i2]",
+					plan.NewDecoratedNode("Index-only table access on index [mytable2.i2]",
 						plan.NewResolvedTable(table2.WithIndexLookup(
 							mustIndexLookup(idxTable2I2.Get(21))),
 						),
@@ -813,7 +813,7 @@ func TestPushdownIndex(t *testing.T) {
 						),
 					),
 					plan.NewTableAlias("t2",
-						plan.NewDecoratedNode("Indexed table access on index [mytable2.i2]",
+						plan.NewDecoratedNode("Index-only table access on index [mytable2.i2]",
 							plan.NewResolvedTable(table2.WithIndexLookup(
 								mustIndexLookup(idxTable2I2.Get(21))),
 							),
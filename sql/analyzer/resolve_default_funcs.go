@@ -0,0 +1,67 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// resolveDefaultFuncs sets the default values of the columns of the DEFAULT(col) functions of the node given, once
+// their columns are resolved, to those of the columns in the schema of the children of the node they're part of. The
+// columns an expression default value refers to, which are those of the table of the column, are looked up in the same
+// schema. The default value of a nullable column without one is NULL, and a non-nullable column without one is an
+// error.
+func resolveDefaultFuncs(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("resolve_default_funcs")
+	defer span.Finish()
+
+	return plan.TransformExpressionsUpWithNode(n, func(node sql.Node, e sql.Expression) (sql.Expression, error) {
+		f, ok := e.(*function.DefaultFunc)
+		if !ok || f.Default() != nil {
+			return e, nil
+		}
+
+		gf, ok := f.Column().(*expression.GetField)
+		if !ok {
+			return e, nil
+		}
+
+		var schema sql.Schema
+		for _, child := range node.Children() {
+			schema = append(schema, child.Schema()...)
+		}
+
+		idx := schema.IndexOf(gf.Name(), gf.Table())
+		if idx < 0 {
+			return e, nil
+		}
+
+		col := schema[idx]
+		if col.Default == nil {
+			if !col.Nullable {
+				return nil, sql.ErrColumnHasNoDefault.New(col.Name)
+			}
+			return f.WithDefault(expression.NewLiteral(nil, col.Type)), nil
+		}
+
+		def, err := expression.TransformUp(col.Default, func(e sql.Expression) (sql.Expression, error) {
+			ref, ok := e.(*expression.GetField)
+			if !ok {
+				return e, nil
+			}
+
+			i := schema.IndexOf(ref.Name(), gf.Table())
+			if i < 0 {
+				return nil, sql.ErrTableColumnNotFound.New(gf.Table(), ref.Name())
+			}
+			return expression.NewGetFieldWithTable(i, schema[i].Type, schema[i].Source, schema[i].Name, schema[i].Nullable), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		a.Log("resolved the default value of column %s to %s", gf, def)
+		return f.WithDefault(def), nil
+	})
+}
@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestResolveDefaultFuncs(t *testing.T) {
+	literal, err := sql.NewColumnDefaultValue(expression.NewLiteral(int64(5), sql.Int64), sql.Int64, true, false)
+	require.NoError(t, err)
+
+	pk := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "pk", false)
+	plusOne, err := sql.NewColumnDefaultValue(
+		expression.NewArithmetic(expression.NewGetField(0, sql.Int64, "pk", false), expression.NewLiteral(int64(1), sql.Int64), "+"),
+		sql.Int64, false, false,
+	)
+	require.NoError(t, err)
+
+	table := memory.NewTable("mytable", sql.Schema{
+		{Name: "pk", Type: sql.Int64, Source: "mytable", PrimaryKey: true},
+		{Name: "a", Type: sql.Int64, Source: "mytable", Default: literal},
+		{Name: "b", Type: sql.Int64, Source: "mytable", Default: plusOne},
+		{Name: "c", Type: sql.Int64, Source: "mytable", Nullable: true},
+		{Name: "d", Type: sql.Int64, Source: "mytable"},
+	})
+	rt := plan.NewResolvedTable(table)
+
+	column := func(idx int, name string, nullable bool) *expression.GetField {
+		return expression.NewGetFieldWithTable(idx, sql.Int64, "mytable", name, nullable)
+	}
+	a, b, c, d := column(1, "a", false), column(2, "b", false), column(3, "c", true), column(4, "d", false)
+
+	resolvedPlusOne, err := plusOne.WithChildren(expression.NewArithmetic(pk, expression.NewLiteral(int64(1), sql.Int64), "+"))
+	require.NoError(t, err)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "literal default",
+			node:     plan.NewFilter(expression.NewEquals(a, function.NewDefaultFunc(a)), rt),
+			expected: plan.NewFilter(expression.NewEquals(a, function.NewDefaultFunc(a).WithDefault(literal)), rt),
+		},
+		{
+			name:     "expression default",
+			node:     plan.NewFilter(expression.NewEquals(b, function.NewDefaultFunc(b)), rt),
+			expected: plan.NewFilter(expression.NewEquals(b, function.NewDefaultFunc(b).WithDefault(resolvedPlusOne)), rt),
+		},
+		{
+			name:     "nullable column without a default",
+			node:     plan.NewProject([]sql.Expression{function.NewDefaultFunc(c)}, rt),
+			expected: plan.NewProject([]sql.Expression{function.NewDefaultFunc(c).WithDefault(expression.NewLiteral(nil, sql.Int64))}, rt),
+		},
+		{
+			name: "non-nullable column without a default",
+			node: plan.NewProject([]sql.Expression{function.NewDefaultFunc(d)}, rt),
+			err:  sql.ErrColumnHasNoDefault,
+		},
+		{
+			name: "unresolved column",
+			node: plan.NewProject([]sql.Expression{function.NewDefaultFunc(uc("a"))}, rt),
+		},
+	}
+
+	runTestCases(t, sql.NewEmptyContext(), tests, NewDefault(nil), getRule("resolve_default_funcs"))
+}
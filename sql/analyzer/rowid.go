@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// fetchByRowID replaces a table filtered by an equality between its row id pseudo-column and a constant with a
+// direct fetch of that row. Only tables implementing sql.RowIDTable that are filtered directly are considered. The
+// rest of the filter, if any, is kept on top of the fetch.
+func fetchByRowID(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("fetch_by_rowid")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		rt, ok := filter.Child.(*plan.ResolvedTable)
+		if !ok {
+			return node, nil
+		}
+
+		table, ok := rt.Table.(sql.RowIDTable)
+		if !ok {
+			return node, nil
+		}
+
+		predicates := splitConjunction(filter.Expression)
+		for i, p := range predicates {
+			id, ok := rowIDValue(table, p)
+			if !ok {
+				continue
+			}
+
+			a.Log("replacing scan of table %s with a fetch by row id", table.Name())
+			fetch, err := plan.NewRowIDFetch(rt, id)
+			if err != nil {
+				return nil, err
+			}
+
+			rest := append(predicates[:i:i], predicates[i+1:]...)
+			if len(rest) == 0 {
+				return fetch, nil
+			}
+
+			return plan.NewFilter(expression.JoinAnd(rest...), fetch), nil
+		}
+
+		return node, nil
+	})
+}
+
+// rowIDValue returns the constant the row id column of the table given is compared with in the expression given, if
+// it's an equality between both.
+func rowIDValue(table sql.RowIDTable, e sql.Expression) (sql.Expression, bool) {
+	eq, ok := e.(*expression.Equals)
+	if !ok {
+		return nil, false
+	}
+
+	left, right := eq.Left(), eq.Right()
+	if _, ok := right.(*expression.GetField); ok {
+		left, right = right, left
+	}
+
+	gf, ok := left.(*expression.GetField)
+	if !ok || !isEvaluable(right) {
+		return nil, false
+	}
+
+	if !strings.EqualFold(gf.Table(), table.Name()) || !strings.EqualFold(gf.Name(), table.RowIDColumn()) {
+		return nil, false
+	}
+
+	return right, true
+}
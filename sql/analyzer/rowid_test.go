@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// rowIDTable is a table whose first column is a row id pseudo-column that can be used to fetch rows directly.
+type rowIDTable struct {
+	*memory.Table
+	rows    []sql.Row
+	fetches int
+}
+
+var _ sql.RowIDTable = (*rowIDTable)(nil)
+
+func (t *rowIDTable) RowIDColumn() string {
+	return "rowid"
+}
+
+func (t *rowIDTable) RowByID(ctx *sql.Context, id interface{}) (sql.Row, error) {
+	t.fetches++
+	for _, row := range t.rows {
+		if row[0] == id {
+			return row, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestFetchByRowID(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "rowid", Type: sql.Int64, Source: "mytable"},
+		{Name: "name", Type: sql.Text, Source: "mytable"},
+	}
+	table := &rowIDTable{Table: memory.NewTable("mytable", schema)}
+	ctx := sql.NewEmptyContext()
+	for i := int64(1); i <= 100; i++ {
+		row := sql.NewRow(i, "foo")
+		table.rows = append(table.rows, row)
+		require.NoError(table.Insert(ctx, row))
+	}
+
+	rowid := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "rowid", false)
+	name := expression.NewGetFieldWithTable(1, sql.Text, "mytable", "name", false)
+	id := expression.NewLiteral(int64(42), sql.Int64)
+	foo := expression.NewEquals(name, expression.NewLiteral("foo", sql.LongText))
+
+	rt := plan.NewResolvedTable(table)
+	fetch, err := plan.NewRowIDFetch(rt, id)
+	require.NoError(err)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "rowid = 42",
+			node:     plan.NewFilter(expression.NewEquals(rowid, id), rt),
+			expected: fetch,
+		},
+		{
+			name:     "42 = rowid",
+			node:     plan.NewFilter(expression.NewEquals(id, rowid), rt),
+			expected: fetch,
+		},
+		{
+			name:     "rowid = 42 and other predicates",
+			node:     plan.NewFilter(expression.NewAnd(foo, expression.NewEquals(rowid, id)), rt),
+			expected: plan.NewFilter(foo, fetch),
+		},
+		{
+			name: "rowid in a range",
+			node: plan.NewFilter(expression.NewGreaterThan(rowid, id), rt),
+		},
+		{
+			name: "rowid compared with another column",
+			node: plan.NewFilter(expression.NewEquals(rowid, name), rt),
+		},
+		{
+			name: "table without rowid",
+			node: plan.NewFilter(
+				expression.NewEquals(rowid, id),
+				plan.NewResolvedTable(memory.NewTable("mytable", schema)),
+			),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("fetch_by_rowid"))
+
+	iter, err := fetch.RowIter(ctx, nil)
+	require.NoError(err)
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{sql.NewRow(int64(42), "foo")}, rows)
+	require.Equal(1, table.fetches)
+
+	// Ids that aren't equal to any row id once converted to its type match no row
+	for _, id := range []sql.Expression{
+		expression.NewLiteral(42.5, sql.Float64),
+		expression.NewLiteral("42abc", sql.LongText),
+		expression.NewLiteral("foo", sql.LongText),
+	} {
+		fetch, err := plan.NewRowIDFetch(rt, id)
+		require.NoError(err)
+
+		iter, err := fetch.RowIter(ctx, nil)
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		require.Empty(rows, id.String())
+	}
+	require.Equal(1, table.fetches)
+
+	fetch, err = plan.NewRowIDFetch(rt, expression.NewLiteral(42.0, sql.Float64))
+	require.NoError(err)
+	iter, err = fetch.RowIter(ctx, nil)
+	require.NoError(err)
+	rows, err = sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{sql.NewRow(int64(42), "foo")}, rows)
+}
@@ -24,6 +24,7 @@ var DefaultRules = []Rule{
 	{"resolve_new_and_old_in_triggers", resolveNewAndOldReferences},
 	{"qualify_columns", qualifyColumns},
 	{"resolve_columns", resolveColumns},
+	{"resolve_default_funcs", resolveDefaultFuncs},
 	{"resolve_bareword_set_variables", resolveBarewordSetVariables},
 	{"resolve_database", resolveDatabase},
 	{"expand_stars", expandStars},
@@ -33,8 +34,23 @@ var DefaultRules = []Rule{
 	{"flatten_group_by_aggregations", flattenGroupByAggregations},
 	{"reorder_projection", reorderProjection},
 	{"resolve_subquery_exprs", resolveSubqueryExpressions},
+	{"warn_tautological_join_conditions", warnTautologicalJoinConditions},
 	{"move_join_conds_to_filter", moveJoinConditionsToFilter},
+	{"fold_exists_subqueries", foldExistsSubqueries},
+	{"fold_empty_in_lists", foldEmptyInLists},
+	{"simplify_self_in_subqueries", simplifySelfInSubqueries},
+	{"transform_null_equals", transformNullEquals},
+	{"fold_null_checks", foldNullChecks},
+	{"fold_boolean_case", foldBooleanCase},
+	{"push_down_negations", pushDownNegations},
+	{"simplify_impossible_between", simplifyImpossibleBetween},
+	{"isolate_comparison_columns", isolateComparisonColumns},
+	{"simplify_point_ranges", simplifyPointRanges},
+	{"merge_in_lists", mergeInLists},
+	{"fold_inequalities_to_not_in", foldInequalitiesToNotIn},
+	{"fold_session_values", foldSessionValues},
 	{"eval_filter", evalFilter},
+	{"remove_constant_sort_fields", removeConstantSortFields},
 	{"optimize_distinct", optimizeDistinct},
 }
 
@@ -48,14 +64,33 @@ var OnceAfterDefault = []Rule{
 	{"assign_catalog", assignCatalog},
 	{"assign_info_schema", assignInfoSchema},
 	{"prune_columns", pruneColumns},
+	{"warn_non_sargable", warnNonSargable},
+	{"warn_null_rejecting_filters", warnNullRejectingFilters},
+	{"collect_predicate_statistics", collectPredicateStatistics},
+	{"fetch_by_rowid", fetchByRowID},
+	{"unique_key_lookup", uniqueKeyLookup},
+	{"skip_scan", skipScan},
+	{"index_merge", indexMerge},
+	{"ordered_index_scan", orderedIndexScan},
+	{"min_max_index_seek", minMaxIndexSeek},
+	{"prune_list_partitions", pruneListPartitions},
+	{"merge_or_ranges", mergeOrRanges},
 	{"pushdown_filters", pushdownFilters},
+	// Must run after pushdown_filters, which doesn't handle more than one filter over the same table.
+	{"pushdown_groupby_filters", pushdownGroupByFilters},
 	{"pushdown_projections", pushdownProjections},
 	{"optimize_joins", optimizeJoins},
 	{"erase_projection", eraseProjection},
 	// One final pass at analyzing subqueries to handle rewriting field indexes after changes to outer scope by
 	// previous rules.
 	{"resolve_subquery_exprs", resolveSubqueryExpressions},
+	{"convert_not_exists_to_anti_joins", convertNotExistsToAntiJoins},
+	{"fold_outer_constants", foldOuterConstants},
+	{"fold_ordered_scan_bounds", foldOrderedScanBounds},
+	{"hash_in_lists", hashInLists},
 	{"cache_subquery_results", cacheSubqueryResults},
+	{"memoize_in_subqueries", memoizeInSubqueries},
+	{"precompute_collation_keys", precomputeCollationKeys},
 	{"resolve_insert_rows", resolveInsertRows},
 	{"apply_triggers", applyTriggers},
 	{"apply_row_update_accumulators", applyUpdateAccumulators},
@@ -65,10 +100,29 @@ var OnceAfterDefault = []Rule{
 // rules have been applied.
 var OnceAfterAll = []Rule{
 	{"track_process", trackProcess},
+	{"invalidate_compiled_predicates", invalidateCompiledPredicates},
+	{"cache_compiled_predicates", cacheCompiledPredicates},
 	{"parallelize", parallelize},
 	{"clear_warnings", clearWarnings},
 }
 
+// specializingRules are the names of the rules that specialize comparisons, by comparing constants with the types
+// known while analyzing the query, or by replacing comparisons with others made for the types of their operands. They
+// aren't applied when the context disables the specialization of comparisons, so that every comparison of the query is
+// evaluated generically.
+var specializingRules = map[string]bool{
+	"simplify_impossible_between": true,
+	"isolate_comparison_columns":  true,
+	"simplify_point_ranges":       true,
+	"merge_in_lists":              true,
+	"fold_inequalities_to_not_in": true,
+	"merge_or_ranges":             true,
+	"fold_ordered_scan_bounds":    true,
+	"hash_in_lists":               true,
+	"precompute_collation_keys":   true,
+	"cache_compiled_predicates":   true,
+}
+
 var (
 	// ErrFieldMissing is returned when the field is not on the schema.
 	ErrFieldMissing = errors.NewKind("field %q is not on schema")
@@ -0,0 +1,313 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+const (
+	// nonSargableWarning is the message of the warning added for a comparison that cannot use an index because the
+	// indexed column is wrapped in another expression.
+	nonSargableWarning = "predicate %s cannot use an index on %s because the column is wrapped in an expression"
+	// warnCodeNonSargable is the code of the warning for a comparison that cannot use an index, which MySQL doesn't
+	// have a code of its own for.
+	warnCodeNonSargable = 1105
+)
+
+// warnNonSargable adds a warning to the context for every filter predicate that compares an indexed column wrapped
+// in an expression, such as UPPER(col) = 'x' or col + 0 = 5, since such predicates can never be satisfied with an
+// index lookup. Only predicates on tables that no other conjunct of the filter can look up with an index are reported,
+// since rewriting them is otherwise pointless, and each column is reported once per predicate. It's purely advisory:
+// the node is always returned unchanged.
+func warnNonSargable(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("warn_non_sargable")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	var filters [][]sql.Expression
+	plan.Inspect(n, func(node sql.Node) bool {
+		if filter, ok := node.(*plan.Filter); ok {
+			filters = append(filters, splitConjunction(filter.Expression))
+		}
+		return true
+	})
+
+	if len(filters) == 0 {
+		return n, nil
+	}
+
+	ia, err := getIndexesForNode(ctx, a, n)
+	if err != nil {
+		return nil, err
+	}
+
+	tableAliases, err := getTableAliases(n)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, predicates := range filters {
+		indexedTables := make(map[string]bool)
+		for _, p := range predicates {
+			if gf, ok := sargableIndexedColumn(ia, tableAliases, p); ok {
+				indexedTables[strings.ToLower(gf.Table())] = true
+			}
+		}
+
+		for _, p := range predicates {
+			reported := make(map[string]bool)
+			for _, col := range nonSargableColumns(ia, tableAliases, p) {
+				if indexedTables[strings.ToLower(col.Table())] || reported[col.String()] {
+					continue
+				}
+				reported[col.String()] = true
+				ctx.Warn(warnCodeNonSargable, nonSargableWarning, p, col)
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// sargableIndexedColumn returns the indexed column the comparison given compares with constant operands as it is,
+// which makes an index lookup possible, if any.
+func sargableIndexedColumn(ia *indexAnalyzer, tableAliases TableAliases, e sql.Expression) (*expression.GetField, bool) {
+	gf, ok := nonConstantOperand(e).(*expression.GetField)
+	if !ok || !isIndexedColumn(ia, tableAliases, gf) {
+		return nil, false
+	}
+	return gf, true
+}
+
+// nonSargableColumns returns the indexed columns of the comparison given that are buried inside another expression,
+// and so can't be used for an index lookup. Comparisons between two non-constant operands, such as join conditions,
+// are never reported, and neither are comparisons of row constructors, whose columns aren't wrapped.
+func nonSargableColumns(ia *indexAnalyzer, tableAliases TableAliases, e sql.Expression) []*expression.GetField {
+	if not, ok := e.(*expression.Not); ok {
+		return nonSargableColumns(ia, tableAliases, not.Child)
+	}
+
+	operand := nonConstantOperand(e)
+	if operand == nil {
+		return nil
+	}
+
+	switch operand.(type) {
+	case *expression.GetField, expression.Tuple:
+		return nil
+	}
+
+	var cols []*expression.GetField
+	sql.Inspect(operand, func(e sql.Expression) bool {
+		if gf, ok := e.(*expression.GetField); ok && isIndexedColumn(ia, tableAliases, gf) {
+			cols = append(cols, gf)
+		}
+		return true
+	})
+
+	return cols
+}
+
+// nonConstantOperand returns the only operand of the comparison given that isn't constant, or nil if it isn't a
+// comparison with constants.
+func nonConstantOperand(e sql.Expression) sql.Expression {
+	switch e := e.(type) {
+	case expression.Comparer:
+		switch {
+		case isEvaluable(e.Right()):
+			return e.Left()
+		case isEvaluable(e.Left()):
+			return e.Right()
+		}
+	case *expression.Between:
+		if isEvaluable(e.Lower) && isEvaluable(e.Upper) {
+			return e.Val
+		}
+	}
+	return nil
+}
+
+// isIndexedColumn returns whether the column given is part of any index known to the index analyzer.
+func isIndexedColumn(ia *indexAnalyzer, tableAliases TableAliases, gf *expression.GetField) bool {
+	name := normalizeExpression(nil, tableAliases, gf).String()
+	for _, idxes := range ia.indexesByTable {
+		for _, idx := range idxes {
+			for _, e := range idx.Expressions() {
+				if e == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ExtractSargable splits the expression given, such as the condition of a filter, into the comparisons of the columns
+// named that a storage engine can evaluate itself, and the residual expression the SQL layer must still evaluate, which
+// is nil if there isn't any. Only conjuncts that compare a column with literals using =, <, >, <=, >=, BETWEEN or IN
+// are extracted, and only when the literals compare with the values of the column as values of its type. Any other
+// conjunct, such as int_col = 1.5, which is compared as a float, is left in the residual expression. Columns are
+// matched by name without case, and are named in the predicates as they are in cols.
+func ExtractSargable(expr sql.Expression, cols []string) ([]sql.PushablePredicate, sql.Expression) {
+	if expr == nil {
+		return nil, nil
+	}
+
+	var pushable []sql.PushablePredicate
+	var residual []sql.Expression
+	for _, e := range splitConjunction(expr) {
+		if predicates, ok := sargablePredicates(e, cols); ok {
+			pushable = append(pushable, predicates...)
+		} else {
+			residual = append(residual, e)
+		}
+	}
+
+	return pushable, expression.JoinAnd(residual...)
+}
+
+// sargablePredicates returns the pushable predicates equivalent to the expression given, if it can be pushed down.
+func sargablePredicates(e sql.Expression, cols []string) ([]sql.PushablePredicate, bool) {
+	switch e := e.(type) {
+	case *expression.Between:
+		col, ok := sargableColumn(e.Val, cols)
+		if !ok {
+			return nil, false
+		}
+
+		lower, ok := pushableValue(e.Val, e.Lower, expression.NewGreaterThanOrEqual(e.Val, e.Lower))
+		if !ok {
+			return nil, false
+		}
+
+		upper, ok := pushableValue(e.Val, e.Upper, expression.NewLessThanOrEqual(e.Val, e.Upper))
+		if !ok {
+			return nil, false
+		}
+
+		return []sql.PushablePredicate{
+			{Column: col, Operator: sql.PredicateGreaterThanOrEqual, Value: lower},
+			{Column: col, Operator: sql.PredicateLessThanOrEqual, Value: upper},
+		}, true
+	case *expression.InTuple:
+		return sargableInTuple(e, cols)
+	case *expression.HashInTuple:
+		return sargableInTuple(&e.InTuple, cols)
+	case expression.CoercionDescriber:
+		op, ok := predicateOperator(e)
+		if !ok {
+			return nil, false
+		}
+
+		operand, value := e.Left(), e.Right()
+		if _, ok := operand.(*expression.Literal); ok {
+			operand, value = value, operand
+			op = flippedPredicateOperator(op)
+		}
+
+		col, ok := sargableColumn(operand, cols)
+		if !ok {
+			return nil, false
+		}
+
+		v, ok := pushableValue(operand, value, e)
+		if !ok {
+			return nil, false
+		}
+
+		return []sql.PushablePredicate{{Column: col, Operator: op, Value: v}}, true
+	default:
+		return nil, false
+	}
+}
+
+// sargableInTuple returns the pushable predicate equivalent to the IN expression given, if it can be pushed down.
+func sargableInTuple(in *expression.InTuple, cols []string) ([]sql.PushablePredicate, bool) {
+	col, ok := sargableColumn(in.Left(), cols)
+	if !ok {
+		return nil, false
+	}
+
+	tuple, ok := in.Right().(expression.Tuple)
+	if !ok || len(tuple) == 0 {
+		return nil, false
+	}
+
+	values := make([]interface{}, len(tuple))
+	for i, elem := range tuple {
+		v, ok := pushableValue(in.Left(), elem, expression.NewEquals(in.Left(), elem))
+		if !ok {
+			return nil, false
+		}
+		values[i] = v
+	}
+
+	return []sql.PushablePredicate{{Column: col, Operator: sql.PredicateIn, Value: values}}, true
+}
+
+// sargableColumn returns the name in cols of the column the expression given is, if it's one of them.
+func sargableColumn(e sql.Expression, cols []string) (string, bool) {
+	gf, ok := e.(*expression.GetField)
+	if !ok {
+		return "", false
+	}
+
+	for _, col := range cols {
+		if strings.EqualFold(col, gf.Name()) {
+			return col, true
+		}
+	}
+	return "", false
+}
+
+// pushableValue returns the value of the literal given converted to the type of the column given, if the comparison
+// given of them compares the literal with the values of the column as a value of the type of the column. NULL values
+// are never pushed down, since comparisons with them are never true.
+func pushableValue(col, lit sql.Expression, c expression.CoercionDescriber) (interface{}, bool) {
+	l, ok := lit.(*expression.Literal)
+	if !ok || l.Value() == nil {
+		return nil, false
+	}
+
+	return expression.ColumnTypeValue(col.Type(), l, c)
+}
+
+// predicateOperator returns the operator of the pushable predicate equivalent to the comparison given, if any.
+func predicateOperator(c expression.Comparer) (sql.PredicateOperator, bool) {
+	switch c.(type) {
+	case *expression.Equals:
+		return sql.PredicateEquals, true
+	case *expression.LessThan:
+		return sql.PredicateLessThan, true
+	case *expression.GreaterThan:
+		return sql.PredicateGreaterThan, true
+	case *expression.LessThanOrEqual:
+		return sql.PredicateLessThanOrEqual, true
+	case *expression.GreaterThanOrEqual:
+		return sql.PredicateGreaterThanOrEqual, true
+	default:
+		return "", false
+	}
+}
+
+// flippedPredicateOperator returns the operator that compares the operands of the one given swapped.
+func flippedPredicateOperator(op sql.PredicateOperator) sql.PredicateOperator {
+	switch op {
+	case sql.PredicateLessThan:
+		return sql.PredicateGreaterThan
+	case sql.PredicateGreaterThan:
+		return sql.PredicateLessThan
+	case sql.PredicateLessThanOrEqual:
+		return sql.PredicateGreaterThanOrEqual
+	case sql.PredicateGreaterThanOrEqual:
+		return sql.PredicateLessThanOrEqual
+	default:
+		return op
+	}
+}
@@ -0,0 +1,161 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestWarnNonSargable(t *testing.T) {
+	table := memory.NewTable("mytable", sql.Schema{
+		{Name: "name", Type: sql.Text, Source: "mytable", PrimaryKey: true},
+		{Name: "t", Type: sql.Text, Source: "mytable"},
+	})
+	table.EnablePrimaryKeyIndexes()
+
+	name := expression.NewGetFieldWithTable(0, sql.Text, "mytable", "name", false)
+	unindexed := expression.NewGetFieldWithTable(1, sql.Text, "mytable", "t", false)
+	x := expression.NewLiteral("x", sql.LongText)
+
+	testCases := []struct {
+		name     string
+		filter   sql.Expression
+		warnings int
+	}{
+		{"indexed column wrapped in function", expression.NewEquals(function.NewUpper(name), x), 1},
+		{"indexed column wrapped on the right", expression.NewEquals(x, function.NewUpper(name)), 1},
+		{"indexed column wrapped in arithmetic", expression.NewEquals(
+			expression.NewPlus(name, expression.NewLiteral(int64(0), sql.Int64)),
+			expression.NewLiteral(int64(5), sql.Int64),
+		), 1},
+		{"bare indexed column", expression.NewEquals(name, x), 0},
+		{"unindexed column wrapped in function", expression.NewEquals(function.NewUpper(unindexed), x), 0},
+		{"non-constant comparison", expression.NewEquals(function.NewUpper(name), unindexed), 0},
+		{"inside conjunction", expression.NewAnd(
+			expression.NewEquals(unindexed, x),
+			expression.NewNot(expression.NewEquals(function.NewLower(name), x)),
+		), 1},
+		{"conjunction with an index lookup", expression.NewAnd(
+			expression.NewEquals(name, x),
+			expression.NewNot(expression.NewEquals(function.NewLower(name), x)),
+		), 0},
+		{"column wrapped twice", expression.NewEquals(
+			expression.NewPlus(name, name),
+			expression.NewLiteral(int64(5), sql.Int64),
+		), 1},
+		{"row constructor", expression.NewGreaterThan(
+			expression.NewTuple(name, unindexed),
+			expression.NewTuple(x, x),
+		), 0},
+	}
+
+	rule := getRule("warn_non_sargable")
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			node := plan.NewFilter(tt.filter, plan.NewResolvedTable(table))
+			ctx := sql.NewEmptyContext()
+
+			result, err := rule.Apply(ctx, NewDefault(nil), node, nil)
+			require.NoError(err)
+			require.Equal(node, result)
+
+			warnings := ctx.Warnings()
+			require.Len(warnings, tt.warnings)
+			for _, w := range warnings {
+				require.Equal(warnCodeNonSargable, w.Code)
+				require.Contains(w.Message, "mytable.name")
+			}
+		})
+	}
+}
+
+func TestExtractSargable(t *testing.T) {
+	i := expression.NewGetFieldWithTable(0, sql.Int64, "t", "i", true)
+	s := expression.NewGetFieldWithTable(1, sql.LongText, "t", "s", true)
+	f := expression.NewGetFieldWithTable(2, sql.Float64, "t", "f", true)
+	other := expression.NewGetFieldWithTable(3, sql.Int64, "t", "other", true)
+	lit := func(v interface{}, typ sql.Type) sql.Expression {
+		return expression.NewLiteral(v, typ)
+	}
+	cols := []string{"I", "s", "f"}
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		pushable []sql.PushablePredicate
+		residual sql.Expression
+	}{
+		{
+			name: "mixed WHERE",
+			expr: expression.JoinAnd(
+				expression.NewEquals(i, lit(int8(5), sql.Int8)),
+				expression.NewGreaterThan(other, lit(int8(1), sql.Int8)),
+				expression.NewLessThanOrEqual(lit("m", sql.LongText), s),
+				expression.NewOr(
+					expression.NewEquals(i, lit(int8(1), sql.Int8)),
+					expression.NewEquals(i, lit(int8(2), sql.Int8)),
+				),
+				expression.NewBetween(f, lit(1.5, sql.Float64), lit(int8(3), sql.Int8)),
+				expression.NewInTuple(s, expression.NewTuple(lit("a", sql.LongText), lit("b", sql.LongText))),
+				expression.NewEquals(i, lit(1.5, sql.Float64)),
+			),
+			pushable: []sql.PushablePredicate{
+				{Column: "I", Operator: sql.PredicateEquals, Value: int64(5)},
+				{Column: "s", Operator: sql.PredicateGreaterThanOrEqual, Value: "m"},
+				{Column: "f", Operator: sql.PredicateGreaterThanOrEqual, Value: 1.5},
+				{Column: "f", Operator: sql.PredicateLessThanOrEqual, Value: float64(3)},
+				{Column: "s", Operator: sql.PredicateIn, Value: []interface{}{"a", "b"}},
+			},
+			residual: expression.JoinAnd(
+				expression.NewGreaterThan(other, lit(int8(1), sql.Int8)),
+				expression.NewOr(
+					expression.NewEquals(i, lit(int8(1), sql.Int8)),
+					expression.NewEquals(i, lit(int8(2), sql.Int8)),
+				),
+				expression.NewEquals(i, lit(1.5, sql.Float64)),
+			),
+		},
+		{
+			name:     "string compared with an integer column",
+			expr:     expression.NewLessThan(i, lit("5", sql.LongText)),
+			pushable: []sql.PushablePredicate{{Column: "I", Operator: sql.PredicateLessThan, Value: int64(5)}},
+		},
+		{
+			name:     "integer compared with a string column",
+			expr:     expression.NewEquals(s, lit(int8(5), sql.Int8)),
+			residual: expression.NewEquals(s, lit(int8(5), sql.Int8)),
+		},
+		{
+			name:     "comparison with NULL",
+			expr:     expression.NewEquals(i, lit(nil, sql.Null)),
+			residual: expression.NewEquals(i, lit(nil, sql.Null)),
+		},
+		{
+			name:     "comparison of two columns",
+			expr:     expression.NewEquals(i, f),
+			residual: expression.NewEquals(i, f),
+		},
+		{
+			name:     "IN list with an element that can't be pushed down",
+			expr:     expression.NewInTuple(i, expression.NewTuple(lit(int8(1), sql.Int8), lit(2.5, sql.Float64))),
+			residual: expression.NewInTuple(i, expression.NewTuple(lit(int8(1), sql.Int8), lit(2.5, sql.Float64))),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			pushable, residual := ExtractSargable(tt.expr, cols)
+			require.Equal(tt.pushable, pushable)
+			require.Equal(tt.residual, residual)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// simplifySelfInSubqueries replaces the IN subqueries of the filters of a table that look up a column of the table in
+// all the values of the same column, such as x IN (SELECT x FROM t) in a filter of t, with x IS NOT NULL, since any
+// value of the column that isn't NULL is among them. Only the subqueries the filter is a conjunction of are replaced,
+// since the IN is NULL instead of false when the column is, and only those that return the column of every row of the
+// table: a subquery with a filter, a limit or a join is left as it is. Floating point columns are left as they are too,
+// since NaN isn't in any set of values. Under the sql.NullEqualsNull policy, NULL is among the values as well, so the
+// subqueries are replaced with true instead.
+func simplifySelfInSubqueries(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("simplify_self_in_subqueries")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		filter, ok := n.(*plan.Filter)
+		if !ok {
+			return n, nil
+		}
+
+		name, table, ok := filteredTable(filter.Child)
+		if !ok {
+			return n, nil
+		}
+
+		conjuncts := splitConjunction(filter.Expression)
+		changed := false
+		for i, c := range conjuncts {
+			in, ok := c.(*plan.InSubquery)
+			if !ok || !isSelfInSubquery(in, name, table) {
+				continue
+			}
+
+			if sql.NullEqualsNullPolicy(ctx) {
+				a.Log("replacing %s with true", in)
+				conjuncts[i] = expression.NewLiteral(true, sql.Boolean)
+			} else {
+				a.Log("replacing %s with a check of the column not being NULL", in)
+				conjuncts[i] = expression.NewNot(expression.NewIsNull(in.Left))
+			}
+			changed = true
+		}
+
+		if !changed {
+			return n, nil
+		}
+		return plan.NewFilter(expression.JoinAnd(conjuncts...), filter.Child), nil
+	})
+}
+
+// isSelfInSubquery returns whether the IN subquery given looks up a column of the table given, with the name given in
+// the query, in all the values of the same column.
+func isSelfInSubquery(in *plan.InSubquery, name string, table sql.Table) bool {
+	column, ok := in.Left.(*expression.GetField)
+	if !ok || !strings.EqualFold(column.Table(), name) || sql.IsFloat(column.Type()) {
+		return false
+	}
+
+	subquery, ok := in.Right.(*plan.Subquery)
+	if !ok {
+		return false
+	}
+
+	project, ok := unwrapRowPreservingNodes(subquery.Query).(*plan.Project)
+	if !ok || len(project.Projections) != 1 {
+		return false
+	}
+
+	innerName, innerTable, ok := filteredTable(project.Child)
+	if !ok || !sameTable(table, innerTable) {
+		return false
+	}
+
+	field, ok := project.Projections[0].(*expression.GetField)
+	return ok && strings.EqualFold(field.Table(), innerName) && strings.EqualFold(field.Name(), column.Name())
+}
+
+// unwrapRowPreservingNodes returns the first node under the one given that isn't a node returning the distinct values
+// of its child, or all of them in some other order.
+func unwrapRowPreservingNodes(n sql.Node) sql.Node {
+	switch n.(type) {
+	case *plan.Distinct, *plan.OrderedDistinct, *plan.Sort, *plan.QueryProcess, *plan.Exchange:
+		return unwrapRowPreservingNodes(n.Children()[0])
+	default:
+		return n
+	}
+}
+
+// filteredTable returns the table the node given reads all the rows of, and the name it has in the query, if the node
+// is a table or an alias of one.
+func filteredTable(n sql.Node) (string, sql.Table, bool) {
+	switch n := n.(type) {
+	case *plan.ResolvedTable:
+		return n.Name(), n.Table, true
+	case *plan.TableAlias:
+		rt, ok := n.Child.(*plan.ResolvedTable)
+		if !ok {
+			return "", nil, false
+		}
+		return n.Name(), rt.Table, true
+	default:
+		return "", nil, false
+	}
+}
+
+// sameTable returns whether the tables given are the same one, once unwrapped. Tables that can't be compared aren't
+// the same.
+func sameTable(a, b sql.Table) bool {
+	for {
+		w, ok := a.(sql.TableWrapper)
+		if !ok {
+			break
+		}
+		a = w.Underlying()
+	}
+
+	for {
+		w, ok := b.(sql.TableWrapper)
+		if !ok {
+			break
+		}
+		b = w.Underlying()
+	}
+
+	if !reflect.TypeOf(a).Comparable() || !reflect.TypeOf(b).Comparable() {
+		return false
+	}
+	return a == b
+}
@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestSimplifySelfInSubqueries(t *testing.T) {
+	schema := sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "y", Type: sql.Int64, Source: "t", Nullable: true},
+		{Name: "f", Type: sql.Float64, Source: "t", Nullable: true},
+	}
+	t1 := plan.NewResolvedTable(memory.NewTable("t", schema))
+	other := plan.NewResolvedTable(memory.NewTable("t", schema))
+
+	x := expression.NewGetFieldWithTable(0, sql.Int64, "t", "x", true)
+	y := expression.NewGetFieldWithTable(1, sql.Int64, "t", "y", true)
+	f := expression.NewGetFieldWithTable(2, sql.Float64, "t", "f", true)
+	// Fields of the subquery, whose rows are prepended with the outer row
+	innerX := expression.NewGetFieldWithTable(3, sql.Int64, "t", "x", true)
+	innerY := expression.NewGetFieldWithTable(4, sql.Int64, "t", "y", true)
+	innerF := expression.NewGetFieldWithTable(5, sql.Float64, "t", "f", true)
+
+	in := func(left sql.Expression, query sql.Node) sql.Expression {
+		return plan.NewInSubquery(left, plan.NewSubquery(query, "select"))
+	}
+	selectX := plan.NewProject([]sql.Expression{innerX}, t1)
+	xNotNull := expression.NewNot(expression.NewIsNull(x))
+	yIsOne := expression.NewEquals(y, expression.NewLiteral(int64(1), sql.Int64))
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "x IN (SELECT x FROM t)",
+			node:     plan.NewFilter(in(x, selectX), t1),
+			expected: plan.NewFilter(xNotNull, t1),
+		},
+		{
+			name:     "x IN (SELECT DISTINCT x FROM t) AND y = 1",
+			node:     plan.NewFilter(expression.NewAnd(in(x, plan.NewDistinct(selectX)), yIsOne), t1),
+			expected: plan.NewFilter(expression.NewAnd(xNotNull, yIsOne), t1),
+		},
+		{
+			name:     "aliased table",
+			node:     plan.NewFilter(in(expression.NewGetFieldWithTable(0, sql.Int64, "a", "x", true), selectX), plan.NewTableAlias("a", t1)),
+			expected: plan.NewFilter(expression.NewNot(expression.NewIsNull(expression.NewGetFieldWithTable(0, sql.Int64, "a", "x", true))), plan.NewTableAlias("a", t1)),
+		},
+		{
+			name: "other column",
+			node: plan.NewFilter(in(x, plan.NewProject([]sql.Expression{innerY}, t1)), t1),
+		},
+		{
+			name: "other table with the same name",
+			node: plan.NewFilter(in(x, plan.NewProject([]sql.Expression{innerX}, other)), t1),
+		},
+		{
+			name: "filtered subquery",
+			node: plan.NewFilter(in(x, plan.NewProject([]sql.Expression{innerX}, plan.NewFilter(
+				expression.NewEquals(innerY, expression.NewLiteral(int64(1), sql.Int64)),
+				t1,
+			))), t1),
+		},
+		{
+			name: "limited subquery",
+			node: plan.NewFilter(in(x, plan.NewLimit(1, selectX)), t1),
+		},
+		{
+			name: "negated",
+			node: plan.NewFilter(expression.NewNot(in(x, selectX)), t1),
+		},
+		{
+			name: "disjunction",
+			node: plan.NewFilter(expression.NewOr(in(x, selectX), yIsOne), t1),
+		},
+		{
+			name: "floating point column",
+			node: plan.NewFilter(in(f, plan.NewProject([]sql.Expression{innerF}, t1)), t1),
+		},
+	}
+
+	runTestCases(t, nil, tests, NewDefault(nil), getRule("simplify_self_in_subqueries"))
+
+	ctx := sql.NewEmptyContext()
+	ctx.ApplyOpts(sql.WithNullComparisonPolicy(sql.NullEqualsNull))
+	runTestCases(t, ctx, []analyzerFnTestCase{
+		{
+			name:     "NULL equal to NULL",
+			node:     plan.NewFilter(in(x, selectX), t1),
+			expected: plan.NewFilter(expression.NewLiteral(true, sql.Boolean), t1),
+		},
+	}, NewDefault(nil), getRule("simplify_self_in_subqueries"))
+}
@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// skipScanMaxDistinctValues is the maximum number of distinct values the leading column of a composite index can have
+// for the index to be skip scanned. With more of them, looking up the index once for each one isn't any cheaper than
+// scanning the whole table.
+const skipScanMaxDistinctValues = 32
+
+// skipScan replaces a table filtered by equalities on all the columns of a composite index but the leading one with a
+// plan.SkipScan of the index, as long as the statistics of the table show the leading column has few distinct values.
+// Only tables implementing sql.StatisticsTable that are filtered directly are considered, and only when no index can be
+// looked up with the filter as usual. The filter is kept on top of the skip scan.
+func skipScan(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("skip_scan")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		rt, ok := filter.Child.(*plan.ResolvedTable)
+		if !ok {
+			return node, nil
+		}
+
+		table, ok := rt.Table.(sql.StatisticsTable)
+		if !ok {
+			return node, nil
+		}
+
+		values := columnEqualities(table.Name(), splitConjunction(filter.Expression))
+		if len(values) == 0 {
+			return node, nil
+		}
+
+		indexes, err := table.GetIndexes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, idx := range indexes {
+			if _, ok := values[indexColumnNames(idx)[0]]; ok {
+				return node, nil
+			}
+		}
+
+		for _, idx := range indexes {
+			columns := indexColumnNames(idx)
+			if len(columns) < 2 {
+				continue
+			}
+
+			keys := make([]sql.Expression, 0, len(columns)-1)
+			for _, col := range columns[1:] {
+				if v, ok := values[col]; ok {
+					keys = append(keys, v)
+				}
+			}
+			if len(keys) != len(columns)-1 {
+				continue
+			}
+
+			prefixes, ok, err := table.DistinctValues(ctx, columns[0])
+			if err != nil {
+				return nil, err
+			}
+
+			// Rows with a NULL leading column can't be looked up in the index
+			if !ok || len(prefixes) > skipScanMaxDistinctValues || containsNull(prefixes) {
+				continue
+			}
+
+			a.Log("skip scanning index %s of table %s over %d distinct values of %s", idx.ID(), table.Name(), len(prefixes), columns[0])
+			scan, err := plan.NewSkipScan(rt, idx, prefixes, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			return plan.NewFilter(filter.Expression, scan), nil
+		}
+
+		return node, nil
+	})
+}
+
+// columnEqualities returns the constants that columns of the table given are compared for equality with in the
+// predicates given, by lower case column name.
+func columnEqualities(table string, predicates []sql.Expression) map[string]sql.Expression {
+	values := make(map[string]sql.Expression)
+	for _, p := range predicates {
+		eq, ok := p.(*expression.Equals)
+		if !ok {
+			continue
+		}
+
+		left, right := eq.Left(), eq.Right()
+		if _, ok := right.(*expression.GetField); ok {
+			left, right = right, left
+		}
+
+		gf, ok := left.(*expression.GetField)
+		if !ok || !isEvaluable(right) || !strings.EqualFold(gf.Table(), table) {
+			continue
+		}
+
+		values[strings.ToLower(gf.Name())] = right
+	}
+	return values
+}
+
+// indexColumnNames returns the lower case names of the columns of the index given.
+func indexColumnNames(idx sql.Index) []string {
+	exprs := idx.Expressions()
+	columns := make([]string, len(exprs))
+	for i, e := range exprs {
+		columns[i] = strings.ToLower(e[strings.LastIndex(e, ".")+1:])
+	}
+	return columns
+}
+
+func containsNull(values []interface{}) bool {
+	for _, v := range values {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
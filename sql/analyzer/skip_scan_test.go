@@ -0,0 +1,123 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// statsTable is a table that knows the distinct values of its columns.
+type statsTable struct {
+	*memory.Table
+	distinct map[string][]interface{}
+}
+
+var _ sql.StatisticsTable = (*statsTable)(nil)
+
+func (t *statsTable) DistinctValues(ctx *sql.Context, column string) ([]interface{}, bool, error) {
+	values, ok := t.distinct[column]
+	return values, ok, nil
+}
+
+func TestSkipScan(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable"},
+		{Name: "b", Type: sql.Int64, Source: "mytable"},
+		{Name: "c", Type: sql.Int64, Source: "mytable"},
+	}
+	ctx := sql.NewEmptyContext()
+	table := &statsTable{
+		Table:    memory.NewTable("mytable", schema),
+		distinct: map[string][]interface{}{"a": {int64(1), int64(2), int64(3)}},
+	}
+	require.NoError(table.CreateIndex(ctx, "a_b", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{
+		{Name: "a"},
+		{Name: "b"},
+	}, ""))
+	for a := int64(1); a <= 3; a++ {
+		for b := int64(1); b <= 10; b++ {
+			require.NoError(table.Insert(ctx, sql.NewRow(a, b, a*b)))
+		}
+	}
+
+	manyValues := &statsTable{Table: table.Table, distinct: map[string][]interface{}{"a": make([]interface{}, skipScanMaxDistinctValues+1)}}
+	for i := range manyValues.distinct["a"] {
+		manyValues.distinct["a"][i] = int64(i)
+	}
+	noStats := &statsTable{Table: table.Table}
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", false)
+	c := expression.NewGetFieldWithTable(2, sql.Int64, "mytable", "c", false)
+	five := expression.NewLiteral(int64(5), sql.Int64)
+	bIsFive := expression.NewEquals(b, five)
+
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	rt := plan.NewResolvedTable(table)
+	scan, err := plan.NewSkipScan(rt, indexes[0], table.distinct["a"], []sql.Expression{five})
+	require.NoError(err)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "b = 5",
+			node:     plan.NewFilter(bIsFive, rt),
+			expected: plan.NewFilter(bIsFive, scan),
+		},
+		{
+			name:     "5 = b",
+			node:     plan.NewFilter(expression.NewEquals(five, b), rt),
+			expected: plan.NewFilter(expression.NewEquals(five, b), scan),
+		},
+		{
+			name:     "b = 5 and c > 10",
+			node:     plan.NewFilter(expression.NewAnd(bIsFive, expression.NewGreaterThan(c, expression.NewLiteral(int64(10), sql.Int64))), rt),
+			expected: plan.NewFilter(expression.NewAnd(bIsFive, expression.NewGreaterThan(c, expression.NewLiteral(int64(10), sql.Int64))), scan),
+		},
+		{
+			name: "a = 1 and b = 5",
+			node: plan.NewFilter(expression.NewAnd(expression.NewEquals(a, expression.NewLiteral(int64(1), sql.Int64)), bIsFive), rt),
+		},
+		{
+			name: "b > 5",
+			node: plan.NewFilter(expression.NewGreaterThan(b, five), rt),
+		},
+		{
+			name: "b = c",
+			node: plan.NewFilter(expression.NewEquals(b, c), rt),
+		},
+		{
+			name: "b = 5 or c = 5",
+			node: plan.NewFilter(expression.NewOr(bIsFive, expression.NewEquals(c, five)), rt),
+		},
+		{
+			name: "too many distinct values",
+			node: plan.NewFilter(bIsFive, plan.NewResolvedTable(manyValues)),
+		},
+		{
+			name: "unknown distinct values",
+			node: plan.NewFilter(bIsFive, plan.NewResolvedTable(noStats)),
+		},
+		{
+			name: "memory table",
+			node: plan.NewFilter(bIsFive, plan.NewResolvedTable(table.Table)),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("skip_scan"))
+
+	rows, err := sql.NodeToRows(ctx, plan.NewFilter(bIsFive, scan))
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		sql.NewRow(int64(1), int64(5), int64(5)),
+		sql.NewRow(int64(2), int64(5), int64(10)),
+		sql.NewRow(int64(3), int64(5), int64(15)),
+	}, rows)
+}
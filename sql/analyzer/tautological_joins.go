@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// tautologicalJoinWarning is the message of the warning added for a join condition that is always true.
+const tautologicalJoinWarning = "join condition %s is always true, which makes the join a cross join"
+
+// oneSidedJoinWarning is the message of the warning added for a join condition that only references the tables of
+// one side of the join.
+const oneSidedJoinWarning = "join condition %s only references one side of the join, which makes it a cross join of the rows it matches"
+
+// warnTautologicalJoinConditions adds a warning to the context for every join whose condition doesn't relate the
+// tables it joins, either because it's always true, as 1 = 1 or t1.a = t1.a are, or because it only references the
+// tables of one side of the join. Such joins are cross joins, which usually isn't intended. It runs before
+// move_join_conds_to_filter, which moves those conditions out of inner joins, and warns about each condition once
+// however many times it runs. It's purely advisory: the node is always returned unchanged.
+func warnTautologicalJoinConditions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("warn_tautological_join_conditions")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	plan.Inspect(n, func(node sql.Node) bool {
+		var join plan.BinaryNode
+		var cond sql.Expression
+		switch node := node.(type) {
+		case *plan.InnerJoin:
+			join, cond = node.BinaryNode, node.Cond
+		case *plan.LeftJoin:
+			join, cond = node.BinaryNode, node.Cond
+		case *plan.RightJoin:
+			join, cond = node.BinaryNode, node.Cond
+		default:
+			return true
+		}
+
+		if isTautological(ctx, cond) {
+			warnOnce(ctx, tautologicalJoinWarning, cond)
+		} else if sources := expressionSources(cond); len(sources) > 0 &&
+			(containsSources(nodeSources(join.Left), sources) || containsSources(nodeSources(join.Right), sources)) {
+			warnOnce(ctx, oneSidedJoinWarning, cond)
+		}
+
+		return true
+	})
+
+	return n, nil
+}
+
+// isTautological returns whether the condition given is always true, leaving aside NULL values, which is the case of
+// deterministic conditions without columns that evaluate to true, and of comparisons of an expression with itself
+// that are true for equal values. A conjunction is tautological if all its parts are.
+func isTautological(ctx *sql.Context, cond sql.Expression) bool {
+	if !isDeterministic(cond) {
+		return false
+	}
+
+	for _, e := range splitConjunction(cond) {
+		switch e := e.(type) {
+		case *expression.Equals, *expression.GreaterThanOrEqual, *expression.LessThanOrEqual:
+			c := e.(expression.Comparer)
+			if !reflect.DeepEqual(c.Left(), c.Right()) {
+				return false
+			}
+		default:
+			if !isEvaluable(e) {
+				return false
+			}
+
+			if ok, err := sql.EvaluateCondition(ctx, e, nil); err != nil || !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// warnOnce adds the warning given to the context unless the session already has it, so that rules that run more than
+// once over the same query don't repeat their warnings.
+func warnOnce(ctx *sql.Context, msg string, args ...interface{}) {
+	message := fmt.Sprintf(msg, args...)
+	for _, w := range ctx.Session.Warnings() {
+		if w.Message == message {
+			return
+		}
+	}
+
+	ctx.Warn(0, "%s", message)
+}
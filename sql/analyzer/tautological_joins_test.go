@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestWarnTautologicalJoinConditions(t *testing.T) {
+	t1 := plan.NewResolvedTable(memory.NewTable("t1", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "t1"},
+	}))
+	t2 := plan.NewResolvedTable(memory.NewTable("t2", sql.Schema{
+		{Name: "b", Type: sql.Int64, Source: "t2"},
+	}))
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "t2", "b", false)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+	rand, err := function.NewRand()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		warnings int
+	}{
+		{
+			"equi-join",
+			plan.NewInnerJoin(t1, t2, expression.NewEquals(a, b)),
+			0,
+		},
+		{
+			"constant true condition",
+			plan.NewInnerJoin(t1, t2, expression.NewEquals(one, one)),
+			1,
+		},
+		{
+			"column compared with itself",
+			plan.NewLeftJoin(t1, t2, expression.NewEquals(a, a)),
+			1,
+		},
+		{
+			"condition on the right side only",
+			plan.NewRightJoin(t1, t2, expression.NewGreaterThan(b, one)),
+			1,
+		},
+		{
+			"tautology in conjunction with an equi-join",
+			plan.NewInnerJoin(t1, t2, expression.NewAnd(expression.NewEquals(one, one), expression.NewEquals(a, b))),
+			0,
+		},
+		{
+			"constant false condition",
+			plan.NewInnerJoin(t1, t2, expression.NewEquals(one, two)),
+			0,
+		},
+		{
+			"nondeterministic condition",
+			plan.NewInnerJoin(t1, t2, expression.NewLessThanOrEqual(rand, rand)),
+			0,
+		},
+	}
+
+	rule := getRule("warn_tautological_join_conditions")
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			result, err := rule.Apply(ctx, NewDefault(nil), tt.node, nil)
+			require.NoError(err)
+			require.Equal(tt.node, result)
+
+			// Running the rule again doesn't repeat the warnings
+			_, err = rule.Apply(ctx, NewDefault(nil), tt.node, nil)
+			require.NoError(err)
+			require.Len(ctx.Warnings(), tt.warnings)
+		})
+	}
+}
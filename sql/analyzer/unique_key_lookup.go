@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// uniqueKeyLookup replaces a table filtered by equalities on all the columns of a unique index, such as its primary
+// key, with a plan.UniqueKeyLookup of the single row they can match. The rest of the filter, if any, is kept on top of
+// the lookup to be checked against that row only, instead of being used to plan range scans. Only tables implementing
+// sql.IndexedTable and sql.IndexAddressableTable that are filtered directly are considered, and only equalities between columns and
+// literals of the same kind, both strings or neither, that are compared as values of the type of the column and convert
+// to it exactly, since otherwise, as with int_col = 3.5, they can match values other than the one looked up. Binary
+// strings compared with text columns are left out as well.
+func uniqueKeyLookup(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("unique_key_lookup")
+	defer span.Finish()
+
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok {
+			return node, nil
+		}
+
+		rt, ok := filter.Child.(*plan.ResolvedTable)
+		if !ok {
+			return node, nil
+		}
+
+		table, ok := rt.Table.(sql.IndexedTable)
+		if !ok {
+			return node, nil
+		}
+
+		if _, ok := rt.Table.(sql.IndexAddressableTable); !ok {
+			return node, nil
+		}
+
+		predicates := splitConjunction(filter.Expression)
+		values := columnEqualities(table.Name(), predicates)
+		if len(values) == 0 {
+			return node, nil
+		}
+
+		indexes, err := table.GetIndexes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, idx := range indexes {
+			if !idx.IsUnique() {
+				continue
+			}
+
+			columns := indexColumnNames(idx)
+			keys := make([]sql.Expression, 0, len(columns))
+			for _, col := range columns {
+				v, ok := values[col]
+				if !ok {
+					break
+				}
+
+				key, ok := exactKey(table.Schema(), table.Name(), col, v)
+				if !ok {
+					break
+				}
+				keys = append(keys, key)
+			}
+			if len(keys) != len(columns) {
+				continue
+			}
+
+			a.Log("looking up the single row of table %s with unique index %s", table.Name(), idx.ID())
+			lookup, err := plan.NewUniqueKeyLookup(rt, idx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			rest := residualPredicates(table.Name(), values, columns, predicates)
+			if len(rest) == 0 {
+				return lookup, nil
+			}
+
+			return plan.NewFilter(expression.JoinAnd(rest...), lookup), nil
+		}
+
+		return node, nil
+	})
+}
+
+// exactKey returns the key given as a literal of the type of the column given of the schema given, if it's a literal of
+// the same kind as the column, both strings or neither, that an equality with the column compares as a value of the
+// type of the column, and that converts to it without losing anything. A binary string compared with a text column,
+// which the index would look up ignoring its case, isn't an exact key either.
+func exactKey(schema sql.Schema, table, column string, key sql.Expression) (sql.Expression, bool) {
+	idx := schema.IndexOf(column, table)
+	if idx < 0 {
+		return nil, false
+	}
+
+	col := schema[idx]
+	lit, ok := key.(*expression.Literal)
+	if !ok || sql.IsText(col.Type) != sql.IsText(lit.Type()) || (sql.IsTextOnly(col.Type) && sql.IsBlob(lit.Type())) {
+		return nil, false
+	}
+
+	// No row is equal to NULL, which the lookup finds no row for
+	if lit.Value() == nil {
+		return lit, true
+	}
+
+	gf := expression.NewGetFieldWithTable(idx, col.Type, table, col.Name, col.Nullable)
+	v, ok := expression.ColumnTypeValue(col.Type, lit, expression.NewEquals(gf, lit))
+	if !ok {
+		return nil, false
+	}
+
+	return expression.NewLiteral(v, col.Type), true
+}
+
+// residualPredicates returns the predicates given but the equalities of the columns given of the table given with the
+// values given, which are matched by looking them up in an index. Any other equality of the columns is kept.
+func residualPredicates(table string, values map[string]sql.Expression, columns []string, predicates []sql.Expression) []sql.Expression {
+	var rest []sql.Expression
+	for _, p := range predicates {
+		matched := false
+		for col, v := range columnEqualities(table, []sql.Expression{p}) {
+			matched = stringContains(columns, col) && v == values[col]
+		}
+
+		if !matched {
+			rest = append(rest, p)
+		}
+	}
+	return rest
+}
@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestUniqueKeyLookup(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable", PrimaryKey: true},
+		{Name: "b", Type: sql.Int64, Source: "mytable"},
+		{Name: "c", Type: sql.Text, Source: "mytable"},
+	}
+	ctx := sql.NewEmptyContext()
+	table := memory.NewTable("mytable", schema)
+	table.EnablePrimaryKeyIndexes()
+	require.NoError(table.CreateIndex(ctx, "b", sql.IndexUsing_Default, sql.IndexConstraint_Unique, []sql.IndexColumn{
+		{Name: "b"},
+	}, ""))
+	require.NoError(table.CreateIndex(ctx, "c", sql.IndexUsing_Default, sql.IndexConstraint_None, []sql.IndexColumn{
+		{Name: "c"},
+	}, ""))
+	for i := int64(1); i <= 3; i++ {
+		require.NoError(table.Insert(ctx, sql.NewRow(i, i*10, "row")))
+	}
+
+	indexes := make(map[string]sql.Index)
+	all, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	for _, idx := range all {
+		indexes[idx.ID()] = idx
+	}
+
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", false)
+	c := expression.NewGetFieldWithTable(2, sql.Text, "mytable", "c", false)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+	ten := expression.NewLiteral(int64(10), sql.Int64)
+	cIsNotFoo := expression.NewNot(expression.NewEquals(c, expression.NewLiteral("foo", sql.LongText)))
+
+	rt := plan.NewResolvedTable(table)
+	byA, err := plan.NewUniqueKeyLookup(rt, indexes["PRIMARY"], []sql.Expression{two})
+	require.NoError(err)
+	byB, err := plan.NewUniqueKeyLookup(rt, indexes["b"], []sql.Expression{ten})
+	require.NoError(err)
+
+	tests := []analyzerFnTestCase{
+		{
+			name:     "a = 2",
+			node:     plan.NewFilter(expression.NewEquals(a, two), rt),
+			expected: byA,
+		},
+		{
+			name:     "2 = a",
+			node:     plan.NewFilter(expression.NewEquals(two, a), rt),
+			expected: byA,
+		},
+		{
+			name:     "a = 2 and c <> 'foo'",
+			node:     plan.NewFilter(expression.NewAnd(expression.NewEquals(a, two), cIsNotFoo), rt),
+			expected: plan.NewFilter(cIsNotFoo, byA),
+		},
+		{
+			name:     "a = 1 and a = 2",
+			node:     plan.NewFilter(expression.NewAnd(expression.NewEquals(a, one), expression.NewEquals(a, two)), rt),
+			expected: plan.NewFilter(expression.NewEquals(a, one), byA),
+		},
+		{
+			name:     "b = 10",
+			node:     plan.NewFilter(expression.NewEquals(b, ten), rt),
+			expected: byB,
+		},
+		{
+			name: "a = '2'",
+			node: plan.NewFilter(expression.NewEquals(a, expression.NewLiteral("2", sql.LongText)), rt),
+		},
+		{
+			name:     "a = 2.0",
+			node:     plan.NewFilter(expression.NewEquals(a, expression.NewLiteral(2.0, sql.Float64)), rt),
+			expected: byA,
+		},
+		{
+			name:     "a = 2 as a smaller integer",
+			node:     plan.NewFilter(expression.NewEquals(a, expression.NewLiteral(int8(2), sql.Int8)), rt),
+			expected: byA,
+		},
+		{
+			name: "a = 2.5",
+			node: plan.NewFilter(expression.NewEquals(a, expression.NewLiteral(2.5, sql.Float64)), rt),
+		},
+		{
+			name: "a = 1 + 1",
+			node: plan.NewFilter(expression.NewEquals(a, expression.NewArithmetic(one, one, "+")), rt),
+		},
+		{
+			name: "a > 2",
+			node: plan.NewFilter(expression.NewGreaterThan(a, two), rt),
+		},
+		{
+			name: "a = b",
+			node: plan.NewFilter(expression.NewEquals(a, b), rt),
+		},
+		{
+			name: "a = 2 or b = 10",
+			node: plan.NewFilter(expression.NewOr(expression.NewEquals(a, two), expression.NewEquals(b, ten)), rt),
+		},
+		{
+			name: "c = 'row', which is not unique",
+			node: plan.NewFilter(expression.NewEquals(c, expression.NewLiteral("row", sql.LongText)), rt),
+		},
+	}
+
+	runTestCases(t, ctx, tests, NewDefault(nil), getRule("unique_key_lookup"))
+
+	rows, err := sql.NodeToRows(ctx, byA)
+	require.NoError(err)
+	require.Equal([]sql.Row{sql.NewRow(int64(2), int64(20), "row")}, rows)
+
+	rows, err = sql.NodeToRows(ctx, plan.NewFilter(cIsNotFoo, byB))
+	require.NoError(err)
+	require.Equal([]sql.Row{sql.NewRow(int64(1), int64(10), "row")}, rows)
+
+	missing, err := plan.NewUniqueKeyLookup(rt, indexes["PRIMARY"], []sql.Expression{expression.NewLiteral(int64(4), sql.Int64)})
+	require.NoError(err)
+	rows, err = sql.NodeToRows(ctx, missing)
+	require.NoError(err)
+	require.Empty(rows)
+
+	_, err = plan.NewUniqueKeyLookup(rt, indexes["c"], []sql.Expression{expression.NewLiteral("row", sql.LongText)})
+	require.True(plan.ErrIndexNotUnique.Is(err))
+}
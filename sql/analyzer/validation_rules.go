@@ -25,6 +25,7 @@ const (
 	validateExplodeUsageRule      = "validate_explode_usage"
 	validateSubqueryColumnsRule   = "validate_subquery_columns"
 	validateUnionSchemasMatchRule = "validate_union_schemas_match"
+	validateOrderableTypesRule    = "validate_orderable_types"
 )
 
 var (
@@ -80,6 +81,10 @@ var (
 	ErrUnionSchemasMatch = errors.NewKind(
 		"the schema of the left side of union does not match the right side, expected %s to match %s",
 	)
+
+	// ErrUnorderableType is returned when the values of a type that has no order are compared with an ordering
+	// operator or sorted.
+	ErrUnorderableType = errors.NewKind("values of type %s can't be ordered, as %s requires")
 )
 
 // DefaultValidationRules to apply while analyzing nodes.
@@ -95,6 +100,7 @@ var DefaultValidationRules = []Rule{
 	{validateExplodeUsageRule, validateExplodeUsage},
 	{validateSubqueryColumnsRule, validateSubqueryColumns},
 	{validateUnionSchemasMatchRule, validateUnionSchemasMatch},
+	{validateOrderableTypesRule, validateOrderableTypes},
 }
 
 func validateIsResolved(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
@@ -356,6 +362,61 @@ func validateCaseResultTypes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *S
 	return n, nil
 }
 
+// validateOrderableTypes returns an error if the node given compares values of a type that isn't orderable with <, >,
+// <=, >= or BETWEEN, or sorts them.
+func validateOrderableTypes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("validate_orderable_types")
+	defer span.Finish()
+
+	var err error
+	plan.Inspect(n, func(node sql.Node) bool {
+		sort, ok := node.(*plan.Sort)
+		if !ok {
+			return true
+		}
+
+		for _, field := range sort.SortFields {
+			if typ := field.Column.Type(); !sql.IsOrderable(typ) {
+				err = ErrUnorderableType.New(typ, "ORDER BY "+field.Column.String())
+				return false
+			}
+		}
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	plan.InspectExpressions(n, func(e sql.Expression) bool {
+		var operands []sql.Expression
+		switch e := e.(type) {
+		case *expression.LessThan, *expression.GreaterThan, *expression.LessThanOrEqual,
+			*expression.GreaterThanOrEqual:
+			cmp := e.(expression.Comparer)
+			operands = []sql.Expression{cmp.Left(), cmp.Right()}
+		case *expression.Between:
+			operands = []sql.Expression{e.Val, e.Lower, e.Upper}
+		default:
+			return true
+		}
+
+		for _, operand := range operands {
+			if typ := operand.Type(); !sql.IsOrderable(typ) {
+				err = ErrUnorderableType.New(typ, e)
+				return false
+			}
+		}
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
 func validateIntervalUsage(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	var invalid bool
 	plan.InspectExpressions(n, func(e sql.Expression) bool {
@@ -412,9 +473,14 @@ func validateExplodeUsage(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scop
 
 func validateSubqueryColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 
-	// First validate that every subquery expression returns a single column
+	// First validate that every subquery expression returns a single column, except the ones of EXISTS, which only
+	// check whether there are rows
 	valid := true
 	plan.InspectExpressions(n, func(e sql.Expression) bool {
+		if _, ok := e.(*plan.ExistsSubquery); ok {
+			return false
+		}
+
 		s, ok := e.(*plan.Subquery)
 		if ok && len(s.Query.Schema()) != 1 {
 			valid = false
@@ -3,6 +3,9 @@ package analyzer
 import (
 	"testing"
 
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -890,6 +893,83 @@ func TestValidateSubqueryColumns(t *testing.T) {
 
 }
 
+func TestValidateOrderableTypes(t *testing.T) {
+	rule := getValidationRule(validateOrderableTypesRule)
+	orderable := expression.NewGetField(0, sql.JSON, "doc", false)
+	unorderable := expression.NewGetField(1, unorderableType{sql.Blob}, "shape", false)
+	value := expression.NewLiteral("foo", sql.LongText)
+
+	testCases := []struct {
+		name string
+		node sql.Node
+		ok   bool
+	}{
+		{
+			"less than of orderable type",
+			plan.NewFilter(expression.NewLessThan(orderable, value), plan.NewResolvedTable(dualTable)),
+			true,
+		},
+		{
+			"less than of unorderable type",
+			plan.NewFilter(expression.NewLessThan(unorderable, value), plan.NewResolvedTable(dualTable)),
+			false,
+		},
+		{
+			"greater than or equal of unorderable type",
+			plan.NewFilter(expression.NewGreaterThanOrEqual(value, unorderable), plan.NewResolvedTable(dualTable)),
+			false,
+		},
+		{
+			"between of unorderable type",
+			plan.NewFilter(expression.NewBetween(unorderable, value, value), plan.NewResolvedTable(dualTable)),
+			false,
+		},
+		{
+			"equality of unorderable type",
+			plan.NewFilter(expression.NewEquals(unorderable, value), plan.NewResolvedTable(dualTable)),
+			true,
+		},
+		{
+			"order by orderable type",
+			plan.NewSort([]plan.SortField{{Column: orderable}}, plan.NewResolvedTable(dualTable)),
+			true,
+		},
+		{
+			"order by unorderable type",
+			plan.NewSort([]plan.SortField{{Column: unorderable}}, plan.NewResolvedTable(dualTable)),
+			false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			_, err := rule.Apply(sql.NewEmptyContext(), nil, tt.node, nil)
+
+			if tt.ok {
+				require.NoError(err)
+			} else {
+				require.Error(err)
+				require.True(ErrUnorderableType.Is(err))
+			}
+		})
+	}
+}
+
+// unorderableType is a type whose values can't be ordered.
+type unorderableType struct{ base sql.Type }
+
+func (t unorderableType) Compare(a, b interface{}) (int, error)      { return t.base.Compare(a, b) }
+func (t unorderableType) Convert(v interface{}) (interface{}, error) { return t.base.Convert(v) }
+func (t unorderableType) MustConvert(v interface{}) interface{}      { return t.base.MustConvert(v) }
+func (t unorderableType) Promote() sql.Type                          { return t }
+func (t unorderableType) SQL(v interface{}) (sqltypes.Value, error)  { return t.base.SQL(v) }
+func (t unorderableType) Type() query.Type                           { return t.base.Type() }
+func (t unorderableType) Zero() interface{}                          { return t.base.Zero() }
+func (t unorderableType) String() string                             { return "SHAPE" }
+
+func (unorderableType) Orderable() bool { return false }
+
 type dummyNode struct{ resolved bool }
 
 func (n dummyNode) String() string                                   { return "dummynode" }
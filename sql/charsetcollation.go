@@ -2,6 +2,8 @@ package sql
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
 
 	"gopkg.in/src-d/go-errors.v1"
 )
@@ -1166,6 +1168,7 @@ var (
 
 	ErrCharacterSetNotSupported = errors.NewKind("Unknown character set: %v")
 	ErrCollationNotSupported    = errors.NewKind("Unknown collation: %v")
+	ErrCollationIllegalMix      = errors.NewKind("Illegal mix of collations (%v,%s) and (%v,%s) for comparison")
 )
 
 const (
@@ -1272,6 +1275,48 @@ func (cs CharacterSet) MaxLength() int64 {
 	return length
 }
 
+// IsUnicode returns whether the CharacterSet encodes Unicode, so that it can encode the characters of any other one.
+func (cs CharacterSet) IsUnicode() bool {
+	switch cs {
+	case CharacterSet_ucs2, CharacterSet_utf16, CharacterSet_utf16le, CharacterSet_utf32, CharacterSet_utf8,
+		CharacterSet_utf8mb3, CharacterSet_utf8mb4:
+		return true
+	default:
+		return false
+	}
+}
+
+// Encodes returns whether all the characters of the string given can be encoded in the CharacterSet, which is whether
+// the string can be converted to it without loss. Only the repertoires of the Unicode character sets, ascii and latin1
+// are known, so a string is assumed to be encodable in any other character set.
+func (cs CharacterSet) Encodes(s string) bool {
+	for _, r := range s {
+		switch cs {
+		case CharacterSet_ascii:
+			if r > unicode.MaxASCII {
+				return false
+			}
+		case CharacterSet_latin1:
+			if r > unicode.MaxLatin1 && !cp1252Runes[r] {
+				return false
+			}
+		case CharacterSet_ucs2, CharacterSet_utf8, CharacterSet_utf8mb3:
+			if r > 0xFFFF {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// cp1252Runes are the characters that latin1, which is actually cp1252, encodes in the bytes that ISO-8859-1 leaves for
+// control characters.
+var cp1252Runes = map[rune]bool{
+	'€': true, '‚': true, 'ƒ': true, '„': true, '…': true, '†': true, '‡': true, 'ˆ': true, '‰': true, 'Š': true,
+	'‹': true, 'Œ': true, 'Ž': true, '‘': true, '’': true, '“': true, '”': true, '•': true, '–': true, '—': true,
+	'˜': true, '™': true, 'š': true, '›': true, 'œ': true, 'ž': true, 'Ÿ': true,
+}
+
 // String returns the string representation of the CharacterSet.
 func (cs CharacterSet) String() string {
 	return string(cs)
@@ -1341,3 +1386,8 @@ func (c Collation) PadSpace() string {
 	}
 	return s.PadSpace
 }
+
+// IsCaseInsensitive returns whether the collation compares strings without regard to their case.
+func (c Collation) IsCaseInsensitive() bool {
+	return strings.HasSuffix(string(c), "_ci")
+}
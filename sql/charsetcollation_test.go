@@ -55,3 +55,27 @@ func testParseCollation(t *testing.T, charset *string, collation *string, binary
 		}
 	})
 }
+
+func TestCharacterSetEncodes(t *testing.T) {
+	tests := []struct {
+		charset  CharacterSet
+		s        string
+		expected bool
+	}{
+		{CharacterSet_ascii, "cafe", true},
+		{CharacterSet_ascii, "café", false},
+		{CharacterSet_latin1, "café", true},
+		{CharacterSet_latin1, "€5", true},
+		{CharacterSet_latin1, "caf☕", false},
+		{CharacterSet_utf8mb3, "caf☕", true},
+		{CharacterSet_utf8mb3, "caf😀", false},
+		{CharacterSet_utf8mb4, "caf😀", true},
+		{CharacterSet_binary, "caf😀", true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.charset, test.s), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.charset.Encodes(test.s))
+		})
+	}
+}
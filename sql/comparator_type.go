@@ -0,0 +1,113 @@
+package sql
+
+import (
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrIncomparable is returned by the Compare method of a type with a partial order when the values given are
+// incomparable: neither of them is less than, equal to or greater than the other.
+var ErrIncomparable = errors.NewKind("values are incomparable: %v and %v")
+
+// Comparator compares two values of a type that aren't NULL. The result is 0 if a == b, -1 if a < b, and +1 if a > b.
+type Comparator func(a, b interface{}) (int, error)
+
+// PartialComparator compares two values of a type with a partial order that aren't NULL, such as version ranges. The
+// result is like that of a Comparator if the values are comparable, which the boolean returned reports.
+type PartialComparator func(a, b interface{}) (int, bool, error)
+
+// comparatorType is a type whose values are compared with a custom comparator instead of the Compare method of the
+// type it wraps, which is used for everything else.
+type comparatorType struct {
+	base       Type
+	comparator Comparator
+}
+
+var _ Type = (*comparatorType)(nil)
+
+// WithComparator returns a type like the one given whose values are compared with the comparator given. A column
+// declared with such a type in the schema of a table is compared with the comparator in all the comparisons and sorts
+// it's part of, whatever it's compared with, so that integrators can give opaque values, such as serialized ones, an
+// order of their own without implementing a whole type.
+func WithComparator(t Type, c Comparator) Type {
+	return &comparatorType{base: t, comparator: c}
+}
+
+// WithPartialComparator returns a type like the one given whose values are compared with the partial comparator
+// given, like WithComparator. Comparing two incomparable values returns ErrIncomparable, so that ordering comparisons
+// of them are NULL, equalities false, and sorts leave them in any order.
+func WithPartialComparator(t Type, c PartialComparator) Type {
+	return &comparatorType{base: t, comparator: func(a, b interface{}) (int, error) {
+		cmp, ok, err := c(a, b)
+		if err != nil {
+			return 0, err
+		}
+
+		if !ok {
+			return 0, ErrIncomparable.New(a, b)
+		}
+
+		return cmp, nil
+	}}
+}
+
+// HasComparator returns whether the type given compares its values with a custom comparator.
+func HasComparator(t Type) bool {
+	_, ok := t.(*comparatorType)
+	return ok
+}
+
+// Compare implements Type interface. Values are converted to the type wrapped before comparing them.
+func (t *comparatorType) Compare(a, b interface{}) (int, error) {
+	if hasNulls, res := compareNulls(a, b); hasNulls {
+		return res, nil
+	}
+
+	a, err := t.base.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+
+	b, err = t.base.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.comparator(a, b)
+}
+
+// Convert implements Type interface.
+func (t *comparatorType) Convert(v interface{}) (interface{}, error) {
+	return t.base.Convert(v)
+}
+
+// MustConvert implements Type interface.
+func (t *comparatorType) MustConvert(v interface{}) interface{} {
+	return t.base.MustConvert(v)
+}
+
+// Promote implements Type interface. Promoting the type keeps its comparator.
+func (t *comparatorType) Promote() Type {
+	return t
+}
+
+// SQL implements Type interface.
+func (t *comparatorType) SQL(v interface{}) (sqltypes.Value, error) {
+	return t.base.SQL(v)
+}
+
+// Type implements Type interface.
+func (t *comparatorType) Type() query.Type {
+	return t.base.Type()
+}
+
+// Zero implements Type interface.
+func (t *comparatorType) Zero() interface{} {
+	return t.base.Zero()
+}
+
+// String implements Type interface.
+func (t *comparatorType) String() string {
+	return t.base.String()
+}
@@ -207,6 +207,37 @@ type FilteredTable interface {
 	Filters() []Expression
 }
 
+// PredicateOperator is the operator of a PushablePredicate.
+type PredicateOperator string
+
+const (
+	// PredicateEquals is satisfied by the values of the column equal to the value of the predicate.
+	PredicateEquals PredicateOperator = "="
+	// PredicateLessThan is satisfied by the values of the column less than the value of the predicate.
+	PredicateLessThan PredicateOperator = "<"
+	// PredicateGreaterThan is satisfied by the values of the column greater than the value of the predicate.
+	PredicateGreaterThan PredicateOperator = ">"
+	// PredicateLessThanOrEqual is satisfied by the values of the column less than or equal to the value of the
+	// predicate.
+	PredicateLessThanOrEqual PredicateOperator = "<="
+	// PredicateGreaterThanOrEqual is satisfied by the values of the column greater than or equal to the value of the
+	// predicate.
+	PredicateGreaterThanOrEqual PredicateOperator = ">="
+	// PredicateIn is satisfied by the values of the column equal to any of the values of the predicate, whose value
+	// is a []interface{}.
+	PredicateIn PredicateOperator = "IN"
+)
+
+// PushablePredicate is a comparison of a column with a constant value that a storage engine can evaluate itself, such
+// as when reading a table, instead of leaving it to the SQL layer. The value is of the type of the column, and compares
+// with its values like the comparison it comes from does, so it can be compared with the Compare method of the type.
+// NULL values are never satisfied by any predicate.
+type PushablePredicate struct {
+	Column   string
+	Operator PredicateOperator
+	Value    interface{}
+}
+
 // ProjectedTable is a table that can produce a specific RowIter
 // that's more optimized given the columns that are projected.
 type ProjectedTable interface {
@@ -259,6 +290,40 @@ type IndexAddressableTable interface {
 	WithIndexLookup(IndexLookup) Table
 }
 
+// RowIDTable is a table that exposes an internal row id pseudo-column, such as a storage rowid or an auto increment
+// key, which can be used to fetch a single row directly instead of scanning the table.
+type RowIDTable interface {
+	Table
+	// RowIDColumn returns the name of the row id pseudo-column of this table.
+	RowIDColumn() string
+	// RowByID returns the row with the given id, or a nil row if there is no such row.
+	RowByID(ctx *Context, id interface{}) (Row, error)
+}
+
+// StatisticsTable is a table with indexes that keeps statistics on the values of its columns, which can be used to
+// choose how to read it.
+type StatisticsTable interface {
+	IndexedTable
+	// DistinctValues returns the distinct values of the column with the name given, or false if they aren't known.
+	DistinctValues(ctx *Context, column string) ([]interface{}, bool, error)
+}
+
+// ListPartitionedTable is a table partitioned by the values of one of its columns, as with PARTITION BY LIST, so that
+// all the rows with a value of the column are in the one partition whose list of values has it.
+type ListPartitionedTable interface {
+	Table
+	// PartitionColumn returns the name of the column the table is partitioned by.
+	PartitionColumn() string
+	// ListPartitions returns all the partitions of the table along with their lists of values.
+	ListPartitions(ctx *Context) ([]ListPartition, error)
+}
+
+// ListPartition is a partition of a ListPartitionedTable with the list of values of the partition column its rows have.
+type ListPartition struct {
+	Partition
+	Values []interface{}
+}
+
 // IndexAlterableTable represents a table that supports index modification operations.
 type IndexAlterableTable interface {
 	Table
@@ -0,0 +1,153 @@
+package sql
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrCollationAlreadyExists is returned when registering a custom collation with the name of a built in one.
+var ErrCollationAlreadyExists = errors.NewKind("collation %s already exists")
+
+// CollationWeight returns the weight of a character in a custom collation. Strings are ordered by the weights of their
+// characters, and characters with the same weight compare as equal.
+type CollationWeight func(r rune) int
+
+// customCollations are the collations registered with RegisterCollation, by their name.
+var customCollations = struct {
+	sync.RWMutex
+	weights map[Collation]CollationWeight
+}{weights: make(map[Collation]CollationWeight)}
+
+// RegisterCollation registers a custom collation with the name given for a character set, whose strings are compared
+// with the weights of their characters. Once registered, it can be used like any built in collation, such as in the
+// COLLATE clause of a column definition. Registering a custom collation again replaces its weights. Since the names of
+// collations are looked up without synchronization, custom collations must be registered before the engine is used.
+func RegisterCollation(name string, charset CharacterSet, weight CollationWeight) (Collation, error) {
+	customCollations.Lock()
+	defer customCollations.Unlock()
+
+	c := Collation(name)
+	if _, ok := collations[name]; ok {
+		if _, custom := customCollations.weights[c]; !custom {
+			return Collation_Default, ErrCollationAlreadyExists.New(name)
+		}
+	}
+
+	if _, ok := characterSetDefaults[charset]; !ok {
+		return Collation_Default, ErrCharacterSetNotSupported.New(charset)
+	}
+
+	collations[name] = c
+	collationToCharacterSet[c] = charset
+	customCollations.weights[c] = weight
+	return c, nil
+}
+
+// IsCustom returns whether the collation was registered with RegisterCollation.
+func (c Collation) IsCustom() bool {
+	customCollations.RLock()
+	defer customCollations.RUnlock()
+	_, ok := customCollations.weights[c]
+	return ok
+}
+
+// CustomCollationWeight returns the weight of the characters of the collation given if it's a custom collation, or nil
+// otherwise.
+func CustomCollationWeight(c Collation) CollationWeight {
+	customCollations.RLock()
+	defer customCollations.RUnlock()
+	return customCollations.weights[c]
+}
+
+// collationForm returns the string given as it's compared according to the collation given: without its trailing
+// spaces for a PAD SPACE collation, and in lower case for a case insensitive one.
+func collationForm(c Collation, s string) string {
+	if c.PadSpace() == PadSpace {
+		s = strings.TrimRight(s, " ")
+	}
+	if c.IsCaseInsensitive() {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+// CollationKey returns a key of the string given that is the same for all the strings that compare as equal with it
+// according to the collation given. The key of a string is the list of weights of its characters for a custom collation,
+// and the string itself for any other one, once its case and trailing spaces are ignored as the collation does.
+func CollationKey(c Collation, s string) string {
+	s = collationForm(c, s)
+	customCollations.RLock()
+	weight, ok := customCollations.weights[c]
+	customCollations.RUnlock()
+	if !ok {
+		return s
+	}
+
+	weights := make([]string, 0, len(s))
+	for _, r := range s {
+		weights = append(weights, strconv.Itoa(weight(r)))
+	}
+	return strings.Join(weights, ",")
+}
+
+// CollationSortKey returns the sort key of the string given according to the collation given, which compares byte by
+// byte with the sort key of another string like CompareStrings compares the strings, so that a string compared or
+// sorted many times only has its key computed once. The key of a string is the list of weights of its characters, of
+// eight bytes each, for a custom collation, and the string itself for any other one, once its case and trailing spaces
+// are ignored as the collation does.
+func CollationSortKey(c Collation, s string) string {
+	s = collationForm(c, s)
+	customCollations.RLock()
+	weight, ok := customCollations.weights[c]
+	customCollations.RUnlock()
+	if !ok {
+		return s
+	}
+
+	key := make([]byte, 0, 8*len(s))
+	var buf [8]byte
+	for _, r := range s {
+		// Flipping the sign bit orders negative weights before positive ones
+		binary.BigEndian.PutUint64(buf[:], uint64(weight(r))^(1<<63))
+		key = append(key, buf[:]...)
+	}
+	return string(key)
+}
+
+// CompareStrings compares two strings according to the collation given. Strings are compared with the weights of
+// their characters for a custom collation, and byte by byte for any other one, ignoring their case for a case
+// insensitive collation and their trailing spaces for a PAD SPACE one. Every path that compares strings of a type, such
+// as comparisons, IN, sorts and MIN and MAX, compares them with it through the Compare method of the type.
+func CompareStrings(c Collation, a, b string) int {
+	a, b = collationForm(c, a), collationForm(c, b)
+	customCollations.RLock()
+	weight, ok := customCollations.weights[c]
+	customCollations.RUnlock()
+	if !ok {
+		return strings.Compare(a, b)
+	}
+
+	ar, br := []rune(a), []rune(b)
+	for i := 0; i < len(ar) && i < len(br); i++ {
+		aw, bw := weight(ar[i]), weight(br[i])
+		switch {
+		case aw < bw:
+			return -1
+		case aw > bw:
+			return 1
+		}
+	}
+
+	switch {
+	case len(ar) < len(br):
+		return -1
+	case len(ar) > len(br):
+		return 1
+	default:
+		return 0
+	}
+}
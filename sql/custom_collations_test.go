@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+// digitsLastWeight orders digits after letters, ignoring the case of letters.
+func digitsLastWeight(r rune) int {
+	if unicode.IsDigit(r) {
+		return 1<<20 + int(r)
+	}
+	return int(unicode.ToLower(r))
+}
+
+func TestRegisterCollation(t *testing.T) {
+	require := require.New(t)
+
+	c, err := RegisterCollation("utf8mb4_test_digits_last", CharacterSet_utf8mb4, digitsLastWeight)
+	require.NoError(err)
+	require.True(c.IsCustom())
+	require.False(Collation_utf8mb4_bin.IsCustom())
+	require.Equal(CharacterSet_utf8mb4, c.CharacterSet())
+
+	name := "utf8mb4_test_digits_last"
+	parsed, err := ParseCollation(nil, &name, false)
+	require.NoError(err)
+	require.Equal(c, parsed)
+
+	_, err = RegisterCollation("utf8mb4_test_digits_last", CharacterSet_utf8mb4, digitsLastWeight)
+	require.NoError(err)
+
+	_, err = RegisterCollation(Collation_utf8mb4_bin.String(), CharacterSet_utf8mb4, digitsLastWeight)
+	require.True(ErrCollationAlreadyExists.Is(err))
+
+	_, err = RegisterCollation("nope_test_digits_last", CharacterSet("nope"), digitsLastWeight)
+	require.True(ErrCharacterSetNotSupported.Is(err))
+}
+
+func TestCompareStringsCustomCollation(t *testing.T) {
+	c, err := RegisterCollation("utf8mb4_test_digits_last", CharacterSet_utf8mb4, digitsLastWeight)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"a", "9", -1},
+		{"9", "z", 1},
+		{"1", "9", -1},
+		{"ab", "AB", 0},
+		{"ab", "abc", -1},
+		{"b1", "bz", 1},
+	}
+
+	typ := MustCreateString(sqltypes.VarChar, 10, c)
+	for _, tt := range testCases {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			require := require.New(t)
+			require.Equal(tt.expected, CompareStrings(c, tt.a, tt.b))
+
+			cmp, err := typ.Compare(tt.a, tt.b)
+			require.NoError(err)
+			require.Equal(tt.expected, cmp)
+		})
+	}
+
+	require.Equal(t, 1, CompareStrings(Collation_utf8mb4_bin, "a", "9"))
+}
+
+func TestCollationSortKey(t *testing.T) {
+	c, err := RegisterCollation("utf8mb4_test_digits_last", CharacterSet_utf8mb4, digitsLastWeight)
+	require.NoError(t, err)
+	negative, err := RegisterCollation("utf8mb4_test_negative", CharacterSet_utf8mb4, func(r rune) int {
+		return -int(r)
+	})
+	require.NoError(t, err)
+
+	values := []string{"", "a", "A", "9", "z", "ab", "AB", "abc", "b1", "bz", "é", "a "}
+	for _, collation := range []Collation{c, negative, Collation_utf8mb4_bin} {
+		for _, a := range values {
+			for _, b := range values {
+				cmp := strings.Compare(CollationSortKey(collation, a), CollationSortKey(collation, b))
+				require.Equal(t, CompareStrings(collation, a, b), cmp, "%s: %q and %q", collation, a, b)
+			}
+		}
+	}
+
+	require.Equal(t, "a9", CollationSortKey(Collation_utf8mb4_bin, "a9"))
+}
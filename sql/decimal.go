@@ -25,12 +25,27 @@ var (
 	ErrMarshalNullDecimal    = errors.NewKind("Decimal cannot marshal a null value")
 )
 
+// DecimalRoundingMode is the way a decimal type coerces values with a greater scale than its own, both when converting
+// and when comparing them.
+type DecimalRoundingMode byte
+
+const (
+	// DecimalRounding_HalfUp rounds to the nearest value, and away from zero when both are equally near.
+	DecimalRounding_HalfUp DecimalRoundingMode = iota
+	// DecimalRounding_Truncate discards any digits beyond the scale.
+	DecimalRounding_Truncate
+	// DecimalRounding_HalfEven rounds to the nearest value, and to the one with an even last digit when both are
+	// equally near.
+	DecimalRounding_HalfEven
+)
+
 type DecimalType interface {
 	Type
 	ConvertToDecimal(v interface{}) (decimal.NullDecimal, error)
 	ExclusiveUpperBound() decimal.Decimal
 	MaximumScale() uint8
 	Precision() uint8
+	RoundingMode() DecimalRoundingMode
 	Scale() uint8
 }
 
@@ -38,10 +53,17 @@ type decimalType struct {
 	exclusiveUpperBound decimal.Decimal
 	precision           uint8
 	scale               uint8
+	rounding            DecimalRoundingMode
 }
 
 // CreateDecimalType creates a DecimalType.
 func CreateDecimalType(precision uint8, scale uint8) (DecimalType, error) {
+	return CreateDecimalTypeWithRounding(precision, scale, DecimalRounding_HalfUp)
+}
+
+// CreateDecimalTypeWithRounding creates a DecimalType that coerces values of a greater scale using the given rounding
+// mode.
+func CreateDecimalTypeWithRounding(precision uint8, scale uint8, rounding DecimalRoundingMode) (DecimalType, error) {
 	if precision > DecimalTypeMaxPrecision {
 		return nil, fmt.Errorf("%v is beyond the max precision", precision)
 	}
@@ -58,6 +80,7 @@ func CreateDecimalType(precision uint8, scale uint8) (DecimalType, error) {
 		exclusiveUpperBound: decimal.New(1, int32(precision-scale)),
 		precision:           precision,
 		scale:               scale,
+		rounding:            rounding,
 	}, nil
 }
 
@@ -70,6 +93,15 @@ func MustCreateDecimalType(precision uint8, scale uint8) DecimalType {
 	return dt
 }
 
+// MustCreateDecimalTypeWithRounding is the same as CreateDecimalTypeWithRounding except it panics on errors.
+func MustCreateDecimalTypeWithRounding(precision uint8, scale uint8, rounding DecimalRoundingMode) DecimalType {
+	dt, err := CreateDecimalTypeWithRounding(precision, scale, rounding)
+	if err != nil {
+		panic(err)
+	}
+	return dt
+}
+
 // Type implements Type interface.
 func (t decimalType) Type() query.Type {
 	return sqltypes.Decimal
@@ -170,7 +202,14 @@ func (t decimalType) ConvertToDecimal(v interface{}) (decimal.NullDecimal, error
 		return decimal.NullDecimal{}, ErrConvertingToDecimal.New(v)
 	}
 
-	res = res.Round(int32(t.scale))
+	switch t.rounding {
+	case DecimalRounding_Truncate:
+		res = res.Truncate(int32(t.scale))
+	case DecimalRounding_HalfEven:
+		res = res.RoundBank(int32(t.scale))
+	default:
+		res = res.Round(int32(t.scale))
+	}
 	if !res.Abs().LessThan(t.exclusiveUpperBound) {
 		return decimal.NullDecimal{}, ErrConvertToDecimalLimit.New()
 	}
@@ -189,7 +228,7 @@ func (t decimalType) MustConvert(v interface{}) interface{} {
 
 // Promote implements the Type interface.
 func (t decimalType) Promote() Type {
-	return MustCreateDecimalType(DecimalTypeMaxPrecision, t.scale)
+	return MustCreateDecimalTypeWithRounding(DecimalTypeMaxPrecision, t.scale, t.rounding)
 }
 
 // SQL implements Type interface.
@@ -234,6 +273,11 @@ func (t decimalType) Precision() uint8 {
 	return t.precision
 }
 
+// RoundingMode returns the way values with a greater scale than this type's are coerced to it.
+func (t decimalType) RoundingMode() DecimalRoundingMode {
+	return t.rounding
+}
+
 // Scale returns the scale, or number of digits after the decimal, that may be held.
 // This will always be less than or equal to the precision.
 func (t decimalType) Scale() uint8 {
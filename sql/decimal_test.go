@@ -126,46 +126,46 @@ func TestDecimalCreate(t *testing.T) {
 		expectedType decimalType
 		expectedErr  bool
 	}{
-		{0, 0, decimalType{decimal.New(1, 10), 10, 0}, false},
+		{0, 0, decimalType{decimal.New(1, 10), 10, 0, DecimalRounding_HalfUp}, false},
 		{0, 1, decimalType{}, true},
 		{0, 5, decimalType{}, true},
 		{0, 10, decimalType{}, true},
 		{0, 30, decimalType{}, true},
 		{0, 65, decimalType{}, true},
 		{0, 66, decimalType{}, true},
-		{1, 0, decimalType{decimal.New(1, 1), 1, 0}, false},
-		{1, 1, decimalType{decimal.New(1, 0), 1, 1}, false},
+		{1, 0, decimalType{decimal.New(1, 1), 1, 0, DecimalRounding_HalfUp}, false},
+		{1, 1, decimalType{decimal.New(1, 0), 1, 1, DecimalRounding_HalfUp}, false},
 		{1, 5, decimalType{}, true},
 		{1, 10, decimalType{}, true},
 		{1, 30, decimalType{}, true},
 		{1, 65, decimalType{}, true},
 		{1, 66, decimalType{}, true},
-		{5, 0, decimalType{decimal.New(1, 5), 5, 0}, false},
-		{5, 1, decimalType{decimal.New(1, 4), 5, 1}, false},
-		{5, 5, decimalType{decimal.New(1, 0), 5, 5}, false},
+		{5, 0, decimalType{decimal.New(1, 5), 5, 0, DecimalRounding_HalfUp}, false},
+		{5, 1, decimalType{decimal.New(1, 4), 5, 1, DecimalRounding_HalfUp}, false},
+		{5, 5, decimalType{decimal.New(1, 0), 5, 5, DecimalRounding_HalfUp}, false},
 		{5, 10, decimalType{}, true},
 		{5, 30, decimalType{}, true},
 		{5, 65, decimalType{}, true},
 		{5, 66, decimalType{}, true},
-		{10, 0, decimalType{decimal.New(1, 10), 10, 0}, false},
-		{10, 1, decimalType{decimal.New(1, 9), 10, 1}, false},
-		{10, 5, decimalType{decimal.New(1, 5), 10, 5}, false},
-		{10, 10, decimalType{decimal.New(1, 0), 10, 10}, false},
+		{10, 0, decimalType{decimal.New(1, 10), 10, 0, DecimalRounding_HalfUp}, false},
+		{10, 1, decimalType{decimal.New(1, 9), 10, 1, DecimalRounding_HalfUp}, false},
+		{10, 5, decimalType{decimal.New(1, 5), 10, 5, DecimalRounding_HalfUp}, false},
+		{10, 10, decimalType{decimal.New(1, 0), 10, 10, DecimalRounding_HalfUp}, false},
 		{10, 30, decimalType{}, true},
 		{10, 65, decimalType{}, true},
 		{10, 66, decimalType{}, true},
-		{30, 0, decimalType{decimal.New(1, 30), 30, 0}, false},
-		{30, 1, decimalType{decimal.New(1, 29), 30, 1}, false},
-		{30, 5, decimalType{decimal.New(1, 25), 30, 5}, false},
-		{30, 10, decimalType{decimal.New(1, 20), 30, 10}, false},
-		{30, 30, decimalType{decimal.New(1, 0), 30, 30}, false},
+		{30, 0, decimalType{decimal.New(1, 30), 30, 0, DecimalRounding_HalfUp}, false},
+		{30, 1, decimalType{decimal.New(1, 29), 30, 1, DecimalRounding_HalfUp}, false},
+		{30, 5, decimalType{decimal.New(1, 25), 30, 5, DecimalRounding_HalfUp}, false},
+		{30, 10, decimalType{decimal.New(1, 20), 30, 10, DecimalRounding_HalfUp}, false},
+		{30, 30, decimalType{decimal.New(1, 0), 30, 30, DecimalRounding_HalfUp}, false},
 		{30, 65, decimalType{}, true},
 		{30, 66, decimalType{}, true},
-		{65, 0, decimalType{decimal.New(1, 65), 65, 0}, false},
-		{65, 1, decimalType{decimal.New(1, 64), 65, 1}, false},
-		{65, 5, decimalType{decimal.New(1, 60), 65, 5}, false},
-		{65, 10, decimalType{decimal.New(1, 55), 65, 10}, false},
-		{65, 30, decimalType{decimal.New(1, 35), 65, 30}, false},
+		{65, 0, decimalType{decimal.New(1, 65), 65, 0, DecimalRounding_HalfUp}, false},
+		{65, 1, decimalType{decimal.New(1, 64), 65, 1, DecimalRounding_HalfUp}, false},
+		{65, 5, decimalType{decimal.New(1, 60), 65, 5, DecimalRounding_HalfUp}, false},
+		{65, 10, decimalType{decimal.New(1, 55), 65, 10, DecimalRounding_HalfUp}, false},
+		{65, 30, decimalType{decimal.New(1, 35), 65, 30, DecimalRounding_HalfUp}, false},
 		{65, 65, decimalType{}, true},
 		{65, 66, decimalType{}, true},
 		{66, 00, decimalType{}, true},
@@ -294,3 +294,27 @@ func TestDecimalString(t *testing.T) {
 		})
 	}
 }
+
+func TestDecimalRoundingMode(t *testing.T) {
+	halfUp := MustCreateDecimalType(10, 2)
+	require.Equal(t, DecimalRounding_HalfUp, halfUp.RoundingMode())
+	val, err := halfUp.Convert("1.005")
+	require.NoError(t, err)
+	require.Equal(t, "1.01", val)
+
+	truncate := MustCreateDecimalTypeWithRounding(10, 2, DecimalRounding_Truncate)
+	require.Equal(t, DecimalRounding_Truncate, truncate.RoundingMode())
+	val, err = truncate.Convert("1.005")
+	require.NoError(t, err)
+	require.Equal(t, "1.00", val)
+	require.Equal(t, DecimalRounding_Truncate, truncate.Promote().(DecimalType).RoundingMode())
+
+	halfEven := MustCreateDecimalTypeWithRounding(10, 2, DecimalRounding_HalfEven)
+	require.Equal(t, DecimalRounding_HalfEven, halfEven.RoundingMode())
+	val, err = halfEven.Convert("1.005")
+	require.NoError(t, err)
+	require.Equal(t, "1.00", val)
+	val, err = halfEven.Convert("1.015")
+	require.NoError(t, err)
+	require.Equal(t, "1.02", val)
+}
@@ -0,0 +1,93 @@
+package sql
+
+import "sync"
+
+// Diagnostics collects information about the decisions made while executing a query, to help debugging surprising
+// results. It's only populated when set in the context with WithDiagnostics, and is safe for concurrent use.
+type Diagnostics struct {
+	mu        sync.Mutex
+	coercions map[string]string
+	// logNullComparisons is whether the comparisons that evaluated to NULL are logged, which is set before the
+	// diagnostics are used, so it's read without locking.
+	logNullComparisons bool
+	nullComparisons    []NullComparison
+}
+
+// maxNullComparisons is the number of comparisons that evaluated to NULL logged at most, so that a query over a large
+// table doesn't log one per row.
+const maxNullComparisons = 1000
+
+// NullComparison is a comparison that evaluated to NULL because one of its operands was NULL, with the row it was
+// evaluated with.
+type NullComparison struct {
+	Comparison string
+	Row        Row
+}
+
+// NewDiagnostics creates a new, empty Diagnostics.
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{coercions: make(map[string]string)}
+}
+
+// LogNullComparisons makes the diagnostics log the comparisons that evaluate to NULL because of a NULL operand, which
+// silently drop the rows a filter evaluates them with. It's off by default, and must be enabled before the diagnostics
+// are set in a context.
+func (d *Diagnostics) LogNullComparisons() *Diagnostics {
+	d.logNullComparisons = true
+	return d
+}
+
+// LogsNullComparisons returns whether the comparisons that evaluate to NULL are logged.
+func (d *Diagnostics) LogsNullComparisons() bool {
+	return d != nil && d.logNullComparisons
+}
+
+// RecordNullComparison logs the comparison given as having evaluated to NULL with the row given, if the comparisons
+// that evaluate to NULL are logged. The row is copied, since the rows of an iterator can be reused.
+func (d *Diagnostics) RecordNullComparison(comparison string, row Row) {
+	if !d.LogsNullComparisons() {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.nullComparisons) >= maxNullComparisons {
+		return
+	}
+	d.nullComparisons = append(d.nullComparisons, NullComparison{Comparison: comparison, Row: row.Copy()})
+}
+
+// NullComparisons returns the logged comparisons that evaluated to NULL, in the order they were evaluated.
+func (d *Diagnostics) NullComparisons() []NullComparison {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]NullComparison(nil), d.nullComparisons...)
+}
+
+// RecordCoercion records the type both operands of the comparison given were converted to before comparing them,
+// such as "signed" or "char".
+func (d *Diagnostics) RecordCoercion(comparison string, coercion string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.coercions[comparison] = coercion
+}
+
+// Coercion returns the type both operands of the comparison given were converted to before comparing them, if any
+// conversion was recorded.
+func (d *Diagnostics) Coercion(comparison string) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	coercion, ok := d.coercions[comparison]
+	return coercion, ok
+}
+
+// Coercions returns all the recorded conversions of comparison operands, keyed by comparison.
+func (d *Diagnostics) Coercions() map[string]string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	coercions := make(map[string]string, len(d.coercions))
+	for k, v := range d.coercions {
+		coercions[k] = v
+	}
+	return coercions
+}
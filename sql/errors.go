@@ -88,6 +88,9 @@ var (
 	// ErrColumnDefaultReturnedNull is returned when a default expression evaluates to nil but the column is non-nullable.
 	ErrColumnDefaultReturnedNull = errors.NewKind(`default value attempted to return null but column is non-nullable`)
 
+	// ErrColumnHasNoDefault is returned when the default value of a non-nullable column without one is requested.
+	ErrColumnHasNoDefault = errors.NewKind("field '%s' doesn't have a default value")
+
 	// ErrDropColumnReferencedInDefault is returned when a column cannot be dropped as it is referenced by another column's default value.
 	ErrDropColumnReferencedInDefault = errors.NewKind(`cannot drop column "%s" as default value of column "%s" references it`)
 
@@ -67,7 +67,7 @@ func (b *Between) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	lower, err = typ.Convert(lower)
+	lower, err = convertComparedValue(typ, b.Lower.Type(), lower)
 	if err != nil {
 		return nil, err
 	}
@@ -81,17 +81,23 @@ func (b *Between) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	upper, err = typ.Convert(upper)
+	upper, err = convertComparedValue(typ, b.Upper.Type(), upper)
 	if err != nil {
 		return nil, err
 	}
 
 	cmpLower, err := typ.Compare(val, lower)
+	if sql.ErrIncomparable.Is(err) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	cmpUpper, err := typ.Compare(val, upper)
+	if sql.ErrIncomparable.Is(err) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,53 @@
+package expression
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// BinaryCast is the BINARY operator, which casts an expression to a binary string. Comparisons with a binary string
+// compare the bytes of both operands, so BINARY makes them case and trailing space sensitive.
+type BinaryCast struct {
+	UnaryExpression
+}
+
+var _ sql.Expression = (*BinaryCast)(nil)
+
+// NewBinaryCast creates a new BinaryCast expression.
+func NewBinaryCast(child sql.Expression) *BinaryCast {
+	return &BinaryCast{UnaryExpression{child}}
+}
+
+// Type implements the Expression interface.
+func (*BinaryCast) Type() sql.Type {
+	return sql.LongBlob
+}
+
+// Eval implements the Expression interface.
+func (b *BinaryCast) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := b.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	return convertValue(val, ConvertToBinary)
+}
+
+func (b *BinaryCast) String() string {
+	return "BINARY " + b.Child.String()
+}
+
+func (b *BinaryCast) DebugString() string {
+	return "BINARY " + sql.DebugString(b.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (b *BinaryCast) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(children), 1)
+	}
+	return NewBinaryCast(children[0]), nil
+}
@@ -0,0 +1,48 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestBinaryCast(t *testing.T) {
+	require := require.New(t)
+
+	e := NewBinaryCast(NewGetField(0, sql.LongText, "s", true))
+	require.Equal(sql.LongBlob, e.Type())
+	require.True(e.IsNullable())
+	require.Equal("BINARY s", e.String())
+	require.Equal("abc", eval(t, e, sql.NewRow("abc")))
+	require.Nil(eval(t, e, sql.NewRow(nil)))
+
+	e = NewBinaryCast(NewGetField(0, sql.Int64, "i", false))
+	require.Equal("10", eval(t, e, sql.NewRow(int64(10))))
+}
+
+func TestBinaryCastComparison(t *testing.T) {
+	s := NewGetField(0, sql.LongText, "s", true)
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		row      sql.Row
+		expected interface{}
+	}{
+		{"case insensitive", NewEquals(s, NewLiteral("ABC", sql.LongText)), sql.NewRow("abc"), true},
+		{"binary column", NewEquals(NewBinaryCast(s), NewLiteral("ABC", sql.LongText)), sql.NewRow("abc"), false},
+		{"binary literal", NewEquals(s, NewBinaryCast(NewLiteral("ABC", sql.LongText))), sql.NewRow("abc"), false},
+		{"binary same case", NewEquals(NewBinaryCast(s), NewLiteral("abc", sql.LongText)), sql.NewRow("abc"), true},
+		{"binary trailing space", NewEquals(NewBinaryCast(s), NewLiteral("abc", sql.LongText)), sql.NewRow("abc "), false},
+		{"binary less than", NewLessThan(NewBinaryCast(s), NewLiteral("a", sql.LongText)), sql.NewRow("B"), true},
+		{"case insensitive less than", NewLessThan(s, NewLiteral("a", sql.LongText)), sql.NewRow("B"), false},
+		{"binary null", NewEquals(NewBinaryCast(s), NewLiteral("abc", sql.LongText)), sql.NewRow(nil), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, tt.expr, tt.row))
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// CollationKey is an expression that evaluates to the sort key of a string according to the collation of its type, as
+// sql.CollationSortKey returns it, so that the strings of a column compared and sorted many times have their keys
+// computed once for each row. Keys compare byte by byte, so they're binary strings.
+type CollationKey struct {
+	UnaryExpression
+}
+
+var _ sql.Expression = (*CollationKey)(nil)
+
+// NewCollationKey creates a new CollationKey expression of the string expression given.
+func NewCollationKey(child sql.Expression) *CollationKey {
+	return &CollationKey{UnaryExpression{child}}
+}
+
+// Type implements the Expression interface.
+func (e *CollationKey) Type() sql.Type {
+	return sql.LongBlob
+}
+
+// Eval implements the Expression interface.
+func (e *CollationKey) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	v, err := e.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	typ, ok := e.Child.Type().(sql.StringType)
+	if !ok {
+		return nil, sql.ErrInvalidType.New(e.Child.Type())
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		converted, err := typ.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		s = converted.(string)
+	}
+
+	return sql.CollationSortKey(typ.Collation(), s), nil
+}
+
+func (e *CollationKey) String() string {
+	return fmt.Sprintf("COLLATION_KEY(%s)", e.Child)
+}
+
+func (e *CollationKey) DebugString() string {
+	return fmt.Sprintf("COLLATION_KEY(%s)", sql.DebugString(e.Child))
+}
+
+// WithChildren implements the Expression interface.
+func (e *CollationKey) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 1)
+	}
+	return NewCollationKey(children[0]), nil
+}
+
+// CollationKeyComparison is a comparison of a string column with a string literal that compares the CollationKey of the
+// column, already computed for the row, with the key of the literal, instead of comparing the strings with the weights
+// of their characters. The key of a value of the column is only used when the comparison would compare the value as it
+// is, which isn't the case for a value with trailing spaces in a PAD SPACE collation, or with uppercase characters in
+// a case insensitive one, nor for an empty string, which can be NULL for the session. Any other value is compared by the
+// comparison itself, so the result is the same as the one of the comparison for every row.
+type CollationKeyComparison struct {
+	Comparison Comparer
+	// Key is the expression of the CollationKey of the column already computed for the row, such as a GetField of it.
+	Key sql.Expression
+	// columnLeft is whether the column is the left operand of the comparison
+	columnLeft      bool
+	literalKey      string
+	op              ComparisonOperator
+	padSpace        bool
+	caseInsensitive bool
+}
+
+var _ sql.Expression = (*CollationKeyComparison)(nil)
+
+// NewCollationKeyComparison returns a CollationKeyComparison of the comparison given of a column with a literal, which
+// uses the key of the column given, or false if the comparison can't be evaluated with the keys of the column. That's
+// only the case for =, <, >, <= and >= comparisons of a column of a variable length string type with a custom collation
+// with a string literal, which are compared with the collation of the column.
+func NewCollationKeyComparison(c Comparer, key sql.Expression) (*CollationKeyComparison, bool) {
+	cmp, op, ok := comparerOperator(c)
+	if !ok {
+		return nil, false
+	}
+
+	if _, err := operatorAccepts(op); err != nil {
+		return nil, false
+	}
+
+	column, literal, columnLeft := c.Left(), c.Right(), true
+	if _, ok := column.(*Literal); ok {
+		column, literal, columnLeft = literal, column, false
+	}
+
+	if _, ok := column.(*GetField); !ok || !streamsText(column.Type()) {
+		return nil, false
+	}
+
+	l, ok := literal.(*Literal)
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := l.Value().(string)
+	if !ok || !sql.IsTextOnly(l.Type()) {
+		return nil, false
+	}
+
+	coercion, err := cmp.coercion()
+	if err != nil || coercion.convertTo != ConvertToChar || coercion.setType != nil || coercion.binaryText {
+		return nil, false
+	}
+
+	collation := coercion.compareType.(sql.StringType).Collation()
+	if !collation.IsCustom() || collation != column.Type().(sql.StringType).Collation() {
+		return nil, false
+	}
+
+	if coercion.padSpace {
+		value = strings.TrimRight(value, " ")
+	}
+	if coercion.caseInsensitive {
+		value = strings.ToLower(value)
+	}
+
+	return &CollationKeyComparison{
+		Comparison:      c,
+		Key:             key,
+		columnLeft:      columnLeft,
+		literalKey:      sql.CollationSortKey(collation, value),
+		op:              op,
+		padSpace:        coercion.padSpace,
+		caseInsensitive: coercion.caseInsensitive,
+	}, true
+}
+
+// Resolved implements the Expression interface.
+func (c *CollationKeyComparison) Resolved() bool {
+	return c.Comparison.Resolved() && c.Key.Resolved()
+}
+
+// IsNullable implements the Expression interface.
+func (c *CollationKeyComparison) IsNullable() bool {
+	return c.Comparison.IsNullable()
+}
+
+// Type implements the Expression interface.
+func (c *CollationKeyComparison) Type() sql.Type {
+	return sql.Boolean
+}
+
+// Children implements the Expression interface.
+func (c *CollationKeyComparison) Children() []sql.Expression {
+	return []sql.Expression{c.Comparison, c.Key}
+}
+
+// Eval implements the Expression interface.
+func (c *CollationKeyComparison) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	column := c.Comparison.Left()
+	if !c.columnLeft {
+		column = c.Comparison.Right()
+	}
+
+	v, err := column.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := v.(string)
+	if !ok || !c.comparesAsIs(s) {
+		return c.Comparison.Eval(ctx, row)
+	}
+
+	key, err := c.Key.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	k, ok := key.(string)
+	if !ok {
+		return c.Comparison.Eval(ctx, row)
+	}
+
+	accepts, err := operatorAccepts(c.op)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.columnLeft {
+		return accepts(strings.Compare(k, c.literalKey)), nil
+	}
+	return accepts(strings.Compare(c.literalKey, k)), nil
+}
+
+// comparesAsIs returns whether the comparison compares the value given of the column as it is, without trimming its
+// trailing spaces or lowering its case, so that its key is the key it's compared with.
+func (c *CollationKeyComparison) comparesAsIs(s string) bool {
+	if s == "" || (c.padSpace && strings.HasSuffix(s, " ")) {
+		return false
+	}
+
+	if c.caseInsensitive {
+		if !utf8.ValidString(s) {
+			return false
+		}
+		for _, r := range s {
+			if unicode.ToLower(r) != r {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// WithChildren implements the Expression interface.
+func (c *CollationKeyComparison) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 2)
+	}
+
+	cmp, ok := children[0].(Comparer)
+	if !ok {
+		return nil, sql.ErrInvalidChildType.New(c, children[0], (*Comparer)(nil))
+	}
+
+	nc := *c
+	nc.Comparison, nc.Key = cmp, children[1]
+	return &nc, nil
+}
+
+func (c *CollationKeyComparison) String() string {
+	return c.Comparison.String()
+}
+
+func (c *CollationKeyComparison) DebugString() string {
+	return fmt.Sprintf("CollationKeyComparison(%s, %s)", sql.DebugString(c.Comparison), sql.DebugString(c.Key))
+}
@@ -1,9 +1,17 @@
 package expression
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
 
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/shopspring/decimal"
 	errors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/internal/regex"
@@ -12,6 +20,14 @@ import (
 
 var ErrInvalidRegexp = errors.NewKind("Invalid regular expression: %s")
 
+const (
+	// warnCodeTruncatedWrongValue is the MySQL code of the warning for a value truncated to convert it to another type.
+	warnCodeTruncatedWrongValue = 1292
+	// warnCodeBinaryComparison is the code of the warning for a nonbinary string compared as a binary one, which
+	// MySQL doesn't have a code of its own for.
+	warnCodeBinaryComparison = 1105
+)
+
 // Comparer implements a comparison expression.
 type Comparer interface {
 	sql.Expression
@@ -20,9 +36,43 @@ type Comparer interface {
 	Right() sql.Expression
 }
 
+// Negatable is a boolean expression that can be negated without wrapping it in a NOT, such as a comparison that can be
+// replaced by its inverse operator.
+type Negatable interface {
+	sql.Expression
+	// Negate returns an expression that is false when this one is true, true when it's false and NULL when it's
+	// NULL, or false if there isn't one.
+	Negate() (sql.Expression, bool)
+}
+
+// CoercionDescriber is a comparison that can tell how the values of its operands are compared without evaluating it.
+type CoercionDescriber interface {
+	Comparer
+	// Coercion returns the type the values of the operands are compared with, and the branch of the comparison that
+	// converts them to it, which is one of the CoercionBranch constants, or the CONVERT type they're converted to.
+	Coercion(ctx *sql.Context) (sql.Type, string, error)
+}
+
+const (
+	// CoercionBranchTuple is the branch of comparisons of tuples, which compare them element by element.
+	CoercionBranchTuple = "tuple"
+	// CoercionBranchComparator is the branch of comparisons of values of a type with a custom comparator.
+	CoercionBranchComparator = "comparator"
+	// CoercionBranchOperandType is the branch of comparisons of values of the same type, which aren't converted.
+	CoercionBranchOperandType = "operand type"
+	// CoercionBranchForced is the branch of comparisons that convert their operands to a fixed type.
+	CoercionBranchForced = "forced"
+	// CoercionBranchRegexp is the branch of REGEXP comparisons of strings, which match them as text.
+	CoercionBranchRegexp = "regexp"
+)
+
 // ErrNilOperand ir returned if some or both of the comparison's operands is nil.
 var ErrNilOperand = errors.NewKind("nil operand found in comparison")
 
+// ErrNaNOperand is returned if some or both of the comparison's operands is a NaN float, which isn't ordered with
+// respect to any other value, so that any comparison with it is false.
+var ErrNaNOperand = errors.NewKind("NaN operand found in comparison")
+
 type comparison struct {
 	BinaryExpression
 	compareType sql.Type
@@ -36,27 +86,145 @@ func newComparison(left, right sql.Expression) comparison {
 // Since both types should be equal, it does not matter which type is used, but for
 // reference, the left type is always used.
 func (c *comparison) Compare(ctx *sql.Context, row sql.Row) (int, error) {
+	return c.compare(ctx, row, false)
+}
+
+// compare compares the operands of the comparison like Compare does. When equality is true, the result is only
+// checked for equality, so tuples are equal only if all their elements are, and aren't if any of them aren't, whether
+// or not any other element is NULL.
+func (c *comparison) compare(ctx *sql.Context, row sql.Row, equality bool) (int, error) {
 	left, right, err := c.evalLeftAndRight(ctx, row)
 	if err != nil {
 		return 0, err
 	}
 
+	return c.compareValues(ctx, left, right, equality, false)
+}
+
+// compareValues compares the values given of the operands of the comparison like compare does. When nullSafe is true,
+// the elements of tuples are checked for equality like <=> does, taking two NULLs as equal and a NULL as not equal to
+// any other value.
+func (c *comparison) compareValues(ctx *sql.Context, left, right interface{}, equality, nullSafe bool) (int, error) {
+	leftType, rightType := c.Left().Type(), c.Right().Type()
+	if sql.IsTuple(leftType) || sql.IsTuple(rightType) {
+		if sql.NumColumns(leftType) != sql.NumColumns(rightType) {
+			return 0, ErrInvalidOperandColumns.New(sql.NumColumns(leftType), sql.NumColumns(rightType))
+		}
+
+		if left == nil || right == nil {
+			return 0, ErrNilOperand.New()
+		}
+
+		return compareTuples(ctx, c.Left(), c.Right(), left, right, equality, nullSafe)
+	}
+
 	if left == nil || right == nil {
 		return 0, ErrNilOperand.New()
 	}
 
-	if c.Left().Type() == c.Right().Type() {
+	if isNaN(left) || isNaN(right) {
+		return 0, ErrNaNOperand.New()
+	}
+
+	if typ, ok := comparatorType(leftType, rightType); ok {
+		return typ.Compare(left, right)
+	}
+
+	if cmp, ok, err := c.compareStreamedText(ctx, left, right); ok || err != nil {
+		return cmp, err
+	}
+
+	genericOnly := ctx != nil && ctx.GenericComparisons
+	if !genericOnly && comparesWithOperandType(c.Left().Type(), c.Right().Type()) {
 		return c.Left().Type().Compare(left, right)
 	}
 
-	left, right, err = c.castLeftAndRight(left, right)
+	left, right, err := c.castLeftAndRight(ctx, left, right)
 	if err != nil {
 		return 0, err
 	}
 
+	if isNaN(left) || isNaN(right) {
+		return 0, ErrNaNOperand.New()
+	}
+
 	return c.compareType.Compare(left, right)
 }
 
+// compareTuples compares the values of tuple operands element by element, as the first pair of elements that aren't
+// equal do, with each pair compared as a comparison of them would. The elements of an operand that is a tuple
+// expression are compared as the expressions they are, so that a column in a tuple is compared with the type and
+// collation a comparison of the column itself would use. A NULL element before that pair makes the result NULL, unless
+// only equality is checked, in which case any pair that isn't equal decides the result. Pairs with NULLs are checked
+// like <=> does when nullSafe is true.
+func compareTuples(ctx *sql.Context, leftOperand, rightOperand sql.Expression, left, right interface{}, equality, nullSafe bool) (int, error) {
+	leftValues, ok := left.([]interface{})
+	if !ok {
+		return 0, sql.ErrNotTuple.New(left)
+	}
+
+	rightValues, ok := right.([]interface{})
+	if !ok {
+		return 0, sql.ErrNotTuple.New(right)
+	}
+
+	leftElems, rightElems := tupleElements(leftOperand, leftValues), tupleElements(rightOperand, rightValues)
+	var nilErr error
+	for i := range leftValues {
+		if nullSafe && (leftValues[i] == nil || rightValues[i] == nil) {
+			if leftValues[i] == nil && rightValues[i] == nil {
+				continue
+			}
+			return 1, nil
+		}
+
+		elem := newComparison(leftElems[i], rightElems[i])
+		l, r := elem.operandValues(ctx, leftValues[i], rightValues[i])
+		cmp, err := elem.compareValues(ctx, l, r, equality, nullSafe)
+		if ErrNilOperand.Is(err) && equality {
+			nilErr = err
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if cmp != 0 {
+			return cmp, nil
+		}
+	}
+
+	return 0, nilErr
+}
+
+// tupleElements returns the expressions of the elements of the tuple operand given, whose values are the ones given:
+// the elements themselves if it's a tuple expression, or literals of the values with the types of the elements of its
+// type otherwise, such as for a subquery that returns a row.
+func tupleElements(operand sql.Expression, values []interface{}) []sql.Expression {
+	if tuple, ok := operand.(Tuple); ok && len(tuple) == len(values) {
+		return tuple
+	}
+
+	types := sql.TupleTypes(operand.Type())
+	elems := make([]sql.Expression, len(values))
+	for i, v := range values {
+		elems[i] = NewLiteral(v, types[i])
+	}
+	return elems
+}
+
+// isNaN returns whether the value given is a NaN float.
+func isNaN(v interface{}) bool {
+	switch v := v.(type) {
+	case float64:
+		return math.IsNaN(v)
+	case float32:
+		return math.IsNaN(float64(v))
+	default:
+		return false
+	}
+}
+
 func (c *comparison) evalLeftAndRight(ctx *sql.Context, row sql.Row) (interface{}, interface{}, error) {
 	left, err := c.Left().Eval(ctx, row)
 	if err != nil {
@@ -68,66 +236,812 @@ func (c *comparison) evalLeftAndRight(ctx *sql.Context, row sql.Row) (interface{
 		return nil, nil, err
 	}
 
+	left, right = c.operandValues(ctx, left, right)
+	return left, right, nil
+}
+
+// operandValues returns the values given of the operands of the comparison as they're compared.
+func (c *comparison) operandValues(ctx *sql.Context, left, right interface{}) (interface{}, interface{}) {
+	// The values of CHAR operands are compared as they're retrieved
+	left, right = sql.CharValue(ctx, c.Left().Type(), left), sql.CharValue(ctx, c.Right().Type(), right)
+
+	// Only empty strings pay for looking up whether the session takes them as NULL
+	if (left == "" || right == "") && sql.EmptyStringIsNull(ctx) {
+		if left == "" {
+			left = nil
+		}
+		if right == "" {
+			right = nil
+		}
+	}
+
+	return left, right
+}
+
+func (c *comparison) castLeftAndRight(ctx *sql.Context, left, right interface{}) (interface{}, interface{}, error) {
+	coercion, err := c.coercion()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	left, right, err = coercion.apply(ctx, left, right)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.compareType = sessionDecimalType(ctx, coercion.compareType)
 	return left, right, nil
 }
 
-func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, interface{}, error) {
+// Coercion implements the CoercionDescriber interface. It follows the same branches compare does.
+func (c *comparison) Coercion(ctx *sql.Context) (sql.Type, string, error) {
+	leftType, rightType := c.Left().Type(), c.Right().Type()
+	if sql.IsTuple(leftType) || sql.IsTuple(rightType) {
+		return leftType, CoercionBranchTuple, nil
+	}
+
+	if typ, ok := comparatorType(leftType, rightType); ok {
+		return typ, CoercionBranchComparator, nil
+	}
+
+	genericOnly := ctx != nil && ctx.GenericComparisons
+	if !genericOnly && comparesWithOperandType(leftType, rightType) {
+		return leftType, CoercionBranchOperandType, nil
+	}
+
+	coercion, err := c.coercion()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return sessionDecimalType(ctx, coercion.compareType), coercion.convertTo, nil
+}
+
+// comparisonCoercion is how the values of the operands of a comparison are converted before comparing them, which
+// only depends on the types of the operands.
+type comparisonCoercion struct {
+	convertTo       string
+	compareType     sql.Type
+	padSpace        bool
+	caseInsensitive bool
+	leftJSON        bool
+	rightJSON       bool
+	// leftLocal and rightLocal are whether the operand is a string compared with a TIMESTAMP, which is a time in the
+	// session time zone that must be converted to UTC like the TIMESTAMP value.
+	leftLocal  bool
+	rightLocal bool
+	// leftNumeric and rightNumeric are whether the operand is a number compared with a DATE, DATETIME or TIMESTAMP,
+	// which is read as a date and time in the YYYYMMDD or YYYYMMDDhhmmss formats.
+	leftNumeric  bool
+	rightNumeric bool
+	// binaryText is whether a binary string is compared with a nonbinary one, which makes the comparison binary
+	binaryText bool
+	// setType is the SET type whose values, and the strings compared with them, are compared by their bitmask
+	setType sql.SetType
+}
+
+// comparatorType returns the type of the operands of the types given that compares its values with a custom
+// comparator, which any comparison of them uses, if any.
+func comparatorType(leftType, rightType sql.Type) (sql.Type, bool) {
+	if sql.HasComparator(leftType) {
+		return leftType, true
+	}
+	if sql.HasComparator(rightType) {
+		return rightType, true
+	}
+	return nil, false
+}
+
+// comparesWithOperandType returns whether the values of operands of the types given are compared with the type of the
+// operands, without converting them. Strings are always compared according to the collation of the comparison, and
+// JSON values depending on whether they are documents or values decoded from them.
+func comparesWithOperandType(leftType, rightType sql.Type) bool {
+	return leftType == rightType && !sql.IsTextOnly(leftType) && !sql.IsJSON(leftType)
+}
+
+// coercion returns how the values of the operands of this comparison are converted before comparing them.
+func (c *comparison) coercion() (comparisonCoercion, error) {
 	leftType := c.Left().Type()
 	rightType := c.Right().Type()
+
+	// A JSON value compared with any other value is compared with it as a JSON value
+	if sql.IsJSON(leftType) || sql.IsJSON(rightType) {
+		return comparisonCoercion{
+			convertTo:   ConvertToJSON,
+			compareType: sql.JSON,
+			leftJSON:    sql.IsJSON(leftType),
+			rightJSON:   sql.IsJSON(rightType),
+		}, nil
+	}
+
+	// A SET value compared with a number or a string is compared by its bitmask, and the string by the bitmask of the
+	// members it lists
+	if set, other, ok := setOperand(leftType, rightType); ok {
+		coercion := comparisonCoercion{convertTo: ConvertToUnsigned, compareType: sql.Uint64}
+		if sql.IsNumber(other) {
+			coercion = numberCoercion(sql.Uint64, other)
+		}
+		coercion.setType = set
+		return coercion, nil
+	}
+
+	// A number compared with a DATE, DATETIME or TIMESTAMP value is read as a date and time, and they're compared
+	// chronologically. Like a string, it's in the session time zone when compared with a TIMESTAMP.
+	if (sql.IsTime(leftType) && sql.IsNumber(rightType)) || (sql.IsNumber(leftType) && sql.IsTime(rightType)) {
+		return comparisonCoercion{
+			convertTo:    ConvertToDatetime,
+			compareType:  sql.Datetime,
+			leftNumeric:  sql.IsNumber(leftType),
+			rightNumeric: sql.IsNumber(rightType),
+			leftLocal:    sql.IsNumber(leftType) && isTimestamp(rightType),
+			rightLocal:   sql.IsNumber(rightType) && isTimestamp(leftType),
+		}, nil
+	}
+
+	if sql.IsDecimal(leftType) && sql.IsDecimal(rightType) {
+		return comparisonCoercion{convertTo: ConvertToDecimal, compareType: c.decimalCompareType()}, nil
+	}
+
 	if sql.IsNumber(leftType) || sql.IsNumber(rightType) {
-		if sql.IsDecimal(leftType) || sql.IsDecimal(rightType) {
-			//TODO: We need to set to the actual DECIMAL type
-			l, r, err := convertLeftAndRight(left, right, ConvertToDecimal)
-			if err != nil {
-				return nil, nil, err
-			}
+		return numberCoercion(leftType, rightType), nil
+	}
 
-			if sql.IsDecimal(leftType) {
-				c.compareType = leftType
-			} else {
-				c.compareType = rightType
-			}
-			return l, r, nil
+	// A TIMESTAMP value is in UTC, while a string compared with it is in the session time zone
+	if (isTimestamp(leftType) && sql.IsTextOnly(rightType)) || (sql.IsTextOnly(leftType) && isTimestamp(rightType)) {
+		return comparisonCoercion{
+			convertTo:   ConvertToDatetime,
+			compareType: sql.Datetime,
+			leftLocal:   sql.IsTextOnly(leftType),
+			rightLocal:  sql.IsTextOnly(rightType),
+		}, nil
+	}
+
+	// PAD SPACE collations ignore trailing spaces when comparing strings, and case insensitive collations ignore their
+	// case. Custom collations compare them with the weights of their characters, and any other collation, such as a
+	// _bin one, byte by byte.
+	collation, err := c.collation()
+	if err != nil {
+		return comparisonCoercion{}, err
+	}
+
+	compareType := sql.CreateLongText(collation)
+
+	return comparisonCoercion{
+		convertTo:       ConvertToChar,
+		compareType:     compareType,
+		padSpace:        collation.PadSpace() == sql.PadSpace,
+		caseInsensitive: collation.IsCaseInsensitive(),
+		binaryText:      implicitlyBinary(c.Left(), c.Right()) || implicitlyBinary(c.Right(), c.Left()),
+	}, nil
+}
+
+// numberCoercion returns how the values of the operands of a comparison of the types given, one of which is a number,
+// are converted before comparing them.
+func numberCoercion(leftType, rightType sql.Type) comparisonCoercion {
+	if sql.IsDecimal(leftType) || sql.IsDecimal(rightType) {
+		//TODO: We need to set to the actual DECIMAL type
+		if sql.IsDecimal(leftType) {
+			return comparisonCoercion{convertTo: ConvertToDecimal, compareType: leftType}
 		}
+		return comparisonCoercion{convertTo: ConvertToDecimal, compareType: rightType}
+	}
 
-		if sql.IsFloat(leftType) || sql.IsFloat(rightType) {
-			l, r, err := convertLeftAndRight(left, right, ConvertToDouble)
-			if err != nil {
-				return nil, nil, err
-			}
+	if sql.IsFloat(leftType) || sql.IsFloat(rightType) {
+		return comparisonCoercion{convertTo: ConvertToDouble, compareType: sql.Float64}
+	}
+
+	if sql.IsSigned(leftType) || sql.IsSigned(rightType) {
+		return comparisonCoercion{convertTo: ConvertToSigned, compareType: sql.Int64}
+	}
+
+	return comparisonCoercion{convertTo: ConvertToUnsigned, compareType: sql.Uint64}
+}
+
+// decimalCompareType returns the decimal type two decimal operands are compared with, which doesn't depend on their
+// order. The type of the only column among them is used, so that it rounds the other operand to its own scale. When
+// both or none of them are columns, the type with the greatest scale is used, which doesn't round any of them, with
+// enough integer digits for both.
+func (c *comparison) decimalCompareType() sql.Type {
+	left, right := c.Left().Type().(sql.DecimalType), c.Right().Type().(sql.DecimalType)
+	_, leftColumn := c.Left().(*GetField)
+	_, rightColumn := c.Right().(*GetField)
+	switch {
+	case leftColumn && !rightColumn:
+		return left
+	case rightColumn && !leftColumn:
+		return right
+	}
+
+	if left.Scale() < right.Scale() || left.Scale() == right.Scale() && left.RoundingMode() > right.RoundingMode() {
+		left, right = right, left
+	}
 
-			c.compareType = sql.Float64
-			return l, r, nil
+	intDigits := left.Precision() - left.Scale()
+	if d := right.Precision() - right.Scale(); d > intDigits {
+		intDigits = d
+	}
+
+	precision := int(intDigits) + int(left.Scale())
+	if precision > sql.DecimalTypeMaxPrecision {
+		precision = sql.DecimalTypeMaxPrecision
+	}
+	return sql.MustCreateDecimalTypeWithRounding(uint8(precision), left.Scale(), left.RoundingMode())
+}
+
+// sessionDecimalType returns the type given with the decimal rounding mode of the context given if it's a decimal type
+// and the context sets one, or the same type otherwise.
+func sessionDecimalType(ctx *sql.Context, typ sql.Type) sql.Type {
+	if ctx == nil || ctx.DecimalRounding == nil {
+		return typ
+	}
+
+	dt, ok := typ.(sql.DecimalType)
+	if !ok || dt.RoundingMode() == *ctx.DecimalRounding {
+		return typ
+	}
+	return sql.MustCreateDecimalTypeWithRounding(dt.Precision(), dt.Scale(), *ctx.DecimalRounding)
+}
+
+// setOperand returns the SET type of an operand of a comparison of the types given whose values are compared by their
+// bitmask, which is a SET compared with a number or a string, along with the type of the other operand.
+func setOperand(leftType, rightType sql.Type) (sql.SetType, sql.Type, bool) {
+	for _, types := range [][2]sql.Type{{leftType, rightType}, {rightType, leftType}} {
+		set, ok := types[0].(sql.SetType)
+		if !ok || sql.IsSet(types[1]) {
+			continue
 		}
 
-		if sql.IsSigned(leftType) || sql.IsSigned(rightType) {
-			l, r, err := convertLeftAndRight(left, right, ConvertToSigned)
-			if err != nil {
-				return nil, nil, err
-			}
+		if sql.IsNumber(types[1]) || sql.IsTextOnly(types[1]) {
+			return set, types[1], true
+		}
+	}
+	return nil, nil, false
+}
+
+// apply converts the values given of the operands of a comparison so they can be compared with its compare type.
+func (cc comparisonCoercion) apply(ctx *sql.Context, left, right interface{}) (interface{}, interface{}, error) {
+	left, right = boolToInt(left), boolToInt(right)
+	if cc.setType != nil {
+		var err error
+		if left, err = setBitmask(cc.setType, left); err != nil {
+			return nil, nil, err
+		}
+		if right, err = setBitmask(cc.setType, right); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if cc.leftNumeric {
+		left = numericDatetime(left)
+	}
+	if cc.rightNumeric {
+		right = numericDatetime(right)
+	}
 
-			c.compareType = sql.Int64
-			return l, r, nil
+	if cc.convertTo == ConvertToJSON {
+		l, err := jsonOperand(left, cc.leftJSON)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		l, r, err := convertLeftAndRight(left, right, ConvertToUnsigned)
+		r, err := jsonOperand(right, cc.rightJSON)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		c.compareType = sql.Uint64
 		return l, r, nil
 	}
 
-	left, right, err := convertLeftAndRight(left, right, ConvertToChar)
+	if cc.leftLocal || cc.rightLocal {
+		loc, err := sql.SessionTimeZone(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if cc.leftLocal {
+			left = localTimeToUTC(left, loc)
+		}
+		if cc.rightLocal {
+			right = localTimeToUTC(right, loc)
+		}
+	}
+
+	cc.warn(ctx, left, right)
+	left, right = cc.numberPrefix(left), cc.numberPrefix(right)
+	left, right, err := convertLeftAndRight(left, right, cc.convertTo)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	c.compareType = sql.LongText
+	if cc.padSpace {
+		if l, ok := left.(string); ok {
+			left = strings.TrimRight(l, " ")
+		}
+		if r, ok := right.(string); ok {
+			right = strings.TrimRight(r, " ")
+		}
+	}
+
+	if cc.caseInsensitive {
+		if l, ok := left.(string); ok {
+			left = strings.ToLower(l)
+		}
+		if r, ok := right.(string); ok {
+			right = strings.ToLower(r)
+		}
+	}
+
 	return left, right, nil
 }
 
+// setBitmask returns the bitmask of the members of the SET type given that the string given lists, or the value given
+// as it is if it isn't a string.
+func setBitmask(set sql.SetType, v interface{}) (interface{}, error) {
+	switch v.(type) {
+	case string, []byte:
+		return set.Marshal(v)
+	default:
+		return v, nil
+	}
+}
+
+// warn adds to the session of the context given, if any, a warning for each surprising conversion of the values given
+// of the operands of a comparison: a string that isn't a number of the type numbers are compared as, which is
+// truncated, or a nonbinary string compared as a binary one.
+func (cc comparisonCoercion) warn(ctx *sql.Context, left, right interface{}) {
+	if ctx == nil || ctx.Session == nil {
+		return
+	}
+
+	if cc.binaryText {
+		ctx.Warn(warnCodeBinaryComparison, "Comparing a nonbinary string with a binary string as binary strings")
+	}
+
+	var typeName string
+	switch cc.convertTo {
+	case ConvertToSigned, ConvertToUnsigned:
+		typeName = "INTEGER"
+	case ConvertToDouble:
+		typeName = "DOUBLE"
+	case ConvertToDecimal:
+		typeName = "DECIMAL"
+	default:
+		return
+	}
+
+	for _, v := range []interface{}{left, right} {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		if _, whole := parseNumberPrefix(s); whole {
+			continue
+		}
+
+		ctx.Warn(warnCodeTruncatedWrongValue, "Truncated incorrect %s value: '%s'", typeName, s)
+	}
+}
+
+// numberPrefix returns the number a string compared as a number is read as, which is the number it starts with, or 0
+// if it doesn't start with one, truncated to an integer if it's compared as one. Any other value is returned as is.
+func (cc comparisonCoercion) numberPrefix(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+
+	switch cc.convertTo {
+	case ConvertToSigned, ConvertToUnsigned:
+		prefix, _ := parseNumberPrefix(s)
+		d, err := decimal.NewFromString(prefix)
+		if err != nil {
+			return v
+		}
+		return d.Truncate(0).String()
+	case ConvertToDouble, ConvertToDecimal:
+		prefix, _ := parseNumberPrefix(s)
+		return prefix
+	default:
+		return v
+	}
+}
+
+// parseNumberPrefix returns the longest prefix of the string given that is a decimal number, leading spaces aside, as
+// MySQL reads a string it converts to a number, or "0" if it doesn't start with one. It also returns whether the whole
+// string is a number, trailing spaces aside, so that nothing is truncated to read it.
+func parseNumberPrefix(s string) (string, bool) {
+	s = strings.TrimLeft(s, " \t\n\r")
+	isDigit := func(i int) bool { return i < len(s) && s[i] >= '0' && s[i] <= '9' }
+
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+
+	digits := 0
+	for ; isDigit(i); i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		j := i + 1
+		for ; isDigit(j); j++ {
+			digits++
+		}
+		if digits > 0 {
+			i = j
+		}
+	}
+	if digits == 0 {
+		return "0", false
+	}
+
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if isDigit(j) {
+			for i = j; isDigit(i); i++ {
+			}
+		}
+	}
+
+	return s[:i], strings.TrimRight(s[i:], " ") == ""
+}
+
+// implicitlyBinary returns whether the first expression given is a binary string compared with a nonbinary one, other
+// than a string made binary with the BINARY operator on purpose.
+func implicitlyBinary(e, other sql.Expression) bool {
+	if _, ok := e.(*BinaryCast); ok {
+		return false
+	}
+	return sql.IsBlob(e.Type()) && sql.IsTextOnly(other.Type())
+}
+
+// recordCoercion records in the diagnostics of the context given, if any, the type the operands of the comparison
+// expression given were converted to in order to compare them.
+func (c *comparison) recordCoercion(ctx *sql.Context, e sql.Expression) {
+	if ctx == nil || ctx.Diagnostics == nil || c.compareType == nil {
+		return
+	}
+
+	var coercion string
+	switch {
+	case sql.IsJSON(c.compareType):
+		coercion = ConvertToJSON
+	case sql.IsDecimal(c.compareType):
+		coercion = ConvertToDecimal
+	case sql.IsTime(c.compareType):
+		coercion = ConvertToDatetime
+	case c.compareType == sql.Float64:
+		coercion = ConvertToDouble
+	case c.compareType == sql.Int64:
+		coercion = ConvertToSigned
+	case c.compareType == sql.Uint64:
+		coercion = ConvertToUnsigned
+	default:
+		coercion = ConvertToChar
+	}
+
+	ctx.Diagnostics.RecordCoercion(e.String(), coercion)
+}
+
+// recordNullComparison logs in the diagnostics of the context given, if any, that the comparison expression given
+// evaluated to NULL with the row given, if the comparisons that evaluate to NULL are logged.
+func recordNullComparison(ctx *sql.Context, e sql.Expression, row sql.Row) {
+	if ctx == nil || !ctx.Diagnostics.LogsNullComparisons() {
+		return
+	}
+	ctx.Diagnostics.RecordNullComparison(e.String(), row)
+}
+
+// collation returns the collation used to compare the operands of this comparison as strings. A binary string operand,
+// such as one cast with BINARY, makes the comparison binary. Otherwise, the collations of the operands are aggregated
+// like MySQL does, by their coercibility, which makes the collation of a column take precedence over the collation of a
+// literal, and a literal of another character set is converted to the character set of the collation if it can be. An
+// error is returned if the collations can't be aggregated, or the literal can't be converted.
+func (c *comparison) collation() (sql.Collation, error) {
+	for _, e := range []sql.Expression{c.Left(), c.Right()} {
+		if sql.IsBlob(e.Type()) {
+			return sql.Collation_binary, nil
+		}
+	}
+
+	left, leftOk := operandCollation(c.Left())
+	right, rightOk := operandCollation(c.Right())
+	switch {
+	case !leftOk && !rightOk:
+		return sql.Collation_Default, nil
+	case !rightOk || left.collation == right.collation:
+		return left.collation, nil
+	case !leftOk:
+		return right.collation, nil
+	}
+
+	result, ok := aggregateCollations(left, right)
+	if !ok {
+		return sql.Collation_Default, sql.ErrCollationIllegalMix.New(left.collation, left.coercibility, right.collation, right.coercibility)
+	}
+
+	charset := result.CharacterSet()
+	for _, o := range []collationDerivation{left, right} {
+		if o.literal != nil && o.collation.CharacterSet() != charset && !charset.Encodes(*o.literal) {
+			return sql.Collation_Default, sql.ErrCollationIllegalMix.New(left.collation, left.coercibility, right.collation, right.coercibility)
+		}
+	}
+
+	return result, nil
+}
+
+// coercibility is how much the collation of a string expression gives way to the collation of another one it's
+// compared with, which is the one with the lowest coercibility.
+type coercibility int
+
+const (
+	// coercibilityImplicit is the coercibility of columns.
+	coercibilityImplicit coercibility = 2
+	// coercibilitySysconst is the coercibility of any other expression that isn't a literal, such as a function.
+	coercibilitySysconst coercibility = 3
+	// coercibilityCoercible is the coercibility of literals.
+	coercibilityCoercible coercibility = 4
+	// coercibilityIgnorable is the coercibility of NULL.
+	coercibilityIgnorable coercibility = 6
+)
+
+func (c coercibility) String() string {
+	switch c {
+	case coercibilityImplicit:
+		return "IMPLICIT"
+	case coercibilitySysconst:
+		return "SYSCONST"
+	case coercibilityCoercible:
+		return "COERCIBLE"
+	default:
+		return "IGNORABLE"
+	}
+}
+
+// collationDerivation is the collation of a string operand of a comparison, along with what's needed to aggregate it
+// with the collation of the other operand.
+type collationDerivation struct {
+	collation    sql.Collation
+	coercibility coercibility
+	// ascii is whether the operand only has ASCII characters, which can be converted to any character set
+	ascii bool
+	// literal is the value of the operand if it's a string literal, which can be converted to another character set
+	literal *string
+}
+
+// operandCollation returns the collation derivation of the operand given, if it's a string.
+func operandCollation(e sql.Expression) (collationDerivation, bool) {
+	st, ok := e.Type().(sql.StringType)
+	if !ok {
+		return collationDerivation{}, false
+	}
+
+	d := collationDerivation{
+		collation:    st.Collation(),
+		coercibility: coercibilitySysconst,
+		ascii:        st.CharacterSet() == sql.CharacterSet_ascii,
+	}
+
+	switch e := e.(type) {
+	case *GetField:
+		d.coercibility = coercibilityImplicit
+	case *Literal:
+		d.coercibility = coercibilityCoercible
+		switch v := e.Value().(type) {
+		case nil:
+			d.coercibility = coercibilityIgnorable
+		case string:
+			d.literal = &v
+			d.ascii = d.ascii || isASCII(v)
+		}
+	}
+
+	return d, true
+}
+
+// aggregateCollations returns the collation two string operands with the collations given are compared with, following
+// the rules MySQL uses to aggregate collations, or false if they can't be aggregated.
+func aggregateCollations(left, right collationDerivation) (sql.Collation, bool) {
+	switch {
+	case left.coercibility == coercibilityIgnorable:
+		return right.collation, true
+	case right.coercibility == coercibilityIgnorable:
+		return left.collation, true
+	}
+
+	if left.collation.CharacterSet() == right.collation.CharacterSet() {
+		switch {
+		case left.coercibility < right.coercibility:
+			return left.collation, true
+		case right.coercibility < left.coercibility:
+			return right.collation, true
+		}
+
+		// Of two collations of a character set, its binary collation takes precedence
+		binary := left.collation.CharacterSet().BinaryCollation()
+		if left.collation == binary || right.collation == binary {
+			return binary, true
+		}
+		return sql.Collation_Default, false
+	}
+
+	switch {
+	case isSupersetOf(left, right):
+		return left.collation, true
+	case isSupersetOf(right, left):
+		return right.collation, true
+	case left.coercibility < right.coercibility && right.coercibility >= coercibilitySysconst:
+		return left.collation, true
+	case right.coercibility < left.coercibility && left.coercibility >= coercibilitySysconst:
+		return right.collation, true
+	default:
+		return sql.Collation_Default, false
+	}
+}
+
+// isSupersetOf returns whether the first string operand given can take any value of the second one, which is when the
+// first one is Unicode and the second one isn't, or is ASCII, unless the coercibility of the second one is lower.
+func isSupersetOf(left, right collationDerivation) bool {
+	leftCharset, rightCharset := left.collation.CharacterSet(), right.collation.CharacterSet()
+	if leftCharset.IsUnicode() {
+		if left.coercibility < right.coercibility {
+			return true
+		}
+
+		// utf8mb4 is a superset of utf8mb3, since it also encodes the characters of 4 bytes
+		supplementary := leftCharset.MaxLength() > rightCharset.MaxLength() &&
+			(rightCharset == sql.CharacterSet_utf8mb3 || rightCharset == sql.CharacterSet_utf8) &&
+			leftCharset == sql.CharacterSet_utf8mb4
+		if left.coercibility == right.coercibility && (!rightCharset.IsUnicode() || supplementary) {
+			return true
+		}
+	}
+
+	return right.ascii && (left.coercibility < right.coercibility || (left.coercibility == right.coercibility && !left.ascii))
+}
+
+// isASCII returns whether the string given only has ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonOperand returns the JSON document of the value of an operand of a comparison with a JSON value. The value of a
+// JSON operand is either a document or a value decoded from one, such as the result of JSON_EXTRACT, while any other
+// value is the JSON scalar for it, so that a string is always compared as a JSON string.
+func jsonOperand(v interface{}, isJSON bool) (interface{}, error) {
+	if b, ok := v.([]byte); ok && isJSON {
+		return sql.JSON.Convert(b)
+	}
+
+	switch v := v.(type) {
+	case string:
+		return json.Marshal(v)
+	case []byte:
+		return json.Marshal(string(v))
+	case time.Time:
+		s, err := sql.LongText.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(s)
+	case int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, decimal.Decimal:
+		f, err := sql.Float64.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(f)
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// isTimestamp returns whether the type given is TIMESTAMP.
+func isTimestamp(t sql.Type) bool {
+	return sql.IsTime(t) && t.Type() == sqltypes.Timestamp
+}
+
+// localTimeToUTC returns the UTC time of a string with a date and time in the location given. Any other value, or a
+// string that isn't a date and time, is returned as is.
+func localTimeToUTC(v interface{}, loc *time.Location) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+
+	t, err := sql.Datetime.ConvertWithoutRangeCheck(s)
+	if err != nil {
+		return v
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc).UTC()
+}
+
+// numericDatetime returns the DATETIME string the number given represents when compared with a temporal value, which
+// MySQL reads in the YYYYMMDD or YYYYMMDDhhmmss formats, or in the YYMMDD or YYMMDDhhmmss ones, with years from 70 to 99
+// in the 1900s and the rest in the 2000s. Any fractional part is taken as fractional seconds. Numbers in no such
+// format are returned as they are, so they aren't converted to a DATETIME.
+func numericDatetime(v interface{}) interface{} {
+	var s string
+	switch n := v.(type) {
+	case int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint:
+		s = fmt.Sprint(n)
+	case float64:
+		s = strconv.FormatFloat(n, 'f', -1, 64)
+	case float32:
+		s = strconv.FormatFloat(float64(n), 'f', -1, 32)
+	case decimal.Decimal:
+		s = n.String()
+	case string:
+		s = n
+	default:
+		return v
+	}
+
+	digits, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits, fraction = s[:i], strings.TrimRight(s[i+1:], "0")
+	}
+
+	if len(digits) == 6 || len(digits) == 12 {
+		if digits[:2] < "70" {
+			digits = "20" + digits
+		} else {
+			digits = "19" + digits
+		}
+	}
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return v
+		}
+	}
+
+	switch len(digits) {
+	case 8:
+		digits += "000000"
+	case 14:
+	default:
+		return v
+	}
+
+	datetime := fmt.Sprintf("%s-%s-%s %s:%s:%s",
+		digits[:4], digits[4:6], digits[6:8], digits[8:10], digits[10:12], digits[12:])
+	if fraction != "" {
+		datetime += "." + fraction
+	}
+	return datetime
+}
+
+// convertComparedValue converts the value given of an operand of the type valueType to the type typ of the operand it's
+// compared with, reading numbers compared with temporal values as dates and times, like comparisons do.
+func convertComparedValue(typ, valueType sql.Type, v interface{}) (interface{}, error) {
+	if sql.IsTime(typ) && sql.IsNumber(valueType) {
+		v = numericDatetime(v)
+	}
+	return typ.Convert(v)
+}
+
+// boolToInt returns the integer a boolean value represents, since BOOLEAN is a synonym of TINYINT. Any other value is
+// returned as is.
+func boolToInt(v interface{}) interface{} {
+	if b, ok := v.(bool); ok {
+		if b {
+			return int8(1)
+		}
+		return int8(0)
+	}
+	return v
+}
+
 func convertLeftAndRight(left, right interface{}, convertTo string) (interface{}, interface{}, error) {
 	l, err := convertValue(left, convertTo)
 	if err != nil {
@@ -147,6 +1061,17 @@ func (*comparison) Type() sql.Type {
 	return sql.Boolean
 }
 
+// negatable returns whether the comparison can be replaced by its inverse operator when negated. That's not the case
+// when any operand can be NaN, or compared as a float parsed from a string that could be, since ordering comparisons
+// with NaN are false rather than NULL, nor for tuples, whose elements could be.
+func (c *comparison) negatable() bool {
+	left, right := c.Left().Type(), c.Right().Type()
+	if sql.IsTuple(left) || sql.IsTuple(right) || sql.IsFloat(left) || sql.IsFloat(right) {
+		return false
+	}
+	return sql.IsText(left) == sql.IsText(right)
+}
+
 // Left implements Comparer interface
 func (c *comparison) Left() sql.Expression { return c.BinaryExpression.Left }
 
@@ -165,12 +1090,23 @@ func NewEquals(left sql.Expression, right sql.Expression) *Equals {
 
 // Eval implements the Expression interface.
 func (e *Equals) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	result, err := e.Compare(ctx, row)
+	// The sql.NullEqualsNull policy makes = behave like <=>
+	if sql.NullEqualsNullPolicy(ctx) {
+		return e.nullSafeEquals(ctx, row, e)
+	}
+
+	result, err := e.compare(ctx, row, true)
+	e.recordCoercion(ctx, e)
 	if err != nil {
 		if ErrNilOperand.Is(err) {
+			recordNullComparison(ctx, e, row)
 			return nil, nil
 		}
 
+		if ErrNaNOperand.Is(err) || sql.ErrIncomparable.Is(err) {
+			return false, nil
+		}
+
 		return nil, err
 	}
 
@@ -193,6 +1129,69 @@ func (e *Equals) DebugString() string {
 	return fmt.Sprintf("%s = %s", sql.DebugString(e.Left()), sql.DebugString(e.Right()))
 }
 
+// NullSafeEquals is a comparison that checks an expression is equal to another like Equals does, except that it takes
+// two NULL operands as equal and a NULL operand as not equal to any other value, as <=> does, so it's never NULL.
+type NullSafeEquals struct {
+	comparison
+}
+
+// NewNullSafeEquals returns a new NullSafeEquals expression.
+func NewNullSafeEquals(left sql.Expression, right sql.Expression) *NullSafeEquals {
+	return &NullSafeEquals{newComparison(left, right)}
+}
+
+// IsNullable implements the Expression interface.
+func (e *NullSafeEquals) IsNullable() bool {
+	return false
+}
+
+// Eval implements the Expression interface.
+func (e *NullSafeEquals) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return e.nullSafeEquals(ctx, row, e)
+}
+
+// nullSafeEquals returns whether the operands of the comparison are equal, taking two NULLs as equal and a NULL as not
+// equal to any other value. The coercion of the comparison is recorded for the expression given, which is the
+// comparison itself.
+func (c *comparison) nullSafeEquals(ctx *sql.Context, row sql.Row, e sql.Expression) (interface{}, error) {
+	left, right, err := c.evalLeftAndRight(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if left == nil || right == nil {
+		return left == nil && right == nil, nil
+	}
+
+	result, err := c.compareValues(ctx, left, right, true, true)
+	c.recordCoercion(ctx, e)
+	if err != nil {
+		if ErrNaNOperand.Is(err) || sql.ErrIncomparable.Is(err) {
+			return false, nil
+		}
+
+		return nil, err
+	}
+
+	return result == 0, nil
+}
+
+// WithChildren implements the Expression interface.
+func (e *NullSafeEquals) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 2)
+	}
+	return NewNullSafeEquals(children[0], children[1]), nil
+}
+
+func (e *NullSafeEquals) String() string {
+	return fmt.Sprintf("%s <=> %s", e.Left(), e.Right())
+}
+
+func (e *NullSafeEquals) DebugString() string {
+	return fmt.Sprintf("%s <=> %s", sql.DebugString(e.Left()), sql.DebugString(e.Right()))
+}
+
 // Regexp is a comparison that checks an expression matches a regexp.
 type Regexp struct {
 	comparison
@@ -226,15 +1225,28 @@ func (re *Regexp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	result, err := re.Compare(ctx, row)
 	if err != nil {
 		if ErrNilOperand.Is(err) {
+			recordNullComparison(ctx, re, row)
 			return nil, nil
 		}
 
+		if ErrNaNOperand.Is(err) {
+			return false, nil
+		}
+
 		return nil, err
 	}
 
 	return result == 0, nil
 }
 
+// Coercion implements the CoercionDescriber interface.
+func (re *Regexp) Coercion(ctx *sql.Context) (sql.Type, string, error) {
+	if sql.IsText(re.Left().Type()) && sql.IsText(re.Right().Type()) {
+		return sql.LongText, CoercionBranchRegexp, nil
+	}
+	return re.comparison.Coercion(ctx)
+}
+
 type matcherErrTuple struct {
 	matcher regex.Matcher
 	err     error
@@ -295,7 +1307,7 @@ func (re *Regexp) compareRegexp(ctx *sql.Context, row sql.Row) (interface{}, err
 	if !re.cached {
 		disposer.Dispose()
 	} else if re.pool != nil {
-		re.pool.Put(matcher)
+		re.pool.Put(matcherErrTuple{matcher, nil})
 	}
 	return ok, nil
 }
@@ -329,11 +1341,22 @@ func NewGreaterThan(left sql.Expression, right sql.Expression) *GreaterThan {
 // Eval implements the Expression interface.
 func (gt *GreaterThan) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	result, err := gt.Compare(ctx, row)
+	gt.recordCoercion(ctx, gt)
 	if err != nil {
 		if ErrNilOperand.Is(err) {
+			recordNullComparison(ctx, gt, row)
+			return nil, nil
+		}
+
+		// Incomparable values are neither less nor greater than each other, so whether they're ordered is unknown
+		if sql.ErrIncomparable.Is(err) {
 			return nil, nil
 		}
 
+		if ErrNaNOperand.Is(err) {
+			return false, nil
+		}
+
 		return nil, err
 	}
 
@@ -348,6 +1371,14 @@ func (gt *GreaterThan) WithChildren(children ...sql.Expression) (sql.Expression,
 	return NewGreaterThan(children[0], children[1]), nil
 }
 
+// Negate implements the Negatable interface.
+func (gt *GreaterThan) Negate() (sql.Expression, bool) {
+	if !gt.negatable() {
+		return nil, false
+	}
+	return NewLessThanOrEqual(gt.Left(), gt.Right()), true
+}
+
 func (gt *GreaterThan) String() string {
 	return fmt.Sprintf("%s > %s", gt.Left(), gt.Right())
 }
@@ -369,11 +1400,22 @@ func NewLessThan(left sql.Expression, right sql.Expression) *LessThan {
 // Eval implements the expression interface.
 func (lt *LessThan) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	result, err := lt.Compare(ctx, row)
+	lt.recordCoercion(ctx, lt)
 	if err != nil {
 		if ErrNilOperand.Is(err) {
+			recordNullComparison(ctx, lt, row)
 			return nil, nil
 		}
 
+		// Incomparable values are neither less nor greater than each other, so whether they're ordered is unknown
+		if sql.ErrIncomparable.Is(err) {
+			return nil, nil
+		}
+
+		if ErrNaNOperand.Is(err) {
+			return false, nil
+		}
+
 		return nil, err
 	}
 
@@ -388,6 +1430,14 @@ func (lt *LessThan) WithChildren(children ...sql.Expression) (sql.Expression, er
 	return NewLessThan(children[0], children[1]), nil
 }
 
+// Negate implements the Negatable interface.
+func (lt *LessThan) Negate() (sql.Expression, bool) {
+	if !lt.negatable() {
+		return nil, false
+	}
+	return NewGreaterThanOrEqual(lt.Left(), lt.Right()), true
+}
+
 func (lt *LessThan) String() string {
 	return fmt.Sprintf("%s < %s", lt.Left(), lt.Right())
 }
@@ -410,11 +1460,22 @@ func NewGreaterThanOrEqual(left sql.Expression, right sql.Expression) *GreaterTh
 // Eval implements the Expression interface.
 func (gte *GreaterThanOrEqual) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	result, err := gte.Compare(ctx, row)
+	gte.recordCoercion(ctx, gte)
 	if err != nil {
 		if ErrNilOperand.Is(err) {
+			recordNullComparison(ctx, gte, row)
+			return nil, nil
+		}
+
+		// Incomparable values are neither less nor greater than each other, so whether they're ordered is unknown
+		if sql.ErrIncomparable.Is(err) {
 			return nil, nil
 		}
 
+		if ErrNaNOperand.Is(err) {
+			return false, nil
+		}
+
 		return nil, err
 	}
 
@@ -429,6 +1490,14 @@ func (gte *GreaterThanOrEqual) WithChildren(children ...sql.Expression) (sql.Exp
 	return NewGreaterThanOrEqual(children[0], children[1]), nil
 }
 
+// Negate implements the Negatable interface.
+func (gte *GreaterThanOrEqual) Negate() (sql.Expression, bool) {
+	if !gte.negatable() {
+		return nil, false
+	}
+	return NewLessThan(gte.Left(), gte.Right()), true
+}
+
 func (gte *GreaterThanOrEqual) String() string {
 	return fmt.Sprintf("%s >= %s", gte.Left(), gte.Right())
 }
@@ -451,11 +1520,22 @@ func NewLessThanOrEqual(left sql.Expression, right sql.Expression) *LessThanOrEq
 // Eval implements the Expression interface.
 func (lte *LessThanOrEqual) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	result, err := lte.Compare(ctx, row)
+	lte.recordCoercion(ctx, lte)
 	if err != nil {
 		if ErrNilOperand.Is(err) {
+			recordNullComparison(ctx, lte, row)
+			return nil, nil
+		}
+
+		// Incomparable values are neither less nor greater than each other, so whether they're ordered is unknown
+		if sql.ErrIncomparable.Is(err) {
 			return nil, nil
 		}
 
+		if ErrNaNOperand.Is(err) {
+			return false, nil
+		}
+
 		return nil, err
 	}
 
@@ -470,6 +1550,14 @@ func (lte *LessThanOrEqual) WithChildren(children ...sql.Expression) (sql.Expres
 	return NewLessThanOrEqual(children[0], children[1]), nil
 }
 
+// Negate implements the Negatable interface.
+func (lte *LessThanOrEqual) Negate() (sql.Expression, bool) {
+	if !lte.negatable() {
+		return nil, false
+	}
+	return NewGreaterThan(lte.Left(), lte.Right()), true
+}
+
 func (lte *LessThanOrEqual) String() string {
 	return fmt.Sprintf("%s <= %s", lte.Left(), lte.Right())
 }
@@ -485,4 +1573,10 @@ var (
 	// ErrInvalidOperandColumns is returned when the columns in the left operand
 	// and the elements of the right operand don't match.
 	ErrInvalidOperandColumns = errors.NewKind("operand should have %d columns, but has %d")
+	// ErrUnhashableInOperand is returned when creating a hashed IN operation
+	// on a type whose values can't be hashed.
+	ErrUnhashableInOperand = errors.NewKind("values of type %s can't be looked up in a hash set")
+	// ErrUnhashableInElement is returned when creating a hashed IN operation
+	// with a list element that isn't a literal.
+	ErrUnhashableInElement = errors.NewKind("element %s of a hashed IN list is not a literal")
 )
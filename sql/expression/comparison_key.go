@@ -0,0 +1,106 @@
+package expression
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ComparisonKey returns a key of the value given of the expression given that is the same for all the values of the
+// expression that compare as equal with it, as comparisons of two values of the expression do. Strings are converted
+// according to the collation of the expression, so that the case of case insensitive collations, and the trailing
+// spaces of PAD SPACE ones, don't change the key, and decimals and times are converted to strings that don't depend
+// on their scale or time zone. The elements of tuples are converted as values of their own types. Unlike the values
+// themselves, the keys can be hashed to deduplicate values.
+func ComparisonKey(ctx *sql.Context, e sql.Expression, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	typ := e.Type()
+	if sql.IsTuple(typ) {
+		values, ok := v.([]interface{})
+		if !ok {
+			return nil, sql.ErrNotTuple.New(v)
+		}
+
+		types := sql.TupleTypes(typ)
+		keys := make([]interface{}, len(values))
+		for i, value := range values {
+			key, err := ComparisonKey(ctx, NewLiteral(value, types[i]), value)
+			if err != nil {
+				return nil, err
+			}
+			keys[i] = key
+		}
+		return keys, nil
+	}
+
+	c := newComparison(e, e)
+	if !comparesWithOperandType(typ, typ) {
+		coercion, err := c.coercion()
+		if err != nil {
+			return nil, err
+		}
+
+		key, _, err := coercion.apply(ctx, v, v)
+		if err != nil {
+			return nil, err
+		}
+
+		if s, ok := key.(string); ok && sql.IsText(coercion.compareType) {
+			collation, err := c.collation()
+			if err != nil {
+				return nil, err
+			}
+			key = sql.CollationKey(collation, s)
+		}
+		v = key
+	}
+
+	switch v := v.(type) {
+	case decimal.Decimal:
+		return v.String(), nil
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano), nil
+	case float64:
+		// -0 is equal to 0
+		if v == 0 {
+			return float64(0), nil
+		}
+	case float32:
+		if v == 0 {
+			return float32(0), nil
+		}
+	}
+	return v, nil
+}
+
+// ComparisonHash returns a hash of the comparison keys of the values given of the expressions given, as ComparisonKey
+// returns them, which is the same for all the values that compare as equal with them. Every operation that finds
+// equal rows or values by hashing them, such as DISTINCT, UNION, GROUP BY and COUNT(DISTINCT), hashes them this way,
+// so that they all agree on which ones are equal.
+func ComparisonHash(ctx *sql.Context, exprs []sql.Expression, values []interface{}) (uint64, error) {
+	keys := make([]interface{}, len(exprs))
+	for i, e := range exprs {
+		key, err := ComparisonKey(ctx, e, values[i])
+		if err != nil {
+			return 0, err
+		}
+		keys[i] = key
+	}
+
+	return sql.CacheKey(keys), nil
+}
+
+// SchemaFields returns a field for each of the columns of the schema given, whose comparison keys are those of the
+// values of the column.
+func SchemaFields(schema sql.Schema) []sql.Expression {
+	fields := make([]sql.Expression, len(schema))
+	for i, col := range schema {
+		fields[i] = NewGetField(i, col.Type, col.Name, col.Nullable)
+	}
+	return fields
+}
@@ -0,0 +1,51 @@
+package expression
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestComparisonKey(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	utc := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		name  string
+		typ   sql.Type
+		a, b  interface{}
+		equal bool
+	}{
+		{"case insensitive strings", sql.CreateLongText(sql.Collation_utf8mb4_general_ci), "foo", "FOO ", true},
+		{"binary collation strings", sql.CreateLongText(sql.Collation_utf8mb4_bin), "foo", "FOO", false},
+		{"decimals with different scales", sql.MustCreateDecimalType(10, 3), decimal.RequireFromString("1.5"), decimal.RequireFromString("1.500"), true},
+		{"different decimals", sql.MustCreateDecimalType(10, 3), decimal.RequireFromString("1.5"), decimal.RequireFromString("1.05"), false},
+		{"times in different zones", sql.Datetime, utc, utc.In(time.FixedZone("", 3600)), true},
+		{"different times", sql.Datetime, utc, utc.Add(time.Second), false},
+		{"zeros", sql.Float64, float64(0), math.Copysign(0, -1), true},
+		{"tuples", sql.CreateTuple(sql.Int64, sql.CreateLongText(sql.Collation_utf8mb4_general_ci)), []interface{}{int64(1), "a"}, []interface{}{int64(1), "A"}, true},
+		{"different tuples", sql.CreateTuple(sql.Int64, sql.LongText), []interface{}{int64(1), "a"}, []interface{}{int64(2), "a"}, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			e := NewGetField(0, tt.typ, "x", true)
+
+			a, err := ComparisonKey(ctx, e, tt.a)
+			require.NoError(err)
+			b, err := ComparisonKey(ctx, e, tt.b)
+			require.NoError(err)
+
+			if tt.equal {
+				require.Equal(a, b)
+			} else {
+				require.NotEqual(a, b)
+			}
+		})
+	}
+}
@@ -1,13 +1,20 @@
 package expression_test
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/internal/regex"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 )
 
 const (
@@ -119,6 +126,27 @@ func TestEquals(t *testing.T) {
 	}
 }
 
+func TestNullSafeEquals(t *testing.T) {
+	require := require.New(t)
+	for resultType, cmpCase := range comparisonCases {
+		get0 := expression.NewGetField(0, resultType, "col1", true)
+		get1 := expression.NewGetField(1, resultType, "col2", true)
+		eq := expression.NewNullSafeEquals(get0, get1)
+		require.Equal(sql.Boolean, eq.Type())
+		require.False(eq.IsNullable())
+		for cmpResult, cases := range cmpCase {
+			for _, pair := range cases {
+				cmp := eval(t, eq, sql.NewRow(pair[0], pair[1]))
+				if cmpResult == testNil {
+					require.Equal(pair[0] == nil && pair[1] == nil, cmp)
+				} else {
+					require.Equal(cmpResult == testEqual, cmp)
+				}
+			}
+		}
+	}
+}
+
 func TestLessThan(t *testing.T) {
 	require := require.New(t)
 	for resultType, cmpCase := range comparisonCases {
@@ -229,3 +257,881 @@ func eval(t *testing.T, e sql.Expression, row sql.Row) interface{} {
 	require.NoError(t, err)
 	return v
 }
+
+func TestDecimalRoundingComparison(t *testing.T) {
+	halfUp := sql.MustCreateDecimalTypeWithRounding(10, 2, sql.DecimalRounding_HalfUp)
+	truncate := sql.MustCreateDecimalTypeWithRounding(10, 2, sql.DecimalRounding_Truncate)
+	value := expression.NewLiteral("1.005", sql.MustCreateDecimalType(10, 3))
+
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		money    string
+		expected bool
+	}{
+		{"round half up, 1.01", halfUp, "1.01", true},
+		{"round half up, 1.00", halfUp, "1.00", false},
+		{"truncate, 1.01", truncate, "1.01", false},
+		{"truncate, 1.00", truncate, "1.00", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			money := expression.NewGetField(0, tt.typ, "money", false)
+
+			// The column rounds the literal to its own scale whatever the order of the operands
+			for _, eq := range []sql.Expression{expression.NewEquals(money, value), expression.NewEquals(value, money)} {
+				result, err := eq.Eval(sql.NewEmptyContext(), sql.NewRow(tt.money))
+				require.NoError(err)
+				require.Equal(tt.expected, result, eq.String())
+			}
+		})
+	}
+
+	// Two decimal literals, or two decimal columns, are compared with the greatest scale, rounding none of them
+	for _, typ := range []sql.Type{halfUp, truncate} {
+		for _, eq := range []sql.Expression{
+			expression.NewEquals(expression.NewLiteral("1.01", typ), value),
+			expression.NewEquals(value, expression.NewLiteral("1.01", typ)),
+			expression.NewEquals(expression.NewLiteral("1.00", typ), value),
+			expression.NewEquals(value, expression.NewLiteral("1.00", typ)),
+		} {
+			require.Equal(t, false, eval(t, eq, nil), eq.String())
+		}
+
+		left := expression.NewGetField(0, typ, "money", false)
+		right := expression.NewGetField(1, value.Type(), "value", false)
+		for _, eq := range []sql.Expression{expression.NewEquals(left, right), expression.NewEquals(right, left)} {
+			require.Equal(t, false, eval(t, eq, sql.NewRow("1.01", "1.005")), eq.String())
+			require.Equal(t, true, eval(t, eq, sql.NewRow("1.01", "1.010")), eq.String())
+		}
+	}
+}
+
+func TestFloatDecimalRoundingComparison(t *testing.T) {
+	halfUp := sql.MustCreateDecimalType(5, 2)
+	halfEven := sql.MustCreateDecimalTypeWithRounding(5, 2, sql.DecimalRounding_HalfEven)
+	sessionHalfUp := sql.NewContext(context.Background(), sql.WithDecimalRounding(sql.DecimalRounding_HalfUp))
+	sessionHalfEven := sql.NewContext(context.Background(), sql.WithDecimalRounding(sql.DecimalRounding_HalfEven))
+
+	testCases := []struct {
+		name     string
+		ctx      *sql.Context
+		typ      sql.Type
+		float    float64
+		money    string
+		expected bool
+	}{
+		{"round half up, 2.675 = 2.68", sql.NewEmptyContext(), halfUp, 2.675, "2.68", true},
+		{"round half up, 2.665 = 2.67", sql.NewEmptyContext(), halfUp, 2.665, "2.67", true},
+		{"round half up, 2.665 = 2.66", sql.NewEmptyContext(), halfUp, 2.665, "2.66", false},
+		{"round half even, 2.675 = 2.68", sql.NewEmptyContext(), halfEven, 2.675, "2.68", true},
+		{"round half even, 2.665 = 2.66", sql.NewEmptyContext(), halfEven, 2.665, "2.66", true},
+		{"round half even, 2.665 = 2.67", sql.NewEmptyContext(), halfEven, 2.665, "2.67", false},
+		{"session round half even, 2.665 = 2.66", sessionHalfEven, halfUp, 2.665, "2.66", true},
+		{"session round half even, 2.665 = 2.67", sessionHalfEven, halfUp, 2.665, "2.67", false},
+		{"session round half up, 2.665 = 2.67", sessionHalfUp, halfEven, 2.665, "2.67", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			eq := expression.NewEquals(
+				expression.NewLiteral(tt.float, sql.Float64),
+				expression.NewGetField(0, tt.typ, "money", false),
+			)
+			result, err := eq.Eval(tt.ctx, sql.NewRow(tt.money))
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestTrailingSpaceComparison(t *testing.T) {
+	padSpace := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin)
+	noPad := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_0900_ai_ci)
+
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		expr     func(left, right sql.Expression) sql.Expression
+		expected bool
+	}{
+		{"pad space equals", padSpace, func(l, r sql.Expression) sql.Expression { return expression.NewEquals(l, r) }, true},
+		{"pad space less than", padSpace, func(l, r sql.Expression) sql.Expression { return expression.NewLessThan(l, r) }, false},
+		{"pad space like", padSpace, expression.NewLike, false},
+		{"no pad equals", noPad, func(l, r sql.Expression) sql.Expression { return expression.NewEquals(l, r) }, false},
+		{"no pad like", noPad, expression.NewLike, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			e := tt.expr(
+				expression.NewGetField(0, tt.typ, "name", false),
+				expression.NewLiteral("a ", sql.LongText),
+			)
+			result, err := e.Eval(sql.NewEmptyContext(), sql.NewRow("a"))
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestBinCollationComparison(t *testing.T) {
+	bin := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin)
+	ci := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_0900_ai_ci)
+
+	binCol := expression.NewGetField(0, bin, "bin_col", false)
+	binCol2 := expression.NewGetField(1, bin, "bin_col2", false)
+	ciCol := expression.NewGetField(2, ci, "ci_col", false)
+	ciCol2 := expression.NewGetField(3, ci, "ci_col2", false)
+	lower := expression.NewLiteral("abc", sql.LongText)
+	row := sql.NewRow("ABC", "abc", "ABC", "abc")
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		expected interface{}
+	}{
+		{"bin column equals literal", expression.NewEquals(binCol, lower), false},
+		{"ci column equals literal", expression.NewEquals(ciCol, lower), true},
+		{"bin columns", expression.NewEquals(binCol, binCol2), false},
+		{"ci columns", expression.NewEquals(ciCol, ciCol2), true},
+		{"bin column less than", expression.NewLessThan(binCol, lower), true},
+		{"ci column less than", expression.NewLessThan(ciCol, lower), false},
+		{"same query", expression.NewAnd(
+			expression.NewEquals(ciCol, lower),
+			expression.NewNot(expression.NewEquals(binCol, lower)),
+		), true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestCharacterSetComparison(t *testing.T) {
+	latin1 := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_latin1_swedish_ci)
+	latin1Bin := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_latin1_bin)
+	german := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_latin1_german1_ci)
+	ascii := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_ascii_general_ci)
+	utf8mb3 := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb3_general_ci)
+	utf8mb4 := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_0900_ai_ci)
+
+	latin1Col := expression.NewGetField(0, latin1, "latin1_col", false)
+	latin1BinCol := expression.NewGetField(1, latin1Bin, "latin1_bin_col", false)
+	germanCol := expression.NewGetField(2, german, "german_col", false)
+	asciiCol := expression.NewGetField(3, ascii, "ascii_col", false)
+	utf8mb3Col := expression.NewGetField(4, utf8mb3, "utf8mb3_col", false)
+	utf8mb4Col := expression.NewGetField(5, utf8mb4, "utf8mb4_col", false)
+	row := sql.NewRow("café", "CAFÉ", "café", "cafe", "café", "CAFÉ")
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{"latin1 column equals convertible literal", expression.NewEquals(latin1Col, expression.NewLiteral("CAFÉ", sql.LongText)), true, nil},
+		{"literal equals latin1 column", expression.NewEquals(expression.NewLiteral("CAFÉ", sql.LongText), latin1Col), true, nil},
+		{"latin1 column equals cp1252 literal", expression.NewEquals(latin1Col, expression.NewLiteral("€", sql.LongText)), false, nil},
+		{"latin1 and utf8mb4 columns", expression.NewEquals(latin1Col, utf8mb4Col), true, nil},
+		{"latin1 and latin1_bin columns", expression.NewEquals(latin1Col, latin1BinCol), false, nil},
+		{"ascii and latin1_bin columns", expression.NewEquals(asciiCol, latin1BinCol), false, nil},
+		{"utf8mb3 and utf8mb4 columns", expression.NewEquals(utf8mb3Col, utf8mb4Col), true, nil},
+		{"latin1 column equals unconvertible literal", expression.NewEquals(latin1Col, expression.NewLiteral("caf☕", sql.LongText)), nil, sql.ErrCollationIllegalMix},
+		{"utf8mb3 column equals unconvertible literal", expression.NewEquals(utf8mb3Col, expression.NewLiteral("caf😀", sql.LongText)), nil, sql.ErrCollationIllegalMix},
+		{"columns of two latin1 collations", expression.NewEquals(latin1Col, germanCol), nil, sql.ErrCollationIllegalMix},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), row)
+			if tt.err != nil {
+				require.Error(err)
+				require.True(tt.err.Is(err), "unexpected error %v", err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestSetComparison(t *testing.T) {
+	set := sql.MustCreateSetType([]string{"a", "b", "c"}, sql.Collation_Default)
+	col := expression.NewGetField(0, set, "s", true)
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		value    interface{}
+		expected interface{}
+	}{
+		{"equals bitmask", expression.NewEquals(col, expression.NewLiteral(int64(3), sql.Int64)), "a,b", true},
+		{"bitmask equals", expression.NewEquals(expression.NewLiteral(uint8(3), sql.Uint8), col), "a,b", true},
+		{"not equals bitmask", expression.NewEquals(col, expression.NewLiteral(int64(3), sql.Int64)), "c", false},
+		{"greater than bitmask", expression.NewGreaterThan(col, expression.NewLiteral(int64(3), sql.Int64)), "c", true},
+		{"less than fractional bitmask", expression.NewLessThan(col, expression.NewLiteral(3.5, sql.Float64)), "a,b", true},
+		{"equals empty bitmask", expression.NewEquals(col, expression.NewLiteral(int64(0), sql.Int64)), "", true},
+		{"equals string", expression.NewEquals(col, expression.NewLiteral("b,a", sql.LongText)), "a,b", true},
+		{"equals string of other case", expression.NewEquals(col, expression.NewLiteral("B,A", sql.LongText)), "a,b", true},
+		{"not equals string", expression.NewEquals(col, expression.NewLiteral("a,c", sql.LongText)), "a,b", false},
+		{"less than string", expression.NewLessThan(col, expression.NewLiteral("c", sql.LongText)), "a,b", true},
+		{"equals NULL", expression.NewEquals(col, expression.NewLiteral(int64(3), sql.Int64)), nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), sql.NewRow(tt.value))
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+
+	_, err := expression.NewEquals(col, expression.NewLiteral("d", sql.LongText)).Eval(sql.NewEmptyContext(), sql.NewRow("a"))
+	require.True(t, sql.ErrInvalidSetValue.Is(err), "unexpected error %v", err)
+}
+
+func TestJSONComparison(t *testing.T) {
+	doc := expression.NewGetField(0, sql.LongText, "doc", false)
+	js := expression.NewGetField(1, sql.JSON, "js", false)
+	extract := func(path string) sql.Expression {
+		e, err := function.NewJSONExtract(doc, expression.NewLiteral(path, sql.LongText))
+		require.NoError(t, err)
+		return e
+	}
+	unquote := func(path string) sql.Expression {
+		return function.NewJSONUnquote(extract(path))
+	}
+	row := sql.NewRow(`{"n": 5, "s": "5", "t": "abc", "a": [1, 2]}`, []byte(`{"b": 2, "a": 1}`))
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		expected interface{}
+	}{
+		{"json number equals number", expression.NewEquals(extract("$.n"), expression.NewLiteral(int64(5), sql.Int64)), true},
+		{"json number equals string", expression.NewEquals(extract("$.n"), expression.NewLiteral("5", sql.LongText)), false},
+		{"unquoted json number equals string", expression.NewEquals(unquote("$.n"), expression.NewLiteral("5", sql.LongText)), true},
+		{"json string equals string", expression.NewEquals(extract("$.s"), expression.NewLiteral("5", sql.LongText)), true},
+		{"json string equals number", expression.NewEquals(extract("$.s"), expression.NewLiteral(int64(5), sql.Int64)), false},
+		{"unquoted json string equals number", expression.NewEquals(unquote("$.s"), expression.NewLiteral(int64(5), sql.Int64)), true},
+		{"json string greater than number", expression.NewGreaterThan(extract("$.t"), expression.NewLiteral(int64(100), sql.Int64)), true},
+		{"json number less than json string", expression.NewLessThan(extract("$.n"), extract("$.s")), true},
+		{"json arrays", expression.NewEquals(extract("$.a"), expression.NewLiteral([]byte("[1, 2]"), sql.JSON)), true},
+		{"json objects with keys in a different order", expression.NewEquals(js, expression.NewLiteral([]byte(`{"a": 1, "b": 2}`), sql.JSON)), true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestNaNComparison(t *testing.T) {
+	nan := expression.NewLiteral(math.NaN(), sql.Float64)
+	inf := expression.NewLiteral(math.Inf(1), sql.Float64)
+	negInf := expression.NewLiteral(math.Inf(-1), sql.Float64)
+	one := expression.NewLiteral(float64(1), sql.Float64)
+	intOne := expression.NewLiteral(int64(1), sql.Int64)
+	typedEquals, err := expression.NewTypedComparison(expression.OpEquals, nan, nan, sql.Float64)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		expected interface{}
+	}{
+		{"NaN = NaN", expression.NewEquals(nan, nan), false},
+		{"NaN = 1", expression.NewEquals(nan, one), false},
+		{"1 = NaN", expression.NewEquals(one, nan), false},
+		{"NaN < 1", expression.NewLessThan(nan, one), false},
+		{"NaN > 1", expression.NewGreaterThan(nan, one), false},
+		{"NaN <= NaN", expression.NewLessThanOrEqual(nan, nan), false},
+		{"NaN >= 1", expression.NewGreaterThanOrEqual(nan, one), false},
+		{"NaN = integer", expression.NewEquals(nan, intOne), false},
+		{"NaN < Inf", expression.NewLessThan(nan, inf), false},
+		{"NaN > -Inf", expression.NewGreaterThan(nan, negInf), false},
+		{"typed NaN = NaN", typedEquals, false},
+		{"Inf = Inf", expression.NewEquals(inf, inf), true},
+		{"Inf > 1", expression.NewGreaterThan(inf, one), true},
+		{"-Inf < 1", expression.NewLessThan(negInf, one), true},
+		{"-Inf < Inf", expression.NewLessThan(negInf, inf), true},
+		{"Inf >= -Inf", expression.NewGreaterThanOrEqual(inf, negInf), true},
+		{"Inf <= integer", expression.NewLessThanOrEqual(inf, intOne), false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestBooleanComparison(t *testing.T) {
+	a := expression.NewGetField(0, sql.Int64, "a", false)
+	b := expression.NewGetField(1, sql.Int64, "b", false)
+	boolCol := expression.NewGetField(2, sql.Boolean, "bool_col", false)
+	zero := expression.NewLiteral(int64(0), sql.Int64)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	trueLit := expression.NewLiteral(true, sql.Boolean)
+
+	testCases := []struct {
+		name     string
+		expr     sql.Expression
+		row      sql.Row
+		expected interface{}
+	}{
+		{"(a > b) = 1, true", expression.NewEquals(expression.NewGreaterThan(a, b), one), sql.NewRow(int64(2), int64(1), int8(0)), true},
+		{"(a > b) = 1, false", expression.NewEquals(expression.NewGreaterThan(a, b), one), sql.NewRow(int64(1), int64(2), int8(0)), false},
+		{"(a > b) = 0", expression.NewEquals(expression.NewGreaterThan(a, b), zero), sql.NewRow(int64(1), int64(2), int8(0)), true},
+		{"bool_col > 0, true", expression.NewGreaterThan(boolCol, zero), sql.NewRow(int64(0), int64(0), int8(1)), true},
+		{"bool_col > 0, false", expression.NewGreaterThan(boolCol, zero), sql.NewRow(int64(0), int64(0), int8(0)), false},
+		{"bool_col = TRUE, true", expression.NewEquals(boolCol, trueLit), sql.NewRow(int64(0), int64(0), int8(1)), true},
+		{"bool_col = TRUE, false", expression.NewEquals(boolCol, trueLit), sql.NewRow(int64(0), int64(0), int8(0)), false},
+		{"TRUE = '1'", expression.NewEquals(trueLit, expression.NewLiteral("1", sql.LongText)), nil, true},
+		{"TRUE = 1.0", expression.NewEquals(trueLit, expression.NewLiteral("1.0", sql.MustCreateDecimalType(10, 1))), nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestComparisonCoercionDiagnostics(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		value    interface{}
+		right    sql.Expression
+		expected string
+	}{
+		{"int and string", sql.Int64, int64(5), expression.NewLiteral("5x", sql.LongText), expression.ConvertToSigned},
+		{"unsigned and unsigned", sql.Uint64, uint64(5), expression.NewLiteral(uint8(5), sql.Uint8), expression.ConvertToUnsigned},
+		{"float and int", sql.Float64, float64(5), expression.NewLiteral(int64(5), sql.Int64), expression.ConvertToDouble},
+		{"decimal and int", sql.MustCreateDecimalType(10, 2), "5.00", expression.NewLiteral(int64(5), sql.Int64), expression.ConvertToDecimal},
+		{"string and string", sql.Text, "5", expression.NewLiteral("5", sql.LongText), expression.ConvertToChar},
+		{"timestamp and string", sql.Timestamp, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), expression.NewLiteral("2020-01-01", sql.LongText), expression.ConvertToDatetime},
+		{"same types", sql.Int64, int64(5), expression.NewLiteral(int64(5), sql.Int64), ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			diagnostics := sql.NewDiagnostics()
+			ctx := sql.NewEmptyContext()
+			ctx.ApplyOpts(sql.WithDiagnostics(diagnostics))
+
+			eq := expression.NewEquals(expression.NewGetField(0, tt.typ, "col", false), tt.right)
+			_, err := eq.Eval(ctx, sql.NewRow(tt.value))
+			require.NoError(err)
+
+			coercion, ok := diagnostics.Coercion(eq.String())
+			require.Equal(tt.expected != "", ok)
+			require.Equal(tt.expected, coercion)
+		})
+	}
+}
+
+func TestNullComparisonLog(t *testing.T) {
+	require := require.New(t)
+
+	col := expression.NewGetField(0, sql.Int64, "col", true)
+	other := expression.NewGetField(1, sql.Int64, "other", true)
+	eq := expression.NewEquals(col, expression.NewLiteral(nil, sql.Null))
+	lt := expression.NewLessThan(col, other)
+	rows := []sql.Row{{int64(1), int64(2)}, {nil, int64(2)}, {int64(1), nil}}
+
+	filter := func(ctx *sql.Context, e sql.Expression) []sql.Row {
+		var matched []sql.Row
+		for _, row := range rows {
+			v, err := e.Eval(ctx, row)
+			require.NoError(err)
+			if v == true {
+				matched = append(matched, row)
+			}
+		}
+		return matched
+	}
+
+	diagnostics := sql.NewDiagnostics()
+	ctx := sql.NewEmptyContext()
+	ctx.ApplyOpts(sql.WithDiagnostics(diagnostics))
+	require.Empty(filter(ctx, eq))
+	require.False(diagnostics.LogsNullComparisons())
+	require.Empty(diagnostics.NullComparisons())
+
+	diagnostics = sql.NewDiagnostics().LogNullComparisons()
+	ctx.ApplyOpts(sql.WithDiagnostics(diagnostics))
+	require.Empty(filter(ctx, eq))
+	require.Equal([]sql.Row{{int64(1), int64(2)}}, filter(ctx, lt))
+	require.Equal([]sql.NullComparison{
+		{Comparison: eq.String(), Row: sql.Row{int64(1), int64(2)}},
+		{Comparison: eq.String(), Row: sql.Row{nil, int64(2)}},
+		{Comparison: eq.String(), Row: sql.Row{int64(1), nil}},
+		{Comparison: lt.String(), Row: sql.Row{nil, int64(2)}},
+		{Comparison: lt.String(), Row: sql.Row{int64(1), nil}},
+	}, diagnostics.NullComparisons())
+}
+
+func TestTimestampComparisonTimeZone(t *testing.T) {
+	ts := expression.NewGetField(0, sql.Timestamp, "ts", false)
+	dt := expression.NewGetField(0, sql.Datetime, "dt", false)
+	noon := sql.NewRow(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	lit := func(s string) sql.Expression {
+		return expression.NewLiteral(s, sql.LongText)
+	}
+
+	testCases := []struct {
+		name     string
+		timeZone string
+		cmp      sql.Expression
+		expected interface{}
+	}{
+		{"UTC equal", "+00:00", expression.NewEquals(ts, lit("2020-06-01 12:00:00")), true},
+		{"UTC not equal", "+00:00", expression.NewEquals(ts, lit("2020-06-01 14:00:00")), false},
+		{"UTC less than", "+00:00", expression.NewLessThan(ts, lit("2020-06-01 13:00:00")), true},
+		{"offset equal", "+02:00", expression.NewEquals(ts, lit("2020-06-01 14:00:00")), true},
+		{"offset not equal", "+02:00", expression.NewEquals(ts, lit("2020-06-01 12:00:00")), false},
+		{"offset less than", "+02:00", expression.NewLessThan(ts, lit("2020-06-01 13:00:00")), false},
+		{"offset literal on the left", "+02:00", expression.NewGreaterThan(lit("2020-06-01 14:30:00"), ts), true},
+		{"named zone equal", "America/New_York", expression.NewEquals(ts, lit("2020-06-01 08:00:00")), true},
+		{"SYSTEM equal", "SYSTEM", expression.NewEquals(ts, lit("2020-06-01 12:00:00")), true},
+		{"datetime ignores the time zone", "+02:00", expression.NewEquals(dt, lit("2020-06-01 12:00:00")), true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			require.NoError(ctx.Set(ctx, "time_zone", sql.LongText, tt.timeZone))
+
+			result, err := tt.cmp.Eval(ctx, noon)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+
+	t.Run("invalid time zone", func(t *testing.T) {
+		ctx := sql.NewEmptyContext()
+		require.NoError(t, ctx.Set(ctx, "time_zone", sql.LongText, "Nowhere/Special"))
+
+		_, err := expression.NewEquals(ts, lit("2020-06-01 12:00:00")).Eval(ctx, noon)
+		require.True(t, sql.ErrInvalidTimeZone.Is(err))
+	})
+}
+
+func TestTemporalNumberComparison(t *testing.T) {
+	d := expression.NewGetField(0, sql.Date, "d", false)
+	dt := expression.NewGetField(1, sql.Datetime, "dt", false)
+	ts := expression.NewGetField(2, sql.Timestamp, "ts", false)
+	row := sql.NewRow(
+		time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+	)
+	lit := func(v interface{}, typ sql.Type) sql.Expression {
+		return expression.NewLiteral(v, typ)
+	}
+
+	testCases := []struct {
+		name     string
+		cmp      sql.Expression
+		expected interface{}
+	}{
+		{"date equal to YYYYMMDD", expression.NewEquals(d, lit(int32(20200101), sql.Int32)), true},
+		{"date not equal to YYYYMMDD", expression.NewEquals(d, lit(int32(20200102), sql.Int32)), false},
+		{"date less than YYYYMMDD", expression.NewLessThan(d, lit(int32(20200102), sql.Int32)), true},
+		{"date equal to YYMMDD", expression.NewEquals(d, lit(int32(200101), sql.Int32)), true},
+		{"date not equal to a later time of the day", expression.NewEquals(d, lit(int64(20200101120000), sql.Int64)), false},
+		{"number on the left", expression.NewGreaterThan(lit(int32(20200102), sql.Int32), d), true},
+		{"datetime equal to YYYYMMDDhhmmss", expression.NewEquals(dt, lit(int64(20200101120000), sql.Int64)), true},
+		{"datetime not equal to YYYYMMDD", expression.NewEquals(dt, lit(int32(20200101), sql.Int32)), false},
+		{"datetime greater than YYYYMMDD", expression.NewGreaterThan(dt, lit(int32(20200101), sql.Int32)), true},
+		{"datetime equal to a decimal", expression.NewEquals(dt, lit("20200101120000.000", sql.MustCreateDecimalType(20, 3))), true},
+		{"datetime less than a float with fractional seconds", expression.NewLessThan(dt, lit(20200101120000.5, sql.Float64)), true},
+		{"timestamp equal to YYYYMMDDhhmmss", expression.NewEquals(ts, lit(int64(20200101120000), sql.Int64)), true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.cmp.Eval(sql.NewEmptyContext(), row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestComparisonWarnings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		value    interface{}
+		right    sql.Expression
+		expected []string
+	}{
+		{"int and truncated string", sql.Int64, int64(12), expression.NewLiteral("12abc", sql.LongText), []string{"Truncated incorrect INTEGER value: '12abc'"}},
+		{"int and number string", sql.Int64, int64(12), expression.NewLiteral("12", sql.LongText), nil},
+		{"int and decimal number string", sql.Int64, int64(1), expression.NewLiteral("1.0", sql.LongText), nil},
+		{"int and number string with spaces", sql.Int64, int64(1), expression.NewLiteral(" 1e2 ", sql.LongText), nil},
+		{"unsigned and negative string", sql.Uint64, uint64(12), expression.NewLiteral("-5", sql.LongText), nil},
+		{"float and truncated string", sql.Float64, 1.5, expression.NewLiteral("1.5x", sql.LongText), []string{"Truncated incorrect DOUBLE value: '1.5x'"}},
+		{"string column and int", sql.Text, "abc", expression.NewLiteral(int64(0), sql.Int64), []string{"Truncated incorrect INTEGER value: 'abc'"}},
+		{"blob and string", sql.Blob, "abc", expression.NewLiteral("abc", sql.LongText), []string{"Comparing a nonbinary string with a binary string as binary strings"}},
+		{"blob and binary string", sql.Blob, "abc", expression.NewBinaryCast(expression.NewLiteral("abc", sql.LongText)), nil},
+		{"string and string", sql.Text, "abc", expression.NewLiteral("abc", sql.LongText), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			_, err := expression.NewEquals(expression.NewGetField(0, tt.typ, "col", false), tt.right).Eval(ctx, sql.NewRow(tt.value))
+			require.NoError(err)
+
+			var messages []string
+			for _, w := range ctx.Warnings() {
+				messages = append(messages, w.Message)
+			}
+			require.Equal(tt.expected, messages)
+		})
+	}
+}
+
+func TestComparisonNumberStrings(t *testing.T) {
+	testCases := []struct {
+		typ      sql.Type
+		value    interface{}
+		s        string
+		expected bool
+	}{
+		{sql.Int64, int64(12), "12abc", true},
+		{sql.Int64, int64(0), "12abc", false},
+		{sql.Int64, int64(12), " 12 ", true},
+		{sql.Int64, int64(12), "012", true},
+		{sql.Int64, int64(1), "1.0", true},
+		{sql.Int64, int64(100), "1e2x", true},
+		{sql.Int64, int64(0), "abc", true},
+		{sql.Int64, int64(-1), "-1.5", true},
+		{sql.Uint64, uint64(12), "12.9abc", true},
+		{sql.Float64, 1.5, "1.5x", true},
+		{sql.Float64, 150.0, "1.5e2", true},
+		{sql.Float64, 0.0, ".x", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%v = '%s'", tt.value, tt.s), func(t *testing.T) {
+			require := require.New(t)
+			e := expression.NewEquals(expression.NewGetField(0, tt.typ, "col", false), expression.NewLiteral(tt.s, sql.LongText))
+			result, err := e.Eval(sql.NewEmptyContext(), sql.NewRow(tt.value))
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestEmptyStringIsNullComparison(t *testing.T) {
+	empty := expression.NewLiteral("", sql.LongText)
+	a := expression.NewLiteral("a", sql.LongText)
+	testCases := []struct {
+		name              string
+		e                 sql.Expression
+		expected          interface{}
+		expectedEmptyNull interface{}
+	}{
+		{"'' = ''", expression.NewEquals(empty, empty), true, nil},
+		{"'' = 'a'", expression.NewEquals(empty, a), false, nil},
+		{"'a' < ''", expression.NewLessThan(a, empty), false, nil},
+		{"'a' = 'a'", expression.NewEquals(a, a), true, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			v, err := tt.e.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+
+			require.NoError(ctx.Set(ctx, sql.EmptyStringIsNullSessionVar, sql.Int8, int8(1)))
+			v, err = tt.e.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.expectedEmptyNull, v)
+		})
+	}
+}
+
+func TestNullComparisonPolicy(t *testing.T) {
+	null := expression.NewLiteral(nil, sql.Null)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+	testCases := []struct {
+		name                   string
+		e                      sql.Expression
+		expected               interface{}
+		expectedNullEqualsNull interface{}
+	}{
+		{"NULL = NULL", expression.NewEquals(null, null), nil, true},
+		{"NULL = 1", expression.NewEquals(null, one), nil, false},
+		{"1 = NULL", expression.NewEquals(one, null), nil, false},
+		{"1 = 1", expression.NewEquals(one, one), true, true},
+		{"1 = 2", expression.NewEquals(one, two), false, false},
+		{"NULL < 1", expression.NewLessThan(null, one), nil, nil},
+		{"NULL <=> NULL", expression.NewNullSafeEquals(null, null), true, true},
+		{"1 <=> NULL", expression.NewNullSafeEquals(one, null), false, false},
+		{
+			"(1, NULL) = (1, NULL)",
+			expression.NewEquals(expression.NewTuple(one, null), expression.NewTuple(one, null)),
+			nil,
+			true,
+		},
+		{
+			"(1, NULL) = (1, 2)",
+			expression.NewEquals(expression.NewTuple(one, null), expression.NewTuple(one, two)),
+			nil,
+			false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := sql.NewEmptyContext()
+			v, err := tt.e.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+
+			ctx.ApplyOpts(sql.WithNullComparisonPolicy(sql.NullEqualsNull))
+			v, err = tt.e.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.expectedNullEqualsNull, v)
+
+			ctx.ApplyOpts(sql.WithNullComparisonPolicy(sql.NullComparisonStrict))
+			v, err = tt.e.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestTupleComparison(t *testing.T) {
+	tuple := func(values ...interface{}) sql.Expression {
+		elems := make([]sql.Expression, len(values))
+		for i, v := range values {
+			switch v := v.(type) {
+			case nil:
+				elems[i] = expression.NewLiteral(nil, sql.Null)
+			case sql.Expression:
+				elems[i] = v
+			default:
+				elems[i] = expression.NewLiteral(v, sql.Int64)
+			}
+		}
+		return expression.NewTuple(elems...)
+	}
+	ops := map[string]func(l, r sql.Expression) sql.Expression{
+		"=":   func(l, r sql.Expression) sql.Expression { return expression.NewEquals(l, r) },
+		"<>":  func(l, r sql.Expression) sql.Expression { return expression.NewNot(expression.NewEquals(l, r)) },
+		"<":   func(l, r sql.Expression) sql.Expression { return expression.NewLessThan(l, r) },
+		">":   func(l, r sql.Expression) sql.Expression { return expression.NewGreaterThan(l, r) },
+		"<=":  func(l, r sql.Expression) sql.Expression { return expression.NewLessThanOrEqual(l, r) },
+		">=":  func(l, r sql.Expression) sql.Expression { return expression.NewGreaterThanOrEqual(l, r) },
+		"<=>": func(l, r sql.Expression) sql.Expression { return expression.NewNullSafeEquals(l, r) },
+	}
+
+	testCases := []struct {
+		left, right sql.Expression
+		expected    map[string]interface{}
+	}{
+		{
+			tuple(int64(1), int64(2)), tuple(int64(1), int64(2)),
+			map[string]interface{}{"=": true, "<>": false, "<": false, ">": false, "<=": true, ">=": true, "<=>": true},
+		},
+		{
+			tuple(int64(1), int64(2)), tuple(int64(1), int64(3)),
+			map[string]interface{}{"=": false, "<>": true, "<": true, ">": false, "<=": true, ">=": false, "<=>": false},
+		},
+		{
+			tuple(int64(2), int64(1)), tuple(int64(1), int64(3)),
+			map[string]interface{}{"=": false, "<>": true, "<": false, ">": true, "<=": false, ">=": true, "<=>": false},
+		},
+		{
+			tuple(int64(1), nil), tuple(int64(2), int64(3)),
+			map[string]interface{}{"=": false, "<>": true, "<": true, ">": false, "<=": true, ">=": false, "<=>": false},
+		},
+		{
+			tuple(nil, int64(1)), tuple(int64(2), int64(3)),
+			map[string]interface{}{"=": false, "<>": true, "<": nil, ">": nil, "<=": nil, ">=": nil, "<=>": false},
+		},
+		{
+			tuple(nil, int64(3)), tuple(int64(2), int64(3)),
+			map[string]interface{}{"=": nil, "<>": nil, "<": nil, ">": nil, "<=": nil, ">=": nil, "<=>": false},
+		},
+		{
+			tuple(int64(1), nil), tuple(int64(1), nil),
+			map[string]interface{}{"=": nil, "<>": nil, "<=>": true},
+		},
+		{
+			tuple(int64(1), tuple(nil, int64(2))), tuple(int64(1), tuple(nil, int64(2))),
+			map[string]interface{}{"<=>": true},
+		},
+		{
+			tuple(int64(1), tuple(int64(2), int64(3))), tuple(int64(1), tuple(int64(2), int64(4))),
+			map[string]interface{}{"=": false, "<>": true, "<": true, ">": false, "<=": true, ">=": false, "<=>": false},
+		},
+	}
+
+	for _, tt := range testCases {
+		for op, expected := range tt.expected {
+			t.Run(fmt.Sprintf("%s %s %s", tt.left, op, tt.right), func(t *testing.T) {
+				result, err := ops[op](tt.left, tt.right).Eval(sql.NewEmptyContext(), nil)
+				require.NoError(t, err)
+				require.Equal(t, expected, result)
+			})
+		}
+	}
+
+	for op, newOp := range ops {
+		t.Run(fmt.Sprintf("operands with different number of columns with %s", op), func(t *testing.T) {
+			_, err := newOp(tuple(int64(1), int64(2)), tuple(int64(1), int64(2), int64(3))).Eval(sql.NewEmptyContext(), nil)
+			require.True(t, expression.ErrInvalidOperandColumns.Is(err), "%v", err)
+
+			_, err = newOp(tuple(int64(1), int64(2)), expression.NewLiteral(int64(1), sql.Int64)).Eval(sql.NewEmptyContext(), nil)
+			require.True(t, expression.ErrInvalidOperandColumns.Is(err), "%v", err)
+		})
+	}
+}
+
+func TestTupleComparisonCoercion(t *testing.T) {
+	ci := sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_general_ci)
+	s := expression.NewGetField(0, ci, "s", true)
+	i := expression.NewGetField(1, sql.Int64, "i", true)
+	str := func(v string) sql.Expression { return expression.NewLiteral(v, sql.LongText) }
+	num := func(v int64) sql.Expression { return expression.NewLiteral(v, sql.Int64) }
+
+	// Each element of a tuple is compared like a comparison of it would be, with the collation of a column
+	testCases := []struct {
+		e        sql.Expression
+		expected interface{}
+	}{
+		{expression.NewGreaterThan(expression.NewTuple(s, i), expression.NewTuple(str("A"), num(1))), true},
+		{expression.NewLessThan(expression.NewTuple(s, i), expression.NewTuple(str("A"), num(1))), false},
+		{expression.NewEquals(expression.NewTuple(s, i), expression.NewTuple(str("A  "), num(2))), true},
+		{expression.NewGreaterThanOrEqual(expression.NewTuple(str("B"), num(0)), expression.NewTuple(s, i)), true},
+		{expression.NewLessThan(expression.NewTuple(i, s), expression.NewTuple(str("2"), str("b"))), true},
+		{expression.NewNullSafeEquals(expression.NewTuple(s, i), expression.NewTuple(str("A"), num(2))), true},
+	}
+
+	row := sql.NewRow("a", int64(2))
+	for _, tt := range testCases {
+		t.Run(tt.e.String(), func(t *testing.T) {
+			result, err := tt.e.Eval(sql.NewEmptyContext(), row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestNegate(t *testing.T) {
+	require := require.New(t)
+
+	i := expression.NewGetField(0, sql.Int64, "i", true)
+	f := expression.NewGetField(1, sql.Float64, "f", true)
+	s := expression.NewGetField(2, sql.Text, "s", true)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+
+	testCases := []struct {
+		comparison expression.Negatable
+		expected   sql.Expression
+	}{
+		{expression.NewGreaterThan(i, one), expression.NewLessThanOrEqual(i, one)},
+		{expression.NewGreaterThanOrEqual(i, one), expression.NewLessThan(i, one)},
+		{expression.NewLessThan(i, one), expression.NewGreaterThanOrEqual(i, one)},
+		{expression.NewLessThanOrEqual(i, one), expression.NewGreaterThan(i, one)},
+		{expression.NewLessThan(s, expression.NewLiteral("a", sql.LongText)), expression.NewGreaterThanOrEqual(s, expression.NewLiteral("a", sql.LongText))},
+		{expression.NewGreaterThan(f, one), nil},
+		{expression.NewGreaterThan(s, one), nil},
+		{expression.NewGreaterThan(expression.NewTuple(i, one), expression.NewTuple(one, i)), nil},
+	}
+
+	rows := []sql.Row{
+		{nil, nil, nil},
+		{int64(0), float64(0), "0"},
+		{int64(1), math.NaN(), "a"},
+		{int64(2), float64(2), "NaN"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.comparison.String(), func(t *testing.T) {
+			negated, ok := tt.comparison.Negate()
+			if tt.expected == nil {
+				require.False(ok)
+				return
+			}
+
+			require.True(ok)
+			require.Equal(tt.expected, negated)
+			for _, row := range rows {
+				expected, err := expression.NewNot(tt.comparison).Eval(sql.NewEmptyContext(), row)
+				require.NoError(err)
+				actual, err := negated.Eval(sql.NewEmptyContext(), row)
+				require.NoError(err)
+				require.Equal(expected, actual)
+			}
+		})
+	}
+}
+
+func TestFixedBinaryComparison(t *testing.T) {
+	binary3 := sql.MustCreateBinary(sqltypes.Binary, 3)
+	stored := binary3.MustConvert("a")
+	require.Equal(t, "a\x00\x00", stored)
+
+	testCases := []struct {
+		right    sql.Expression
+		expected interface{}
+	}{
+		{expression.NewLiteral("a", sql.LongText), false},
+		{expression.NewLiteral("a\x00\x00", sql.LongText), true},
+		{expression.NewLiteral("a  ", sql.LongText), false},
+		{expression.NewLiteral("a\x00\x00", binary3), true},
+		{expression.NewLiteral("a\x00", sql.MustCreateBinary(sqltypes.VarBinary, 3)), false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%q", tt.right.String()), func(t *testing.T) {
+			e := expression.NewEquals(expression.NewGetField(0, binary3, "b", true), tt.right)
+			result, err := e.Eval(sql.NewEmptyContext(), sql.NewRow(stored))
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -97,7 +97,9 @@ func (c *Count) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
 	return count, nil
 }
 
-// CountDistinct node to count how many rows are in the result set.
+// CountDistinct node to count how many distinct values of an expression, or of a tuple of them, are in the result set,
+// ignoring NULLs. Values that compare as equal, such as strings that only differ in case with a case insensitive
+// collation, are counted once.
 type CountDistinct struct {
 	expression.UnaryExpression
 }
@@ -146,9 +148,13 @@ func (c *CountDistinct) WithChildren(children ...sql.Expression) (sql.Expression
 // Update implements the Aggregation interface.
 func (c *CountDistinct) Update(ctx *sql.Context, buffer, row sql.Row) error {
 	seen := buffer[0].(map[uint64]struct{})
-	var value interface{}
+	var hash uint64
 	if _, ok := c.Child.(*expression.Star); ok {
-		value = row
+		var err error
+		hash, err = hashstructure.Hash(row, nil)
+		if err != nil {
+			return fmt.Errorf("count distinct unable to hash value: %s", err)
+		}
 	} else {
 		v, err := c.Child.Eval(ctx, row)
 		if v == nil {
@@ -159,12 +165,20 @@ func (c *CountDistinct) Update(ctx *sql.Context, buffer, row sql.Row) error {
 			return err
 		}
 
-		value = v
-	}
+		// Rows with NULL in any of the expressions counted aren't counted
+		if values, ok := v.([]interface{}); ok {
+			for _, elem := range values {
+				if elem == nil {
+					return nil
+				}
+			}
+		}
 
-	hash, err := hashstructure.Hash(value, nil)
-	if err != nil {
-		return fmt.Errorf("count distinct unable to hash value: %s", err)
+		// Values that compare as equal are counted once, as they're the same value in DISTINCT
+		hash, err = expression.ComparisonHash(ctx, []sql.Expression{c.Child}, []interface{}{v})
+		if err != nil {
+			return err
+		}
 	}
 
 	seen[hash] = struct{}{}
@@ -3,6 +3,7 @@ package aggregation
 import (
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -124,3 +125,54 @@ func TestCountDistinctEvalString(t *testing.T) {
 	require.NoError(c.Update(ctx, b, sql.NewRow("bar")))
 	require.Equal(int64(2), eval(t, c, b))
 }
+
+func TestCountDistinctEvalCaseInsensitiveString(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	c := NewCountDistinct(expression.NewGetField(0, sql.CreateLongText(sql.Collation_utf8mb4_general_ci), "", true))
+	b := c.NewBuffer()
+	require.NoError(c.Update(ctx, b, sql.NewRow("foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow("FOO")))
+	require.NoError(c.Update(ctx, b, sql.NewRow("Foo  ")))
+	require.NoError(c.Update(ctx, b, sql.NewRow("bar")))
+	require.Equal(int64(2), eval(t, c, b))
+
+	c = NewCountDistinct(expression.NewGetField(0, sql.CreateLongText(sql.Collation_utf8mb4_bin), "", true))
+	b = c.NewBuffer()
+	require.NoError(c.Update(ctx, b, sql.NewRow("foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow("FOO")))
+	require.NoError(c.Update(ctx, b, sql.NewRow("bar")))
+	require.Equal(int64(3), eval(t, c, b))
+}
+
+func TestCountDistinctEvalDecimal(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	c := NewCountDistinct(expression.NewGetField(0, sql.MustCreateDecimalType(10, 3), "", true))
+	b := c.NewBuffer()
+	for _, s := range []string{"1", "1.0", "1.000", "2.5", "2.50", "-0.000"} {
+		require.NoError(c.Update(ctx, b, sql.NewRow(decimal.RequireFromString(s))))
+	}
+	require.NoError(c.Update(ctx, b, sql.NewRow(nil)))
+	require.Equal(int64(3), eval(t, c, b))
+}
+
+func TestCountDistinctEvalTuple(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	c := NewCountDistinct(expression.NewTuple(
+		expression.NewGetField(0, sql.Int64, "a", true),
+		expression.NewGetField(1, sql.LongText, "b", true),
+	))
+	b := c.NewBuffer()
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "FOO")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(1), "bar")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(2), "foo")))
+	require.NoError(c.Update(ctx, b, sql.NewRow(int64(2), nil)))
+	require.NoError(c.Update(ctx, b, sql.NewRow(nil, "foo")))
+	require.Equal(int64(3), eval(t, c, b))
+}
@@ -46,6 +46,23 @@ func TestMax_Eval_Text(t *testing.T) {
 	v, err := m.Eval(ctx, b)
 	assert.NoError(err)
 	assert.Equal("b", v)
+
+	// A case insensitive collation orders strings ignoring their case, unlike a binary one
+	for typ, expected := range map[sql.Type]string{
+		sql.Text: "C",
+		sql.CreateText(sql.Collation_utf8mb4_bin): "a",
+	} {
+		m := NewMax(expression.NewGetField(0, typ, "field", true))
+		b := m.NewBuffer()
+
+		m.Update(ctx, b, sql.NewRow("B"))
+		m.Update(ctx, b, sql.NewRow("a"))
+		m.Update(ctx, b, sql.NewRow("C"))
+
+		v, err := m.Eval(ctx, b)
+		assert.NoError(err)
+		assert.Equal(expected, v)
+	}
 }
 
 func TestMax_Eval_Timestamp(t *testing.T) {
@@ -37,7 +37,7 @@ func TestMin_Eval_Text(t *testing.T) {
 	assert := require.New(t)
 	ctx := sql.NewEmptyContext()
 
-	m := NewMin(expression.NewGetField(0, sql.Text, "field", true))
+	m := NewMin(expression.NewGetField(0, sql.CreateText(sql.Collation_utf8mb4_bin), "field", true))
 	b := m.NewBuffer()
 
 	m.Update(ctx, b, sql.NewRow("a"))
@@ -47,6 +47,29 @@ func TestMin_Eval_Text(t *testing.T) {
 	v, err := m.Eval(ctx, b)
 	assert.NoError(err)
 	assert.Equal("A", v)
+
+	// A case insensitive collation takes strings that only differ in case as equal, so the first one is kept
+	m = NewMin(expression.NewGetField(0, sql.Text, "field", true))
+	b = m.NewBuffer()
+
+	m.Update(ctx, b, sql.NewRow("a"))
+	m.Update(ctx, b, sql.NewRow("A"))
+	m.Update(ctx, b, sql.NewRow("b"))
+
+	v, err = m.Eval(ctx, b)
+	assert.NoError(err)
+	assert.Equal("a", v)
+
+	m = NewMin(expression.NewGetField(0, sql.Text, "field", true))
+	b = m.NewBuffer()
+
+	m.Update(ctx, b, sql.NewRow("b"))
+	m.Update(ctx, b, sql.NewRow("C"))
+	m.Update(ctx, b, sql.NewRow("a"))
+
+	v, err = m.Eval(ctx, b)
+	assert.NoError(err)
+	assert.Equal("a", v)
 }
 
 func TestMin_Eval_Timestamp(t *testing.T) {
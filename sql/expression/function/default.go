@@ -0,0 +1,99 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// DefaultFunc is the DEFAULT(col) function, which returns the default value of a column, such as to find the rows
+// whose value of the column is still the default one with WHERE col = DEFAULT(col). It's of the type of the column, so
+// it's compared with the column like another value of the column is. Its default value is set by the analyzer once the
+// column is resolved, and it isn't resolved until then. A column without a default value has a default of NULL if it's
+// nullable, and no default otherwise. It isn't to be confused with the DEFAULT keyword of an INSERT or a SET statement,
+// expression.DefaultColumn.
+type DefaultFunc struct {
+	column sql.Expression
+	// def is the default value of the column, nil until it's set
+	def sql.Expression
+}
+
+var _ sql.FunctionExpression = (*DefaultFunc)(nil)
+
+// NewDefaultFunc creates a new DefaultFunc expression of the column given, whose default value is yet to be set.
+func NewDefaultFunc(column sql.Expression) *DefaultFunc {
+	return &DefaultFunc{column: column}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *DefaultFunc) FunctionName() string {
+	return "default"
+}
+
+// Column returns the column whose default value the function returns.
+func (f *DefaultFunc) Column() sql.Expression {
+	return f.column
+}
+
+// Default returns the default value of the column, or nil if it isn't set yet.
+func (f *DefaultFunc) Default() sql.Expression {
+	return f.def
+}
+
+// WithDefault returns a copy of the function with the default value of its column given, which is evaluated with the
+// row the function is.
+func (f *DefaultFunc) WithDefault(def sql.Expression) *DefaultFunc {
+	return &DefaultFunc{column: f.column, def: def}
+}
+
+// Resolved implements the sql.Expression interface.
+func (f *DefaultFunc) Resolved() bool {
+	return f.column.Resolved() && f.def != nil && f.def.Resolved()
+}
+
+// Type implements the sql.Expression interface.
+func (f *DefaultFunc) Type() sql.Type {
+	return f.column.Type()
+}
+
+// IsNullable implements the sql.Expression interface.
+func (f *DefaultFunc) IsNullable() bool {
+	if f.def == nil {
+		return true
+	}
+	return f.def.IsNullable()
+}
+
+// Children implements the sql.Expression interface. The default value is a child once it's set, so that the columns
+// it refers to are resolved like any other.
+func (f *DefaultFunc) Children() []sql.Expression {
+	if f.def == nil {
+		return []sql.Expression{f.column}
+	}
+	return []sql.Expression{f.column, f.def}
+}
+
+// WithChildren implements the Expression interface.
+func (f *DefaultFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(f.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), len(f.Children()))
+	}
+
+	if len(children) == 1 {
+		return NewDefaultFunc(children[0]), nil
+	}
+	return &DefaultFunc{column: children[0], def: children[1]}, nil
+}
+
+func (f *DefaultFunc) String() string {
+	return fmt.Sprintf("DEFAULT(%s)", f.column)
+}
+
+// Eval implements the sql.Expression interface.
+func (f *DefaultFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if f.def == nil {
+		return nil, fmt.Errorf("the default value of %s is not set", f.column)
+	}
+
+	return f.def.Eval(ctx, row)
+}
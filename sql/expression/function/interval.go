@@ -0,0 +1,125 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// IntervalFunc is the INTERVAL(N, N1, N2, ...) function, which returns the position of N among the arguments after
+// it: 0 if N < N1, 1 if N < N2 and so on, the number of those arguments if N isn't less than any of them, or -1 if N
+// is NULL. The arguments after N are expected to be sorted in ascending order, and the NULL ones are never greater
+// than N. It isn't to be confused with the INTERVAL expression of temporal arithmetic, expression.Interval.
+type IntervalFunc struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*IntervalFunc)(nil)
+
+// NewIntervalFunc creates a new IntervalFunc expression.
+func NewIntervalFunc(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("INTERVAL", "2 or more", len(args))
+	}
+
+	return &IntervalFunc{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *IntervalFunc) FunctionName() string {
+	return "interval"
+}
+
+// Resolved implements the sql.Expression interface.
+func (f *IntervalFunc) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Type implements the sql.Expression interface.
+func (f *IntervalFunc) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the sql.Expression interface.
+func (f *IntervalFunc) IsNullable() bool { return false }
+
+// Children implements the sql.Expression interface.
+func (f *IntervalFunc) Children() []sql.Expression { return f.args }
+
+// WithChildren implements the Expression interface.
+func (f *IntervalFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewIntervalFunc(children...)
+}
+
+func (f *IntervalFunc) String() string {
+	var parts = make([]string, len(f.args))
+	for i, arg := range f.args {
+		parts[i] = arg.String()
+	}
+	return fmt.Sprintf("INTERVAL(%s)", strings.Join(parts, ", "))
+}
+
+// Eval implements the sql.Expression interface.
+func (f *IntervalFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Interval")
+	defer span.Finish()
+
+	n, err := f.args[0].Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == nil {
+		return int64(-1), nil
+	}
+
+	// Every argument is compared with N as a number of the type all of them can be converted to
+	typ := intervalCompareType(f.args)
+	value := expression.NewLiteral(n, f.args[0].Type())
+	for i, arg := range f.args[1:] {
+		greater, err := expression.NewTypedComparison(expression.OpGreaterThan, arg, value, typ)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := greater.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if b, ok := result.(bool); ok && b {
+			return int64(i), nil
+		}
+	}
+
+	return int64(len(f.args) - 1), nil
+}
+
+// intervalCompareType returns the type the arguments of INTERVAL are compared with: BIGINT if they're all signed
+// integers, DECIMAL if they're all integers or decimals, and DOUBLE otherwise. NULL arguments don't count.
+func intervalCompareType(args []sql.Expression) sql.Type {
+	allSigned, allExact := true, true
+	for _, arg := range args {
+		typ := arg.Type()
+		switch {
+		case typ == sql.Null, sql.IsSigned(typ):
+		case sql.IsUnsigned(typ), sql.IsDecimal(typ):
+			allSigned = false
+		default:
+			allSigned, allExact = false, false
+		}
+	}
+
+	if allSigned {
+		return sql.Int64
+	}
+	if allExact {
+		return sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, sql.DecimalTypeMaxScale)
+	}
+	return sql.Float64
+}
@@ -0,0 +1,61 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestIntervalFunc(t *testing.T) {
+	lit := func(v interface{}, typ sql.Type) sql.Expression {
+		return expression.NewLiteral(v, typ)
+	}
+	ints := func(vs ...int64) []sql.Expression {
+		args := make([]sql.Expression, len(vs))
+		for i, v := range vs {
+			args[i] = lit(v, sql.Int64)
+		}
+		return args
+	}
+	decimalType := sql.MustCreateDecimalType(10, 2)
+
+	testCases := []struct {
+		name     string
+		n        sql.Expression
+		args     []sql.Expression
+		expected int64
+	}{
+		{"less than the first", lit(int64(0), sql.Int64), ints(1, 10, 100), 0},
+		{"equal to the first", lit(int64(1), sql.Int64), ints(1, 10, 100), 1},
+		{"between two", lit(int64(5), sql.Int64), ints(1, 10, 100), 1},
+		{"equal to the last", lit(int64(100), sql.Int64), ints(1, 10, 100), 3},
+		{"greater than the last", lit(int64(1000), sql.Int64), ints(1, 10, 100), 3},
+		{"single argument", lit(int64(5), sql.Int64), ints(10), 0},
+		{"NULL", lit(nil, sql.Null), ints(1, 10, 100), -1},
+		{"NULL arguments", lit(int64(5), sql.Int64), []sql.Expression{lit(nil, sql.Null), lit(int64(10), sql.Int64)}, 1},
+		{"float", lit(1.5, sql.Float64), ints(1, 2), 1},
+		{"string", lit("15", sql.LongText), ints(1, 10, 100), 2},
+		{"decimal", lit(decimal.RequireFromString("9.99"), decimalType), ints(1, 10), 1},
+		{"unsigned", lit(uint64(18446744073709551615), sql.Uint64), ints(1, 10), 2},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			f, err := NewIntervalFunc(append([]sql.Expression{tt.n}, tt.args...)...)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	_, err := NewIntervalFunc(lit(int64(1), sql.Int64))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
@@ -0,0 +1,137 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oliveagle/jsonpath"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSONContains returns whether a JSON document contains a candidate JSON document, or whether the candidate is
+// contained at the path given in the document, if any. It returns NULL if any argument is NULL, or if the document
+// has no value at the path. See sql.JSONContains for what containment means.
+type JSONContains struct {
+	JSON      sql.Expression
+	Candidate sql.Expression
+	Path      sql.Expression
+}
+
+var _ sql.FunctionExpression = (*JSONContains)(nil)
+
+// NewJSONContains creates a new JSONContains UDF.
+func NewJSONContains(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_CONTAINS", "2 or 3", len(args))
+	}
+
+	if len(args) == 2 {
+		return &JSONContains{args[0], args[1], nil}, nil
+	}
+
+	return &JSONContains{args[0], args[1], args[2]}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONContains) FunctionName() string {
+	return "json_contains"
+}
+
+// Resolved implements the sql.Expression interface.
+func (j *JSONContains) Resolved() bool {
+	for _, c := range j.Children() {
+		if !c.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Type implements the sql.Expression interface.
+func (j *JSONContains) Type() sql.Type { return sql.Boolean }
+
+// IsNullable implements the sql.Expression interface.
+func (j *JSONContains) IsNullable() bool {
+	// The document may have no value at the path
+	return true
+}
+
+// Children implements the sql.Expression interface.
+func (j *JSONContains) Children() []sql.Expression {
+	if j.Path == nil {
+		return []sql.Expression{j.JSON, j.Candidate}
+	}
+	return []sql.Expression{j.JSON, j.Candidate, j.Path}
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONContains) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONContains(children...)
+}
+
+func (j *JSONContains) String() string {
+	children := j.Children()
+	var parts = make([]string, len(children))
+	for i, c := range children {
+		parts[i] = c.String()
+	}
+	return fmt.Sprintf("JSON_CONTAINS(%s)", strings.Join(parts, ", "))
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONContains) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONContains")
+	defer span.Finish()
+
+	target, ok, err := evalJSONArg(ctx, row, j.JSON)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	candidate, ok, err := evalJSONArg(ctx, row, j.Candidate)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	if j.Path != nil {
+		path, err := j.Path.Eval(ctx, row)
+		if path == nil || err != nil {
+			return nil, err
+		}
+
+		path, err = sql.LongText.Convert(path)
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := jsonpath.Compile(path.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		target, err = c.Lookup(target)
+		if err != nil {
+			// There's no value at the path
+			return nil, nil
+		}
+	}
+
+	return sql.JSONContains(target, candidate), nil
+}
+
+// evalJSONArg evaluates the argument given of a JSON function and decodes it as a JSON document. It returns false if
+// the argument is NULL, which isn't the same as the JSON null value.
+func evalJSONArg(ctx *sql.Context, row sql.Row, e sql.Expression) (interface{}, bool, error) {
+	v, err := e.Eval(ctx, row)
+	if v == nil || err != nil {
+		return nil, false, err
+	}
+
+	doc, err := unmarshalVal(v)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return doc, true, nil
+}
@@ -0,0 +1,63 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONContains(t *testing.T) {
+	f2, err := NewJSONContains(
+		expression.NewGetField(0, sql.LongText, "target", true),
+		expression.NewGetField(1, sql.LongText, "candidate", true),
+	)
+	require.NoError(t, err)
+
+	f3, err := NewJSONContains(
+		expression.NewGetField(0, sql.LongText, "target", true),
+		expression.NewGetField(1, sql.LongText, "candidate", true),
+		expression.NewGetField(2, sql.LongText, "path", true),
+	)
+	require.NoError(t, err)
+
+	doc := `{"a": 1, "b": [1, 2, {"c": "x"}], "d": {"e": true, "f": null}}`
+	testCases := []struct {
+		name     string
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+	}{
+		{"scalar in an array", f2, sql.Row{`[1, 2, 3]`, `2`}, true},
+		{"scalar not in an array", f2, sql.Row{`[1, 2, 3]`, `4`}, false},
+		{"numbers of different representations", f2, sql.Row{`[1, 2, 3]`, `2.0`}, true},
+		{"scalars of different types", f2, sql.Row{`[1, 2, 3]`, `"2"`}, false},
+		{"equal scalars", f2, sql.Row{`"a"`, `"a"`}, true},
+		{"array in a scalar", f2, sql.Row{`1`, `[1]`}, false},
+		{"subset of an array", f2, sql.Row{`[1, 2, 3]`, `[3, 1]`}, true},
+		{"array that isn't a subset", f2, sql.Row{`[1, 2, 3]`, `[1, 4]`}, false},
+		{"object subset", f2, sql.Row{doc, `{"a": 1, "d": {"e": true}}`}, true},
+		{"object with a different value", f2, sql.Row{doc, `{"a": 2}`}, false},
+		{"object with a missing key", f2, sql.Row{doc, `{"z": 1}`}, false},
+		{"object in an array", f2, sql.Row{`[{"a": 1, "b": 2}]`, `{"a": 1}`}, true},
+		{"object in a scalar", f2, sql.Row{`1`, `{"a": 1}`}, false},
+		{"scalar at a path", f3, sql.Row{doc, `1`, `$.a`}, true},
+		{"object in an array at a path", f3, sql.Row{doc, `{"c": "x"}`, `$.b`}, true},
+		{"null at a path", f3, sql.Row{doc, `null`, `$.d.f`}, true},
+		{"missing path", f3, sql.Row{doc, `1`, `$.z`}, nil},
+		{"NULL target", f2, sql.Row{nil, `1`}, nil},
+		{"NULL candidate", f2, sql.Row{`[1]`, nil}, nil},
+		{"NULL path", f3, sql.Row{doc, `1`, nil}, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
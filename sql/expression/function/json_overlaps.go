@@ -0,0 +1,59 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSONOverlaps returns whether two JSON documents have any value in common. It returns NULL if any argument is NULL.
+// See sql.JSONOverlaps for when documents overlap.
+type JSONOverlaps struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONOverlaps)(nil)
+
+// NewJSONOverlaps creates a new JSONOverlaps UDF.
+func NewJSONOverlaps(left, right sql.Expression) sql.Expression {
+	return &JSONOverlaps{expression.BinaryExpression{Left: left, Right: right}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONOverlaps) FunctionName() string {
+	return "json_overlaps"
+}
+
+// Type implements the sql.Expression interface.
+func (j *JSONOverlaps) Type() sql.Type { return sql.Boolean }
+
+func (j *JSONOverlaps) String() string {
+	return fmt.Sprintf("JSON_OVERLAPS(%s, %s)", j.Left, j.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONOverlaps) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewJSONOverlaps(children[0], children[1]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONOverlaps) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONOverlaps")
+	defer span.Finish()
+
+	left, ok, err := evalJSONArg(ctx, row, j.Left)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	right, ok, err := evalJSONArg(ctx, row, j.Right)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	return sql.JSONOverlaps(left, right), nil
+}
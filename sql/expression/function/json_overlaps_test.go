@@ -0,0 +1,44 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONOverlaps(t *testing.T) {
+	f := NewJSONOverlaps(
+		expression.NewGetField(0, sql.LongText, "a", true),
+		expression.NewGetField(1, sql.LongText, "b", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"arrays with an element in common", sql.Row{`[1, 3, 5]`, `[2, 5, 7]`}, true},
+		{"arrays without elements in common", sql.Row{`[1, 3, 5]`, `[2, 4, 6]`}, false},
+		{"arrays with an array in common", sql.Row{`[[1, 2], 3]`, `[[1, 2], 4]`}, true},
+		{"arrays with part of an array in common", sql.Row{`[[1, 2], 3]`, `[1, 4]`}, false},
+		{"scalar in an array", sql.Row{`5`, `[1, 3, 5]`}, true},
+		{"object in an array", sql.Row{`[{"a": 1}]`, `{"a": 1}`}, true},
+		{"objects with a key in common", sql.Row{`{"a": 1, "b": 2}`, `{"b": 2, "c": 3}`}, true},
+		{"objects with a key of different values", sql.Row{`{"a": 1}`, `{"a": 2}`}, false},
+		{"equal scalars", sql.Row{`1`, `1.0`}, true},
+		{"scalar and object", sql.Row{`1`, `{"a": 1}`}, false},
+		{"NULL argument", sql.Row{nil, `[1]`}, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
@@ -296,7 +296,7 @@ var positiveSignRegex = regexp.MustCompile(`^+?[0-9]*\.?[0-9]*[1-9]`)
 
 func SignFunc(_ *sql.Context, arg interface{}) (interface{}, error) {
 	switch typedVal := arg.(type) {
-	case int8, int16, int32, int64, float64, float32, int, decimal.Decimal:
+	case int8, int16, int32, int64, int:
 		val, err := sql.Int64.Convert(arg)
 
 		if err != nil {
@@ -312,6 +312,26 @@ func SignFunc(_ *sql.Context, arg interface{}) (interface{}, error) {
 
 		return int8(1), nil
 
+	case float32, float64:
+		// Converting to an integer first would round fractions such as -0.4 to 0
+		val, err := sql.Float64.Convert(arg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		n := val.(float64)
+		if n == 0 {
+			return int8(0), nil
+		} else if n < 0 {
+			return int8(-1), nil
+		}
+
+		return int8(1), nil
+
+	case decimal.Decimal:
+		return int8(typedVal.Sign()), nil
+
 	case uint8, uint16, uint32, uint64, uint:
 		val, err := sql.Uint64.Convert(arg)
 
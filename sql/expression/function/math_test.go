@@ -233,6 +233,11 @@ func TestSignFunc(t *testing.T) {
 	tf.AddSignedVariations(int8(0), 0)
 	tf.AddUnsignedVariations(int8(0), 0)
 	tf.AddFloatVariations(int8(0), 0)
+	tf.AddFloatVariations(int8(-1), -0.4)
+	tf.AddFloatVariations(int8(1), 0.4)
+	tf.AddSucceeding(int8(-1), decimal.NewFromFloat(-0.25))
+	tf.AddSucceeding(int8(0), decimal.Zero)
+	tf.AddSucceeding(int8(1), decimal.NewFromFloat(12.5))
 	tf.AddSucceeding(int8(1), time.Now())
 	tf.AddSucceeding(int8(0), false)
 	tf.AddSucceeding(int8(1), true)
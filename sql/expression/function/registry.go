@@ -11,6 +11,14 @@ func connIDFuncLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
 	return ctx.ID(), nil
 }
 
+func lastInsertIDFuncLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
+	_, v := ctx.Get(sql.LastInsertIDSessionVar)
+	if v == nil {
+		return uint64(0), nil
+	}
+	return sql.Uint64.Convert(v)
+}
+
 func userFuncLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
 	return ctx.Client().User, nil
 }
@@ -66,10 +74,14 @@ var Defaults = []sql.Function{
 	sql.Function3{Name: "if", Fn: NewIf},
 	sql.Function2{Name: "ifnull", Fn: NewIfNull},
 	sql.Function2{Name: "instr", Fn: NewInstr},
+	sql.FunctionN{Name: "interval", Fn: NewIntervalFunc},
 	sql.Function1{Name: "is_binary", Fn: NewIsBinary},
+	sql.FunctionN{Name: "json_contains", Fn: NewJSONContains},
 	sql.FunctionN{Name: "json_extract", Fn: NewJSONExtract},
+	sql.Function2{Name: "json_overlaps", Fn: NewJSONOverlaps},
 	sql.Function1{Name: "json_unquote", Fn: NewJSONUnquote},
 	sql.Function1{Name: "last", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewLast(e) }},
+	sql.NewFunction0("last_insert_id", sql.Uint64, lastInsertIDFuncLogic),
 	sql.Function1{Name: "lcase", Fn: NewLower},
 	sql.FunctionN{Name: "least", Fn: NewLeast},
 	sql.Function2{Name: "left", Fn: NewLeft},
@@ -124,6 +136,7 @@ var Defaults = []sql.Function{
 	sql.Function2{Name: "timediff", Fn: NewTimeDiff},
 	sql.Function1{Name: "upper", Fn: NewUpper},
 	sql.NewFunction0("user", sql.LongText, userFuncLogic),
+	sql.Function2{Name: "vec_distance", Fn: NewVecDistance},
 	sql.FunctionN{Name: "week", Fn: NewWeek},
 	sql.Function1{Name: "weekday", Fn: NewWeekday},
 	NewUnaryDatetimeFunc("weekofyear", sql.Uint64, weekFuncLogic),
@@ -0,0 +1,122 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ErrInvalidVector is returned when a value isn't a vector, which is written as an array of numbers such as '[1, 2.5]'.
+var ErrInvalidVector = errors.NewKind("invalid vector value: %v")
+
+// ErrVectorDimensions is returned when the distance between vectors of different dimensions is computed.
+var ErrVectorDimensions = errors.NewKind("vectors of different dimensions: %d and %d")
+
+// VecDistance is a function that returns the Euclidean distance between two vectors, written as JSON arrays of
+// numbers, such as '[1, 2.5, 3]'. The distance is a DOUBLE, so it can be compared with a threshold like any other
+// number, as in WHERE vec_distance(embedding, '[0.1, 0.2]') < 0.5.
+type VecDistance struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*VecDistance)(nil)
+
+// NewVecDistance creates a new VecDistance expression.
+func NewVecDistance(e1, e2 sql.Expression) sql.Expression {
+	return &VecDistance{
+		expression.BinaryExpression{
+			Left:  e1,
+			Right: e2,
+		},
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *VecDistance) FunctionName() string {
+	return "vec_distance"
+}
+
+// Type implements the Expression interface.
+func (d *VecDistance) Type() sql.Type { return sql.Float64 }
+
+// IsNullable implements the Expression interface.
+func (d *VecDistance) IsNullable() bool { return d.Left.IsNullable() || d.Right.IsNullable() }
+
+func (d *VecDistance) String() string {
+	return fmt.Sprintf("vec_distance(%s, %s)", d.Left, d.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (d *VecDistance) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewVecDistance(children[0], children[1]), nil
+}
+
+// Eval implements the Expression interface. The distance is NULL if either vector is.
+func (d *VecDistance) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	left, err := d.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if left == nil {
+		return nil, nil
+	}
+
+	right, err := d.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if right == nil {
+		return nil, nil
+	}
+
+	a, err := toVector(left)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := toVector(right)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(a) != len(b) {
+		return nil, ErrVectorDimensions.New(len(a), len(b))
+	}
+
+	var sum float64
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+
+	return math.Sqrt(sum), nil
+}
+
+// toVector parses the vector given, which is a string or a JSON value holding an array of numbers.
+func toVector(v interface{}) ([]float64, error) {
+	var data []byte
+	switch v := v.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return nil, ErrInvalidVector.New(v)
+	}
+
+	var vector []float64
+	if err := json.Unmarshal(data, &vector); err != nil {
+		return nil, ErrInvalidVector.New(string(data))
+	}
+
+	return vector, nil
+}
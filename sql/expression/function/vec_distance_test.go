@@ -0,0 +1,73 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestVecDistance(t *testing.T) {
+	f := NewVecDistance(
+		expression.NewGetField(0, sql.LongText, "a", true),
+		expression.NewGetField(1, sql.JSON, "b", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{"null left", sql.NewRow(nil, "[1, 2]"), nil, false},
+		{"null right", sql.NewRow("[1, 2]", nil), nil, false},
+		{"same vectors", sql.NewRow("[1, 2]", "[1, 2]"), float64(0), false},
+		{"different vectors", sql.NewRow("[0, 0]", []byte("[3, 4]")), float64(5), false},
+		{"fractional coordinates", sql.NewRow("[0.5]", "[0.25]"), float64(0.25), false},
+		{"different dimensions", sql.NewRow("[1, 2]", "[1]"), nil, true},
+		{"invalid vector", sql.NewRow("[1, 'a']", "[1, 2]"), nil, true},
+		{"not an array", sql.NewRow(int64(1), "[1]"), nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+				require.Equal(tt.expected, v)
+			}
+		})
+	}
+}
+
+func TestVecDistanceComparison(t *testing.T) {
+	distance := NewVecDistance(
+		expression.NewGetField(0, sql.LongText, "v", true),
+		expression.NewLiteral("[0, 0]", sql.LongText),
+	)
+	threshold := expression.NewLiteral(0.5, sql.Float64)
+
+	testCases := []struct {
+		name     string
+		vector   interface{}
+		expected interface{}
+	}{
+		{"closer than the threshold", "[0.3, 0.3]", true},
+		{"farther than the threshold", "[0.3, 0.4]", false},
+		{"null vector", nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := expression.NewLessThan(distance, threshold).Eval(sql.NewEmptyContext(), sql.NewRow(tt.vector))
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
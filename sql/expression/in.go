@@ -58,7 +58,12 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
+	// The sql.NullEqualsNull policy makes NULL equal to the NULL elements of the list, and not equal to any other
+	nullEqualsNull := sql.NullEqualsNullPolicy(ctx)
 	if left == nil {
+		if nullEqualsNull {
+			return in.hasNullElement(ctx, row)
+		}
 		return nil, nil
 	}
 
@@ -68,11 +73,6 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	// also if no match is found in the list and one of the expressions in the list is NULL.
 	rightNull := false
 
-	left, err = typ.Convert(left)
-	if err != nil {
-		return nil, err
-	}
-
 	switch right := in.Right().(type) {
 	case Tuple:
 		for _, el := range right {
@@ -81,23 +81,35 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			}
 		}
 
+		if leftElems > 1 {
+			return in.evalRows(ctx, row, left, right, nullEqualsNull)
+		}
+
+		left, err = typ.Convert(left)
+		if err != nil {
+			return nil, err
+		}
+
 		for _, el := range right {
 			right, err := el.Eval(ctx, row)
 			if err != nil {
 				return nil, err
 			}
 
-			if !rightNull && right == nil {
-				rightNull = true
+			if right == nil {
+				rightNull = !nullEqualsNull
 				continue
 			}
 
-			right, err = typ.Convert(right)
+			right, err = convertComparedValue(typ, el.Type(), right)
 			if err != nil {
 				return nil, err
 			}
 
 			cmp, err := typ.Compare(left, right)
+			if sql.ErrIncomparable.Is(err) {
+				continue
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -117,6 +129,67 @@ func (in *InTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 }
 
+// evalRows returns whether the row value given of the left operand is in the list of rows given. Each row of the list
+// is compared with it the way an equality of them does, so that the elements of a row are compared with the types and
+// collations a comparison of the elements themselves would use.
+func (in *InTuple) evalRows(ctx *sql.Context, row sql.Row, left interface{}, right Tuple, nullEqualsNull bool) (interface{}, error) {
+	rightNull := false
+	for _, el := range right {
+		v, err := el.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if v == nil {
+			rightNull = !nullEqualsNull
+			continue
+		}
+
+		c := newComparison(in.Left(), el)
+		cmp, err := c.compareValues(ctx, left, v, true, nullEqualsNull)
+		if ErrNilOperand.Is(err) {
+			rightNull = true
+			continue
+		}
+		if sql.ErrIncomparable.Is(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if cmp == 0 {
+			return true, nil
+		}
+	}
+
+	if rightNull {
+		return nil, nil
+	}
+
+	return false, nil
+}
+
+// hasNullElement returns whether any element of the list of the IN expression is NULL with the row given.
+func (in *InTuple) hasNullElement(ctx *sql.Context, row sql.Row) (bool, error) {
+	right, ok := in.Right().(Tuple)
+	if !ok {
+		return false, ErrUnsupportedInOperand.New(in.Right())
+	}
+
+	for _, el := range right {
+		v, err := el.Eval(ctx, row)
+		if err != nil {
+			return false, err
+		}
+
+		if v == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // WithChildren implements the Expression interface.
 func (in *InTuple) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	if len(children) != 2 {
@@ -142,3 +215,121 @@ func (in *InTuple) Children() []sql.Expression {
 func NewNotInTuple(left sql.Expression, right sql.Expression) sql.Expression {
 	return NewNot(NewInTuple(left, right))
 }
+
+// HashInTuple is an InTuple whose list is made of literals, which are looked up in a hash set of their values instead
+// of being compared with the left operand one by one. It's only valid for types whose equal values always have the same
+// key, as told by IsHashableInType.
+type HashInTuple struct {
+	InTuple
+	typ     sql.Type
+	set     map[uint64][]interface{}
+	hasNull bool
+}
+
+var _ Comparer = (*HashInTuple)(nil)
+
+// IsHashableInType returns whether values of the type given that are equal always have the same HashInKey, so that an
+// IN list of them can be looked up in a hash set of its values.
+func IsHashableInType(typ sql.Type) bool {
+	if sql.IsInteger(typ) {
+		return true
+	}
+
+	_, ok := typ.(sql.StringType)
+	return ok && sql.IsTextOnly(typ)
+}
+
+// HashInKey returns the key of the value given, converted to the hashable type given, in a hash set of values of the
+// type. Strings are keyed by their collation key, so that all the strings that compare as equal have the same key.
+func HashInKey(typ sql.Type, v interface{}) uint64 {
+	if st, ok := typ.(sql.StringType); ok {
+		if s, ok := v.(string); ok {
+			return sql.CacheKey(sql.CollationKey(st.Collation(), s))
+		}
+	}
+	return sql.CacheKey(v)
+}
+
+// NewHashInTuple creates a HashInTuple expression. The right operand must be a tuple of literals, and the type of the
+// left one must be hashable.
+func NewHashInTuple(left sql.Expression, right sql.Expression) (*HashInTuple, error) {
+	tuple, ok := right.(Tuple)
+	if !ok {
+		return nil, ErrUnsupportedInOperand.New(right)
+	}
+
+	typ := left.Type().Promote()
+	if !IsHashableInType(typ) {
+		return nil, ErrUnhashableInOperand.New(typ)
+	}
+
+	in := &HashInTuple{InTuple: InTuple{BinaryExpression{left, right}}, typ: typ, set: make(map[uint64][]interface{})}
+	for _, el := range tuple {
+		lit, ok := el.(*Literal)
+		if !ok {
+			return nil, ErrUnhashableInElement.New(el)
+		}
+
+		if lit.Value() == nil {
+			in.hasNull = true
+			continue
+		}
+
+		v, err := typ.Convert(lit.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		key := HashInKey(typ, v)
+		in.set[key] = append(in.set[key], v)
+	}
+
+	return in, nil
+}
+
+// Eval implements the Expression interface.
+func (in *HashInTuple) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	left, err := in.Left().Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sql.NullEqualsNull policy makes NULL equal to the NULL elements of the list, and not equal to any other
+	nullEqualsNull := sql.NullEqualsNullPolicy(ctx)
+	if left == nil {
+		if nullEqualsNull {
+			return in.hasNull, nil
+		}
+		return nil, nil
+	}
+
+	left, err = in.typ.Convert(left)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range in.set[HashInKey(in.typ, left)] {
+		cmp, err := in.typ.Compare(left, v)
+		if err != nil {
+			return nil, err
+		}
+
+		if cmp == 0 {
+			return true, nil
+		}
+	}
+
+	if in.hasNull && !nullEqualsNull {
+		return nil, nil
+	}
+
+	return false, nil
+}
+
+// WithChildren implements the Expression interface.
+func (in *HashInTuple) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(in, len(children), 2)
+	}
+	return NewHashInTuple(children[0], children[1])
+}
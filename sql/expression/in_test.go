@@ -189,3 +189,149 @@ func TestNotInTuple(t *testing.T) {
 		})
 	}
 }
+
+func TestHashInTuple(t *testing.T) {
+	lit := func(v interface{}, typ sql.Type) sql.Expression {
+		return expression.NewLiteral(v, typ)
+	}
+	ints := expression.NewTuple(lit(int64(1), sql.Int64), lit(int8(2), sql.Int8), lit("3", sql.LongText))
+	intsAndNull := expression.NewTuple(lit(int64(1), sql.Int64), lit(nil, sql.Null))
+	strs := expression.NewTuple(lit("foo", sql.LongText), lit("bar", sql.LongText), lit(int64(1), sql.Int64))
+	intField := expression.NewGetField(0, sql.Int64, "foo", true)
+	strField := expression.NewGetField(0, sql.LongText, "foo", true)
+	binField := expression.NewGetField(0, sql.CreateLongText(sql.Collation_utf8mb4_bin), "foo", true)
+
+	testCases := []struct {
+		name   string
+		left   sql.Expression
+		right  sql.Expression
+		row    sql.Row
+		result interface{}
+	}{
+		{"integer in list", intField, ints, sql.NewRow(int64(2)), true},
+		{"converted integer in list", intField, ints, sql.NewRow(int64(3)), true},
+		{"integer not in list", intField, ints, sql.NewRow(int64(4)), false},
+		{"left is nil", intField, ints, sql.NewRow(nil), nil},
+		{"integer in list with NULL", intField, intsAndNull, sql.NewRow(int64(1)), true},
+		{"integer not in list with NULL", intField, intsAndNull, sql.NewRow(int64(2)), nil},
+		{"string in list", strField, strs, sql.NewRow("bar"), true},
+		{"converted string in list", strField, strs, sql.NewRow("1"), true},
+		{"string not in list", strField, strs, sql.NewRow("baz"), false},
+		{"string of another case in list", strField, strs, sql.NewRow("BAR"), true},
+		{"binary string of another case not in list", binField, strs, sql.NewRow("BAR"), false},
+		{"binary string with trailing spaces in list", binField, strs, sql.NewRow("bar  "), true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			hashed, err := expression.NewHashInTuple(tt.left, tt.right)
+			require.NoError(err)
+
+			result, err := hashed.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+
+			expected, err := expression.NewInTuple(tt.left, tt.right).Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(expected, result)
+		})
+	}
+}
+
+func TestInTupleRowsCollations(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	bin := sql.CreateLongText(sql.Collation_utf8mb4_bin)
+	s := expression.NewGetField(0, bin, "s", true)
+	i := expression.NewGetField(1, sql.Int64, "i", true)
+	row := sql.NewRow("Z", int64(5))
+
+	// The collation of the column takes precedence over the one of the literal, both in = and in IN
+	lower := expression.NewTuple(expression.NewLiteral("z", sql.LongText), expression.NewLiteral(int64(5), sql.Int64))
+	for _, e := range []sql.Expression{
+		expression.NewEquals(lower, expression.NewTuple(s, i)),
+		expression.NewInTuple(lower, expression.NewTuple(expression.NewTuple(s, i))),
+		expression.NewInTuple(expression.NewTuple(s, i), expression.NewTuple(lower)),
+	} {
+		result, err := e.Eval(ctx, row)
+		require.NoError(err)
+		require.Equal(false, result, e.String())
+	}
+
+	padded := expression.NewTuple(expression.NewLiteral("Z  ", sql.LongText), expression.NewLiteral(int64(5), sql.Int64))
+	for _, e := range []sql.Expression{
+		expression.NewEquals(padded, expression.NewTuple(s, i)),
+		expression.NewInTuple(padded, expression.NewTuple(expression.NewTuple(s, i))),
+		expression.NewInTuple(expression.NewTuple(s, i), expression.NewTuple(padded)),
+	} {
+		result, err := e.Eval(ctx, row)
+		require.NoError(err)
+		require.Equal(true, result, e.String())
+	}
+}
+
+func TestInTupleNullComparisonPolicy(t *testing.T) {
+	lit := func(v interface{}) sql.Expression {
+		return expression.NewLiteral(v, sql.Int64)
+	}
+	field := expression.NewGetField(0, sql.Int64, "foo", true)
+	withNull := expression.NewTuple(lit(int64(1)), expression.NewLiteral(nil, sql.Null))
+	withoutNull := expression.NewTuple(lit(int64(1)), lit(int64(2)))
+
+	ctx := sql.NewEmptyContext()
+	ctx.ApplyOpts(sql.WithNullComparisonPolicy(sql.NullEqualsNull))
+
+	testCases := []struct {
+		name   string
+		right  sql.Expression
+		row    sql.Row
+		result interface{}
+	}{
+		{"NULL in list with NULL", withNull, sql.NewRow(nil), true},
+		{"NULL not in list without NULL", withoutNull, sql.NewRow(nil), false},
+		{"value not in list with NULL", withNull, sql.NewRow(int64(3)), false},
+		{"value in list with NULL", withNull, sql.NewRow(int64(1)), true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			result, err := expression.NewInTuple(field, tt.right).Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+
+			hashed, err := expression.NewHashInTuple(field, tt.right)
+			require.NoError(err)
+			result, err = hashed.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.result, result)
+		})
+	}
+}
+
+func TestNewHashInTupleErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := expression.NewHashInTuple(
+		expression.NewGetField(0, sql.Float64, "foo", false),
+		expression.NewTuple(expression.NewLiteral(1.5, sql.Float64)),
+	)
+	require.True(expression.ErrUnhashableInOperand.Is(err))
+
+	_, err = expression.NewHashInTuple(
+		expression.NewGetField(0, sql.Int64, "foo", false),
+		expression.NewTuple(expression.NewGetField(1, sql.Int64, "bar", false)),
+	)
+	require.True(expression.ErrUnhashableInElement.Is(err))
+
+	_, err = expression.NewHashInTuple(
+		expression.NewGetField(0, sql.Int64, "foo", false),
+		expression.NewLiteral(int64(1), sql.Int64),
+	)
+	require.True(expression.ErrUnsupportedInOperand.Is(err))
+}
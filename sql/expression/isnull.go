@@ -2,7 +2,8 @@ package expression
 
 import "github.com/dolthub/go-mysql-server/sql"
 
-// IsNull is an expression that checks if an expression is null.
+// IsNull is an expression that checks if an expression is null. Empty strings are null too when the session takes them
+// as NULL.
 type IsNull struct {
 	UnaryExpression
 }
@@ -29,6 +30,10 @@ func (e *IsNull) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
+	if v == "" {
+		return sql.EmptyStringIsNull(ctx), nil
+	}
+
 	return v == nil, nil
 }
 
@@ -17,4 +17,13 @@ func TestIsNull(t *testing.T) {
 	require.Equal(false, e.IsNullable())
 	require.Equal(true, eval(t, e, sql.NewRow(nil)))
 	require.Equal(false, eval(t, e, sql.NewRow("")))
+
+	ctx := sql.NewEmptyContext()
+	require.NoError(ctx.Set(ctx, sql.EmptyStringIsNullSessionVar, sql.Int8, int8(1)))
+	v, err := e.Eval(ctx, sql.NewRow(""))
+	require.NoError(err)
+	require.Equal(true, v)
+	v, err = e.Eval(ctx, sql.NewRow("a"))
+	require.NoError(err)
+	require.Equal(false, v)
 }
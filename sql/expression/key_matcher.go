@@ -0,0 +1,52 @@
+package expression
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// KeyMatcher tells whether two rows have the same values for the columns of a unique key, such as a primary key or a
+// unique index. Values are compared the same way an equality comparison of the columns compares them, so strings are
+// compared with the collation of their column. A NULL value in a key never matches any other, so that any number of
+// rows can have NULL in a unique key, whatever the NULL comparison policy of the context. Matching keys isn't part of
+// the query, so nothing is recorded in the diagnostics of the context.
+type KeyMatcher struct {
+	columns     []int
+	comparisons []comparison
+}
+
+// NewKeyMatcher creates a new KeyMatcher for the columns with the indexes given of rows of the schema given.
+func NewKeyMatcher(schema sql.Schema, columns []int) *KeyMatcher {
+	comparisons := make([]comparison, len(columns))
+	for i, idx := range columns {
+		col := schema[idx]
+		field := NewGetField(idx, col.Type, col.Name, col.Nullable)
+		comparisons[i] = newComparison(field, field)
+	}
+
+	return &KeyMatcher{columns: columns, comparisons: comparisons}
+}
+
+// Columns returns the indexes of the key columns.
+func (m *KeyMatcher) Columns() []int {
+	return m.columns
+}
+
+// Matches returns whether the two rows given have the same key.
+func (m *KeyMatcher) Matches(ctx *sql.Context, left, right sql.Row) (bool, error) {
+	for i, idx := range m.columns {
+		if left[idx] == nil || right[idx] == nil {
+			return false, nil
+		}
+
+		cmp, err := m.comparisons[i].compareValues(ctx, left[idx], right[idx], true, false)
+		if err != nil {
+			return false, err
+		}
+
+		if cmp != 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,63 @@
+package expression
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestKeyMatcher(t *testing.T) {
+	schema := sql.Schema{
+		{Name: "ci", Type: sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_0900_ai_ci), Nullable: true},
+		{Name: "bin", Type: sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_bin), Nullable: true},
+		{Name: "i", Type: sql.Int64, Nullable: true},
+	}
+
+	testCases := []struct {
+		name     string
+		columns  []int
+		left     sql.Row
+		right    sql.Row
+		expected bool
+	}{
+		{"case insensitive key with different case", []int{0}, sql.Row{"abc", "a", 1}, sql.Row{"ABC", "b", 2}, true},
+		{"no pad key with trailing spaces", []int{0}, sql.Row{"abc", "a", 1}, sql.Row{"abc  ", "b", 2}, false},
+		{"case insensitive key with different values", []int{0}, sql.Row{"abc", "a", 1}, sql.Row{"abd", "a", 1}, false},
+		{"binary key with different case", []int{1}, sql.Row{"x", "abc", 1}, sql.Row{"x", "ABC", 1}, false},
+		{"binary key with same values", []int{1}, sql.Row{"x", "abc", 1}, sql.Row{"y", "abc", 2}, true},
+		{"multi column key", []int{0, 2}, sql.Row{"abc", "a", int64(1)}, sql.Row{"ABC", "b", int64(1)}, true},
+		{"multi column key with one different value", []int{0, 2}, sql.Row{"abc", "a", int64(1)}, sql.Row{"ABC", "a", int64(2)}, false},
+		{"NULL key", []int{2}, sql.Row{"a", "a", nil}, sql.Row{"a", "a", nil}, false},
+		{"NULL in a multi column key", []int{0, 2}, sql.Row{"a", "a", nil}, sql.Row{"a", "a", nil}, false},
+		{"NULL and non NULL key", []int{2}, sql.Row{"a", "a", nil}, sql.Row{"a", "a", int64(1)}, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			m := NewKeyMatcher(schema, tt.columns)
+			require.Equal(tt.columns, m.Columns())
+
+			matches, err := m.Matches(sql.NewEmptyContext(), tt.left, tt.right)
+			require.NoError(err)
+			require.Equal(tt.expected, matches)
+
+			matches, err = m.Matches(sql.NewEmptyContext(), tt.right, tt.left)
+			require.NoError(err)
+			require.Equal(tt.expected, matches)
+
+			// Neither the NULL comparison policy nor the diagnostics of the context change how keys are matched
+			diagnostics := sql.NewDiagnostics().LogNullComparisons()
+			ctx := sql.NewContext(context.Background(), sql.WithDiagnostics(diagnostics), sql.WithNullComparisonPolicy(sql.NullEqualsNull))
+			matches, err = m.Matches(ctx, tt.left, tt.right)
+			require.NoError(err)
+			require.Equal(tt.expected, matches)
+			require.Empty(diagnostics.Coercions())
+			require.Empty(diagnostics.NullComparisons())
+		})
+	}
+}
@@ -1,43 +1,113 @@
 package expression
 
 import (
-	"bytes"
 	"fmt"
 	"regexp"
 	"strings"
 	"sync"
+	"unicode/utf8"
+
+	errors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/internal/regex"
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-// Like performs pattern matching against two strings.
+// ErrInvalidLikeEscape is returned when the escape character of a LIKE isn't a single character.
+var ErrInvalidLikeEscape = errors.NewKind("Incorrect arguments to ESCAPE")
+
+// customCollationUnit is the regular expression of a character of a string matched with a custom collation, which is
+// matched by the eight bytes of its weight.
+const customCollationUnit = ".{8}"
+
+// Like performs pattern matching against two strings. The pattern is matched with the collation the operands are
+// compared with: without regard to case for a case insensitive collation, by the weights of the characters for a
+// custom collation, and byte by byte for a binary string or a binary LIKE, in which a _ matches a single byte.
 type Like struct {
 	BinaryExpression
+	// Escape is the expression of the escape character of the pattern, or nil for the default one, a backslash.
+	Escape sql.Expression
 	pool   *sync.Pool
 	cached bool
+	binary bool
 }
 
 // NewLike creates a new LIKE expression.
 func NewLike(left, right sql.Expression) sql.Expression {
+	return newLike(left, right, nil, false)
+}
+
+// NewLikeBinary creates a new LIKE BINARY expression, which matches the bytes of the operands exactly regardless of
+// their collations.
+func NewLikeBinary(left, right sql.Expression) sql.Expression {
+	return newLike(left, right, nil, true)
+}
+
+// NewLikeWithEscape creates a new LIKE expression, or LIKE BINARY expression if binary is true, whose pattern escapes
+// its wildcards with the character escape evaluates to instead of a backslash. An empty escape character leaves the
+// pattern with none.
+func NewLikeWithEscape(left, right, escape sql.Expression, binary bool) sql.Expression {
+	return newLike(left, right, escape, binary)
+}
+
+func newLike(left, right, escape sql.Expression, binary bool) sql.Expression {
 	var cached = true
-	sql.Inspect(right, func(e sql.Expression) bool {
-		if _, ok := e.(*GetField); ok {
-			cached = false
+	for _, e := range []sql.Expression{right, escape} {
+		if e == nil {
+			continue
 		}
-		return true
-	})
+		sql.Inspect(e, func(e sql.Expression) bool {
+			if _, ok := e.(*GetField); ok {
+				cached = false
+			}
+			return true
+		})
+	}
 
 	return &Like{
 		BinaryExpression: BinaryExpression{left, right},
+		Escape:           escape,
 		pool:             nil,
 		cached:           cached,
+		binary:           binary,
 	}
 }
 
+// IsBinary returns whether this is a LIKE BINARY expression.
+func (l *Like) IsBinary() bool {
+	return l.binary
+}
+
+// matching returns how the operands are matched, which depends on the collation they're compared with.
+func (l *Like) matching() (likeMatching, error) {
+	if l.binary {
+		return likeMatching{collation: sql.Collation_binary}, nil
+	}
+
+	cmp := newComparison(l.Left, l.Right)
+	collation, err := cmp.collation()
+	if err != nil {
+		return likeMatching{}, err
+	}
+	return likeMatching{collation: collation}, nil
+}
+
 // Type implements the sql.Expression interface.
 func (l *Like) Type() sql.Type { return sql.Boolean }
 
+// Children implements the sql.Expression interface.
+func (l *Like) Children() []sql.Expression {
+	if l.Escape == nil {
+		return l.BinaryExpression.Children()
+	}
+	return []sql.Expression{l.Left, l.Right, l.Escape}
+}
+
+// Resolved implements the sql.Expression interface.
+func (l *Like) Resolved() bool {
+	return l.BinaryExpression.Resolved() && (l.Escape == nil || l.Escape.Resolved())
+}
+
 // Eval implements the sql.Expression interface.
 func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	span, ctx := ctx.Span("expression.Like")
@@ -47,7 +117,12 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	if err != nil || left == nil {
 		return nil, err
 	}
-	left, err = sql.LongText.Convert(left)
+	left, err = sql.LongText.Convert(sql.CharValue(ctx, l.Left.Type(), left))
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := l.matching()
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +143,16 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
-		right = patternToGoRegex(v.(string))
+
+		escape, err := l.escape(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err = m.regex(v.(string), escape)
+		if err != nil {
+			return nil, err
+		}
 	}
 	// for non-cached regex every time create a new matcher
 	if !l.cached {
@@ -92,7 +176,7 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	ok := matcher.Match(left.(string))
+	ok := matcher.Match(m.subject(left.(string)))
 	if !l.cached {
 		disposer.Dispose()
 	} else if l.pool != nil {
@@ -103,54 +187,158 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return ok, nil
 }
 
+// escape returns the escape character of the pattern, which is empty if it has none.
+func (l *Like) escape(ctx *sql.Context, row sql.Row) (string, error) {
+	if l.Escape == nil {
+		return `\`, nil
+	}
+
+	v, err := l.Escape.Eval(ctx, row)
+	if err != nil {
+		return "", err
+	}
+
+	// A NULL escape character is the default one, as if there were none
+	if v == nil {
+		return `\`, nil
+	}
+
+	v, err = sql.LongText.Convert(v)
+	if err != nil {
+		return "", err
+	}
+
+	escape := v.(string)
+	if utf8.RuneCountInString(escape) > 1 {
+		return "", ErrInvalidLikeEscape.New()
+	}
+	return escape, nil
+}
+
 func (l *Like) String() string {
-	return fmt.Sprintf("%s LIKE %s", l.Left, l.Right)
+	op := "LIKE"
+	if l.binary {
+		op = "LIKE BINARY"
+	}
+
+	if l.Escape != nil {
+		return fmt.Sprintf("%s %s %s ESCAPE %s", l.Left, op, l.Right, l.Escape)
+	}
+	return fmt.Sprintf("%s %s %s", l.Left, op, l.Right)
 }
 
 // WithChildren implements the Expression interface.
 func (l *Like) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	if len(children) != 2 {
-		return nil, sql.ErrInvalidChildrenNumber.New(l, len(children), 2)
+	expected := len(l.Children())
+	if len(children) != expected {
+		return nil, sql.ErrInvalidChildrenNumber.New(l, len(children), expected)
+	}
+
+	var escape sql.Expression
+	if expected == 3 {
+		escape = children[2]
+	}
+	return newLike(children[0], children[1], escape, l.binary), nil
+}
+
+// likeMatching is how a LIKE matches a string with a pattern, as the collation it's matched with decides. Strings of a
+// binary collation are matched byte by byte, and strings of a custom collation by the weights of their characters,
+// each of which is represented with a character for each of its bytes, as the regular expression of the pattern is.
+type likeMatching struct {
+	collation sql.Collation
+}
+
+// subject returns the string given as it's matched with the regular expression of a pattern.
+func (m likeMatching) subject(s string) string {
+	switch {
+	case m.collation.CharacterSet() == sql.CharacterSet_binary:
+		return bytesAsRunes(s)
+	case m.collation.IsCustom():
+		if m.collation.IsCaseInsensitive() {
+			s = strings.ToLower(s)
+		}
+		return bytesAsRunes(sql.CollationSortKey(m.collation, s))
+	default:
+		return s
+	}
+}
+
+// regex returns the regular expression of the pattern given, whose escape character is the one given, if any.
+func (m likeMatching) regex(pattern, escape string) (string, error) {
+	unit := "."
+	literal := func(r rune) string {
+		return regexp.QuoteMeta(string(r))
+	}
+
+	switch {
+	case m.collation.CharacterSet() == sql.CharacterSet_binary:
+		pattern, escape = bytesAsRunes(pattern), bytesAsRunes(escape)
+		if utf8.RuneCountInString(escape) > 1 {
+			return "", ErrInvalidLikeEscape.New()
+		}
+	case m.collation.IsCustom():
+		unit = customCollationUnit
+		literal = func(r rune) string {
+			return regexp.QuoteMeta(m.subject(string(r)))
+		}
 	}
-	return NewLike(children[0], children[1]), nil
+
+	escapeRune := rune(-1)
+	if escape != "" {
+		escapeRune, _ = utf8.DecodeRuneInString(escape)
+	}
+
+	re := likeRegex(pattern, escapeRune, literal, unit)
+	if m.collation.IsCaseInsensitive() && !m.collation.IsCustom() {
+		re = "(?i)" + re
+	}
+	return re, nil
+}
+
+// bytesAsRunes returns a string with a character for each byte of the string given, whose code point is the byte, so
+// that a regular expression matches the string given byte by byte.
+func bytesAsRunes(s string) string {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		buf.WriteRune(rune(s[i]))
+	}
+	return buf.String()
 }
 
+// patternToGoRegex returns the regular expression of a LIKE pattern escaped with backslashes that matches strings
+// character by character.
 func patternToGoRegex(pattern string) string {
-	var buf bytes.Buffer
-	buf.WriteString("(?s)")
-	buf.WriteRune('^')
+	return likeRegex(pattern, '\\', func(r rune) string {
+		return regexp.QuoteMeta(string(r))
+	}, ".")
+}
+
+// likeRegex returns the regular expression of the LIKE pattern given, whose escape character is the one given, or none
+// if it's negative. A % matches any number of units, a _ matches the regular expression of a single unit given, and
+// any other character, as well as an escaped wildcard or escape character, matches the regular expression literal
+// returns for it. An escape character at the end of the pattern matches itself.
+func likeRegex(pattern string, escape rune, literal func(rune) string, unit string) string {
+	var buf strings.Builder
+	buf.WriteString("(?s)^")
 	var escaped bool
-	for _, r := range strings.Replace(regexp.QuoteMeta(pattern), `\\`, `\`, -1) {
-		switch r {
-		case '_':
-			if escaped {
-				buf.WriteRune(r)
-			} else {
-				buf.WriteRune('.')
-			}
-		case '%':
-			if !escaped {
-				buf.WriteString(".*")
-			} else {
-				buf.WriteRune(r)
-			}
-		case '\\':
-			if escaped {
-				buf.WriteString(`\\`)
-			} else {
-				escaped = true
-				continue
-			}
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			buf.WriteString(literal(r))
+			escaped = false
+		case r == escape:
+			escaped = true
+		case r == '%':
+			buf.WriteString(".*")
+		case r == '_':
+			buf.WriteString(unit)
 		default:
-			if escaped {
-				buf.WriteString(`\`)
-			}
-			buf.WriteRune(r)
+			buf.WriteString(literal(r))
 		}
+	}
 
-		if escaped {
-			escaped = false
-		}
+	if escaped {
+		buf.WriteString(literal(escape))
 	}
 
 	buf.WriteRune('$')
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -64,3 +65,127 @@ func TestLike(t *testing.T) {
 		})
 	}
 }
+
+func TestLikeBinary(t *testing.T) {
+	ci := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_0900_ai_ci)
+	bin := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin)
+
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		binary   bool
+		value    string
+		expected bool
+	}{
+		{"case insensitive column", ci, false, "abcdef", true},
+		{"case insensitive column, binary", ci, true, "abcdef", false},
+		{"case insensitive column, binary, same case", ci, true, "ABCdef", true},
+		{"case sensitive column", bin, false, "abcdef", false},
+		{"case sensitive column, same case", bin, false, "ABCdef", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			left := NewGetField(0, tt.typ, "name", false)
+			right := NewLiteral("ABC%", sql.LongText)
+
+			var like sql.Expression
+			if tt.binary {
+				like = NewLikeBinary(left, right)
+			} else {
+				like = NewLike(left, right)
+			}
+
+			require.Equal(t, tt.expected, eval(t, like, sql.NewRow(tt.value)))
+		})
+	}
+}
+
+func TestLikeEscape(t *testing.T) {
+	testCases := []struct {
+		value, pattern string
+		escape         interface{}
+		expected       interface{}
+	}{
+		{"a%c", "a|%c", "|", true},
+		{"abc", "a|%c", "|", false},
+		{"a_c", "a|_c", "|", true},
+		{"abc", "a|_c", "|", false},
+		{"a|c", "a||c", "|", true},
+		{"a|", "a|", "|", true},
+		{`a\c`, `a\_`, "|", true},
+		{"a%c", "a%%c", "%", true},
+		{"abc", "a%%c", "%", false},
+		{"a%c", "a\\%c", nil, true},
+		{"abc", "a\\%c", nil, false},
+		{"abc", "a\\%c", "", false},
+		{"a\\bc", "a\\%c", "", true},
+		{"aéc", "aé_c", "é", false},
+		{"a_c", "aé_c", "é", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%q LIKE %q ESCAPE %v", tt.value, tt.pattern, tt.escape), func(t *testing.T) {
+			like := NewLikeWithEscape(
+				NewGetField(0, sql.LongText, "value", false),
+				NewLiteral(tt.pattern, sql.LongText),
+				NewLiteral(tt.escape, sql.LongText),
+				false,
+			)
+			require.Equal(t, tt.expected, eval(t, like, sql.NewRow(tt.value)))
+		})
+	}
+
+	like := NewLikeWithEscape(NewLiteral("a", sql.LongText), NewLiteral("a", sql.LongText), NewLiteral("||", sql.LongText), false)
+	_, err := like.Eval(sql.NewEmptyContext(), nil)
+	require.True(t, ErrInvalidLikeEscape.Is(err))
+}
+
+func TestLikeCollation(t *testing.T) {
+	digitsLast, err := sql.RegisterCollation("utf8mb4_test_like_digits_last_ci", sql.CharacterSet_utf8mb4, func(r rune) int {
+		if r >= '0' && r <= '9' {
+			return int(r) + 1000
+		}
+		return int(r)
+	})
+	require.NoError(t, err)
+
+	ci := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_0900_ai_ci)
+	bin := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin)
+	custom := sql.MustCreateString(sqltypes.VarChar, 20, digitsLast)
+	blob := sql.MustCreateBinary(sqltypes.Blob, 100)
+
+	testCases := []struct {
+		name           string
+		typ            sql.Type
+		value, pattern string
+		expected       bool
+	}{
+		{"case insensitive", ci, "ABC", "a_c", true},
+		{"case sensitive", bin, "ABC", "a_c", false},
+		{"case sensitive, same case", bin, "aBc", "a_c", true},
+		{"multibyte character", bin, "é", "_", true},
+		{"blob, multibyte character", blob, "é", "_", false},
+		{"blob, bytes of a multibyte character", blob, "é", "__", true},
+		{"blob, case sensitive", blob, "ABC", "a%", false},
+		{"blob, escaped wildcard", blob, "a%\xff", "a\\%_", true},
+		{"custom", custom, "a1b", "a_b", true},
+		{"custom, case insensitive", custom, "A1B", "a%b", true},
+		{"custom, different character", custom, "a1b", "a2b", false},
+		{"custom, escaped wildcard", custom, "a_b", "a\\_b", true},
+		{"custom, escaped wildcard, different character", custom, "a1b", "a\\_b", false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			like := NewLike(NewGetField(0, tt.typ, "value", false), NewLiteral(tt.pattern, sql.LongText))
+			require.Equal(t, tt.expected, eval(t, like, sql.NewRow(tt.value)))
+		})
+	}
+
+	swedish := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_latin1_swedish_ci)
+	german := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_latin1_german1_ci)
+	like := NewLike(NewGetField(0, swedish, "a", false), NewGetField(1, german, "b", false))
+	_, err = like.Eval(sql.NewEmptyContext(), sql.NewRow("a", "a"))
+	require.True(t, sql.ErrCollationIllegalMix.Is(err))
+}
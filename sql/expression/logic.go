@@ -90,6 +90,22 @@ func NewOr(left, right sql.Expression) sql.Expression {
 	return &Or{BinaryExpression{Left: left, Right: right}}
 }
 
+// JoinOr joins several expressions with Or.
+func JoinOr(exprs ...sql.Expression) sql.Expression {
+	switch len(exprs) {
+	case 0:
+		return nil
+	case 1:
+		return exprs[0]
+	default:
+		result := NewOr(exprs[0], exprs[1])
+		for _, e := range exprs[2:] {
+			result = NewOr(result, e)
+		}
+		return result
+	}
+}
+
 func (o *Or) String() string {
 	return fmt.Sprintf("%s OR %s", o.Left, o.Right)
 }
@@ -127,11 +143,11 @@ func (o *Or) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
-	if lval == nil && rval == nil {
+	if lval == nil || rval == nil {
 		return nil, nil
 	}
 
-	return rval == true, nil
+	return false, nil
 }
 
 // WithChildren implements the Expression interface.
@@ -51,6 +51,8 @@ func TestOr(t *testing.T) {
 		{"both true", true, true, true},
 		{"both false", false, false, false},
 		{"both null", nil, nil, nil},
+		{"left is null, right is false", nil, false, nil},
+		{"left is false, right is null", false, nil, nil},
 	}
 
 	for _, tt := range testCases {
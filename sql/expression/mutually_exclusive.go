@@ -0,0 +1,195 @@
+package expression
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// columnRange is the range of values of a column accepted by a comparison between that column and a constant.
+type columnRange struct {
+	column *GetField
+	Range
+}
+
+// AreMutuallyExclusive returns whether the two comparisons given can never both be true for the same row, such as
+// a < 5 and a > 10. Only comparisons of the same column against literals that compare with it as values of its type
+// are considered; for any other comparisons it returns false, since their exclusivity can't be proven.
+func AreMutuallyExclusive(c1, c2 Comparer) (bool, error) {
+	r1, ok := comparisonRange(c1)
+	if !ok {
+		return false, nil
+	}
+
+	r2, ok := comparisonRange(c2)
+	if !ok {
+		return false, nil
+	}
+
+	if !strings.EqualFold(r1.column.Table(), r2.column.Table()) || !strings.EqualFold(r1.column.Name(), r2.column.Name()) {
+		return false, nil
+	}
+
+	// A comparison against NULL is never true
+	for _, r := range []*columnRange{r1, r2} {
+		for _, b := range []*RangeBound{r.Lower, r.Upper} {
+			if b != nil && b.Value.(*Literal).Value() == nil {
+				return true, nil
+			}
+		}
+	}
+
+	lower, err := tighterBound(r1.Lower, r2.Lower, 1)
+	if err != nil {
+		return false, err
+	}
+
+	upper, err := tighterBound(r1.Upper, r2.Upper, -1)
+	if err != nil {
+		return false, err
+	}
+
+	if lower == nil || upper == nil {
+		return false, nil
+	}
+
+	cmp, err := compareConstants(lower.Value, upper.Value)
+	if err != nil {
+		return false, err
+	}
+
+	return cmp > 0 || (cmp == 0 && !(lower.Inclusive && upper.Inclusive)), nil
+}
+
+// comparisonRange returns the range of values of a column accepted by the comparison given, if it's a comparison
+// between a column and a literal.
+func comparisonRange(c Comparer) (*columnRange, bool) {
+	left, right := c.Left(), c.Right()
+	flipped := false
+	if _, ok := left.(*Literal); ok {
+		left, right = right, left
+		flipped = true
+	}
+
+	column, ok := left.(*GetField)
+	if !ok {
+		return nil, false
+	}
+
+	lit, ok := right.(*Literal)
+	if !ok {
+		return nil, false
+	}
+
+	// Bounds are compared as values of the type of the column, so they must compare with the column as such
+	if lit.Value() != nil {
+		cd, ok := c.(CoercionDescriber)
+		if !ok {
+			return nil, false
+		}
+
+		v, ok := ColumnTypeValue(column.Type(), lit, cd)
+		if !ok {
+			return nil, false
+		}
+		right = NewLiteral(v, column.Type())
+	}
+
+	r := &columnRange{column: column}
+	switch c.(type) {
+	case *Equals:
+		r.Lower = &RangeBound{right, true}
+		r.Upper = &RangeBound{right, true}
+	case *LessThan, *LessThanOrEqual:
+		_, inclusive := c.(*LessThanOrEqual)
+		if flipped {
+			r.Lower = &RangeBound{right, inclusive}
+		} else {
+			r.Upper = &RangeBound{right, inclusive}
+		}
+	case *GreaterThan, *GreaterThanOrEqual:
+		_, inclusive := c.(*GreaterThanOrEqual)
+		if flipped {
+			r.Upper = &RangeBound{right, inclusive}
+		} else {
+			r.Lower = &RangeBound{right, inclusive}
+		}
+	default:
+		return nil, false
+	}
+
+	return r, true
+}
+
+// tighterBound returns the most restrictive of the two bounds given. The direction is 1 for lower bounds, where the
+// greatest bound is the most restrictive, and -1 for upper bounds.
+func tighterBound(b1, b2 *RangeBound, direction int) (*RangeBound, error) {
+	if b1 == nil {
+		return b2, nil
+	}
+
+	if b2 == nil {
+		return b1, nil
+	}
+
+	cmp, err := compareConstants(b1.Value, b2.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cmp * direction {
+	case 1:
+		return b1, nil
+	case -1:
+		return b2, nil
+	default:
+		return &RangeBound{b1.Value, b1.Inclusive && b2.Inclusive}, nil
+	}
+}
+
+func compareConstants(left, right sql.Expression) (int, error) {
+	c := newComparison(left, right)
+	return c.Compare(sql.NewEmptyContext(), nil)
+}
+
+// ColumnTypeValue returns the value of the literal given converted to the type of a column given, if the comparison
+// given of the column and the literal compares the literal with the values of the column as a value of the type of the
+// column, and converting it loses nothing, so that it compares equal to the value it's converted to. Numbers compared
+// with a numeric column are always ordered like the number they're converted to, as long as it's equal to them.
+func ColumnTypeValue(colType sql.Type, lit *Literal, c CoercionDescriber) (interface{}, bool) {
+	compareType, branch, err := c.Coercion(nil)
+	if err != nil {
+		return nil, false
+	}
+
+	var ok bool
+	switch branch {
+	case CoercionBranchOperandType:
+		ok = true
+	case ConvertToSigned, ConvertToUnsigned:
+		ok = sql.IsInteger(colType)
+	case ConvertToDouble:
+		ok = sql.IsFloat(colType)
+	case ConvertToDecimal:
+		ok = sql.IsDecimal(colType) && compareType.String() == colType.String()
+	case ConvertToChar:
+		ok = sql.IsTextOnly(colType)
+	}
+	ok = ok || sql.IsNumber(colType) && sql.IsNumber(lit.Type())
+
+	if !ok {
+		return nil, false
+	}
+
+	v, err := colType.Convert(lit.Value())
+	if err != nil {
+		return nil, false
+	}
+
+	cmp, err := compareConstants(NewLiteral(v, colType), lit)
+	if err != nil || cmp != 0 {
+		return nil, false
+	}
+
+	return v, true
+}
@@ -0,0 +1,66 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestAreMutuallyExclusive(t *testing.T) {
+	a := NewGetFieldWithTable(0, sql.Int64, "t", "a", false)
+	b := NewGetFieldWithTable(1, sql.Int64, "t", "b", false)
+	s := NewGetFieldWithTable(2, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 20), "t", "s", false)
+	lit := func(v int64) sql.Expression {
+		return NewLiteral(v, sql.Int64)
+	}
+	str := func(v string) sql.Expression {
+		return NewLiteral(v, sql.LongText)
+	}
+
+	testCases := []struct {
+		name     string
+		c1, c2   Comparer
+		expected bool
+	}{
+		{"a < 5, a > 10", NewLessThan(a, lit(5)), NewGreaterThan(a, lit(10)), true},
+		{"a < 5, 10 < a", NewLessThan(a, lit(5)), NewLessThan(lit(10), a), true},
+		{"a = 1, a = 2", NewEquals(a, lit(1)), NewEquals(a, lit(2)), true},
+		{"a = 5, a > 5", NewEquals(a, lit(5)), NewGreaterThan(a, lit(5)), true},
+		{"a < 5, a >= 5", NewLessThan(a, lit(5)), NewGreaterThanOrEqual(a, lit(5)), true},
+		{"a = NULL, a > 1", NewEquals(a, NewLiteral(nil, sql.Null)), NewGreaterThan(a, lit(1)), true},
+		{"a <= 5, a >= 5", NewLessThanOrEqual(a, lit(5)), NewGreaterThanOrEqual(a, lit(5)), false},
+		{"a < 10, a > 5", NewLessThan(a, lit(10)), NewGreaterThan(a, lit(5)), false},
+		{"a = 5, 5 >= a", NewEquals(a, lit(5)), NewGreaterThanOrEqual(lit(5), a), false},
+		{"a < 5, a < 1", NewLessThan(a, lit(5)), NewLessThan(a, lit(1)), false},
+		{"different columns", NewLessThan(a, lit(5)), NewGreaterThan(b, lit(10)), false},
+		{"column against column", NewLessThan(a, b), NewGreaterThan(a, lit(10)), false},
+		{"unsupported comparison", NewInTuple(a, NewTuple(lit(1))), NewEquals(a, lit(2)), false},
+		{"a > '9', a < '10'", NewGreaterThan(a, str("9")), NewLessThan(a, str("10")), false},
+		{"a > '10', a < '9'", NewGreaterThan(a, str("10")), NewLessThan(a, str("9")), true},
+		{"a = '9', a = 9", NewEquals(a, str("9")), NewEquals(a, lit(9)), false},
+		{"a >= 9, a < 9.5", NewGreaterThanOrEqual(a, lit(9)), NewLessThan(a, NewLiteral(9.5, sql.Float64)), false},
+		{"a = 'x', a = 'y'", NewEquals(a, str("x")), NewEquals(a, str("y")), false},
+		{"s > 10, s < 9", NewGreaterThan(s, lit(10)), NewLessThan(s, lit(9)), false},
+		{"s > '10', s < '9'", NewGreaterThan(s, str("10")), NewLessThan(s, str("9")), false},
+		{"s = 'a', s = 'A'", NewEquals(s, str("a")), NewEquals(s, str("A")), false},
+		{"s = 'a', s = 'b'", NewEquals(s, str("a")), NewEquals(s, str("b")), true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			exclusive, err := AreMutuallyExclusive(tt.c1, tt.c2)
+			require.NoError(err)
+			require.Equal(tt.expected, exclusive)
+
+			exclusive, err = AreMutuallyExclusive(tt.c2, tt.c1)
+			require.NoError(err)
+			require.Equal(tt.expected, exclusive)
+		})
+	}
+}
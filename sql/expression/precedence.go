@@ -0,0 +1,170 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Operator precedence levels, from the loosest to the tightest binding, as defined by MySQL.
+// https://dev.mysql.com/doc/refman/8.0/en/operator-precedence.html
+const (
+	precedenceOr = iota
+	precedenceAnd
+	precedenceNot
+	precedenceBetween
+	precedenceComparison
+	precedenceBitOr
+	precedenceBitAnd
+	precedenceShift
+	precedenceAdditive
+	precedenceMultiplicative
+	precedenceBitXor
+	precedenceUnary
+	precedencePrimary
+)
+
+// PrecedenceString returns the string representation of the given expression, parenthesizing operands wherever the
+// operator precedence and associativity rules of MySQL would otherwise group them differently. Unlike String, the
+// result can be parsed back into an expression tree with the same structure, which makes it suitable to regenerate
+// queries from rewritten expressions.
+func PrecedenceString(e sql.Expression) string {
+	switch e := e.(type) {
+	case *And:
+		return precedenceBinary(e.Left, "AND", e.Right, precedenceAnd)
+	case *Or:
+		return precedenceBinary(e.Left, "OR", e.Right, precedenceOr)
+	case *Not:
+		return "NOT " + precedenceOperand(e.Child, precedenceNot-1)
+	case *Equals:
+		return precedenceComparisonString(e.Left(), "=", e.Right())
+	case *LessThan:
+		return precedenceComparisonString(e.Left(), "<", e.Right())
+	case *GreaterThan:
+		return precedenceComparisonString(e.Left(), ">", e.Right())
+	case *LessThanOrEqual:
+		return precedenceComparisonString(e.Left(), "<=", e.Right())
+	case *GreaterThanOrEqual:
+		return precedenceComparisonString(e.Left(), ">=", e.Right())
+	case *TypedComparison:
+		return precedenceComparisonString(e.Left(), string(e.op), e.Right())
+	case *Regexp:
+		return precedenceComparisonString(e.Left(), "REGEXP", e.Right())
+	case *Like:
+		op := "LIKE"
+		if e.binary {
+			op = "LIKE BINARY"
+		}
+		if e.Escape != nil {
+			return precedenceComparisonString(e.Left, op, e.Right) + " ESCAPE " + precedenceOperand(e.Escape, precedenceComparison)
+		}
+		return precedenceComparisonString(e.Left, op, e.Right)
+	case *InTuple:
+		return precedenceComparisonString(e.Left(), "IN", e.Right())
+	case *HashInTuple:
+		return precedenceComparisonString(e.Left(), "IN", e.Right())
+	case *IsNull:
+		return precedenceOperand(e.Child, precedenceComparison) + " IS NULL"
+	case *IsTrue:
+		if e.invert {
+			return precedenceOperand(e.Child, precedenceComparison) + " " + IsFalseStr
+		}
+		return precedenceOperand(e.Child, precedenceComparison) + " " + IsTrueStr
+	case *Between:
+		return fmt.Sprintf(
+			"%s BETWEEN %s AND %s",
+			precedenceOperand(e.Val, precedenceComparison),
+			precedenceOperand(e.Lower, precedenceComparison),
+			precedenceOperand(e.Upper, precedenceComparison),
+		)
+	case *Arithmetic:
+		return precedenceBinary(e.Left, e.Op, e.Right, arithmeticPrecedence(e.Op))
+	case *UnaryMinus:
+		child := precedenceOperand(e.Child, precedenceUnary-1)
+		// Two consecutive dashes would start a comment
+		if strings.HasPrefix(child, "-") {
+			child = "(" + child + ")"
+		}
+		return "-" + child
+	case *BinaryCast:
+		return "BINARY " + precedenceOperand(e.Child, precedenceUnary-1)
+	case Tuple:
+		return "(" + precedenceList(e) + ")"
+	case *UnresolvedFunction:
+		return fmt.Sprintf("%s(%s)", e.name, precedenceList(e.Arguments))
+	default:
+		return e.String()
+	}
+}
+
+// precedenceBinary returns the string of a left associative binary operator, which groups operands of the same
+// precedence on its left without parentheses, but not on its right.
+func precedenceBinary(left sql.Expression, op string, right sql.Expression, precedence int) string {
+	return fmt.Sprintf("%s %s %s", precedenceOperand(left, precedence-1), op, precedenceOperand(right, precedence))
+}
+
+// precedenceComparisonString returns the string of a comparison. Comparisons of comparisons are always parenthesized,
+// as they're easy to misread even where MySQL would group them the same way.
+func precedenceComparisonString(left sql.Expression, op string, right sql.Expression) string {
+	return fmt.Sprintf("%s %s %s", precedenceOperand(left, precedenceComparison), op, precedenceOperand(right, precedenceComparison))
+}
+
+// precedenceOperand returns the string of an operand, parenthesized if its operator doesn't bind tighter than the
+// given precedence.
+func precedenceOperand(e sql.Expression, precedence int) string {
+	s := PrecedenceString(e)
+	if expressionPrecedence(e) <= precedence {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+func precedenceList(exprs []sql.Expression) string {
+	strs := make([]string, len(exprs))
+	for i, e := range exprs {
+		strs[i] = PrecedenceString(e)
+	}
+	return strings.Join(strs, ", ")
+}
+
+func expressionPrecedence(e sql.Expression) int {
+	switch e := e.(type) {
+	case *Or:
+		return precedenceOr
+	case *And:
+		return precedenceAnd
+	case *Not:
+		return precedenceNot
+	case *Between:
+		return precedenceBetween
+	case *Equals, *LessThan, *GreaterThan, *LessThanOrEqual, *GreaterThanOrEqual, *TypedComparison, *Regexp, *Like,
+		*InTuple, *HashInTuple, *IsNull, *IsTrue:
+		return precedenceComparison
+	case *Arithmetic:
+		return arithmeticPrecedence(e.Op)
+	case *UnaryMinus, *BinaryCast:
+		return precedenceUnary
+	default:
+		return precedencePrimary
+	}
+}
+
+func arithmeticPrecedence(op string) int {
+	switch strings.ToLower(op) {
+	case sqlparser.BitOrStr:
+		return precedenceBitOr
+	case sqlparser.BitAndStr:
+		return precedenceBitAnd
+	case sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr:
+		return precedenceShift
+	case sqlparser.PlusStr, sqlparser.MinusStr:
+		return precedenceAdditive
+	case sqlparser.BitXorStr:
+		return precedenceBitXor
+	default:
+		return precedenceMultiplicative
+	}
+}
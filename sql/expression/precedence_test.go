@@ -0,0 +1,90 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestPrecedenceString(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"a = b AND c", "a = b AND c"},
+		{"a = (b AND c)", "a = (b AND c)"},
+		{"(a = b) = c", "(a = b) = c"},
+		{"a = (b = c)", "a = (b = c)"},
+		{"a OR b AND c", "a OR b AND c"},
+		{"(a OR b) AND c", "(a OR b) AND c"},
+		{"a AND (b AND c)", "a AND (b AND c)"},
+		{"(a AND b) AND c", "a AND b AND c"},
+		{"NOT a = b", "NOT a = b"},
+		{"(NOT a) = b", "(NOT a) = b"},
+		{"NOT (a AND b)", "NOT (a AND b)"},
+		{"NOT NOT a", "NOT NOT a"},
+		{"a != b OR c NOT LIKE 'x%'", "NOT a = b OR NOT c LIKE \"x%\""},
+		{"a - (b - c)", "a - (b - c)"},
+		{"(a - b) - c", "a - b - c"},
+		{"(a + b) * c", "(a + b) * c"},
+		{"a + b * c", "a + b * c"},
+		{"a | b & c << 1", "a | b & c << 1"},
+		{"((a | b) & c) << 1", "((a | b) & c) << 1"},
+		{"-(a + b) > -c", "-(a + b) > -c"},
+		{"-(-1) < a", "-(-1) < a"},
+		{"(a = b) IS NULL", "(a = b) IS NULL"},
+		{"(a OR b) IS TRUE", "(a OR b) IS TRUE"},
+		{"a + 1 IS FALSE", "a + 1 IS FALSE"},
+		{"(a BETWEEN b AND c) BETWEEN (d = e) AND f + 1", "(a BETWEEN b AND c) BETWEEN (d = e) AND f + 1"},
+		{"(a OR b) IN (c = d, e AND f)", "(a OR b) IN (c = d, e AND f)"},
+		{"a NOT IN (1, 2)", "NOT a IN (1, 2)"},
+		{"a REGEXP (b LIKE c)", "a REGEXP (b LIKE c)"},
+		{"coalesce(a = b AND c, d) > 1.5", "coalesce(a = b AND c, d) > 1.5"},
+		{"BINARY a = b", "BINARY a = b"},
+		{"BINARY (a + b) = -c", "BINARY (a + b) = -c"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.input, func(t *testing.T) {
+			require := require.New(t)
+
+			expr := parseFilterExpression(t, tt.input)
+			result := expression.PrecedenceString(expr)
+			require.Equal(tt.expected, result)
+			require.Equal(expr, parseFilterExpression(t, result))
+		})
+	}
+}
+
+func TestPrecedenceStringTypedComparison(t *testing.T) {
+	require := require.New(t)
+
+	c, err := expression.NewTypedComparison(
+		expression.OpLessThanOrEqual,
+		expression.NewAnd(expression.NewUnresolvedColumn("a"), expression.NewUnresolvedColumn("b")),
+		expression.NewLiteral(int8(1), sql.Int8),
+		sql.Int64,
+	)
+	require.NoError(err)
+	require.Equal("(a AND b) <= 1", expression.PrecedenceString(c))
+}
+
+func parseFilterExpression(t *testing.T, condition string) sql.Expression {
+	node, err := parse.Parse(sql.NewEmptyContext(), "SELECT * FROM t WHERE "+condition)
+	require.NoError(t, err)
+
+	var filter *plan.Filter
+	plan.Inspect(node, func(n sql.Node) bool {
+		if f, ok := n.(*plan.Filter); ok {
+			filter = f
+		}
+		return filter == nil
+	})
+	require.NotNil(t, filter)
+	return filter.Expression
+}
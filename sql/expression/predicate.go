@@ -0,0 +1,128 @@
+package expression
+
+import (
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrUncompilablePredicate is returned when a comparison can't be compiled into a predicate.
+var ErrUncompilablePredicate = errors.NewKind("comparison can't be compiled into a predicate: %s")
+
+// CompilePredicate returns a predicate that evaluates the comparison given against a row, so that storage engines
+// can filter rows with it themselves. How the values of the operands are converted to compare them is resolved once,
+// from their types, instead of for every row. As in a filter, a row for which an operand is NULL doesn't satisfy it.
+// Since there's no session, strings compared with a TIMESTAMP are taken to be in UTC.
+func CompilePredicate(c Comparer) (func(sql.Row) (bool, error), error) {
+	var compare func(left, right interface{}) (int, error)
+	cmp, op, ok := comparerOperator(c)
+	if ok {
+		compare = inferredTypeCompare(cmp)
+	} else if tc, ok := c.(*TypedComparison); ok {
+		cmp, op = &tc.comparison, tc.op
+		compare = forcedTypeCompare(tc.forceType)
+	} else {
+		return nil, ErrUncompilablePredicate.New(c)
+	}
+
+	accepts, err := operatorAccepts(op)
+	if err != nil {
+		return nil, err
+	}
+
+	// Operands are columns and literals in the general case, which don't need anything from the context
+	ctx := sql.NewEmptyContext()
+	return func(row sql.Row) (bool, error) {
+		left, right, err := cmp.evalLeftAndRight(ctx, row)
+		if err != nil {
+			return false, err
+		}
+
+		if left == nil || right == nil || isNaN(left) || isNaN(right) {
+			return false, nil
+		}
+
+		result, err := compare(left, right)
+		if err != nil {
+			if ErrNaNOperand.Is(err) || sql.ErrIncomparable.Is(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		return accepts(result), nil
+	}, nil
+}
+
+// inferredTypeCompare returns the function comparing the values of the operands of the comparison given with the type
+// inferred from the types of the operands, like comparison.Compare does.
+func inferredTypeCompare(c *comparison) func(left, right interface{}) (int, error) {
+	leftType := c.Left().Type()
+	if typ, ok := comparatorType(leftType, c.Right().Type()); ok {
+		return typ.Compare
+	}
+
+	if comparesWithOperandType(leftType, c.Right().Type()) {
+		return leftType.Compare
+	}
+
+	coercion, err := c.coercion()
+	if err != nil {
+		return func(left, right interface{}) (int, error) {
+			return 0, err
+		}
+	}
+
+	return func(left, right interface{}) (int, error) {
+		left, right, err := coercion.apply(nil, left, right)
+		if err != nil {
+			return 0, err
+		}
+
+		if isNaN(left) || isNaN(right) {
+			return 0, ErrNaNOperand.New()
+		}
+
+		return coercion.compareType.Compare(left, right)
+	}
+}
+
+// forcedTypeCompare returns the function comparing two values converted to the type given.
+func forcedTypeCompare(typ sql.Type) func(left, right interface{}) (int, error) {
+	return func(left, right interface{}) (int, error) {
+		left, err := typ.Convert(left)
+		if err != nil {
+			return 0, err
+		}
+
+		right, err = typ.Convert(right)
+		if err != nil {
+			return 0, err
+		}
+
+		if isNaN(left) || isNaN(right) {
+			return 0, ErrNaNOperand.New()
+		}
+
+		return typ.Compare(left, right)
+	}
+}
+
+// operatorAccepts returns the function reporting whether the result of comparing two values satisfies the operator
+// given.
+func operatorAccepts(op ComparisonOperator) (func(int) bool, error) {
+	switch op {
+	case OpEquals:
+		return func(cmp int) bool { return cmp == 0 }, nil
+	case OpLessThan:
+		return func(cmp int) bool { return cmp == -1 }, nil
+	case OpGreaterThan:
+		return func(cmp int) bool { return cmp == 1 }, nil
+	case OpLessThanOrEqual:
+		return func(cmp int) bool { return cmp < 1 }, nil
+	case OpGreaterThanOrEqual:
+		return func(cmp int) bool { return cmp > -1 }, nil
+	default:
+		return nil, ErrUnsupportedComparisonOperator.New(op)
+	}
+}
@@ -0,0 +1,246 @@
+package expression
+
+import (
+	"encoding/json"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrUnserializablePredicate is returned when a predicate has an expression that can't be serialized.
+var ErrUnserializablePredicate = errors.NewKind("predicate can't be serialized: %s")
+
+// ErrInvalidSerializedPredicate is returned when a serialized predicate can't be deserialized.
+var ErrInvalidSerializedPredicate = errors.NewKind("invalid serialized predicate: %s")
+
+// Kinds of the serialized expressions of a predicate.
+const (
+	serializedComparison      = "comparison"
+	serializedTypedComparison = "typed_comparison"
+	serializedNullSafeEquals  = "null_safe_equals"
+	serializedRegexp          = "regexp"
+	serializedIn              = "in"
+	serializedBetween         = "between"
+	serializedNot             = "not"
+	serializedTuple           = "tuple"
+	serializedColumn          = "column"
+	serializedLiteral         = "literal"
+)
+
+// serializedArity is the number of operands of the serialized expressions with a fixed number of them.
+var serializedArity = map[string]int{
+	serializedComparison:      2,
+	serializedTypedComparison: 2,
+	serializedNullSafeEquals:  2,
+	serializedRegexp:          2,
+	serializedIn:              2,
+	serializedBetween:         3,
+	serializedNot:             1,
+}
+
+// serializedExpression is the JSON representation of an expression of a serialized predicate. Types are represented
+// by their SQL definitions, such as VARCHAR(20) COLLATE utf8mb4_bin, and the values of literals by their SQL
+// representations in those types.
+type serializedExpression struct {
+	Kind     string                  `json:"kind"`
+	Operator ComparisonOperator      `json:"op,omitempty"`
+	Operands []*serializedExpression `json:"operands,omitempty"`
+	Type     string                  `json:"type,omitempty"`
+	Index    int                     `json:"index,omitempty"`
+	Table    string                  `json:"table,omitempty"`
+	Name     string                  `json:"name,omitempty"`
+	Nullable bool                    `json:"nullable,omitempty"`
+	Value    []byte                  `json:"value,omitempty"`
+	Null     bool                    `json:"null,omitempty"`
+}
+
+// MarshalPredicate serializes a predicate so that it can be sent to another process, such as the shards of a
+// distributed storage engine, which deserializes it with UnmarshalPredicate to filter rows with it. Predicates are
+// made of =, <=>, <, >, <=, >=, REGEXP, IN and BETWEEN comparisons, and their negations, of columns, literals and tuples of
+// them. Columns keep their indexes in the row, so the rows the predicate is evaluated against must have the same
+// schema. Custom collations must be registered in both processes.
+func MarshalPredicate(e sql.Expression) ([]byte, error) {
+	se, err := serializeExpression(e)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(se)
+}
+
+// UnmarshalPredicate deserializes a predicate serialized by MarshalPredicate.
+func UnmarshalPredicate(data []byte) (sql.Expression, error) {
+	var se serializedExpression
+	if err := json.Unmarshal(data, &se); err != nil {
+		return nil, ErrInvalidSerializedPredicate.Wrap(err, err.Error())
+	}
+	return deserializeExpression(&se)
+}
+
+func serializeExpression(e sql.Expression) (*serializedExpression, error) {
+	switch e := e.(type) {
+	case *Equals, *LessThan, *GreaterThan, *LessThanOrEqual, *GreaterThanOrEqual:
+		cmp, op, _ := comparerOperator(e.(Comparer))
+		return serializeOperands(&serializedExpression{Kind: serializedComparison, Operator: op}, cmp.Left(), cmp.Right())
+	case *TypedComparison:
+		se, err := serializeOperands(&serializedExpression{Kind: serializedTypedComparison, Operator: e.op}, e.Left(), e.Right())
+		if err != nil {
+			return nil, err
+		}
+		se.Type = e.forceType.String()
+		return se, nil
+	case *NullSafeEquals:
+		return serializeOperands(&serializedExpression{Kind: serializedNullSafeEquals}, e.Left(), e.Right())
+	case *Regexp:
+		return serializeOperands(&serializedExpression{Kind: serializedRegexp}, e.Left(), e.Right())
+	case *HashInTuple:
+		return serializeOperands(&serializedExpression{Kind: serializedIn}, e.Left(), e.Right())
+	case *InTuple:
+		return serializeOperands(&serializedExpression{Kind: serializedIn}, e.Left(), e.Right())
+	case *Between:
+		return serializeOperands(&serializedExpression{Kind: serializedBetween}, e.Val, e.Lower, e.Upper)
+	case *Not:
+		return serializeOperands(&serializedExpression{Kind: serializedNot}, e.Child)
+	case Tuple:
+		return serializeOperands(&serializedExpression{Kind: serializedTuple}, e...)
+	case *GetField:
+		return &serializedExpression{
+			Kind:     serializedColumn,
+			Type:     e.Type().String(),
+			Index:    e.Index(),
+			Table:    e.Table(),
+			Name:     e.Name(),
+			Nullable: e.IsNullable(),
+		}, nil
+	case *Literal:
+		se := &serializedExpression{Kind: serializedLiteral, Type: e.Type().String()}
+		if e.Value() == nil {
+			se.Null = true
+			return se, nil
+		}
+
+		v, err := e.Type().SQL(e.Value())
+		if err != nil {
+			return nil, ErrUnserializablePredicate.Wrap(err, e)
+		}
+		se.Value = v.ToBytes()
+		return se, nil
+	default:
+		return nil, ErrUnserializablePredicate.New(e)
+	}
+}
+
+// serializeOperands serializes the operands given as the operands of the serialized expression given.
+func serializeOperands(se *serializedExpression, operands ...sql.Expression) (*serializedExpression, error) {
+	se.Operands = make([]*serializedExpression, len(operands))
+	for i, operand := range operands {
+		var err error
+		se.Operands[i], err = serializeExpression(operand)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return se, nil
+}
+
+func deserializeExpression(se *serializedExpression) (sql.Expression, error) {
+	operands := make([]sql.Expression, len(se.Operands))
+	for i, operand := range se.Operands {
+		if operand == nil {
+			return nil, ErrInvalidSerializedPredicate.New("missing operand of " + se.Kind)
+		}
+
+		var err error
+		operands[i], err = deserializeExpression(operand)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if n, ok := serializedArity[se.Kind]; ok && len(operands) != n {
+		return nil, ErrInvalidSerializedPredicate.New(se.Kind + " with a wrong number of operands")
+	}
+
+	switch se.Kind {
+	case serializedComparison:
+		switch se.Operator {
+		case OpEquals:
+			return NewEquals(operands[0], operands[1]), nil
+		case OpLessThan:
+			return NewLessThan(operands[0], operands[1]), nil
+		case OpGreaterThan:
+			return NewGreaterThan(operands[0], operands[1]), nil
+		case OpLessThanOrEqual:
+			return NewLessThanOrEqual(operands[0], operands[1]), nil
+		case OpGreaterThanOrEqual:
+			return NewGreaterThanOrEqual(operands[0], operands[1]), nil
+		default:
+			return nil, ErrUnsupportedComparisonOperator.New(se.Operator)
+		}
+	case serializedTypedComparison:
+		typ, err := deserializeType(se.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewTypedComparison(se.Operator, operands[0], operands[1], typ)
+	case serializedNullSafeEquals:
+		return NewNullSafeEquals(operands[0], operands[1]), nil
+	case serializedRegexp:
+		return NewRegexp(operands[0], operands[1]), nil
+	case serializedIn:
+		return NewInTuple(operands[0], operands[1]), nil
+	case serializedBetween:
+		return NewBetween(operands[0], operands[1], operands[2]), nil
+	case serializedNot:
+		return NewNot(operands[0]), nil
+	case serializedTuple:
+		return NewTuple(operands...), nil
+	case serializedColumn:
+		typ, err := deserializeType(se.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewGetFieldWithTable(se.Index, typ, se.Table, se.Name, se.Nullable), nil
+	case serializedLiteral:
+		typ, err := deserializeType(se.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if se.Null {
+			return NewLiteral(nil, typ), nil
+		}
+
+		v, err := typ.Convert(string(se.Value))
+		if err != nil {
+			return nil, ErrInvalidSerializedPredicate.Wrap(err, err.Error())
+		}
+		return NewLiteral(v, typ), nil
+	default:
+		return nil, ErrInvalidSerializedPredicate.New("unknown kind of expression " + se.Kind)
+	}
+}
+
+// deserializeType returns the type of the SQL definition given, as returned by its String method.
+func deserializeType(definition string) (sql.Type, error) {
+	if definition == sql.Null.String() {
+		return sql.Null, nil
+	}
+
+	stmt, err := sqlparser.Parse("CREATE TABLE t (c " + definition + ")")
+	if err != nil {
+		return nil, ErrInvalidSerializedPredicate.Wrap(err, "type "+definition)
+	}
+
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil || len(ddl.TableSpec.Columns) != 1 {
+		return nil, ErrInvalidSerializedPredicate.New("type " + definition)
+	}
+
+	typ, err := sql.ColumnTypeToType(&ddl.TableSpec.Columns[0].Type)
+	if err != nil {
+		return nil, ErrInvalidSerializedPredicate.Wrap(err, "type "+definition)
+	}
+	return typ, nil
+}
@@ -0,0 +1,100 @@
+package expression
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestPredicateSerialization(t *testing.T) {
+	decimal := sql.MustCreateDecimalType(10, 2)
+	varchar := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin)
+	varbinary := sql.MustCreateBinary(sqltypes.VarBinary, 10)
+	enum := sql.MustCreateEnumType([]string{"small", "medium", "large"}, sql.Collation_Default)
+
+	i := NewGetFieldWithTable(0, sql.Int64, "t", "i", true)
+	f := NewGetFieldWithTable(1, sql.Float64, "t", "f", true)
+	d := NewGetFieldWithTable(2, decimal, "t", "d", true)
+	s := NewGetFieldWithTable(3, varchar, "t", "s", true)
+	b := NewGetFieldWithTable(4, varbinary, "t", "b", true)
+	dt := NewGetFieldWithTable(5, sql.Datetime, "t", "dt", true)
+	e := NewGetFieldWithTable(6, enum, "t", "e", true)
+
+	rows := []sql.Row{
+		{int64(1), 0.5, "1.25", "a", "\x00\xff", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), uint16(1)},
+		{int64(5), 2.75, "10.50", "b ", "ab", time.Date(2021, 6, 15, 12, 30, 0, 500000000, time.UTC), uint16(2)},
+		{int64(-3), -1.0, "-0.01", "B", "\xff", time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC), uint16(3)},
+		{nil, nil, nil, nil, nil, nil, nil},
+	}
+
+	int64Lit := func(v int64) sql.Expression { return NewLiteral(v, sql.Int64) }
+	typed, err := NewTypedComparison(OpGreaterThan, d, NewLiteral("1.3", sql.LongText), sql.Float64)
+	require.NoError(t, err)
+	hashIn, err := NewHashInTuple(i, NewTuple(int64Lit(1), int64Lit(-3)))
+	require.NoError(t, err)
+
+	predicates := []sql.Expression{
+		NewEquals(i, int64Lit(5)),
+		NewLessThan(f, NewLiteral(1.5, sql.Float64)),
+		NewGreaterThan(d, NewLiteral("1.25", decimal)),
+		NewLessThanOrEqual(NewLiteral("2020-01-01 00:00:00", sql.LongText), dt),
+		NewGreaterThanOrEqual(dt, NewLiteral(time.Date(2021, 6, 15, 12, 30, 0, 500000000, time.UTC), sql.Datetime)),
+		NewNot(NewEquals(s, NewLiteral("b", sql.LongText))),
+		NewEquals(b, NewLiteral("\x00\xff", varbinary)),
+		NewEquals(e, NewLiteral("medium", sql.LongText)),
+		NewEquals(i, NewLiteral(nil, sql.Null)),
+		NewNullSafeEquals(i, NewLiteral(nil, sql.Null)),
+		NewNullSafeEquals(NewTuple(i, s), NewTuple(int64Lit(5), NewLiteral("b", sql.LongText))),
+		NewRegexp(s, NewLiteral("^[a-z]", sql.LongText)),
+		typed,
+		NewInTuple(i, NewTuple(int64Lit(1), int64Lit(5), NewLiteral(nil, sql.Null))),
+		NewNotInTuple(s, NewTuple(NewLiteral("a", sql.LongText), NewLiteral("c", sql.LongText))),
+		hashIn,
+		NewInTuple(NewTuple(i, s), NewTuple(NewTuple(int64Lit(5), NewLiteral("b ", sql.LongText)), NewTuple(int64Lit(1), NewLiteral("x", sql.LongText)))),
+		NewBetween(f, NewLiteral(int64(0), sql.Int8), NewLiteral(2.75, sql.Float64)),
+		NewNot(NewBetween(i, int64Lit(0), i)),
+	}
+
+	ctx := sql.NewEmptyContext()
+	for _, p := range predicates {
+		t.Run(p.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			data, err := MarshalPredicate(p)
+			require.NoError(err)
+			deserialized, err := UnmarshalPredicate(data)
+			require.NoError(err)
+			require.Equal(p.String(), deserialized.String())
+
+			for _, row := range rows {
+				expected, err := p.Eval(ctx, row)
+				require.NoError(err)
+				actual, err := deserialized.Eval(ctx, row)
+				require.NoError(err)
+				require.Equal(expected, actual, "%v", row)
+			}
+		})
+	}
+}
+
+func TestPredicateSerializationErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := MarshalPredicate(NewAnd(NewEquals(NewLiteral(int64(1), sql.Int64), NewLiteral(int64(1), sql.Int64)), NewLiteral(true, sql.Boolean)))
+	require.True(ErrUnserializablePredicate.Is(err))
+
+	for _, data := range []string{
+		`{`,
+		`{"kind":"comparison","op":"<>","operands":[{"kind":"literal","type":"BIGINT","value":"MQ=="},{"kind":"literal","type":"BIGINT","value":"MQ=="}]}`,
+		`{"kind":"comparison","op":"=","operands":[{"kind":"literal","type":"BIGINT","value":"MQ=="}]}`,
+		`{"kind":"column","type":"NOT A TYPE","name":"a"}`,
+		`{"kind":"and"}`,
+	} {
+		_, err := UnmarshalPredicate([]byte(data))
+		require.Error(err, data)
+	}
+}
@@ -0,0 +1,119 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestCompilePredicate(t *testing.T) {
+	text := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_Default)
+	binText := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin)
+	i := NewGetField(0, sql.Int64, "i", true)
+	f := NewGetField(1, sql.Float64, "f", true)
+	s := NewGetField(2, text, "s", true)
+	b := NewGetField(3, binText, "b", true)
+	reversed := NewGetField(0, sql.WithComparator(sql.Int64, func(a, b interface{}) (int, error) {
+		return sql.Int64.Compare(b, a)
+	}), "i", true)
+
+	testCases := []struct {
+		name  string
+		left  sql.Expression
+		right sql.Expression
+	}{
+		{"int columns", i, NewGetField(0, sql.Int64, "i", true)},
+		{"int and string", i, NewLiteral("5", sql.LongText)},
+		{"int and float", i, f},
+		{"float and literal", f, NewLiteral(int64(3), sql.Int64)},
+		{"string and literal", s, NewLiteral("ab", sql.LongText)},
+		{"string and int", s, NewLiteral(int64(3), sql.Int64)},
+		{"binary string and literal", b, NewLiteral("ab", sql.LongText)},
+		{"strings", s, b},
+		{"column with a comparator and literal", reversed, NewLiteral("5", sql.LongText)},
+	}
+
+	rows := predicateTestRows(1000)
+	for _, tt := range testCases {
+		comparers := []Comparer{
+			NewEquals(tt.left, tt.right),
+			NewGreaterThan(tt.left, tt.right),
+			NewLessThan(tt.left, tt.right),
+			NewGreaterThanOrEqual(tt.left, tt.right),
+			NewLessThanOrEqual(tt.left, tt.right),
+		}
+
+		for _, c := range comparers {
+			t.Run(fmt.Sprintf("%s: %s", tt.name, c), func(t *testing.T) {
+				require := require.New(t)
+				predicate, err := CompilePredicate(c)
+				require.NoError(err)
+
+				for _, row := range rows {
+					expected, err := c.Eval(sql.NewEmptyContext(), row)
+					require.NoError(err)
+
+					result, err := predicate(row)
+					require.NoError(err)
+					require.Equal(expected == true, result, "row %v", row)
+				}
+			})
+		}
+	}
+}
+
+func TestCompileTypedPredicate(t *testing.T) {
+	require := require.New(t)
+
+	s := NewGetField(0, sql.LongText, "s", true)
+	c, err := NewTypedComparison(OpGreaterThan, s, NewLiteral("9", sql.LongText), sql.Int64)
+	require.NoError(err)
+
+	predicate, err := CompilePredicate(c)
+	require.NoError(err)
+
+	for _, row := range []sql.Row{{"10"}, {"9"}, {"1"}, {nil}} {
+		expected, err := c.Eval(sql.NewEmptyContext(), row)
+		require.NoError(err)
+
+		result, err := predicate(row)
+		require.NoError(err)
+		require.Equal(expected == true, result, "row %v", row)
+	}
+}
+
+func TestCompilePredicateUnsupported(t *testing.T) {
+	_, err := CompilePredicate(NewRegexp(NewLiteral("a", sql.LongText), NewLiteral("a", sql.LongText)))
+	require.True(t, ErrUncompilablePredicate.Is(err))
+}
+
+// predicateTestRows returns rows with an integer, a float and two strings, some of which are NULL. Some of the
+// floats are NaN or infinite.
+func predicateTestRows(n int) []sql.Row {
+	strs := []string{"a", "ab", "AB", "ab ", "b", "3", "10", ""}
+	specialFloats := []float64{math.NaN(), math.Inf(1), math.Inf(-1)}
+	r := rand.New(rand.NewSource(1))
+	rows := make([]sql.Row, n)
+	for i := range rows {
+		row := sql.Row{
+			int64(r.Intn(20) - 10),
+			float64(r.Intn(100)) / 10,
+			strs[r.Intn(len(strs))],
+			strs[r.Intn(len(strs))],
+		}
+		if r.Intn(20) == 0 {
+			row[1] = specialFloats[r.Intn(len(specialFloats))]
+		}
+		if r.Intn(10) == 0 {
+			row[r.Intn(len(row))] = nil
+		}
+		rows[i] = row
+	}
+	return rows
+}
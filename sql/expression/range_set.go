@@ -0,0 +1,300 @@
+package expression
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// RangeBound is one of the ends of a Range. Its value is always a literal.
+type RangeBound struct {
+	Value     sql.Expression
+	Inclusive bool
+}
+
+// Range is an interval of values. A nil bound means the range is unbounded on that side.
+type Range struct {
+	Lower *RangeBound
+	Upper *RangeBound
+}
+
+func (r Range) String() string {
+	var sb strings.Builder
+	if r.Lower == nil {
+		sb.WriteString("(-inf")
+	} else if r.Lower.Inclusive {
+		fmt.Fprintf(&sb, "[%s", r.Lower.Value)
+	} else {
+		fmt.Fprintf(&sb, "(%s", r.Lower.Value)
+	}
+
+	sb.WriteString(", ")
+
+	if r.Upper == nil {
+		sb.WriteString("+inf)")
+	} else if r.Upper.Inclusive {
+		fmt.Fprintf(&sb, "%s]", r.Upper.Value)
+	} else {
+		fmt.Fprintf(&sb, "%s)", r.Upper.Value)
+	}
+
+	return sb.String()
+}
+
+// ExpressionRange returns the column and the range of its values accepted by the expression given, if it's a
+// comparison or BETWEEN between a column and non NULL literals. The literals of the range are converted to the type of
+// the column, and it returns false if they can't be converted without changing their value.
+func ExpressionRange(e sql.Expression) (*GetField, Range, bool) {
+	var column *GetField
+	var r Range
+	switch e := e.(type) {
+	case *Between:
+		var ok bool
+		column, ok = e.Val.(*GetField)
+		if !ok {
+			return nil, Range{}, false
+		}
+
+		r = Range{&RangeBound{e.Lower, true}, &RangeBound{e.Upper, true}}
+	case Comparer:
+		cr, ok := comparisonRange(e)
+		if !ok {
+			return nil, Range{}, false
+		}
+
+		column, r = cr.column, cr.Range
+	default:
+		return nil, Range{}, false
+	}
+
+	for _, b := range []*RangeBound{r.Lower, r.Upper} {
+		if b == nil {
+			continue
+		}
+
+		lit, ok := b.Value.(*Literal)
+		if !ok || lit.Value() == nil {
+			return nil, Range{}, false
+		}
+
+		val, err := column.Type().Convert(lit.Value())
+		if err != nil {
+			return nil, Range{}, false
+		}
+
+		converted := NewLiteral(val, column.Type())
+		cmp, err := compareConstants(converted, lit)
+		if err != nil || cmp != 0 {
+			return nil, Range{}, false
+		}
+
+		b.Value = converted
+	}
+
+	return column, r, true
+}
+
+// RangeSet is an expression that checks whether the value of a column is in any of a set of ranges, which are sorted
+// and never overlap. It's equivalent to a disjunction of comparisons of the column against the bounds of each range.
+type RangeSet struct {
+	Column sql.Expression
+	Ranges []Range
+}
+
+var _ sql.Expression = (*RangeSet)(nil)
+
+// NewRangeSet creates a new RangeSet for the column and ranges given, which are merged when they overlap or are
+// adjacent. The bounds of the ranges must be literals of the type of the column.
+func NewRangeSet(column sql.Expression, ranges ...Range) (*RangeSet, error) {
+	sorted := make([]Range, len(ranges))
+	copy(sorted, ranges)
+
+	var sortErr error
+	sort.SliceStable(sorted, func(i, j int) bool {
+		cmp, err := compareLowerBounds(sorted[i].Lower, sorted[j].Lower)
+		if err != nil {
+			sortErr = err
+		}
+		return cmp < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	var merged []Range
+	for _, r := range sorted {
+		if len(merged) == 0 {
+			merged = append(merged, r)
+			continue
+		}
+
+		last := &merged[len(merged)-1]
+		overlaps, err := rangesOverlap(*last, r)
+		if err != nil {
+			return nil, err
+		}
+
+		if !overlaps {
+			merged = append(merged, r)
+			continue
+		}
+
+		upper, err := looserUpperBound(last.Upper, r.Upper)
+		if err != nil {
+			return nil, err
+		}
+		last.Upper = upper
+	}
+
+	return &RangeSet{Column: column, Ranges: merged}, nil
+}
+
+// compareLowerBounds compares two lower bounds, where a missing bound is the lowest, and an inclusive bound is lower
+// than an exclusive one of the same value.
+func compareLowerBounds(b1, b2 *RangeBound) (int, error) {
+	switch {
+	case b1 == nil && b2 == nil:
+		return 0, nil
+	case b1 == nil:
+		return -1, nil
+	case b2 == nil:
+		return 1, nil
+	}
+
+	cmp, err := compareConstants(b1.Value, b2.Value)
+	if err != nil || cmp != 0 {
+		return cmp, err
+	}
+
+	switch {
+	case b1.Inclusive == b2.Inclusive:
+		return 0, nil
+	case b1.Inclusive:
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
+
+// rangesOverlap returns whether the range given, which doesn't start before the first one, overlaps with it or starts
+// right where it ends, so that the two can be merged into a single range.
+func rangesOverlap(first, next Range) (bool, error) {
+	if first.Upper == nil || next.Lower == nil {
+		return true, nil
+	}
+
+	cmp, err := compareConstants(next.Lower.Value, first.Upper.Value)
+	if err != nil {
+		return false, err
+	}
+
+	return cmp < 0 || (cmp == 0 && (next.Lower.Inclusive || first.Upper.Inclusive)), nil
+}
+
+// looserUpperBound returns the least restrictive of the two upper bounds given.
+func looserUpperBound(b1, b2 *RangeBound) (*RangeBound, error) {
+	if b1 == nil || b2 == nil {
+		return nil, nil
+	}
+
+	cmp, err := compareConstants(b1.Value, b2.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case cmp > 0:
+		return b1, nil
+	case cmp < 0:
+		return b2, nil
+	default:
+		return &RangeBound{b1.Value, b1.Inclusive || b2.Inclusive}, nil
+	}
+}
+
+// Eval implements the Expression interface.
+func (r *RangeSet) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := r.Column.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	lit := NewLiteral(val, r.Column.Type())
+	for _, rng := range r.Ranges {
+		if rng.Lower != nil {
+			cmp, err := compareConstants(lit, rng.Lower.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			if cmp < 0 || (cmp == 0 && !rng.Lower.Inclusive) {
+				continue
+			}
+		}
+
+		if rng.Upper != nil {
+			cmp, err := compareConstants(lit, rng.Upper.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			if cmp > 0 || (cmp == 0 && !rng.Upper.Inclusive) {
+				continue
+			}
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Type implements the Expression interface.
+func (*RangeSet) Type() sql.Type {
+	return sql.Boolean
+}
+
+// IsNullable implements the Expression interface.
+func (r *RangeSet) IsNullable() bool {
+	return r.Column.IsNullable()
+}
+
+// Resolved implements the Expression interface.
+func (r *RangeSet) Resolved() bool {
+	return r.Column.Resolved()
+}
+
+// Children implements the Expression interface.
+func (r *RangeSet) Children() []sql.Expression {
+	return []sql.Expression{r.Column}
+}
+
+// WithChildren implements the Expression interface.
+func (r *RangeSet) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 1)
+	}
+	return &RangeSet{Column: children[0], Ranges: r.Ranges}, nil
+}
+
+func (r *RangeSet) String() string {
+	ranges := make([]string, len(r.Ranges))
+	for i, rng := range r.Ranges {
+		ranges[i] = rng.String()
+	}
+	return fmt.Sprintf("%s IN RANGES (%s)", r.Column, strings.Join(ranges, ", "))
+}
+
+func (r *RangeSet) DebugString() string {
+	ranges := make([]string, len(r.Ranges))
+	for i, rng := range r.Ranges {
+		ranges[i] = rng.String()
+	}
+	return fmt.Sprintf("%s IN RANGES (%s)", sql.DebugString(r.Column), strings.Join(ranges, ", "))
+}
@@ -0,0 +1,108 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestNewRangeSet(t *testing.T) {
+	x := NewGetFieldWithTable(0, sql.Int64, "t", "x", true)
+	lit := func(v int64) sql.Expression {
+		return NewLiteral(v, sql.Int64)
+	}
+
+	rangeOf := func(e sql.Expression) Range {
+		_, r, ok := ExpressionRange(e)
+		require.True(t, ok)
+		return r
+	}
+
+	testCases := []struct {
+		name     string
+		ranges   []sql.Expression
+		expected string
+	}{
+		{
+			"disjoint ranges",
+			[]sql.Expression{NewGreaterThan(x, lit(100)), NewLessThan(x, lit(5))},
+			"t.x IN RANGES ((-inf, 5), (100, +inf))",
+		},
+		{
+			"overlapping ranges",
+			[]sql.Expression{NewBetween(x, lit(1), lit(3)), NewBetween(x, lit(2), lit(10))},
+			"t.x IN RANGES ([1, 10])",
+		},
+		{
+			"adjacent ranges",
+			[]sql.Expression{NewLessThan(x, lit(5)), NewBetween(x, lit(5), lit(10))},
+			"t.x IN RANGES ((-inf, 10])",
+		},
+		{
+			"ranges touching at an excluded value",
+			[]sql.Expression{NewLessThan(x, lit(5)), NewGreaterThan(x, lit(5))},
+			"t.x IN RANGES ((-inf, 5), (5, +inf))",
+		},
+		{
+			"range contained in another",
+			[]sql.Expression{NewBetween(x, lit(1), lit(10)), NewEquals(x, lit(5)), NewGreaterThan(lit(20), x)},
+			"t.x IN RANGES ((-inf, 20))",
+		},
+		{
+			"coerced bounds",
+			[]sql.Expression{NewLessThan(x, NewLiteral("5", sql.LongText)), NewGreaterThanOrEqual(x, NewLiteral(float64(100), sql.Float64))},
+			"t.x IN RANGES ((-inf, 5), [100, +inf))",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var ranges []Range
+			for _, e := range tt.ranges {
+				ranges = append(ranges, rangeOf(e))
+			}
+
+			rs, err := NewRangeSet(x, ranges...)
+			require.NoError(err)
+			require.Equal(tt.expected, rs.String())
+
+			// The range set accepts the same values as the disjunction of the expressions it was built from
+			disjunction := JoinOr(tt.ranges...)
+			for _, v := range []interface{}{nil, int64(-1), int64(1), int64(2), int64(5), int64(7), int64(10), int64(15), int64(20), int64(100), int64(101)} {
+				expected, err := disjunction.Eval(sql.NewEmptyContext(), sql.NewRow(v))
+				require.NoError(err)
+				result, err := rs.Eval(sql.NewEmptyContext(), sql.NewRow(v))
+				require.NoError(err)
+				require.Equal(expected, result, "%v", v)
+			}
+		})
+	}
+}
+
+func TestExpressionRange(t *testing.T) {
+	x := NewGetFieldWithTable(0, sql.Int64, "t", "x", true)
+
+	testCases := []struct {
+		name string
+		expr sql.Expression
+		ok   bool
+	}{
+		{"comparison", NewLessThan(x, NewLiteral(int64(5), sql.Int64)), true},
+		{"between", NewBetween(x, NewLiteral(int64(1), sql.Int64), NewLiteral(int64(5), sql.Int64)), true},
+		{"null bound", NewLessThan(x, NewLiteral(nil, sql.Null)), false},
+		{"bound changed by conversion", NewLessThan(x, NewLiteral(float64(5.5), sql.Float64)), false},
+		{"column against column", NewLessThan(x, x), false},
+		{"between with column bound", NewBetween(x, x, NewLiteral(int64(5), sql.Int64)), false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, ok := ExpressionRange(tt.expr)
+			require.Equal(t, tt.ok, ok)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package expression
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// streamedTextLength is the length in bytes from which the TEXT and BLOB values of a comparison are read incrementally
+// instead of converted before comparing them, which copies them whole, such as to lower their case.
+const streamedTextLength = 64 * 1024
+
+// compareStreamedText compares the values given of the operands of the comparison like compareValues does, if they're
+// both values of variable length string types and any of them is large, by reading them a character at a time until
+// the first one that tells them apart. The boolean returned is whether the values were compared.
+func (c *comparison) compareStreamedText(ctx *sql.Context, left, right interface{}) (int, bool, error) {
+	if !streamsText(c.Left().Type()) || !streamsText(c.Right().Type()) {
+		return 0, false, nil
+	}
+
+	l, ok := newTextOperand(left)
+	if !ok {
+		return 0, false, nil
+	}
+
+	r, ok := newTextOperand(right)
+	if !ok {
+		return 0, false, nil
+	}
+
+	if l.len() < streamedTextLength && r.len() < streamedTextLength {
+		return 0, false, nil
+	}
+
+	coercion, err := c.coercion()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if coercion.convertTo != ConvertToChar || coercion.setType != nil {
+		return 0, false, nil
+	}
+
+	coercion.warn(ctx, left, right)
+	c.compareType = coercion.compareType
+	return compareText(coercion.compareType.(sql.StringType).Collation(), l, r, coercion.padSpace, coercion.caseInsensitive), true, nil
+}
+
+// streamsText returns whether the values of the type given can be compared by compareStreamedText, which is the case
+// for the string types whose values aren't padded when converted, unlike those of CHAR and BINARY.
+func streamsText(t sql.Type) bool {
+	if _, ok := t.(sql.StringType); !ok {
+		return false
+	}
+
+	switch t.Type() {
+	case sqltypes.Char, sqltypes.Binary:
+		return false
+	default:
+		return true
+	}
+}
+
+// compareText compares two strings like a comparison of them with the collation given does once they're converted,
+// without copying them: trailing spaces are ignored if padSpace is true, the case of the characters if caseInsensitive
+// is, and characters are compared with their weights if the collation is a custom one, or byte by byte otherwise.
+func compareText(collation sql.Collation, l, r textOperand, padSpace, caseInsensitive bool) int {
+	if padSpace {
+		l, r = l.trimSpaces(), r.trimSpaces()
+	}
+
+	weight := sql.CustomCollationWeight(collation)
+	if weight == nil && !caseInsensitive {
+		return l.compareBytes(r)
+	}
+
+	// Lowering the case of a string maps each of its characters, and comparing strings byte by byte compares the code
+	// points of their characters, since UTF-8 preserves their order
+	var i, j int
+	for i < l.len() && j < r.len() {
+		lb, rb := l.byteAt(i), r.byteAt(j)
+		if weight == nil && lb < utf8.RuneSelf && rb < utf8.RuneSelf {
+			i, j = i+1, j+1
+			if lb == rb {
+				continue
+			}
+
+			lb, rb = lowerASCII(lb), lowerASCII(rb)
+			switch {
+			case lb < rb:
+				return -1
+			case lb > rb:
+				return 1
+			}
+			continue
+		}
+
+		lr, lsize := l.runeAt(i)
+		rr, rsize := r.runeAt(j)
+		i, j = i+lsize, j+rsize
+		if lr == rr {
+			continue
+		}
+
+		if caseInsensitive {
+			lr, rr = unicode.ToLower(lr), unicode.ToLower(rr)
+		}
+
+		lw, rw := int(lr), int(rr)
+		if weight != nil {
+			lw, rw = weight(lr), weight(rr)
+		}
+
+		switch {
+		case lw < rw:
+			return -1
+		case lw > rw:
+			return 1
+		}
+	}
+
+	switch {
+	case i < l.len():
+		return 1
+	case j < r.len():
+		return -1
+	default:
+		return 0
+	}
+}
+
+func lowerASCII(b byte) byte {
+	if 'A' <= b && b <= 'Z' {
+		return b + 'a' - 'A'
+	}
+	return b
+}
+
+// textOperand is a string or []byte value of an operand of a comparison, which is read as it is instead of converted
+// to the other kind.
+type textOperand struct {
+	s       string
+	b       []byte
+	isBytes bool
+}
+
+// newTextOperand returns the textOperand of the value given, if it's a string or a []byte.
+func newTextOperand(v interface{}) (textOperand, bool) {
+	switch v := v.(type) {
+	case string:
+		return textOperand{s: v}, true
+	case []byte:
+		return textOperand{b: v, isBytes: true}, true
+	default:
+		return textOperand{}, false
+	}
+}
+
+func (t textOperand) len() int {
+	if t.isBytes {
+		return len(t.b)
+	}
+	return len(t.s)
+}
+
+// runeAt returns the character starting at the byte offset given and its size in bytes, which is utf8.RuneError and
+// 1 for a byte that doesn't start a valid UTF-8 encoding.
+func (t textOperand) runeAt(i int) (rune, int) {
+	if t.isBytes {
+		return utf8.DecodeRune(t.b[i:])
+	}
+	return utf8.DecodeRuneInString(t.s[i:])
+}
+
+func (t textOperand) byteAt(i int) byte {
+	if t.isBytes {
+		return t.b[i]
+	}
+	return t.s[i]
+}
+
+func (t textOperand) trimSpaces() textOperand {
+	if t.isBytes {
+		return textOperand{b: bytes.TrimRight(t.b, " "), isBytes: true}
+	}
+	return textOperand{s: strings.TrimRight(t.s, " ")}
+}
+
+// compareBytes compares the operand with the one given byte by byte.
+func (t textOperand) compareBytes(other textOperand) int {
+	switch {
+	case !t.isBytes && !other.isBytes:
+		return strings.Compare(t.s, other.s)
+	case t.isBytes && other.isBytes:
+		return bytes.Compare(t.b, other.b)
+	}
+
+	for i := 0; i < t.len() && i < other.len(); i++ {
+		a, b := t.byteAt(i), other.byteAt(i)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	}
+
+	switch {
+	case t.len() < other.len():
+		return -1
+	case t.len() > other.len():
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,125 @@
+package expression
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestCompareStreamedText(t *testing.T) {
+	reversed, err := sql.RegisterCollation("utf8mb4_test_streamed_reversed", sql.CharacterSet_utf8mb4, func(r rune) int {
+		return -int(r)
+	})
+	require.NoError(t, err)
+
+	types := []sql.Type{
+		sql.CreateLongText(sql.Collation_utf8mb4_bin),
+		sql.CreateLongText(sql.Collation_utf8mb4_general_ci),
+		sql.CreateLongText(sql.Collation_utf8mb4_0900_ai_ci),
+		sql.CreateLongText(reversed),
+		sql.LongBlob,
+	}
+
+	prefix := strings.Repeat("abcdé", streamedTextLength/5)
+	values := []interface{}{
+		prefix,
+		prefix + "x",
+		prefix + "X",
+		prefix + "x  ",
+		prefix + "  ",
+		prefix + " \x01",
+		prefix + "\xff",
+		prefix + "ÉA",
+		[]byte(prefix + "x"),
+		[]byte(prefix + "ÉA "),
+		"ABCDÉ" + prefix,
+		"abc",
+	}
+
+	for _, typ := range types {
+		for _, left := range values {
+			for _, right := range values {
+				c := newComparison(NewGetField(0, typ, "l", false), NewGetField(1, typ, "r", false))
+				streamed, ok, err := c.compareStreamedText(sql.NewEmptyContext(), left, right)
+				require.NoError(t, err)
+				if !ok {
+					require.Equal(t, "abc", left)
+					require.Equal(t, "abc", right)
+					continue
+				}
+
+				l, r, err := c.castLeftAndRight(sql.NewEmptyContext(), left, right)
+				require.NoError(t, err)
+				materialized, err := c.compareType.Compare(l, r)
+				require.NoError(t, err)
+
+				require.Equal(t, materialized, streamed, "%s: %q and %q", typ, tail(left), tail(right))
+			}
+		}
+	}
+}
+
+func TestCompareStreamedTextSmallOperands(t *testing.T) {
+	c := newComparison(NewGetField(0, sql.LongText, "l", false), NewGetField(1, sql.LongText, "r", false))
+	_, ok, err := c.compareStreamedText(sql.NewEmptyContext(), "abc", "abd")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	c = newComparison(NewGetField(0, sql.Int64, "l", false), NewGetField(1, sql.LongText, "r", false))
+	_, ok, err = c.compareStreamedText(sql.NewEmptyContext(), int64(1), strings.Repeat("1", streamedTextLength))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+// tail returns the last characters of a string or []byte value.
+func tail(v interface{}) string {
+	s, ok := v.(string)
+	if !ok {
+		s = string(v.([]byte))
+	}
+	if len(s) > 8 {
+		return s[len(s)-8:]
+	}
+	return s
+}
+
+func BenchmarkCompareLargeText(b *testing.B) {
+	text := sql.CreateLongText(sql.Collation_utf8mb4_general_ci)
+	prefix := strings.Repeat("abcdé", 4*1024*1024/5)
+	benchmarks := []struct {
+		name        string
+		left, right string
+	}{
+		{"first byte differs", "a" + prefix, "b" + prefix},
+		{"last byte differs", prefix + "a", prefix + "b"},
+	}
+
+	for _, bb := range benchmarks {
+		c := newComparison(NewGetField(0, text, "l", false), NewGetField(1, text, "r", false))
+
+		b.Run(bb.name+"/materialized", func(b *testing.B) {
+			ctx := sql.NewEmptyContext()
+			for n := 0; n < b.N; n++ {
+				l, r, err := c.castLeftAndRight(ctx, bb.left, bb.right)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := c.compareType.Compare(l, r); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(bb.name+"/streamed", func(b *testing.B) {
+			ctx := sql.NewEmptyContext()
+			for n := 0; n < b.N; n++ {
+				if _, _, err := c.compareStreamedText(ctx, bb.left, bb.right); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
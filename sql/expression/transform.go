@@ -57,6 +57,62 @@ func TransformUpWithNode(n sql.Node, e sql.Expression, f TransformExprWithNodeFu
 	return f(n, e)
 }
 
+// TransformComparisons applies a transformation function to every comparison in the expressions of the given node
+// and its children, from the bottom up. Each comparison is replaced with the expression returned by the function.
+func TransformComparisons(n sql.Node, f func(Comparer) (sql.Expression, error)) (sql.Node, error) {
+	return transformNodeExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		if c, ok := e.(Comparer); ok {
+			return f(c)
+		}
+		return e, nil
+	})
+}
+
+// transformNodeExpressionsUp applies a transformation function to all expressions on the given tree from the bottom
+// up. Nodes can't be transformed with the helpers in the plan package here, since that package depends on this one.
+func transformNodeExpressionsUp(n sql.Node, f sql.TransformExprFunc) (sql.Node, error) {
+	if o, ok := n.(sql.OpaqueNode); !ok || !o.Opaque() {
+		children := n.Children()
+		if len(children) > 0 {
+			newChildren := make([]sql.Node, len(children))
+			for i, c := range children {
+				c, err := transformNodeExpressionsUp(c, f)
+				if err != nil {
+					return nil, err
+				}
+				newChildren[i] = c
+			}
+
+			var err error
+			n, err = n.WithChildren(newChildren...)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	e, ok := n.(sql.Expressioner)
+	if !ok {
+		return n, nil
+	}
+
+	exprs := e.Expressions()
+	if len(exprs) == 0 {
+		return n, nil
+	}
+
+	newExprs := make([]sql.Expression, len(exprs))
+	for i, e := range exprs {
+		e, err := TransformUp(e, f)
+		if err != nil {
+			return nil, err
+		}
+		newExprs[i] = e
+	}
+
+	return e.WithExpressions(newExprs...)
+}
+
 // ExpressionToColumn converts the expression to the form that should be used in a Schema. Expressions that have Name()
 // and Table() methods will use these; otherwise, String() and "" are used, respectively. The type and nullability are
 // taken from the expression directly.
@@ -0,0 +1,55 @@
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestTransformComparisons(t *testing.T) {
+	require := require.New(t)
+
+	table := plan.NewResolvedTable(memory.NewTable("mytable", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "mytable"},
+		{Name: "b", Type: sql.Int64, Source: "mytable"},
+	}))
+	a := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "mytable", "b", false)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+
+	node := plan.NewProject(
+		[]sql.Expression{expression.NewAlias("gt", expression.NewGreaterThan(a, b))},
+		plan.NewFilter(
+			expression.NewAnd(
+				expression.NewGreaterThan(a, one),
+				expression.NewEquals(b, one),
+			),
+			table,
+		),
+	)
+
+	result, err := expression.TransformComparisons(node, func(c expression.Comparer) (sql.Expression, error) {
+		if _, ok := c.(*expression.GreaterThan); !ok {
+			return c, nil
+		}
+		return expression.NewNot(expression.NewLessThanOrEqual(c.Left(), c.Right())), nil
+	})
+	require.NoError(err)
+
+	expected := plan.NewProject(
+		[]sql.Expression{expression.NewAlias("gt", expression.NewNot(expression.NewLessThanOrEqual(a, b)))},
+		plan.NewFilter(
+			expression.NewAnd(
+				expression.NewNot(expression.NewLessThanOrEqual(a, one)),
+				expression.NewEquals(b, one),
+			),
+			table,
+		),
+	)
+	require.Equal(expected, result)
+}
@@ -0,0 +1,145 @@
+package expression
+
+import (
+	"fmt"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ComparisonOperator is the operator applied by a comparison expression.
+type ComparisonOperator string
+
+const (
+	// OpEquals is the = operator.
+	OpEquals ComparisonOperator = "="
+	// OpLessThan is the < operator.
+	OpLessThan ComparisonOperator = "<"
+	// OpGreaterThan is the > operator.
+	OpGreaterThan ComparisonOperator = ">"
+	// OpLessThanOrEqual is the <= operator.
+	OpLessThanOrEqual ComparisonOperator = "<="
+	// OpGreaterThanOrEqual is the >= operator.
+	OpGreaterThanOrEqual ComparisonOperator = ">="
+)
+
+// ErrUnsupportedComparisonOperator is returned when a comparison is built with an unknown operator.
+var ErrUnsupportedComparisonOperator = errors.NewKind("unsupported comparison operator: %s")
+
+// TypedComparison is a comparison that converts both operands to a fixed type before comparing them, instead of
+// inferring the type to compare with from the types of its operands.
+type TypedComparison struct {
+	comparison
+	op        ComparisonOperator
+	forceType sql.Type
+}
+
+var _ Comparer = (*TypedComparison)(nil)
+
+// NewTypedComparison creates a new TypedComparison expression that compares left and right with the given operator,
+// converting both of them to forceType first.
+func NewTypedComparison(op ComparisonOperator, left, right sql.Expression, forceType sql.Type) (*TypedComparison, error) {
+	switch op {
+	case OpEquals, OpLessThan, OpGreaterThan, OpLessThanOrEqual, OpGreaterThanOrEqual:
+	default:
+		return nil, ErrUnsupportedComparisonOperator.New(op)
+	}
+
+	return &TypedComparison{
+		comparison: newComparison(left, right),
+		op:         op,
+		forceType:  forceType,
+	}, nil
+}
+
+// Operator returns the operator of this comparison.
+func (tc *TypedComparison) Operator() ComparisonOperator {
+	return tc.op
+}
+
+// ForceType returns the type both operands are converted to before comparing them.
+func (tc *TypedComparison) ForceType() sql.Type {
+	return tc.forceType
+}
+
+// Coercion implements the CoercionDescriber interface.
+func (tc *TypedComparison) Coercion(*sql.Context) (sql.Type, string, error) {
+	return tc.forceType, CoercionBranchForced, nil
+}
+
+// Compare implements the Comparer interface.
+func (tc *TypedComparison) Compare(ctx *sql.Context, row sql.Row) (int, error) {
+	left, right, err := tc.evalLeftAndRight(ctx, row)
+	if err != nil {
+		return 0, err
+	}
+
+	if left == nil || right == nil {
+		return 0, ErrNilOperand.New()
+	}
+
+	left, err = tc.forceType.Convert(left)
+	if err != nil {
+		return 0, err
+	}
+
+	right, err = tc.forceType.Convert(right)
+	if err != nil {
+		return 0, err
+	}
+
+	if isNaN(left) || isNaN(right) {
+		return 0, ErrNaNOperand.New()
+	}
+
+	return tc.forceType.Compare(left, right)
+}
+
+// Eval implements the Expression interface.
+func (tc *TypedComparison) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	result, err := tc.Compare(ctx, row)
+	if err != nil {
+		if ErrNilOperand.Is(err) {
+			recordNullComparison(ctx, tc, row)
+			return nil, nil
+		}
+
+		if ErrNaNOperand.Is(err) {
+			return false, nil
+		}
+
+		return nil, err
+	}
+
+	switch tc.op {
+	case OpEquals:
+		return result == 0, nil
+	case OpLessThan:
+		return result == -1, nil
+	case OpGreaterThan:
+		return result == 1, nil
+	case OpLessThanOrEqual:
+		return result < 1, nil
+	case OpGreaterThanOrEqual:
+		return result > -1, nil
+	default:
+		return nil, ErrUnsupportedComparisonOperator.New(tc.op)
+	}
+}
+
+// WithChildren implements the Expression interface.
+func (tc *TypedComparison) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(tc, len(children), 2)
+	}
+	return NewTypedComparison(tc.op, children[0], children[1], tc.forceType)
+}
+
+func (tc *TypedComparison) String() string {
+	return fmt.Sprintf("%s %s %s", tc.Left(), tc.op, tc.Right())
+}
+
+func (tc *TypedComparison) DebugString() string {
+	return fmt.Sprintf("(%s %s %s) AS %s", sql.DebugString(tc.Left()), tc.op, sql.DebugString(tc.Right()), tc.forceType)
+}
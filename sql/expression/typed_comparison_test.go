@@ -0,0 +1,85 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestTypedComparison(t *testing.T) {
+	testCases := []struct {
+		name      string
+		op        ComparisonOperator
+		left      sql.Expression
+		right     sql.Expression
+		forceType sql.Type
+		inferred  interface{}
+		forced    interface{}
+	}{
+		{
+			"strings compared numerically",
+			OpGreaterThan,
+			NewLiteral("10", sql.LongText),
+			NewLiteral("9", sql.LongText),
+			sql.Int64,
+			false,
+			true,
+		},
+		{
+			"numbers compared as strings",
+			OpGreaterThan,
+			NewLiteral(int64(10), sql.Int64),
+			NewLiteral(int64(9), sql.Int64),
+			sql.LongText,
+			true,
+			false,
+		},
+		{
+			"strings compared as decimals",
+			OpEquals,
+			NewLiteral("1.50", sql.LongText),
+			NewLiteral("1.5", sql.LongText),
+			sql.MustCreateDecimalType(10, 2),
+			false,
+			true,
+		},
+		{
+			"null operand",
+			OpLessThanOrEqual,
+			NewLiteral(nil, sql.Null),
+			NewLiteral("1", sql.LongText),
+			sql.Int64,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var inferred sql.Expression
+			switch tt.op {
+			case OpGreaterThan:
+				inferred = NewGreaterThan(tt.left, tt.right)
+			case OpEquals:
+				inferred = NewEquals(tt.left, tt.right)
+			case OpLessThanOrEqual:
+				inferred = NewLessThanOrEqual(tt.left, tt.right)
+			}
+			require.Equal(tt.inferred, eval(t, inferred, nil))
+
+			forced, err := NewTypedComparison(tt.op, tt.left, tt.right, tt.forceType)
+			require.NoError(err)
+			require.Equal(tt.forced, eval(t, forced, nil))
+		})
+	}
+}
+
+func TestTypedComparisonInvalidOperator(t *testing.T) {
+	_, err := NewTypedComparison("<>", NewLiteral(1, sql.Int64), NewLiteral(1, sql.Int64), sql.Int64)
+	require.Error(t, err)
+	require.True(t, ErrUnsupportedComparisonOperator.Is(err))
+}
@@ -0,0 +1,232 @@
+package expression
+
+import (
+	"math"
+	"strings"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// VectorPredicate is a comparison of a column with a literal compiled to be evaluated over a batch of values of the
+// column at once, such as a column of a columnar storage engine, instead of row by row. Comparisons whose values are
+// compared as int64, float64 or strings without a custom collation are evaluated with a loop specialized for that
+// type. Any other comparison, or value of an unexpected type, is evaluated as CompilePredicate would.
+type VectorPredicate struct {
+	// Column is the index of the column compared in the rows of the comparison.
+	Column int
+	// selectFn sets the selection vector given for the values given.
+	selectFn func(values []interface{}, sel []bool) error
+}
+
+// Select sets sel[i] to whether the i-th value given of the column satisfies the comparison, which it never does when
+// it's NULL. The selection vector must be at least as long as the values.
+func (p *VectorPredicate) Select(values []interface{}, sel []bool) error {
+	return p.selectFn(values, sel[:len(values)])
+}
+
+// CompileVectorPredicate returns the comparison given compiled to be evaluated over batches of values of the column it
+// compares with a literal. The column may be on either side of the comparison. As with CompilePredicate, there's no
+// session, so strings compared with a TIMESTAMP are taken to be in UTC.
+func CompileVectorPredicate(c Comparer) (*VectorPredicate, error) {
+	column, literal, swapped := c.Left(), c.Right(), false
+	if _, ok := column.(*GetField); !ok {
+		column, literal, swapped = literal, column, true
+	}
+
+	field, ok := column.(*GetField)
+	if !ok {
+		return nil, ErrUncompilablePredicate.New(c)
+	}
+
+	lit, ok := literal.(*Literal)
+	if !ok {
+		return nil, ErrUncompilablePredicate.New(c)
+	}
+
+	predicate, err := CompilePredicate(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// The values are placed in a row of their own to evaluate them generically
+	row := make(sql.Row, field.Index()+1)
+	generic := func(v interface{}) (bool, error) {
+		row[field.Index()] = v
+		return predicate(row)
+	}
+
+	p := &VectorPredicate{Column: field.Index()}
+	if lit.Value() == nil {
+		p.selectFn = func(values []interface{}, sel []bool) error {
+			for i := range sel {
+				sel[i] = false
+			}
+			return nil
+		}
+		return p, nil
+	}
+
+	p.selectFn = func(values []interface{}, sel []bool) error {
+		for i, v := range values {
+			ok, err := generic(v)
+			if err != nil {
+				return err
+			}
+			sel[i] = ok
+		}
+		return nil
+	}
+
+	cmp, op, ok := comparerOperator(c)
+	if !ok {
+		return p, nil
+	}
+
+	accepts, err := operatorAccepts(op)
+	if err != nil {
+		return nil, err
+	}
+
+	// A literal on the left is compared with the values the other way around
+	sign := 1
+	if swapped {
+		sign = -1
+	}
+
+	if _, ok := comparatorType(cmp.Left().Type(), cmp.Right().Type()); ok {
+		return p, nil
+	}
+
+	coercion, err := cmp.coercion()
+	if err != nil {
+		return nil, err
+	}
+
+	if coercion.setType != nil || coercion.leftLocal || coercion.rightLocal || coercion.leftNumeric ||
+		coercion.rightNumeric || coercion.binaryText {
+		return p, nil
+	}
+
+	_, key, err := coercion.apply(nil, lit.Value(), lit.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case coercion.convertTo == ConvertToSigned && coercion.compareType == sql.Int64:
+		k, ok := key.(int64)
+		if !ok {
+			return p, nil
+		}
+
+		p.selectFn = func(values []interface{}, sel []bool) error {
+			for i, v := range values {
+				n, ok := v.(int64)
+				if !ok {
+					var err error
+					if sel[i], err = generic(v); err != nil {
+						return err
+					}
+					continue
+				}
+
+				sel[i] = accepts(sign * compareInt64s(n, k))
+			}
+			return nil
+		}
+	case coercion.convertTo == ConvertToDouble && coercion.compareType == sql.Float64:
+		k, ok := key.(float64)
+		if !ok || math.IsNaN(k) {
+			return p, nil
+		}
+
+		p.selectFn = func(values []interface{}, sel []bool) error {
+			for i, v := range values {
+				f, ok := v.(float64)
+				if !ok {
+					var err error
+					if sel[i], err = generic(v); err != nil {
+						return err
+					}
+					continue
+				}
+
+				sel[i] = !math.IsNaN(f) && accepts(sign*compareFloat64s(f, k))
+			}
+			return nil
+		}
+	case coercion.convertTo == ConvertToChar && coercion.compareType == sql.LongText:
+		k, ok := key.(string)
+		// CHAR values are compared without their trailing spaces, which is left to the generic comparison
+		if !ok || !sql.IsTextOnly(field.Type()) || field.Type().Type() == sqltypes.Char {
+			return p, nil
+		}
+
+		p.selectFn = func(values []interface{}, sel []bool) error {
+			for i, v := range values {
+				s, ok := v.(string)
+				if !ok {
+					var err error
+					if sel[i], err = generic(v); err != nil {
+						return err
+					}
+					continue
+				}
+
+				if coercion.padSpace {
+					s = strings.TrimRight(s, " ")
+				}
+				if coercion.caseInsensitive {
+					s = strings.ToLower(s)
+				}
+				sel[i] = accepts(sign * strings.Compare(s, k))
+			}
+			return nil
+		}
+	}
+
+	return p, nil
+}
+
+// comparerOperator returns the comparison and operator of the comparer given, unless it forces the type of its
+// operands.
+func comparerOperator(c Comparer) (*comparison, ComparisonOperator, bool) {
+	switch c := c.(type) {
+	case *Equals:
+		return &c.comparison, OpEquals, true
+	case *GreaterThan:
+		return &c.comparison, OpGreaterThan, true
+	case *LessThan:
+		return &c.comparison, OpLessThan, true
+	case *GreaterThanOrEqual:
+		return &c.comparison, OpGreaterThanOrEqual, true
+	case *LessThanOrEqual:
+		return &c.comparison, OpLessThanOrEqual, true
+	default:
+		return nil, "", false
+	}
+}
+
+func compareInt64s(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloat64s(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,167 @@
+package expression
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestCompileVectorPredicate(t *testing.T) {
+	text := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_Default)
+	binText := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_bin)
+	i := NewGetField(0, sql.Int64, "i", true)
+	f := NewGetField(1, sql.Float64, "f", true)
+	s := NewGetField(2, text, "s", true)
+	b := NewGetField(3, binText, "b", true)
+
+	testCases := []struct {
+		name    string
+		column  *GetField
+		literal sql.Expression
+	}{
+		{"int and int", i, NewLiteral(int64(3), sql.Int64)},
+		{"int and small int", i, NewLiteral(int8(-2), sql.Int8)},
+		{"int and string", i, NewLiteral("5", sql.LongText)},
+		{"int and float", i, NewLiteral(2.5, sql.Float64)},
+		{"float and float", f, NewLiteral(4.5, sql.Float64)},
+		{"float and int", f, NewLiteral(int64(3), sql.Int64)},
+		{"string and string", s, NewLiteral("ab", sql.LongText)},
+		{"string and int", s, NewLiteral(int64(3), sql.Int64)},
+		{"binary string and string", b, NewLiteral("ab", sql.LongText)},
+		{"int and null", i, NewLiteral(nil, sql.Null)},
+	}
+
+	rows := predicateTestRows(1000)
+	for _, tt := range testCases {
+		for _, swapped := range []bool{false, true} {
+			left, right := sql.Expression(tt.column), tt.literal
+			if swapped {
+				left, right = right, left
+			}
+
+			comparers := []Comparer{
+				NewEquals(left, right),
+				NewGreaterThan(left, right),
+				NewLessThan(left, right),
+				NewGreaterThanOrEqual(left, right),
+				NewLessThanOrEqual(left, right),
+			}
+
+			for _, c := range comparers {
+				t.Run(fmt.Sprintf("%s: %s", tt.name, c), func(t *testing.T) {
+					require := require.New(t)
+					predicate, err := CompileVectorPredicate(c)
+					require.NoError(err)
+					require.Equal(tt.column.Index(), predicate.Column)
+
+					values := make([]interface{}, len(rows))
+					for j, row := range rows {
+						values[j] = row[tt.column.Index()]
+					}
+
+					sel := make([]bool, len(values))
+					require.NoError(predicate.Select(values, sel))
+
+					for j, row := range rows {
+						expected, err := c.Eval(sql.NewEmptyContext(), row)
+						require.NoError(err)
+						require.Equal(expected == true, sel[j], "row %v", row)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestCompileVectorPredicateUnexpectedValues(t *testing.T) {
+	require := require.New(t)
+
+	c := NewGreaterThan(NewGetField(0, sql.Int64, "i", true), NewLiteral(int64(3), sql.Int64))
+	predicate, err := CompileVectorPredicate(c)
+	require.NoError(err)
+
+	// Values that aren't int64 are compared generically
+	values := []interface{}{int64(4), int32(5), "2", nil, uint8(3), int64(3)}
+	sel := make([]bool, len(values))
+	require.NoError(predicate.Select(values, sel))
+	require.Equal([]bool{true, true, false, false, false, false}, sel)
+}
+
+func TestCompileVectorPredicateUnsupported(t *testing.T) {
+	i := NewGetField(0, sql.Int64, "i", true)
+	for _, c := range []Comparer{
+		NewEquals(i, NewGetField(1, sql.Int64, "j", true)),
+		NewEquals(NewLiteral(int64(1), sql.Int64), NewLiteral(int64(1), sql.Int64)),
+		NewRegexp(NewGetField(0, sql.LongText, "s", true), NewLiteral("a", sql.LongText)),
+	} {
+		_, err := CompileVectorPredicate(c)
+		require.True(t, ErrUncompilablePredicate.Is(err), "%s", c)
+	}
+}
+
+func BenchmarkVectorPredicate(b *testing.B) {
+	text := sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_Default)
+	benchmarks := []struct {
+		name string
+		c    Comparer
+	}{
+		{"int", NewGreaterThan(NewGetField(0, sql.Int64, "i", true), NewLiteral(int64(3), sql.Int64))},
+		{"float", NewLessThan(NewGetField(1, sql.Float64, "f", true), NewLiteral(4.5, sql.Float64))},
+		{"string", NewEquals(NewGetField(2, text, "s", true), NewLiteral("ab", sql.LongText))},
+	}
+
+	rows := predicateTestRows(1024)
+	for _, bb := range benchmarks {
+		column := bb.c.Left().(*GetField).Index()
+		values := make([]interface{}, len(rows))
+		for i, row := range rows {
+			values[i] = row[column]
+		}
+		sel := make([]bool, len(values))
+
+		b.Run(bb.name+"/scalar", func(b *testing.B) {
+			ctx := sql.NewEmptyContext()
+			for n := 0; n < b.N; n++ {
+				for i, row := range rows {
+					v, err := bb.c.Eval(ctx, row)
+					if err != nil {
+						b.Fatal(err)
+					}
+					sel[i] = v == true
+				}
+			}
+		})
+
+		b.Run(bb.name+"/compiled", func(b *testing.B) {
+			predicate, err := CompilePredicate(bb.c)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			for n := 0; n < b.N; n++ {
+				for i, row := range rows {
+					if sel[i], err = predicate(row); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+
+		b.Run(bb.name+"/vector", func(b *testing.B) {
+			predicate, err := CompileVectorPredicate(bb.c)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			for n := 0; n < b.N; n++ {
+				if err := predicate.Select(values, sel); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
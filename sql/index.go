@@ -1,6 +1,10 @@
 package sql
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
 
 // Index is the basic representation of an index. It can be extended with
 // more functionality by implementing more specific interfaces.
@@ -53,6 +57,19 @@ type DescendIndex interface {
 	DescendRange(lessOrEqual, greaterThan []interface{}) (IndexLookup, error)
 }
 
+// OrderedIndex is an index whose AscendIndex and DescendIndex lookups return the rows of the table in the order of
+// their keys, ascending or descending, across all the partitions of the table. A query sorted by the columns of such an
+// index and limited to a number of rows reads only as many of them as it needs. The keys of an index of several columns
+// are ordered like tuples of their values are compared, column by column, and so are the bounds of its lookups, so
+// that a lookup seeks to the position of its first key, as keyset pagination with WHERE (a, b) > (1, 2) does. The
+// AscendGreaterOrEqual and DescendLessOrEqual lookups of no keys are of every key of the index. None of the indexes of
+// the memory package is ordered, so the analyzer only scans the ordered indexes of integrators that implement it.
+type OrderedIndex interface {
+	Index
+	// IsOrdered returns whether the lookups of the index return the rows in the order of their keys.
+	IsOrdered() bool
+}
+
 // NegateIndex is an index that supports retrieving negated values.
 type NegateIndex interface {
 	// Not returns an IndexLookup for keys that are not equal
@@ -86,3 +103,61 @@ type MergeableIndexLookup interface {
 	// Currently unused.
 	Difference(...IndexLookup) IndexLookup
 }
+
+// SameIndexExpression returns whether the expressions given, as returned by Index.Expressions() or the String method of
+// an expression, are the same once canonicalized with CanonicalIndexExpression. It's used to match the expressions of
+// queries with those of indexes, including functional indexes such as one on UPPER(mytable.name), whatever the case
+// and spacing the index declares them with.
+func SameIndexExpression(a, b string) bool {
+	return a == b || CanonicalIndexExpression(a) == CanonicalIndexExpression(b)
+}
+
+// CanonicalIndexExpression returns the canonical form of the expression given, which is lowercase, without backticks
+// around identifiers and with only the whitespace that separates two words, such as NOT and the operand it negates.
+// Quoted strings are kept as they are.
+func CanonicalIndexExpression(expr string) string {
+	var sb strings.Builder
+	var quote rune
+	var escaped, space bool
+	var last rune
+	for _, r := range expr {
+		if quote != 0 {
+			sb.WriteRune(r)
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == quote:
+				quote = 0
+			}
+			last = r
+			continue
+		}
+
+		switch {
+		case r == '`':
+			continue
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case r == '\'' || r == '"':
+			quote = r
+		}
+
+		if space && isWordRune(last) && isWordRune(r) {
+			sb.WriteRune(' ')
+		}
+		space = false
+
+		r = unicode.ToLower(r)
+		sb.WriteRune(r)
+		last = r
+	}
+
+	return sb.String()
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
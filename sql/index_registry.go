@@ -340,7 +340,7 @@ Indexes:
 						continue
 					}
 
-					if ie == e.String() {
+					if SameIndexExpression(ie, e.String()) {
 						used[i] = struct{}{}
 						found = true
 						matched = append(matched, e)
@@ -395,7 +395,7 @@ func exprListsMatch(a, b []string) bool {
 				continue
 			}
 
-			if va == vb {
+			if SameIndexExpression(va, vb) {
 				visited[j] = true
 				found = true
 				break
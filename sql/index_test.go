@@ -0,0 +1,32 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalIndexExpression(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{"mytable.name", "mytable.name"},
+		{"UPPER(mytable.name)", "upper(mytable.name)"},
+		{"upper( `mytable`.`Name` )", "upper(mytable.name)"},
+		{"(mytable.a + 1)", "(mytable.a+1)"},
+		{"NOT  mytable.b", "not mytable.b"},
+		{"CONCAT(mytable.s, ' A ')", "concat(mytable.s,' A ')"},
+		{`CONCAT(mytable.s, "it\"S ")`, `concat(mytable.s,"it\"S ")`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.expr, func(t *testing.T) {
+			require.Equal(t, tt.expected, CanonicalIndexExpression(tt.expr))
+		})
+	}
+
+	require.True(t, SameIndexExpression("UPPER(mytable.name)", "upper(`mytable`.`name`)"))
+	require.False(t, SameIndexExpression("UPPER(mytable.name)", "LOWER(mytable.name)"))
+	require.False(t, SameIndexExpression("CONCAT(mytable.s, 'A')", "CONCAT(mytable.s, 'a')"))
+}
@@ -1,8 +1,9 @@
 package sql
 
 import (
-	"bytes"
 	"encoding/json"
+	"sort"
+	"strings"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
@@ -16,13 +17,224 @@ type JsonType interface {
 
 type jsonType struct{}
 
-// Compare implements Type interface.
+// Compare implements Type interface. Values of different JSON types are ordered by their type, from lowest to highest
+// precedence: null, numbers, strings, objects, arrays and booleans. Values of the same JSON type are compared by value.
 func (t jsonType) Compare(a interface{}, b interface{}) (int, error) {
 	if hasNulls, res := compareNulls(a, b); hasNulls {
 		return res, nil
 	}
-	//TODO: this won't work if a JSON has two fields in a different order
-	return bytes.Compare(a.([]byte), b.([]byte)), nil
+
+	av, err := t.decode(a)
+	if err != nil {
+		return 0, err
+	}
+
+	bv, err := t.decode(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return compareJSONValues(av, bv), nil
+}
+
+// decode returns the value of the JSON document given, decoded with the default types of encoding/json.
+func (t jsonType) decode(v interface{}) (interface{}, error) {
+	doc, err := t.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(doc.([]byte), &decoded); err != nil {
+		return nil, err
+	}
+
+	return decoded, nil
+}
+
+// jsonTypePrecedence returns the precedence of the type of the decoded JSON value given when comparing it with values
+// of other types.
+func jsonTypePrecedence(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case float64:
+		return 1
+	case string:
+		return 2
+	case map[string]interface{}:
+		return 3
+	case []interface{}:
+		return 4
+	case bool:
+		return 5
+	default:
+		return -1
+	}
+}
+
+// compareJSONValues compares two decoded JSON values.
+func compareJSONValues(a, b interface{}) int {
+	pa, pb := jsonTypePrecedence(a), jsonTypePrecedence(b)
+	switch {
+	case pa < pb:
+		return -1
+	case pa > pb:
+		return 1
+	}
+
+	switch a := a.(type) {
+	case float64:
+		b := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	case bool:
+		b := b.(bool)
+		switch {
+		case a == b:
+			return 0
+		case b:
+			return -1
+		default:
+			return 1
+		}
+	case []interface{}:
+		b := b.([]interface{})
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if cmp := compareJSONValues(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		}
+		return compareInts(len(a), len(b))
+	case map[string]interface{}:
+		b := b.(map[string]interface{})
+		if cmp := compareInts(len(a), len(b)); cmp != 0 {
+			return cmp
+		}
+
+		aKeys, bKeys := sortedJSONKeys(a), sortedJSONKeys(b)
+		for i := range aKeys {
+			if cmp := strings.Compare(aKeys[i], bKeys[i]); cmp != 0 {
+				return cmp
+			}
+		}
+
+		for _, k := range aKeys {
+			if cmp := compareJSONValues(a[k], b[k]); cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	default:
+		return 0
+	}
+}
+
+// JSONContains returns whether the decoded JSON value given contains the candidate decoded JSON value, as
+// JSON_CONTAINS does. A scalar contains the scalars equal to it, an object contains the objects whose keys it all has,
+// with values containing theirs, and an array contains the values that any of its elements contains, as well as the
+// arrays whose elements it all contains that way.
+func JSONContains(target, candidate interface{}) bool {
+	switch target := target.(type) {
+	case []interface{}:
+		if candidate, ok := candidate.([]interface{}); ok {
+			for _, c := range candidate {
+				if !JSONContains(target, c) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for _, t := range target {
+			if JSONContains(t, candidate) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		candidate, ok := candidate.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for k, c := range candidate {
+			t, ok := target[k]
+			if !ok || !JSONContains(t, c) {
+				return false
+			}
+		}
+		return true
+	default:
+		return compareJSONValues(target, candidate) == 0
+	}
+}
+
+// JSONOverlaps returns whether the decoded JSON values given have any value in common, as JSON_OVERLAPS does. Arrays
+// overlap when any of their elements is equal, and objects when they have any key with equal values. Any other value
+// is compared with the elements of an array as if it was an array of its own, and it overlaps with any other value
+// when they're equal.
+func JSONOverlaps(a, b interface{}) bool {
+	aArray, aOk := a.([]interface{})
+	bArray, bOk := b.([]interface{})
+	if aOk || bOk {
+		if !aOk {
+			aArray = []interface{}{a}
+		}
+		if !bOk {
+			bArray = []interface{}{b}
+		}
+
+		for _, av := range aArray {
+			for _, bv := range bArray {
+				if compareJSONValues(av, bv) == 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	aObject, aOk := a.(map[string]interface{})
+	bObject, bOk := b.(map[string]interface{})
+	if aOk && bOk {
+		for k, av := range aObject {
+			if bv, ok := bObject[k]; ok && compareJSONValues(av, bv) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	return compareJSONValues(a, b) == 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func sortedJSONKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Convert implements Type interface.
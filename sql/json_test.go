@@ -20,6 +20,14 @@ func TestJsonCompare(t *testing.T) {
 		{[]byte("A"), []byte("B"), -1},
 		{[]byte("A"), []byte("A"), 0},
 		{[]byte("C"), []byte("B"), 1},
+		{[]byte("5"), []byte("5.0"), 0},
+		{[]byte("10"), []byte("9"), 1},
+		{[]byte(`"10"`), []byte(`"9"`), -1},
+		{[]byte("100"), []byte(`"1"`), -1},
+		{[]byte(`[1, 2]`), []byte(`[1, 2, 3]`), -1},
+		{[]byte(`{"a": 1, "b": 2}`), []byte(`{"b": 2, "a": 1}`), 0},
+		{[]byte("true"), []byte(`[1]`), 1},
+		{[]byte("null"), []byte("0"), -1},
 	}
 
 	for _, test := range tests {
@@ -417,6 +417,18 @@ func compareFloats(a interface{}, b interface{}) (int, error) {
 		return 0, err
 	}
 
+	// NaN isn't ordered with respect to any other value, so it's sorted before any number, like NULL, to keep the
+	// order total
+	aNaN, bNaN := math.IsNaN(ca), math.IsNaN(cb)
+	switch {
+	case aNaN && bNaN:
+		return 0, nil
+	case aNaN:
+		return -1, nil
+	case bNaN:
+		return 1, nil
+	}
+
 	if ca == cb {
 		return 0, nil
 	}
@@ -53,7 +53,7 @@ var (
 	setRegex             = regexp.MustCompile(`^set\s+`)
 )
 
-var describeSupportedFormats = []string{"tree"}
+var describeSupportedFormats = []string{"tree", plan.DescribeFormatCoercions}
 
 // These constants aren't exported from vitess for some reason. This could be removed if we changed this.
 const (
@@ -123,6 +123,10 @@ func Parse(ctx *sql.Context, query string) (sql.Node, error) {
 		s = fixSetQuery(s)
 	}
 
+	if strings.Contains(lowerQuery, "row") {
+		s = removeRowConstructors(s)
+	}
+
 	stmt, err := sqlparser.Parse(s)
 	if err != nil {
 		return nil, err
@@ -209,6 +213,8 @@ func convertExplain(ctx *sql.Context, n *sqlparser.Explain) (sql.Node, error) {
 	switch strings.ToLower(n.ExplainFormat) {
 	case "", sqlparser.TreeStr:
 	// tree format, do nothing
+	case plan.DescribeFormatCoercions:
+		explainFmt = plan.DescribeFormatCoercions
 	default:
 		return nil, errInvalidDescribeFormat.New(
 			n.ExplainFormat,
@@ -425,10 +431,10 @@ func convertUnion(ctx *sql.Context, u *sqlparser.Union) (sql.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	if u.Type == sqlparser.UnionStr || u.Type == sqlparser.UnionAllStr {
+	if u.Type == sqlparser.UnionAllStr {
 		return plan.NewUnion(left, right), nil
-	} else if u.Type == sqlparser.UnionDistinctStr {
-		return plan.NewDistinct(plan.NewUnion(left, right)), nil
+	} else if u.Type == sqlparser.UnionStr || u.Type == sqlparser.UnionDistinctStr {
+		return plan.NewDistinctUnion(left, right), nil
 	}
 	return nil, ErrUnsupportedFeature.New(u.Type)
 }
@@ -1491,6 +1497,9 @@ func exprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 	default:
 		return nil, ErrUnsupportedSyntax.New(sqlparser.String(e))
 	case *sqlparser.Default:
+		if v.ColName != "" {
+			return function.NewDefaultFunc(expression.NewUnresolvedColumn(v.ColName)), nil
+		}
 		return expression.NewDefaultColumn(v.ColName), nil
 	case *sqlparser.SubstrExpr:
 		var (
@@ -1555,7 +1564,7 @@ func exprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 			}
 
 			if len(exprs) != 1 {
-				return nil, ErrUnsupportedSyntax.New("more than one expression in COUNT")
+				return aggregation.NewCountDistinct(expression.NewTuple(exprs...)), nil
 			}
 
 			return aggregation.NewCountDistinct(exprs[0]), nil
@@ -1644,6 +1653,13 @@ func exprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		// TODO: get the original select statement, not the reconstruction
 		selectString := sqlparser.String(v.Select)
 		return plan.NewSubquery(node, selectString), nil
+	case *sqlparser.ExistsExpr:
+		subquery, err := exprToExpression(ctx, v.Subquery)
+		if err != nil {
+			return nil, err
+		}
+
+		return plan.NewExistsSubquery(subquery), nil
 	case *sqlparser.CaseExpr:
 		return caseExprToExpression(ctx, v)
 	case *sqlparser.IntervalExpr:
@@ -1763,7 +1779,18 @@ func comparisonExprToExpression(ctx *sql.Context, c *sqlparser.ComparisonExpr) (
 		return nil, err
 	}
 
-	right, err := exprToExpression(ctx, c.Right)
+	// LIKE BINARY 'pattern' is parsed as a LIKE with a BINARY unary operator on the pattern
+	var binary bool
+	rightExpr := c.Right
+	if u, ok := rightExpr.(*sqlparser.UnaryExpr); ok && strings.ToLower(u.Operator) == sqlparser.BinaryStr {
+		switch strings.ToLower(c.Operator) {
+		case sqlparser.LikeStr, sqlparser.NotLikeStr:
+			binary = true
+			rightExpr = u.Expr
+		}
+	}
+
+	right, err := exprToExpression(ctx, rightExpr)
 	if err != nil {
 		return nil, err
 	}
@@ -1775,6 +1802,8 @@ func comparisonExprToExpression(ctx *sql.Context, c *sqlparser.ComparisonExpr) (
 		return expression.NewNot(expression.NewRegexp(left, right)), nil
 	case sqlparser.EqualStr:
 		return expression.NewEquals(left, right), nil
+	case sqlparser.NullSafeEqualStr:
+		return expression.NewNullSafeEquals(left, right), nil
 	case sqlparser.LessThanStr:
 		return expression.NewLessThan(left, right), nil
 	case sqlparser.LessEqualStr:
@@ -1805,15 +1834,37 @@ func comparisonExprToExpression(ctx *sql.Context, c *sqlparser.ComparisonExpr) (
 		default:
 			return nil, ErrUnsupportedFeature.New(fmt.Sprintf("NOT IN %T", right))
 		}
-	case sqlparser.LikeStr:
-		return expression.NewLike(left, right), nil
-	case sqlparser.NotLikeStr:
-		return expression.NewNot(expression.NewLike(left, right)), nil
+	case sqlparser.LikeStr, sqlparser.NotLikeStr:
+		like, err := likeExprToExpression(ctx, left, right, c.Escape, binary)
+		if err != nil {
+			return nil, err
+		}
+		if strings.ToLower(c.Operator) == sqlparser.NotLikeStr {
+			return expression.NewNot(like), nil
+		}
+		return like, nil
 	default:
 		return nil, ErrUnsupportedFeature.New(c.Operator)
 	}
 }
 
+// likeExprToExpression returns the LIKE expression of the operands given, whose pattern is escaped with the escape
+// character given, if any.
+func likeExprToExpression(ctx *sql.Context, left, right sql.Expression, escape sqlparser.Expr, binary bool) (sql.Expression, error) {
+	if escape == nil {
+		if binary {
+			return expression.NewLikeBinary(left, right), nil
+		}
+		return expression.NewLike(left, right), nil
+	}
+
+	escapeExpr, err := exprToExpression(ctx, escape)
+	if err != nil {
+		return nil, err
+	}
+	return expression.NewLikeWithEscape(left, right, escapeExpr, binary), nil
+}
+
 func groupByToExpressions(ctx *sql.Context, g sqlparser.GroupBy) ([]sql.Expression, error) {
 	es := make([]sql.Expression, len(g))
 	for i, ve := range g {
@@ -1863,6 +1914,13 @@ func unaryExprToExpression(ctx *sql.Context, e *sqlparser.UnaryExpr) (sql.Expres
 	case sqlparser.PlusStr:
 		// Unary plus expressions do nothing (do not turn the expression positive). Just return the underlying expression.
 		return exprToExpression(ctx, e.Expr)
+	case sqlparser.BinaryStr:
+		expr, err := exprToExpression(ctx, e.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.NewBinaryCast(expr), nil
 
 	default:
 		return nil, ErrUnsupportedFeature.New("unary operator: " + e.Operator)
@@ -1906,6 +1964,33 @@ func binaryExprToExpression(ctx *sql.Context, be *sqlparser.BinaryExpr) (sql.Exp
 
 		return expression.NewArithmetic(l, r, be.Operator), nil
 
+	case
+		sqlparser.JSONExtractOp,
+		sqlparser.JSONUnquoteExtractOp:
+
+		l, err := exprToExpression(ctx, be.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := exprToExpression(ctx, be.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		// col->path is a JSON value, which is compared according to the rules for JSON values, while col->>path is
+		// the unquoted text of that value, which is compared like any other string
+		extract, err := function.NewJSONExtract(l, r)
+		if err != nil {
+			return nil, err
+		}
+
+		if be.Operator == sqlparser.JSONUnquoteExtractOp {
+			return function.NewJSONUnquote(extract), nil
+		}
+
+		return extract, nil
+
 	default:
 		return nil, ErrUnsupportedFeature.New(be.Operator)
 	}
@@ -2077,6 +2162,75 @@ func readString(r *bufio.Reader, single bool) []rune {
 	return result
 }
 
+// removeRowConstructors removes the ROW keyword of the row constructors in the query given, which the parser doesn't
+// support, so that ROW(a, b) is parsed as the tuple (a, b) it's equivalent to. The ROW keyword of FOR EACH ROW in
+// triggers, and any row in strings or quoted identifiers, are kept.
+func removeRowConstructors(s string) string {
+	runes := []rune(s)
+	result := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		ru := runes[i]
+		switch {
+		case ru == '\'' || ru == '"' || ru == '`':
+			end := i + 1
+			for end < len(runes) && runes[end] != ru {
+				if runes[end] == '\\' && ru != '`' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				end = len(runes) - 1
+			}
+			result = append(result, runes[i:end+1]...)
+			i = end
+		case isIdentifierRune(ru):
+			end := i
+			for end < len(runes) && isIdentifierRune(runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			if !strings.EqualFold(word, "row") || !isRowConstructor(runes, i, end) {
+				result = append(result, runes[i:end]...)
+			}
+			i = end - 1
+		default:
+			result = append(result, ru)
+		}
+	}
+	return string(result)
+}
+
+// isRowConstructor returns whether the ROW keyword between the positions given of the query given starts a row
+// constructor, as it does when it's followed by a parenthesis and isn't part of FOR EACH ROW or a qualified name.
+func isRowConstructor(query []rune, start, end int) bool {
+	next := end
+	for next < len(query) && unicode.IsSpace(query[next]) {
+		next++
+	}
+	if next >= len(query) || query[next] != '(' {
+		return false
+	}
+
+	prev := start - 1
+	for prev >= 0 && unicode.IsSpace(query[prev]) {
+		prev--
+	}
+	if prev >= 0 && query[prev] == '.' {
+		return false
+	}
+
+	wordEnd := prev + 1
+	for prev >= 0 && isIdentifierRune(query[prev]) {
+		prev--
+	}
+	return !strings.EqualFold(string(query[prev+1:wordEnd]), "each")
+}
+
+func isIdentifierRune(ru rune) bool {
+	return ru == '_' || ru == '$' || unicode.IsLetter(ru) || unicode.IsDigit(ru)
+}
+
 func parseShowTableStatus(ctx *sql.Context, query string) (sql.Node, error) {
 	buf := bufio.NewReader(strings.NewReader(query))
 	err := parseFuncs{
@@ -14,6 +14,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
@@ -757,6 +758,11 @@ var fixtures = map[string]sql.Node{
 			[]sql.Expression{expression.NewStar()},
 			plan.NewUnresolvedTable("foo", "")),
 	),
+	"EXPLAIN FORMAT=coercions SELECT * FROM foo": plan.NewDescribeQuery(
+		"coercions", plan.NewProject(
+			[]sql.Expression{expression.NewStar()},
+			plan.NewUnresolvedTable("foo", "")),
+	),
 	"DESCRIBE SELECT * FROM foo": plan.NewDescribeQuery(
 		"tree", plan.NewProject(
 			[]sql.Expression{expression.NewStar()},
@@ -839,6 +845,18 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("foo", ""),
 		),
 	),
+	`SELECT * FROM foo WHERE foo <=> NULL;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewFilter(
+			expression.NewNullSafeEquals(
+				expression.NewUnresolvedColumn("foo"),
+				expression.NewLiteral(nil, sql.Null),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
 	`SELECT * FROM foo WHERE foo != 'bar';`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewStar(),
@@ -851,6 +869,36 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("foo", ""),
 		),
 	),
+	`SELECT * FROM foo WHERE doc->'$.n' = '5';`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				&function.JSONExtract{
+					JSON:  expression.NewUnresolvedColumn("doc"),
+					Paths: []sql.Expression{expression.NewLiteral("$.n", sql.LongText)},
+				},
+				expression.NewLiteral("5", sql.LongText),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT * FROM foo WHERE doc->>'$.n' = '5';`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				function.NewJSONUnquote(&function.JSONExtract{
+					JSON:  expression.NewUnresolvedColumn("doc"),
+					Paths: []sql.Expression{expression.NewLiteral("$.n", sql.LongText)},
+				}),
+				expression.NewLiteral("5", sql.LongText),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
 	`SELECT foo, bar FROM foo LIMIT 10;`: plan.NewLimit(10,
 		plan.NewProject(
 			[]sql.Expression{
@@ -1391,6 +1439,31 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("foo", ""),
 		),
 	),
+	`SELECT * FROM foo WHERE EXISTS (SELECT j FROM baz)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			plan.NewExistsSubquery(
+				plan.NewSubquery(plan.NewProject(
+					[]sql.Expression{expression.NewUnresolvedColumn("j")},
+					plan.NewUnresolvedTable("baz", ""),
+				), "select j from baz"),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT * FROM foo WHERE ROW(i, j) < ROW(1, 2)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			expression.NewLessThan(
+				expression.NewTuple(expression.NewUnresolvedColumn("i"), expression.NewUnresolvedColumn("j")),
+				expression.NewTuple(
+					expression.NewLiteral(int8(1), sql.Int8),
+					expression.NewLiteral(int8(2), sql.Int8),
+				),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
 	`SELECT a, b FROM t ORDER BY 2, 1`: plan.NewSort(
 		[]plan.SortField{
 			{
@@ -1644,6 +1717,70 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("foo", ""),
 		),
 	),
+	`SELECT * FROM foo WHERE i LIKE BINARY 'foo'`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			expression.NewLikeBinary(
+				expression.NewUnresolvedColumn("i"),
+				expression.NewLiteral("foo", sql.LongText),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT * FROM foo WHERE i NOT LIKE BINARY 'foo'`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			expression.NewNot(expression.NewLikeBinary(
+				expression.NewUnresolvedColumn("i"),
+				expression.NewLiteral("foo", sql.LongText),
+			)),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT * FROM foo WHERE i LIKE 'a|%' ESCAPE '|'`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			expression.NewLikeWithEscape(
+				expression.NewUnresolvedColumn("i"),
+				expression.NewLiteral("a|%", sql.LongText),
+				expression.NewLiteral("|", sql.LongText),
+				false,
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT * FROM foo WHERE i NOT LIKE BINARY 'a|%' ESCAPE '|'`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			expression.NewNot(expression.NewLikeWithEscape(
+				expression.NewUnresolvedColumn("i"),
+				expression.NewLiteral("a|%", sql.LongText),
+				expression.NewLiteral("|", sql.LongText),
+				true,
+			)),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT * FROM foo WHERE BINARY i = 'foo'`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewBinaryCast(expression.NewUnresolvedColumn("i")),
+				expression.NewLiteral("foo", sql.LongText),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
+	`SELECT * FROM foo WHERE i = BINARY 'foo'`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("i"),
+				expression.NewBinaryCast(expression.NewLiteral("foo", sql.LongText)),
+			),
+			plan.NewUnresolvedTable("foo", ""),
+		),
+	),
 	`SHOW FIELDS FROM foo`:       plan.NewShowColumns(false, plan.NewUnresolvedTable("foo", "")),
 	`SHOW FULL COLUMNS FROM foo`: plan.NewShowColumns(true, plan.NewUnresolvedTable("foo", "")),
 	`SHOW FIELDS FROM foo WHERE Field = 'bar'`: plan.NewFilter(
@@ -2125,7 +2262,7 @@ var fixtures = map[string]sql.Node{
 		`CREATE TRIGGER myTrigger BEFORE UPDATE ON foo FOR EACH ROW FOLLOWS yourTrigger INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 		`INSERT INTO zzz (a,b) VALUES (old.a, old.b)`,
 	),
-	`SELECT 2 UNION SELECT 3`: plan.NewUnion(
+	`SELECT 2 UNION SELECT 3`: plan.NewDistinctUnion(
 		plan.NewProject(
 			[]sql.Expression{expression.NewLiteral(int8(2), sql.Int8)},
 			plan.NewUnresolvedTable("dual", ""),
@@ -2135,7 +2272,7 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("dual", ""),
 		),
 	),
-	`(SELECT 2) UNION (SELECT 3)`: plan.NewUnion(
+	`(SELECT 2) UNION (SELECT 3)`: plan.NewDistinctUnion(
 		plan.NewProject(
 			[]sql.Expression{expression.NewLiteral(int8(2), sql.Int8)},
 			plan.NewUnresolvedTable("dual", ""),
@@ -2145,8 +2282,8 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("dual", ""),
 		),
 	),
-	`SELECT 2 UNION SELECT 3 UNION SELECT 4`: plan.NewUnion(
-		plan.NewUnion(
+	`SELECT 2 UNION SELECT 3 UNION SELECT 4`: plan.NewDistinctUnion(
+		plan.NewDistinctUnion(
 			plan.NewProject(
 				[]sql.Expression{expression.NewLiteral(int8(2), sql.Int8)},
 				plan.NewUnresolvedTable("dual", ""),
@@ -2161,12 +2298,12 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("dual", ""),
 		),
 	),
-	`SELECT 2 UNION (SELECT 3 UNION SELECT 4)`: plan.NewUnion(
+	`SELECT 2 UNION (SELECT 3 UNION SELECT 4)`: plan.NewDistinctUnion(
 		plan.NewProject(
 			[]sql.Expression{expression.NewLiteral(int8(2), sql.Int8)},
 			plan.NewUnresolvedTable("dual", ""),
 		),
-		plan.NewUnion(
+		plan.NewDistinctUnion(
 			plan.NewProject(
 				[]sql.Expression{expression.NewLiteral(int8(3), sql.Int8)},
 				plan.NewUnresolvedTable("dual", ""),
@@ -2187,16 +2324,14 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("dual", ""),
 		),
 	),
-	`SELECT 2 UNION DISTINCT SELECT 3`: plan.NewDistinct(
-		plan.NewUnion(
-			plan.NewProject(
-				[]sql.Expression{expression.NewLiteral(int8(2), sql.Int8)},
-				plan.NewUnresolvedTable("dual", ""),
-			),
-			plan.NewProject(
-				[]sql.Expression{expression.NewLiteral(int8(3), sql.Int8)},
-				plan.NewUnresolvedTable("dual", ""),
-			),
+	`SELECT 2 UNION DISTINCT SELECT 3`: plan.NewDistinctUnion(
+		plan.NewProject(
+			[]sql.Expression{expression.NewLiteral(int8(2), sql.Int8)},
+			plan.NewUnresolvedTable("dual", ""),
+		),
+		plan.NewProject(
+			[]sql.Expression{expression.NewLiteral(int8(3), sql.Int8)},
+			plan.NewUnresolvedTable("dual", ""),
 		),
 	),
 }
@@ -2347,6 +2482,26 @@ func TestFixSetQuery(t *testing.T) {
 	}
 }
 
+func TestRemoveRowConstructors(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{"SELECT ROW(1, 2) = ROW(1, 2)", "SELECT (1, 2) = (1, 2)"},
+		{"SELECT row (a, b) < (1, 2) FROM t", "SELECT  (a, b) < (1, 2) FROM t"},
+		{"SELECT ROW(1, ROW(2, 3))", "SELECT (1, (2, 3))"},
+		{"SELECT 'ROW(1, 2)', `row`(1)", "SELECT 'ROW(1, 2)', `row`(1)"},
+		{"SELECT 'it\\'s ROW(1, 2)'", "SELECT 'it\\'s ROW(1, 2)'"},
+		{"SELECT rows, arrow(1), t.row(1) FROM t", "SELECT rows, arrow(1), t.row(1) FROM t"},
+		{"CREATE TRIGGER trig BEFORE INSERT ON t FOR EACH ROW (SELECT 1)", "CREATE TRIGGER trig BEFORE INSERT ON t FOR EACH ROW (SELECT 1)"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, removeRowConstructors(tt.in))
+		})
+	}
+}
+
 func TestPrintTree(t *testing.T) {
 	require := require.New(t)
 	node, err := Parse(sql.NewEmptyContext(), `
@@ -0,0 +1,181 @@
+package plan
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// AntiJoin is an anti join between two tables, which returns the rows of the left side for which no row of the right
+// side satisfies the condition, such as the rows of a query filtered with NOT EXISTS on a correlated subquery. The
+// condition is evaluated over the row of the left side followed by the row of the right side, and a row of the right
+// side only matches when it's true, not when it's NULL. So rows whose join key is NULL are always returned, and rows of
+// the right side whose join key is NULL never exclude any row, as with NOT EXISTS.
+type AntiJoin struct {
+	BinaryNode
+	Cond sql.Expression
+}
+
+// NewAntiJoin creates a new anti join node from two tables.
+func NewAntiJoin(left, right sql.Node, cond sql.Expression) *AntiJoin {
+	return &AntiJoin{
+		BinaryNode: BinaryNode{
+			Left:  left,
+			Right: right,
+		},
+		Cond: cond,
+	}
+}
+
+// Schema implements the Node interface. Only the rows of the left side are returned.
+func (j *AntiJoin) Schema() sql.Schema {
+	return j.Left.Schema()
+}
+
+// Resolved implements the Resolvable interface.
+func (j *AntiJoin) Resolved() bool {
+	return j.Left.Resolved() && j.Right.Resolved() && j.Cond.Resolved()
+}
+
+// RowIter implements the Node interface. The rows of the right side are read once, the first time they're needed, and
+// kept in memory to be matched with every row of the left side.
+func (j *AntiJoin) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.AntiJoin")
+
+	l, err := j.Left.RowIter(ctx, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	cache, dispose := ctx.Memory.NewRowsCache()
+	return sql.NewSpanIter(span, &antiJoinIter{
+		left:          l,
+		rightProvider: j.Right,
+		ctx:           ctx,
+		cond:          j.Cond,
+		rightRows:     cache,
+		dispose:       dispose,
+		rowSize:       len(j.Left.Schema()) + len(j.Right.Schema()),
+	}), nil
+}
+
+// WithChildren implements the Node interface.
+func (j *AntiJoin) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+
+	return NewAntiJoin(children[0], children[1], j.Cond), nil
+}
+
+// WithExpressions implements the Expressioner interface.
+func (j *AntiJoin) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(exprs), 1)
+	}
+
+	return NewAntiJoin(j.Left, j.Right, exprs[0]), nil
+}
+
+func (j *AntiJoin) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("AntiJoin(%s)", j.Cond)
+	_ = pr.WriteChildren(j.Left.String(), j.Right.String())
+	return pr.String()
+}
+
+// Expressions implements the Expressioner interface.
+func (j *AntiJoin) Expressions() []sql.Expression {
+	return []sql.Expression{j.Cond}
+}
+
+type antiJoinIter struct {
+	left          sql.RowIter
+	rightProvider rowIterProvider
+	ctx           *sql.Context
+	cond          sql.Expression
+
+	rightLoaded bool
+	rightRows   sql.RowsCache
+	dispose     sql.DisposeFunc
+	rowSize     int
+}
+
+func (i *antiJoinIter) loadRight() error {
+	iter, err := i.rightProvider.RowIter(i.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			iter.Close()
+			return err
+		}
+
+		if err := i.rightRows.Add(row); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+
+	i.rightLoaded = true
+	return iter.Close()
+}
+
+func (i *antiJoinIter) Next() (sql.Row, error) {
+	for {
+		left, err := i.left.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !i.rightLoaded {
+			if err := i.loadRight(); err != nil {
+				return nil, err
+			}
+		}
+
+		matched, err := i.matches(left)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matched {
+			return left, nil
+		}
+	}
+}
+
+// matches returns whether any row of the right side satisfies the condition with the row of the left side given.
+func (i *antiJoinIter) matches(left sql.Row) (bool, error) {
+	row := make(sql.Row, i.rowSize)
+	copy(row, left)
+	for _, right := range i.rightRows.Get() {
+		copy(row[len(left):], right)
+		ok, err := conditionIsTrue(i.ctx, row, i.cond)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (i *antiJoinIter) Close() error {
+	if i.dispose != nil {
+		i.dispose()
+		i.dispose = nil
+	}
+
+	return i.left.Close()
+}
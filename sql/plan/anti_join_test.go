@@ -0,0 +1,93 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestAntiJoin(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	newTable := func(name string, rows ...sql.Row) *memory.Table {
+		table := memory.NewTable(name, sql.Schema{
+			{Name: "id", Type: sql.Int64, Source: name},
+			{Name: "k", Type: sql.Int64, Source: name, Nullable: true},
+		})
+		for _, r := range rows {
+			require.NoError(t, table.Insert(ctx, r))
+		}
+		return table
+	}
+
+	left := NewResolvedTable(newTable("l",
+		sql.NewRow(int64(1), int64(1)),
+		sql.NewRow(int64(2), int64(2)),
+		sql.NewRow(int64(3), nil),
+		sql.NewRow(int64(4), int64(4)),
+	))
+	right := NewResolvedTable(newTable("r",
+		sql.NewRow(int64(1), int64(1)),
+		sql.NewRow(int64(2), nil),
+		sql.NewRow(int64(3), int64(4)),
+	))
+	empty := NewResolvedTable(newTable("e"))
+
+	lk := expression.NewGetFieldWithTable(1, sql.Int64, "l", "k", true)
+	rk := expression.NewGetFieldWithTable(3, sql.Int64, "r", "k", true)
+	keyEquals := expression.NewEquals(lk, rk)
+
+	testCases := []struct {
+		name     string
+		right    sql.Node
+		cond     sql.Expression
+		expected []sql.Row
+	}{
+		{
+			"NULL keys never match",
+			right,
+			keyEquals,
+			[]sql.Row{
+				{int64(2), int64(2)},
+				{int64(3), nil},
+			},
+		},
+		{
+			"NULL keys matched explicitly",
+			right,
+			expression.NewOr(keyEquals, expression.NewAnd(expression.NewIsNull(lk), expression.NewIsNull(rk))),
+			[]sql.Row{
+				{int64(2), int64(2)},
+			},
+		},
+		{
+			"empty right side",
+			empty,
+			keyEquals,
+			[]sql.Row{
+				{int64(1), int64(1)},
+				{int64(2), int64(2)},
+				{int64(3), nil},
+				{int64(4), int64(4)},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			j := NewAntiJoin(left, tt.right, tt.cond)
+			require.Equal(left.Schema(), j.Schema())
+
+			iter, err := j.RowIter(ctx, nil)
+			require.NoError(err)
+
+			rows, err := sql.RowIterToRows(iter)
+			require.NoError(err)
+			require.Equal(tt.expected, rows)
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package plan
 
 import (
+	"fmt"
 	"io"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 // Describe is a node that describes its children.
@@ -74,6 +76,11 @@ type DescribeQuery struct {
 	Format string
 }
 
+// DescribeFormatCoercions is the format of a DescribeQuery that annotates every comparison in the plan with the type
+// the values of its operands are compared with, and the branch of the comparison that converts them to it, such as
+// EXPLAIN FORMAT=coercions SELECT * FROM t WHERE id = '5'.
+const DescribeFormatCoercions = "coercions"
+
 // DescribeSchema is the schema returned by a DescribeQuery node.
 var DescribeSchema = sql.Schema{
 	{Name: "plan", Type: sql.LongText},
@@ -91,8 +98,17 @@ func (d *DescribeQuery) Schema() sql.Schema {
 
 // RowIter implements the Node interface.
 func (d *DescribeQuery) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	child := d.Child
+	if d.Format == DescribeFormatCoercions {
+		var err error
+		child, err = annotateCoercions(ctx, child)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var rows []sql.Row
-	for _, l := range strings.Split(d.Child.String(), "\n") {
+	for _, l := range strings.Split(child.String(), "\n") {
 		if strings.TrimSpace(l) != "" {
 			rows = append(rows, sql.NewRow(l))
 		}
@@ -115,3 +131,38 @@ func (d *DescribeQuery) WithChildren(children ...sql.Node) (sql.Node, error) {
 
 	return NewDescribeQuery(d.Format, children[0]), nil
 }
+
+// coercionAnnotation is a comparison whose description is annotated with how the values of its operands are compared.
+// It's only meant to be printed.
+type coercionAnnotation struct {
+	expression.CoercionDescriber
+	compareType sql.Type
+	branch      string
+}
+
+func (a *coercionAnnotation) String() string {
+	return fmt.Sprintf("%s [compare type: %s, branch: %s]", a.CoercionDescriber, a.compareType, a.branch)
+}
+
+// annotateCoercions returns the node given with its comparisons, and those of its subqueries, annotated with how the
+// values of their operands are compared.
+func annotateCoercions(ctx *sql.Context, n sql.Node) (sql.Node, error) {
+	return TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		switch e := e.(type) {
+		case *Subquery:
+			query, err := annotateCoercions(ctx, e.Query)
+			if err != nil {
+				return nil, err
+			}
+			return e.WithQuery(query), nil
+		case expression.CoercionDescriber:
+			compareType, branch, err := e.Coercion(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &coercionAnnotation{e, compareType, branch}, nil
+		default:
+			return e, nil
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 // Distinct is a node that ensures all rows that come from it are unique.
@@ -33,7 +34,7 @@ func (d *Distinct) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 		return nil, err
 	}
 
-	return sql.NewSpanIter(span, newDistinctIter(ctx, it)), nil
+	return sql.NewSpanIter(span, newDistinctIter(ctx, it, d.Child.Schema())), nil
 }
 
 // WithChildren implements the Node interface.
@@ -53,20 +54,27 @@ func (d Distinct) String() string {
 }
 
 // distinctIter keeps track of the hashes of all rows that have been emitted.
-// It does not emit any rows whose hashes have been seen already.
+// It does not emit any rows whose hashes have been seen already. Rows are hashed
+// by the comparison keys of their columns, so rows whose columns all compare as
+// equal, such as strings that only differ in case in a case insensitive
+// collation, have the same hash.
 // TODO: come up with a way to use less memory than keeping all hashes in memory.
 // Even though they are just 64-bit integers, this could be a problem in large
 // result sets.
 type distinctIter struct {
+	ctx       *sql.Context
 	childIter sql.RowIter
+	columns   []sql.Expression
 	seen      sql.KeyValueCache
 	dispose   sql.DisposeFunc
 }
 
-func newDistinctIter(ctx *sql.Context, child sql.RowIter) *distinctIter {
+func newDistinctIter(ctx *sql.Context, child sql.RowIter, schema sql.Schema) *distinctIter {
 	cache, dispose := ctx.Memory.NewHistoryCache()
 	return &distinctIter{
+		ctx:       ctx,
 		childIter: child,
+		columns:   expression.SchemaFields(schema),
 		seen:      cache,
 		dispose:   dispose,
 	}
@@ -82,7 +90,11 @@ func (di *distinctIter) Next() (sql.Row, error) {
 			return nil, err
 		}
 
-		hash := sql.CacheKey(row)
+		hash, err := expression.ComparisonHash(di.ctx, di.columns, row)
+		if err != nil {
+			return nil, err
+		}
+
 		if _, err := di.seen.Get(hash); err == nil {
 			continue
 		}
@@ -103,6 +115,7 @@ func (di *distinctIter) Close() error {
 func (di *distinctIter) Dispose() {
 	if di.dispose != nil {
 		di.dispose()
+		di.dispose = nil
 	}
 }
 
@@ -134,7 +147,7 @@ func (d *OrderedDistinct) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, e
 		return nil, err
 	}
 
-	return sql.NewSpanIter(span, newOrderedDistinctIter(it, d.Child.Schema())), nil
+	return sql.NewSpanIter(span, newOrderedDistinctIter(ctx, it, d.Child.Schema())), nil
 }
 
 // WithChildren implements the Node interface.
@@ -154,15 +167,17 @@ func (d OrderedDistinct) String() string {
 }
 
 // orderedDistinctIter iterates the children iterator and skips all the
-// repeated rows assuming the iterator has all rows sorted.
+// repeated rows assuming the iterator has all rows sorted. Rows are repeated
+// when they have the same hash as the previous one, as in distinctIter.
 type orderedDistinctIter struct {
+	ctx       *sql.Context
 	childIter sql.RowIter
-	schema    sql.Schema
-	prevRow   sql.Row
+	columns   []sql.Expression
+	prevHash  *uint64
 }
 
-func newOrderedDistinctIter(child sql.RowIter, schema sql.Schema) *orderedDistinctIter {
-	return &orderedDistinctIter{childIter: child, schema: schema}
+func newOrderedDistinctIter(ctx *sql.Context, child sql.RowIter, schema sql.Schema) *orderedDistinctIter {
+	return &orderedDistinctIter{ctx: ctx, childIter: child, columns: expression.SchemaFields(schema)}
 }
 
 func (di *orderedDistinctIter) Next() (sql.Row, error) {
@@ -172,18 +187,16 @@ func (di *orderedDistinctIter) Next() (sql.Row, error) {
 			return nil, err
 		}
 
-		if di.prevRow != nil {
-			ok, err := di.prevRow.Equals(row, di.schema)
-			if err != nil {
-				return nil, err
-			}
+		hash, err := expression.ComparisonHash(di.ctx, di.columns, row)
+		if err != nil {
+			return nil, err
+		}
 
-			if ok {
-				continue
-			}
+		if di.prevHash != nil && *di.prevHash == hash {
+			continue
 		}
 
-		di.prevRow = row
+		di.prevHash = &hash
 		return row, nil
 	}
 }
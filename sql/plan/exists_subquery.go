@@ -0,0 +1,62 @@
+package plan
+
+import (
+	"fmt"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ErrUnsupportedExistsOperand is returned when the operand of EXISTS isn't a subquery.
+var ErrUnsupportedExistsOperand = errors.NewKind("unsupported EXISTS operand: %s")
+
+// ExistsSubquery is an expression that checks whether a subquery returns any row. It's in the plan package, instead of
+// the expression package, for the same reason as InSubquery.
+type ExistsSubquery struct {
+	expression.UnaryExpression
+}
+
+var _ sql.Expression = (*ExistsSubquery)(nil)
+
+// NewExistsSubquery creates an ExistsSubquery expression.
+func NewExistsSubquery(subquery sql.Expression) *ExistsSubquery {
+	return &ExistsSubquery{expression.UnaryExpression{Child: subquery}}
+}
+
+// Type implements the Expression interface.
+func (e *ExistsSubquery) Type() sql.Type {
+	return sql.Boolean
+}
+
+// IsNullable implements the Expression interface.
+func (e *ExistsSubquery) IsNullable() bool {
+	return false
+}
+
+// Eval implements the Expression interface.
+func (e *ExistsSubquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	subquery, ok := e.Child.(*Subquery)
+	if !ok {
+		return nil, ErrUnsupportedExistsOperand.New(e.Child)
+	}
+
+	return subquery.HasResultRow(ctx, row)
+}
+
+// WithChildren implements the Expression interface.
+func (e *ExistsSubquery) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 1)
+	}
+	return NewExistsSubquery(children[0]), nil
+}
+
+func (e *ExistsSubquery) String() string {
+	return fmt.Sprintf("EXISTS %s", e.Child)
+}
+
+func (e *ExistsSubquery) DebugString() string {
+	return fmt.Sprintf("EXISTS %s", sql.DebugString(e.Child))
+}
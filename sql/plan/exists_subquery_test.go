@@ -0,0 +1,65 @@
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+func TestExistsSubquery(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	table := memory.NewTable("foo", sql.Schema{
+		{Name: "t", Source: "foo", Type: sql.Text},
+	})
+
+	require.NoError(t, table.Insert(ctx, sql.Row{"one"}))
+	require.NoError(t, table.Insert(ctx, sql.Row{"two"}))
+
+	// The outer row is prepended to the rows of the table, so the column of the table is the second one
+	query := plan.NewFilter(
+		expression.NewEquals(
+			expression.NewGetField(1, sql.Text, "t", false),
+			expression.NewGetField(0, sql.Text, "outer", false),
+		),
+		plan.NewResolvedTable(table),
+	)
+	exists := plan.NewExistsSubquery(plan.NewSubquery(query, "select * from foo where t = outer"))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"matching row", sql.NewRow("two"), true},
+		{"no matching row", sql.NewRow("three"), false},
+		{"null", sql.NewRow(nil), false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := exists.Eval(ctx, tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("empty table", func(t *testing.T) {
+		empty := memory.NewTable("empty", sql.Schema{
+			{Name: "t", Source: "empty", Type: sql.Text},
+		})
+		subquery := plan.NewSubquery(plan.NewResolvedTable(empty), "select * from empty").WithCachedResults()
+		result, err := plan.NewExistsSubquery(subquery).Eval(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, false, result)
+	})
+
+	t.Run("operand that isn't a subquery", func(t *testing.T) {
+		_, err := plan.NewExistsSubquery(expression.NewLiteral(int64(1), sql.Int64)).Eval(ctx, nil)
+		require.True(t, plan.ErrUnsupportedExistsOperand.Is(err))
+	})
+}
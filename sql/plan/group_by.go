@@ -2,7 +2,6 @@ package plan
 
 import (
 	"fmt"
-	"hash/crc64"
 	"io"
 	"strings"
 
@@ -315,25 +314,23 @@ func (i *groupByGroupingIter) Close() error {
 	return i.child.Close()
 }
 
-var table = crc64.MakeTable(crc64.ISO)
-
 func groupingKey(
 	ctx *sql.Context,
 	exprs []sql.Expression,
 	row sql.Row,
 ) (uint64, error) {
-	vals := make([]string, 0, len(exprs))
+	vals := make([]interface{}, 0, len(exprs))
 
 	for _, expr := range exprs {
 		v, err := expr.Eval(ctx, row)
 		if err != nil {
 			return 0, err
 		}
-		vals = append(vals, fmt.Sprintf("%#v", v))
+		vals = append(vals, v)
 	}
 
-	// TODO: use a faster hash func
-	return crc64.Checksum([]byte(strings.Join(vals, ",")), table), nil
+	// Values that compare as equal are in the same group, as they're the same value in DISTINCT
+	return expression.ComparisonHash(ctx, exprs, vals)
 }
 
 func fillBuffer(expr sql.Expression) sql.Row {
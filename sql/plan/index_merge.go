@@ -0,0 +1,183 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// IndexMerge is a node that reads the rows of a table that match a disjunction of equalities on columns of different
+// indexes, such as a = 1 OR b = 2, by looking up each index in turn and returning the union of the rows found, instead
+// of scanning the whole table. Each row is returned once: the rows found by a lookup that match the condition of an
+// earlier one have already been returned by it, so they're skipped.
+type IndexMerge struct {
+	*ResolvedTable
+	Indexes []sql.Index
+	// Keys are the values each index is looked up with.
+	Keys []sql.Expression
+	// Conditions are the equalities each lookup matches the rows of.
+	Conditions []sql.Expression
+}
+
+var _ sql.Node = (*IndexMerge)(nil)
+var _ sql.Expressioner = (*IndexMerge)(nil)
+
+// NewIndexMerge creates a new IndexMerge node for the given table, which will look up each of the indexes given with
+// the key of the same position, the rows of which match the condition of the same position.
+func NewIndexMerge(table *ResolvedTable, indexes []sql.Index, keys, conditions []sql.Expression) (*IndexMerge, error) {
+	if _, ok := table.Table.(sql.IndexAddressableTable); !ok {
+		return nil, sql.ErrInvalidChildType.New(table, table.Table, (*sql.IndexAddressableTable)(nil))
+	}
+
+	if len(keys) != len(indexes) || len(conditions) != len(indexes) {
+		return nil, sql.ErrInvalidChildrenNumber.New(table, len(keys)+len(conditions), 2*len(indexes))
+	}
+
+	return &IndexMerge{ResolvedTable: table, Indexes: indexes, Keys: keys, Conditions: conditions}, nil
+}
+
+// RowIter implements the Node interface.
+func (m *IndexMerge) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.IndexMerge")
+
+	keys := make([]interface{}, len(m.Keys))
+	for i, e := range m.Keys {
+		key, err := e.Eval(ctx, row)
+		if err != nil {
+			span.Finish()
+			return nil, err
+		}
+		keys[i] = key
+	}
+
+	return sql.NewSpanIter(span, &indexMergeIter{
+		ctx:        ctx,
+		table:      m.Table.(sql.IndexAddressableTable),
+		indexes:    m.Indexes,
+		keys:       keys,
+		conditions: m.Conditions,
+	}), nil
+}
+
+// Expressions implements the Expressioner interface.
+func (m *IndexMerge) Expressions() []sql.Expression {
+	return append(append([]sql.Expression{}, m.Keys...), m.Conditions...)
+}
+
+// WithExpressions implements the Expressioner interface.
+func (m *IndexMerge) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(m.Keys)+len(m.Conditions) {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(exprs), len(m.Keys)+len(m.Conditions))
+	}
+
+	return NewIndexMerge(m.ResolvedTable, m.Indexes, exprs[:len(m.Keys)], exprs[len(m.Keys):])
+}
+
+// WithChildren implements the Node interface.
+func (m *IndexMerge) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 0)
+	}
+
+	return m, nil
+}
+
+func (m *IndexMerge) String() string {
+	return fmt.Sprintf("IndexMerge(%s on %s)", m.Name(), m.lookupsString(func(e sql.Expression) string { return e.String() }))
+}
+
+func (m *IndexMerge) DebugString() string {
+	return fmt.Sprintf("IndexMerge(%s on %s)", m.Name(), m.lookupsString(func(e sql.Expression) string { return sql.DebugString(e) }))
+}
+
+func (m *IndexMerge) lookupsString(str func(sql.Expression) string) string {
+	lookups := make([]string, len(m.Indexes))
+	for i, idx := range m.Indexes {
+		lookups[i] = fmt.Sprintf("%s = %s", idx.ID(), str(m.Keys[i]))
+	}
+	return strings.Join(lookups, ", ")
+}
+
+// indexMergeIter iterates over the rows of the lookup of each index in turn, skipping those already returned by an
+// earlier lookup.
+type indexMergeIter struct {
+	ctx        *sql.Context
+	table      sql.IndexAddressableTable
+	indexes    []sql.Index
+	keys       []interface{}
+	conditions []sql.Expression
+	next       int
+	rows       sql.RowIter
+}
+
+func (i *indexMergeIter) Next() (sql.Row, error) {
+	for {
+		if i.rows == nil {
+			if i.next >= len(i.indexes) {
+				return nil, io.EOF
+			}
+
+			// No row is equal to NULL
+			if i.keys[i.next] == nil {
+				i.next++
+				continue
+			}
+
+			lookup, err := i.indexes[i.next].Get(i.keys[i.next])
+			if err != nil {
+				return nil, err
+			}
+			i.next++
+
+			table := i.table.WithIndexLookup(lookup)
+			partitions, err := table.Partitions(i.ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			i.rows = sql.NewTableRowIter(i.ctx, table, partitions)
+		}
+
+		row, err := i.rows.Next()
+		if err == io.EOF {
+			i.rows = nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		seen, err := i.matchesEarlierLookup(row)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen {
+			return row, nil
+		}
+	}
+}
+
+// matchesEarlierLookup returns whether the row given, found by the current lookup, matches the condition of one of
+// the lookups before it.
+func (i *indexMergeIter) matchesEarlierLookup(row sql.Row) (bool, error) {
+	for _, cond := range i.conditions[:i.next-1] {
+		ok, err := sql.EvaluateCondition(i.ctx, cond, row)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (i *indexMergeIter) Close() error {
+	if i.rows != nil {
+		return i.rows.Close()
+	}
+	return nil
+}
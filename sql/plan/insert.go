@@ -193,38 +193,15 @@ func (i insertIter) Next() (returnRow sql.Row, returnErr error) {
 				return nil, err
 			}
 
-			// Handle ON DUPLICATE KEY UPDATE clause
-			var pkExpression sql.Expression
-			for index, col := range i.schema {
-				if col.PrimaryKey {
-					value := row[index]
-					exp := expression.NewEquals(expression.NewGetField(index, col.Type, col.Name, col.Nullable), expression.NewLiteral(value, col.Type))
-					if pkExpression != nil {
-						pkExpression = expression.NewAnd(pkExpression, exp)
-					} else {
-						pkExpression = exp
-					}
-				}
-			}
-
-			filter := NewFilter(pkExpression, i.tableNode)
-			filterIter, err := filter.RowIter(i.ctx, row)
-			if err != nil {
-				return nil, err
+			// Handle ON DUPLICATE KEY UPDATE clause. By definition, there can only be a single row to update, and only one
+			// row should ever be updated according to the spec:
+			// https://dev.mysql.com/doc/refman/8.0/en/insert-on-duplicate.html
+			rowToUpdate, findErr := i.findDuplicate(row)
+			if findErr != nil {
+				return nil, findErr
 			}
 
-			defer func() {
-				err := filterIter.Close()
-				if returnErr == nil {
-					returnErr = err
-				}
-			}()
-
-			// By definition, there can only be a single row here. And only one row should ever be updated according to the
-			// spec:
-			// https://dev.mysql.com/doc/refman/8.0/en/insert-on-duplicate.html
-			rowToUpdate, err := filterIter.Next()
-			if err != nil {
+			if rowToUpdate == nil {
 				return nil, err
 			}
 
@@ -246,6 +223,113 @@ func (i insertIter) Next() (returnRow sql.Row, returnErr error) {
 	return row, nil
 }
 
+// findDuplicate returns the row of the table inserted into with the same primary key or unique index key as the row
+// given, matching keys the same way their unique index does, or nil if there's no such row.
+func (i insertIter) findDuplicate(row sql.Row) (sql.Row, error) {
+	keys, err := uniqueKeyMatchers(i.ctx, i.tableNode)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := i.tableNode.RowIter(i.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	for {
+		tableRow, err := iter.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			duplicate, err := key.Matches(i.ctx, tableRow, row)
+			if err != nil {
+				return nil, err
+			}
+
+			if duplicate {
+				return tableRow, nil
+			}
+		}
+	}
+}
+
+// uniqueKeyMatchers returns the matchers of the primary key and the unique index keys of the table of the node given.
+func uniqueKeyMatchers(ctx *sql.Context, node sql.Node) ([]*expression.KeyMatcher, error) {
+	schema := node.Schema()
+
+	var keys []*expression.KeyMatcher
+	var pkColIdxes []int
+	for idx, col := range schema {
+		if col.PrimaryKey {
+			pkColIdxes = append(pkColIdxes, idx)
+		}
+	}
+	if len(pkColIdxes) > 0 {
+		keys = append(keys, expression.NewKeyMatcher(schema, pkColIdxes))
+	}
+
+	indexed, ok := getIndexedTable(node)
+	if !ok {
+		return keys, nil
+	}
+
+	indexes, err := indexed.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		if !index.IsUnique() {
+			continue
+		}
+
+		var colIdxes []int
+		for _, e := range index.Expressions() {
+			name := e[strings.LastIndex(e, ".")+1:]
+			idx := schema.IndexOf(name, index.Table())
+			if idx < 0 {
+				colIdxes = nil
+				break
+			}
+			colIdxes = append(colIdxes, idx)
+		}
+
+		if len(colIdxes) > 0 {
+			keys = append(keys, expression.NewKeyMatcher(schema, colIdxes))
+		}
+	}
+
+	return keys, nil
+}
+
+// getIndexedTable returns the table of the node given, if it has indexes.
+func getIndexedTable(node sql.Node) (sql.IndexedTable, bool) {
+	var table sql.Table
+	Inspect(node, func(n sql.Node) bool {
+		if rt, ok := n.(*ResolvedTable); ok {
+			table = rt.Table
+		}
+		return table == nil
+	})
+
+	for {
+		switch t := table.(type) {
+		case sql.IndexedTable:
+			return t, true
+		case sql.TableWrapper:
+			table = t.Underlying()
+		default:
+			return nil, false
+		}
+	}
+}
+
 func (i insertIter) Close() error {
 	if i.inserter != nil {
 		if err := i.inserter.Close(i.ctx); err != nil {
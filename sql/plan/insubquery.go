@@ -16,7 +16,9 @@ package plan
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/dolthub/go-mysql-server/internal/bloom"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
@@ -26,8 +28,20 @@ import (
 // plan node than an expression in its evaluation).
 type InSubquery struct {
 	expression.BinaryExpression
+	// Bloom filter of the cached results of the subquery, built the first time they're evaluated
+	bloomOnce    sync.Once
+	bloomFilter  *bloom.Filter
+	bloomHasNull bool
 }
 
+// BloomFilterThreshold is the number of cached subquery results from which IN uses a bloom filter over them, to
+// discard the values that are definitely not in the results without looking for them.
+var BloomFilterThreshold = 10000
+
+// bloomFilterFalsePositiveRate is the rate of values not in the subquery results that still need to be looked for
+// when a bloom filter is used.
+const bloomFilterFalsePositiveRate = 0.01
+
 var _ sql.Expression = (*InSubquery)(nil)
 
 // Type implements sql.Expression
@@ -37,7 +51,7 @@ func (in *InSubquery) Type() sql.Type {
 
 // NewInSubquery creates an InSubquery expression.
 func NewInSubquery(left sql.Expression, right sql.Expression) *InSubquery {
-	return &InSubquery{expression.BinaryExpression{Left: left, Right: right}}
+	return &InSubquery{BinaryExpression: expression.BinaryExpression{Left: left, Right: right}}
 }
 
 // Eval implements the Expression interface.
@@ -68,19 +82,51 @@ func (in *InSubquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			return nil, expression.ErrInvalidOperandColumns.New(leftElems, 1)
 		}
 
+		// Strings are compared with the collation a comparison of the operands aggregates, like any other comparison
 		typ := right.Type()
+		if sql.IsTextOnly(typ) && sql.IsTextOnly(in.Left.Type()) {
+			typ, _, err = expression.NewEquals(in.Left, subqueryOperand(right)).Coercion(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		values, err := right.EvalMultiple(ctx, row)
 		if err != nil {
 			return nil, err
 		}
 
+		// The sql.NullEqualsNull policy makes NULL equal to the NULL results of the subquery, and not equal to any other
+		nullEqualsNull := sql.NullEqualsNullPolicy(ctx)
+		if leftNull && nullEqualsNull {
+			for _, val := range values {
+				if val == nil {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+
+		if !leftNull && len(values) > 0 {
+			filter, hasNull := in.subqueryBloomFilter(right, typ, values)
+			if filter != nil {
+				converted, err := typ.Convert(left)
+				if err == nil && !filter.MayContain(expression.HashInKey(typ, converted)) {
+					if hasNull && !nullEqualsNull {
+						return nil, nil
+					}
+					return false, nil
+				}
+			}
+		}
+
 		for _, val := range values {
 			// If there are any values in the right-hand side, and the left-hand side is nil, IN evaluates to NULL
 			if leftNull {
 				return nil, nil
 			}
 
-			if !rightNull && val == nil {
+			if val == nil {
 				rightNull = true
 				continue
 			}
@@ -104,7 +150,7 @@ func (in *InSubquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 			}
 		}
 
-		if rightNull {
+		if rightNull && !nullEqualsNull {
 			return nil, nil
 		}
 
@@ -114,6 +160,73 @@ func (in *InSubquery) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 }
 
+// subqueryBloomFilter returns a bloom filter of the results given of the subquery given, hashed as values of the type
+// they're compared as, and whether any of them is NULL. It's only built once, when the subquery results are cached and
+// there are at least BloomFilterThreshold of them, and returns nil otherwise. Only values of types whose equal values
+// always have the same key, as told by expression.IsHashableInType, are added to the filter.
+func (in *InSubquery) subqueryBloomFilter(subquery *Subquery, typ sql.Type, values []interface{}) (*bloom.Filter, bool) {
+	if !subquery.resultsCached {
+		return nil, false
+	}
+
+	in.bloomOnce.Do(func() {
+		if len(values) < BloomFilterThreshold || !expression.IsHashableInType(typ) {
+			return
+		}
+
+		filter := bloom.New(len(values), bloomFilterFalsePositiveRate)
+		for _, val := range values {
+			if val == nil {
+				in.bloomHasNull = true
+				continue
+			}
+
+			val, err := typ.Convert(val)
+			if err != nil {
+				// A value that can't be hashed could be missed by the filter
+				return
+			}
+
+			filter.Add(expression.HashInKey(typ, val))
+		}
+
+		in.bloomFilter = filter
+	})
+
+	return in.bloomFilter, in.bloomHasNull
+}
+
+// subqueryOperand returns the column the subquery given projects, if it projects a column, so that the collation of its
+// results is derived like the collation of a column in a comparison, or the subquery itself otherwise.
+func subqueryOperand(subquery *Subquery) sql.Expression {
+	n := subquery.Query
+	for {
+		var projected sql.Expression
+		switch n := n.(type) {
+		case *Project:
+			projected = n.Projections[0]
+		case *GroupBy:
+			projected = n.SelectedExprs[0]
+		}
+
+		if projected != nil {
+			if alias, ok := projected.(*expression.Alias); ok {
+				projected = alias.Child
+			}
+			if field, ok := projected.(*expression.GetField); ok {
+				return field
+			}
+			return subquery
+		}
+
+		children := n.Children()
+		if len(children) != 1 {
+			return subquery
+		}
+		n = children[0]
+	}
+}
+
 // WithChildren implements the Expression interface.
 func (in *InSubquery) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	if len(children) != 2 {
@@ -15,6 +15,7 @@
 package plan_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -201,3 +202,216 @@ func TestNotInSubquery(t *testing.T) {
 		})
 	}
 }
+
+func TestInSubqueryBloomFilter(t *testing.T) {
+	defer func(threshold int) { plan.BloomFilterThreshold = threshold }(plan.BloomFilterThreshold)
+	plan.BloomFilterThreshold = 10
+
+	ctx := sql.NewEmptyContext()
+
+	// Even numbers from 0 to 198
+	evens := memory.NewTable("evens", sql.Schema{
+		{Name: "n", Source: "evens", Type: sql.Int64, Nullable: true},
+	})
+	for i := 0; i < 100; i++ {
+		require.NoError(t, evens.Insert(ctx, sql.NewRow(int64(i*2))))
+	}
+
+	withNull := memory.NewTable("with_null", sql.Schema{
+		{Name: "n", Source: "with_null", Type: sql.Int64, Nullable: true},
+	})
+	for i := 0; i < 100; i++ {
+		require.NoError(t, withNull.Insert(ctx, sql.NewRow(int64(i*2))))
+	}
+	require.NoError(t, withNull.Insert(ctx, sql.NewRow(nil)))
+
+	subquery := func(table sql.Table) *plan.Subquery {
+		return plan.NewSubquery(plan.NewProject([]sql.Expression{
+			expression.NewGetField(1, sql.Int64, "n", true),
+		}, plan.NewResolvedTable(table)), "").WithCachedResults()
+	}
+
+	t.Run("no false positives or negatives", func(t *testing.T) {
+		require := require.New(t)
+		in := plan.NewInSubquery(expression.NewGetField(0, sql.Int64, "n", true), subquery(evens))
+		for i := 0; i < 400; i++ {
+			result, err := in.Eval(ctx, sql.NewRow(int64(i)))
+			require.NoError(err)
+			require.Equal(i%2 == 0 && i < 200, result, "%d", i)
+		}
+
+		result, err := in.Eval(ctx, sql.NewRow(nil))
+		require.NoError(err)
+		require.Nil(result)
+	})
+
+	t.Run("converted left values", func(t *testing.T) {
+		require := require.New(t)
+		in := plan.NewInSubquery(expression.NewGetField(0, sql.LongText, "s", true), subquery(evens))
+		for i := 0; i < 400; i++ {
+			result, err := in.Eval(ctx, sql.NewRow(fmt.Sprint(i)))
+			require.NoError(err)
+			require.Equal(i%2 == 0 && i < 200, result, "%d", i)
+		}
+	})
+
+	t.Run("strings of a case insensitive collation", func(t *testing.T) {
+		require := require.New(t)
+		words := memory.NewTable("words", sql.Schema{
+			{Name: "s", Source: "words", Type: sql.Text, Nullable: true},
+		})
+		for i := 0; i < 100; i++ {
+			require.NoError(words.Insert(ctx, sql.NewRow(fmt.Sprintf("word%d", i))))
+		}
+
+		in := plan.NewInSubquery(expression.NewGetField(0, sql.Text, "s", true), plan.NewSubquery(plan.NewProject([]sql.Expression{
+			expression.NewGetField(1, sql.Text, "s", true),
+		}, plan.NewResolvedTable(words)), "").WithCachedResults())
+		for i := 0; i < 200; i++ {
+			result, err := in.Eval(ctx, sql.NewRow(fmt.Sprintf("WORD%d", i)))
+			require.NoError(err)
+			require.Equal(i < 100, result, "%d", i)
+		}
+	})
+
+	t.Run("strings of mixed collations", func(t *testing.T) {
+		require := require.New(t)
+		plan.BloomFilterThreshold = 2
+		defer func() { plan.BloomFilterThreshold = 10 }()
+
+		a := memory.NewTable("a", sql.Schema{
+			{Name: "s", Source: "a", Type: sql.CreateText(sql.Collation_utf8mb4_bin), Nullable: true},
+		})
+		b := memory.NewTable("b", sql.Schema{
+			{Name: "s", Source: "b", Type: sql.Text, Nullable: true},
+		})
+		for _, s := range []string{"Abc", "Def", "ghi"} {
+			require.NoError(a.Insert(ctx, sql.NewRow(s)))
+			require.NoError(b.Insert(ctx, sql.NewRow(s)))
+		}
+
+		// The binary collation of the column of a takes precedence over the default one of the column of b
+		inA := plan.NewInSubquery(expression.NewGetField(0, sql.Text, "s", true), plan.NewSubquery(plan.NewProject([]sql.Expression{
+			expression.NewGetField(1, sql.CreateText(sql.Collation_utf8mb4_bin), "s", true),
+		}, plan.NewResolvedTable(a)), "").WithCachedResults())
+		inB := plan.NewInSubquery(expression.NewGetField(0, sql.CreateText(sql.Collation_utf8mb4_bin), "s", true), plan.NewSubquery(plan.NewProject([]sql.Expression{
+			expression.NewGetField(1, sql.Text, "s", true),
+		}, plan.NewResolvedTable(b)), "").WithCachedResults())
+
+		for s, expected := range map[string]bool{"Abc": true, "ABC": false, "Def": true, "def": false, "ghi": true, "xyz": false} {
+			for _, in := range []sql.Expression{inA, inB} {
+				result, err := in.Eval(ctx, sql.NewRow(s))
+				require.NoError(err)
+				require.Equal(expected, result, "%s %s", in, s)
+			}
+		}
+	})
+
+	t.Run("null in subquery results", func(t *testing.T) {
+		require := require.New(t)
+		in := plan.NewInSubquery(expression.NewGetField(0, sql.Int64, "n", true), subquery(withNull))
+		for i := 0; i < 400; i++ {
+			result, err := in.Eval(ctx, sql.NewRow(int64(i)))
+			require.NoError(err)
+			if i%2 == 0 && i < 200 {
+				require.Equal(true, result, "%d", i)
+			} else {
+				require.Nil(result, "%d", i)
+			}
+		}
+	})
+}
+
+func BenchmarkInSubqueryBloomFilter(b *testing.B) {
+	ctx := sql.NewEmptyContext()
+	table := memory.NewTable("big", sql.Schema{
+		{Name: "n", Source: "big", Type: sql.Int64},
+	})
+	for i := 0; i < 100000; i++ {
+		require.NoError(b, table.Insert(ctx, sql.NewRow(int64(i*2))))
+	}
+
+	in := plan.NewInSubquery(
+		expression.NewGetField(0, sql.Int64, "n", false),
+		plan.NewSubquery(plan.NewProject([]sql.Expression{
+			expression.NewGetField(1, sql.Int64, "n", false),
+		}, plan.NewResolvedTable(table)), "").WithCachedResults(),
+	)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Odd numbers are never in the results
+		result, err := in.Eval(ctx, sql.NewRow(int64(i*2+1)))
+		require.NoError(b, err)
+		require.Equal(b, false, result)
+	}
+}
+
+// partitionsCountingTable is a table that counts how many times it's read.
+type partitionsCountingTable struct {
+	*memory.Table
+	reads int
+}
+
+func (t *partitionsCountingTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	t.reads++
+	return t.Table.Partitions(ctx)
+}
+
+func TestInSubqueryMemoizedResults(t *testing.T) {
+	defer func(max int) { plan.SubqueryMemoMaxEntries = max }(plan.SubqueryMemoMaxEntries)
+
+	ctx := sql.NewEmptyContext()
+	inner := &partitionsCountingTable{Table: memory.NewTable("inner", sql.Schema{
+		{Name: "g", Source: "inner", Type: sql.Int64},
+		{Name: "v", Source: "inner", Type: sql.Int64},
+	})}
+	for g := int64(0); g < 3; g++ {
+		for v := g * 10; v < g*10+5; v++ {
+			require.NoError(t, inner.Insert(ctx, sql.NewRow(g, v)))
+		}
+	}
+
+	// SELECT v FROM inner WHERE inner.g = outer.g, for an outer row of (g, v)
+	subquery := plan.NewSubquery(plan.NewProject([]sql.Expression{
+		expression.NewGetField(3, sql.Int64, "v", false),
+	}, plan.NewFilter(
+		expression.NewEquals(expression.NewGetField(0, sql.Int64, "g", false), expression.NewGetField(2, sql.Int64, "g", false)),
+		plan.NewResolvedTable(inner),
+	)), "")
+	require.Nil(t, subquery.MemoizedBy())
+
+	eval := func(subquery *plan.Subquery) []interface{} {
+		in := plan.NewInSubquery(expression.NewGetField(1, sql.Int64, "v", false), subquery)
+		var results []interface{}
+		for i := int64(0); i < 30; i++ {
+			result, err := in.Eval(ctx, sql.NewRow(i%3, i))
+			require.NoError(t, err)
+			results = append(results, result)
+		}
+		return results
+	}
+
+	expected := eval(subquery)
+	require.Equal(t, 30, inner.reads)
+	for i, result := range expected {
+		g := int64(i % 3)
+		require.Equal(t, int64(i) >= g*10 && int64(i) < g*10+5, result, "%d", i)
+	}
+
+	t.Run("once per distinct correlation value", func(t *testing.T) {
+		inner.reads = 0
+		memoized := subquery.WithMemoizedResults([]int{0})
+		require.Equal(t, []int{0}, memoized.MemoizedBy())
+		require.Equal(t, expected, eval(memoized))
+		require.Equal(t, 3, inner.reads)
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		plan.SubqueryMemoMaxEntries = 2
+		inner.reads = 0
+		require.Equal(t, expected, eval(subquery.WithMemoizedResults([]int{0})))
+		// The results for the third value, which 10 of the outer rows have, aren't memoized
+		require.Equal(t, 2+10, inner.reads)
+	})
+}
@@ -0,0 +1,202 @@
+package plan
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// OrderedIndexScan is a node that reads the rows of a table within a range of the keys of a sql.OrderedIndex in the
+// order of the index, ascending or descending, so that a query sorted by the columns of the index and limited to a
+// number of rows stops reading them as soon as it has enough. In ascending order, the range includes its lower bound
+// and excludes its upper one, and in descending order the opposite, as the lookups of sql.AscendIndex and
+// sql.DescendIndex do, so the rows it returns must still be filtered by the condition the bounds come from.
+type OrderedIndexScan struct {
+	*ResolvedTable
+	Index      sql.Index
+	Descending bool
+	// Lower and Upper are the bounds of the range of keys read, either of which can be nil for an unbounded range. The
+	// bounds of an index of several columns are tuples.
+	Lower sql.Expression
+	Upper sql.Expression
+}
+
+var _ sql.Node = (*OrderedIndexScan)(nil)
+var _ sql.Expressioner = (*OrderedIndexScan)(nil)
+
+// NewOrderedIndexScan creates a new OrderedIndexScan node for the given table, which will read the keys of the index
+// given between the bounds given in the order given.
+func NewOrderedIndexScan(table *ResolvedTable, index sql.Index, descending bool, lower, upper sql.Expression) (*OrderedIndexScan, error) {
+	if _, ok := table.Table.(sql.IndexAddressableTable); !ok {
+		return nil, sql.ErrInvalidChildType.New(table, table.Table, (*sql.IndexAddressableTable)(nil))
+	}
+
+	return &OrderedIndexScan{ResolvedTable: table, Index: index, Descending: descending, Lower: lower, Upper: upper}, nil
+}
+
+// RowIter implements the Node interface.
+func (s *OrderedIndexScan) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.OrderedIndexScan")
+
+	lower, lowerOk, err := boundKey(ctx, s.Lower, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	upper, upperOk, err := boundKey(ctx, s.Upper, row)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	if !lowerOk || !upperOk {
+		span.Finish()
+		return sql.RowsToRowIter(), nil
+	}
+
+	lookup, err := s.lookup(lower, upper)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	table := s.Table.(sql.IndexAddressableTable).WithIndexLookup(lookup)
+	partitions, err := table.Partitions(ctx)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, sql.NewTableRowIter(ctx, table, partitions)), nil
+}
+
+// boundKey returns the key of an index the bound given evaluates to, or nil for no bound, which is the values of a tuple
+// for an index of several columns. The boolean returned is false if the bound is NULL, since no key is within a range
+// bounded by NULL.
+func boundKey(ctx *sql.Context, bound sql.Expression, row sql.Row) ([]interface{}, bool, error) {
+	if bound == nil {
+		return nil, true, nil
+	}
+
+	key, err := bound.Eval(ctx, row)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if key == nil {
+		return nil, false, nil
+	}
+
+	if sql.IsTuple(bound.Type()) {
+		values, ok := key.([]interface{})
+		if !ok {
+			return nil, false, sql.ErrNotTuple.New(key)
+		}
+		return values, true, nil
+	}
+	return []interface{}{key}, true, nil
+}
+
+// lookup returns the lookup of the index of the keys between the bounds given, in the order of the scan.
+func (s *OrderedIndexScan) lookup(lower, upper []interface{}) (sql.IndexLookup, error) {
+	if s.Descending {
+		idx, ok := s.Index.(sql.DescendIndex)
+		if !ok {
+			return nil, sql.ErrInvalidChildType.New(s, s.Index, (*sql.DescendIndex)(nil))
+		}
+
+		switch {
+		case lower != nil && upper != nil:
+			return idx.DescendRange(upper, lower)
+		case lower != nil:
+			return idx.DescendGreater(lower...)
+		default:
+			// Without either bound, this is a lookup of no keys, which is of every key
+			return idx.DescendLessOrEqual(upper...)
+		}
+	}
+
+	idx, ok := s.Index.(sql.AscendIndex)
+	if !ok {
+		return nil, sql.ErrInvalidChildType.New(s, s.Index, (*sql.AscendIndex)(nil))
+	}
+
+	switch {
+	case lower != nil && upper != nil:
+		return idx.AscendRange(lower, upper)
+	case upper != nil:
+		return idx.AscendLessThan(upper...)
+	default:
+		// Without either bound, this is a lookup of no keys, which is of every key
+		return idx.AscendGreaterOrEqual(lower...)
+	}
+}
+
+// Expressions implements the Expressioner interface.
+func (s *OrderedIndexScan) Expressions() []sql.Expression {
+	var exprs []sql.Expression
+	for _, e := range []sql.Expression{s.Lower, s.Upper} {
+		if e != nil {
+			exprs = append(exprs, e)
+		}
+	}
+	return exprs
+}
+
+// WithExpressions implements the Expressioner interface.
+func (s *OrderedIndexScan) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(s.Expressions()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(exprs), len(s.Expressions()))
+	}
+
+	lower, upper := s.Lower, s.Upper
+	if lower != nil {
+		lower, exprs = exprs[0], exprs[1:]
+	}
+	if upper != nil {
+		upper = exprs[0]
+	}
+
+	return NewOrderedIndexScan(s.ResolvedTable, s.Index, s.Descending, lower, upper)
+}
+
+// WithChildren implements the Node interface.
+func (s *OrderedIndexScan) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 0)
+	}
+
+	return s, nil
+}
+
+func (s *OrderedIndexScan) String() string {
+	return fmt.Sprintf("OrderedIndexScan(%s on %s %s, %s)", s.Name(), s.Index.ID(), s.order(), s.rangeString(func(e sql.Expression) string { return e.String() }))
+}
+
+func (s *OrderedIndexScan) DebugString() string {
+	return fmt.Sprintf("OrderedIndexScan(%s on %s %s, %s)", s.Name(), s.Index.ID(), s.order(), s.rangeString(func(e sql.Expression) string { return sql.DebugString(e) }))
+}
+
+func (s *OrderedIndexScan) order() string {
+	if s.Descending {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// rangeString returns the range of the keys read, such as [1, 10) in ascending order or (1, 10] in descending order.
+func (s *OrderedIndexScan) rangeString(str func(sql.Expression) string) string {
+	lower, upper := "-inf", "+inf"
+	if s.Lower != nil {
+		lower = str(s.Lower)
+	}
+	if s.Upper != nil {
+		upper = str(s.Upper)
+	}
+
+	if s.Descending {
+		return fmt.Sprintf("(%s, %s]", lower, upper)
+	}
+	return fmt.Sprintf("[%s, %s)", lower, upper)
+}
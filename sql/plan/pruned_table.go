@@ -0,0 +1,75 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// PartitionPrunedTable is a node that reads the rows of only some of the partitions of a table, the ones that can have
+// rows matching the filter on top of it, skipping the rest.
+type PartitionPrunedTable struct {
+	*ResolvedTable
+	Partitions []sql.Partition
+}
+
+var _ sql.Node = (*PartitionPrunedTable)(nil)
+
+// NewPartitionPrunedTable creates a new PartitionPrunedTable node that reads the partitions given of the table given.
+func NewPartitionPrunedTable(table *ResolvedTable, partitions []sql.Partition) *PartitionPrunedTable {
+	return &PartitionPrunedTable{ResolvedTable: table, Partitions: partitions}
+}
+
+// RowIter implements the Node interface.
+func (t *PartitionPrunedTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.PartitionPrunedTable")
+	partitions := &partitionSliceIter{partitions: t.Partitions}
+	return sql.NewSpanIter(span, sql.NewTableRowIter(ctx, t.Table, partitions)), nil
+}
+
+// WithChildren implements the Node interface.
+func (t *PartitionPrunedTable) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 0)
+	}
+
+	return t, nil
+}
+
+func (t *PartitionPrunedTable) String() string {
+	return fmt.Sprintf("PartitionPrunedTable(%s, partitions: %s)", t.Name(), t.partitionsString())
+}
+
+func (t *PartitionPrunedTable) DebugString() string {
+	return fmt.Sprintf("PartitionPrunedTable(%s, partitions: %s)", sql.DebugString(t.ResolvedTable), t.partitionsString())
+}
+
+func (t *PartitionPrunedTable) partitionsString() string {
+	keys := make([]string, len(t.Partitions))
+	for i, p := range t.Partitions {
+		keys[i] = string(p.Key())
+	}
+	return strings.Join(keys, ", ")
+}
+
+// partitionSliceIter iterates over a slice of partitions.
+type partitionSliceIter struct {
+	partitions []sql.Partition
+	next       int
+}
+
+func (i *partitionSliceIter) Next() (sql.Partition, error) {
+	if i.next >= len(i.partitions) {
+		return nil, io.EOF
+	}
+
+	p := i.partitions[i.next]
+	i.next++
+	return p, nil
+}
+
+func (i *partitionSliceIter) Close() error {
+	return nil
+}
@@ -0,0 +1,53 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestPartitionPrunedTable(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := memory.NewPartitionedTable("t", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "t"},
+	}, 3)
+	for i := int64(1); i <= 6; i++ {
+		require.NoError(table.Insert(ctx, sql.NewRow(i)))
+	}
+
+	iter, err := table.Partitions(ctx)
+	require.NoError(err)
+	var partitions []sql.Partition
+	for {
+		p, err := iter.Next()
+		if err != nil {
+			break
+		}
+		partitions = append(partitions, p)
+	}
+	require.Len(partitions, 3)
+
+	// rowsOf returns the rows of the partitions given, read directly from the table
+	rowsOf := func(partitions ...sql.Partition) []sql.Row {
+		var rows []sql.Row
+		for _, p := range partitions {
+			iter, err := table.PartitionRows(ctx, p)
+			require.NoError(err)
+			partitionRows, err := sql.RowIterToRows(iter)
+			require.NoError(err)
+			rows = append(rows, partitionRows...)
+		}
+		return rows
+	}
+
+	for _, pruned := range [][]sql.Partition{partitions[:1], {partitions[0], partitions[2]}, nil} {
+		rows, err := sql.NodeToRows(ctx, NewPartitionPrunedTable(NewResolvedTable(table), pruned))
+		require.NoError(err)
+		require.ElementsMatch(rowsOf(pruned...), rows)
+	}
+}
@@ -0,0 +1,109 @@
+package plan
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// RowIDFetch is a node that fetches at most one row of a table directly by its row id, instead of scanning the whole
+// table. It replaces a ResolvedTable filtered by an equality on the row id pseudo-column of a sql.RowIDTable.
+type RowIDFetch struct {
+	*ResolvedTable
+	ID sql.Expression
+}
+
+var _ sql.Node = (*RowIDFetch)(nil)
+var _ sql.Expressioner = (*RowIDFetch)(nil)
+
+// NewRowIDFetch creates a new RowIDFetch node for the given table, which will fetch the row with the id given.
+func NewRowIDFetch(table *ResolvedTable, id sql.Expression) (*RowIDFetch, error) {
+	if _, ok := table.Table.(sql.RowIDTable); !ok {
+		return nil, sql.ErrInvalidChildType.New(table, table.Table, (*sql.RowIDTable)(nil))
+	}
+
+	return &RowIDFetch{ResolvedTable: table, ID: id}, nil
+}
+
+// RowIter implements the Node interface.
+func (r *RowIDFetch) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.RowIDFetch")
+	defer span.Finish()
+
+	id, err := r.ID.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == nil {
+		return sql.RowsToRowIter(), nil
+	}
+
+	table := r.Table.(sql.RowIDTable)
+	if idx := r.Schema().IndexOf(table.RowIDColumn(), table.Name()); idx >= 0 {
+		// A value that can't be converted to the type of the row id, or that isn't equal to the row id it's converted
+		// to, such as 42.5 truncated to 42, matches no row.
+		typ := r.Schema()[idx].Type
+		converted, err := typ.Convert(id)
+		if err != nil {
+			return sql.RowsToRowIter(), nil
+		}
+
+		eq, err := expression.NewEquals(
+			expression.NewLiteral(converted, typ),
+			expression.NewLiteral(id, r.ID.Type()),
+		).Eval(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if eq != true {
+			return sql.RowsToRowIter(), nil
+		}
+
+		id = converted
+	}
+
+	fetched, err := table.RowByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if fetched == nil {
+		return sql.RowsToRowIter(), nil
+	}
+
+	return sql.RowsToRowIter(fetched), nil
+}
+
+// Expressions implements the Expressioner interface.
+func (r *RowIDFetch) Expressions() []sql.Expression {
+	return []sql.Expression{r.ID}
+}
+
+// WithExpressions implements the Expressioner interface.
+func (r *RowIDFetch) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(exprs), 1)
+	}
+
+	return NewRowIDFetch(r.ResolvedTable, exprs[0])
+}
+
+// WithChildren implements the Node interface.
+func (r *RowIDFetch) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 0)
+	}
+
+	return r, nil
+}
+
+func (r *RowIDFetch) String() string {
+	return fmt.Sprintf("RowIDFetch(%s.%s = %s)", r.Name(), r.Table.(sql.RowIDTable).RowIDColumn(), r.ID)
+}
+
+func (r *RowIDFetch) DebugString() string {
+	return fmt.Sprintf("RowIDFetch(%s.%s = %s)", r.Name(), r.Table.(sql.RowIDTable).RowIDColumn(), sql.DebugString(r.ID))
+}
@@ -0,0 +1,153 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// SkipScan is a node that reads the rows of a table that match equalities on all the columns of a composite index but
+// the leading one. Instead of scanning the whole table, it looks up the index with each of the distinct values of the
+// leading column in turn, skipping over all the keys of the index that don't match the rest of the columns.
+type SkipScan struct {
+	*ResolvedTable
+	Index sql.Index
+	// Prefixes are the distinct values of the leading column of the index.
+	Prefixes []interface{}
+	// Keys are the values of the rest of the columns of the index.
+	Keys []sql.Expression
+}
+
+var _ sql.Node = (*SkipScan)(nil)
+var _ sql.Expressioner = (*SkipScan)(nil)
+
+// NewSkipScan creates a new SkipScan node for the given table, which will look up the index given with each of the
+// prefixes given followed by the values of the keys given.
+func NewSkipScan(table *ResolvedTable, index sql.Index, prefixes []interface{}, keys []sql.Expression) (*SkipScan, error) {
+	if _, ok := table.Table.(sql.IndexAddressableTable); !ok {
+		return nil, sql.ErrInvalidChildType.New(table, table.Table, (*sql.IndexAddressableTable)(nil))
+	}
+
+	return &SkipScan{ResolvedTable: table, Index: index, Prefixes: prefixes, Keys: keys}, nil
+}
+
+// RowIter implements the Node interface.
+func (s *SkipScan) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.SkipScan")
+
+	keys := make([]interface{}, len(s.Keys))
+	for i, e := range s.Keys {
+		key, err := e.Eval(ctx, row)
+		if err != nil {
+			span.Finish()
+			return nil, err
+		}
+
+		// No row is equal to NULL
+		if key == nil {
+			span.Finish()
+			return sql.RowsToRowIter(), nil
+		}
+
+		keys[i] = key
+	}
+
+	return sql.NewSpanIter(span, &skipScanIter{
+		ctx:      ctx,
+		table:    s.Table.(sql.IndexAddressableTable),
+		index:    s.Index,
+		prefixes: s.Prefixes,
+		keys:     keys,
+	}), nil
+}
+
+// Expressions implements the Expressioner interface.
+func (s *SkipScan) Expressions() []sql.Expression {
+	return s.Keys
+}
+
+// WithExpressions implements the Expressioner interface.
+func (s *SkipScan) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(s.Keys) {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(exprs), len(s.Keys))
+	}
+
+	return NewSkipScan(s.ResolvedTable, s.Index, s.Prefixes, exprs)
+}
+
+// WithChildren implements the Node interface.
+func (s *SkipScan) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 0)
+	}
+
+	return s, nil
+}
+
+func (s *SkipScan) String() string {
+	return fmt.Sprintf("SkipScan(%s on %s, %d prefixes, keys: %s)", s.Name(), s.Index.ID(), len(s.Prefixes), s.keysString(func(e sql.Expression) string { return e.String() }))
+}
+
+func (s *SkipScan) DebugString() string {
+	return fmt.Sprintf("SkipScan(%s on %s, prefixes: %v, keys: %s)", s.Name(), s.Index.ID(), s.Prefixes, s.keysString(func(e sql.Expression) string { return sql.DebugString(e) }))
+}
+
+func (s *SkipScan) keysString(str func(sql.Expression) string) string {
+	keys := make([]string, len(s.Keys))
+	for i, k := range s.Keys {
+		keys[i] = str(k)
+	}
+	return strings.Join(keys, ", ")
+}
+
+// skipScanIter iterates over the rows of the lookups of an index with each prefix in turn.
+type skipScanIter struct {
+	ctx      *sql.Context
+	table    sql.IndexAddressableTable
+	index    sql.Index
+	prefixes []interface{}
+	keys     []interface{}
+	next     int
+	rows     sql.RowIter
+}
+
+func (i *skipScanIter) Next() (sql.Row, error) {
+	for {
+		if i.rows == nil {
+			if i.next >= len(i.prefixes) {
+				return nil, io.EOF
+			}
+
+			lookup, err := i.index.Get(append([]interface{}{i.prefixes[i.next]}, i.keys...)...)
+			if err != nil {
+				return nil, err
+			}
+			i.next++
+
+			table := i.table.WithIndexLookup(lookup)
+			partitions, err := table.Partitions(i.ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			i.rows = sql.NewTableRowIter(i.ctx, table, partitions)
+		}
+
+		row, err := i.rows.Next()
+		if err == io.EOF {
+			i.rows = nil
+			continue
+		}
+
+		return row, err
+	}
+}
+
+func (i *skipScanIter) Close() error {
+	if i.rows != nil {
+		return i.rows.Close()
+	}
+	return nil
+}
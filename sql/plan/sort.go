@@ -282,6 +282,10 @@ func (s *Sorter) Less(i, j int) bool {
 		}
 
 		cmp, err := typ.Compare(av, bv)
+		// Incomparable values are sorted as if they were equal
+		if sql.ErrIncomparable.Is(err) {
+			continue
+		}
 		if err != nil {
 			s.LastError = err
 			return false
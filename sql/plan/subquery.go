@@ -16,6 +16,8 @@ package plan
 
 import (
 	"fmt"
+	"io"
+	"sync"
 
 	errors "gopkg.in/src-d/go-errors.v1"
 
@@ -38,6 +40,63 @@ type Subquery struct {
 	resultsCached bool
 	// Cached results, if any
 	cache interface{}
+	// The memo of the results of a correlated subquery by the values of the outer row it depends on, if any
+	memo *subqueryMemo
+}
+
+// SubqueryMemoMaxEntries is the number of distinct values of the outer row a correlated subquery depends on whose
+// results are memoized at most. The analyzer only memoizes the results of a subquery if the statistics of the outer
+// tables estimate it's evaluated with no more distinct values than these.
+var SubqueryMemoMaxEntries = 64
+
+// subqueryMemoMaxValues is the number of results of a correlated subquery memoized at most, across all the values of
+// the outer row it depends on, so that memoizing the results of a subquery that returns many rows doesn't take up a lot
+// of memory.
+const subqueryMemoMaxValues = 100000
+
+// subqueryMemo holds the results of a correlated subquery by the values of the columns of the outer row it depends on,
+// during the execution of a query, so that it's only run once for each distinct combination of them. It's safe for
+// concurrent use.
+type subqueryMemo struct {
+	// correlation are the indexes of the columns of the outer row the subquery depends on
+	correlation []int
+	mu          sync.Mutex
+	results     map[string][]interface{}
+	values      int
+}
+
+// key returns the key of the results of the subquery for the row given, which is made of the values of the columns
+// of the row the subquery depends on.
+func (m *subqueryMemo) key(row sql.Row) (string, bool) {
+	values := make([]interface{}, len(m.correlation))
+	for i, idx := range m.correlation {
+		if idx >= len(row) {
+			return "", false
+		}
+		values[i] = row[idx]
+	}
+	return fmt.Sprintf("%#v", values), true
+}
+
+func (m *subqueryMemo) get(key string) ([]interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result, ok := m.results[key]
+	return result, ok
+}
+
+// put memoizes the results given of the subquery for the key given, unless the memo is full.
+func (m *subqueryMemo) put(key string, result []interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.results) >= SubqueryMemoMaxEntries || m.values+len(result) > subqueryMemoMaxValues {
+		return
+	}
+
+	if _, ok := m.results[key]; !ok {
+		m.results[key] = result
+		m.values += len(result)
+	}
 }
 
 // NewSubquery returns a new subquery expression.
@@ -168,6 +227,31 @@ func (s *Subquery) EvalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, e
 		return s.cache.([]interface{}), nil
 	}
 
+	if s.memo == nil {
+		return s.evalMultiple(ctx, row)
+	}
+
+	key, ok := s.memo.key(row)
+	if !ok {
+		return s.evalMultiple(ctx, row)
+	}
+
+	if result, ok := s.memo.get(key); ok {
+		return result, nil
+	}
+
+	result, err := s.evalMultiple(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	s.memo.put(key, result)
+	return result, nil
+}
+
+// evalMultiple runs the subquery with the row given and returns all the rows it returns.
+func (s *Subquery) evalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, error) {
+
 	q, err := TransformUp(s.Query, prependRowInPlan(row))
 	if err != nil {
 		return nil, err
@@ -206,6 +290,40 @@ func (s *Subquery) EvalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, e
 	return result, nil
 }
 
+// HasResultRow returns whether a subquery returns any row, which stops it after the first one.
+func (s *Subquery) HasResultRow(ctx *sql.Context, row sql.Row) (bool, error) {
+	if s.resultsCached {
+		return s.cache.(bool), nil
+	}
+
+	q, err := TransformUp(s.Query, prependRowInPlan(row))
+	if err != nil {
+		return false, err
+	}
+
+	iter, err := q.RowIter(ctx, row)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = iter.Next()
+	hasRow := err == nil
+	if err != nil && err != io.EOF {
+		iter.Close()
+		return false, err
+	}
+
+	if err := iter.Close(); err != nil {
+		return false, err
+	}
+
+	if s.canCacheResults {
+		s.cache, s.resultsCached = hasRow, true
+	}
+
+	return hasRow, nil
+}
+
 // IsNullable implements the Expression interface.
 func (s *Subquery) IsNullable() bool {
 	return s.Query.Schema()[0].Nullable
@@ -216,6 +334,9 @@ func (s *Subquery) String() string {
 }
 
 func (s *Subquery) DebugString() string {
+	if s.memo != nil {
+		return fmt.Sprintf("(%s), cacheable = %t, memoized by = %v", sql.DebugString(s.Query), s.canCacheResults, s.memo.correlation)
+	}
 	return fmt.Sprintf("(%s), cacheable = %t", sql.DebugString(s.Query), s.canCacheResults)
 }
 
@@ -260,3 +381,21 @@ func (s *Subquery) WithCachedResults() *Subquery {
 	ns.canCacheResults = true
 	return &ns
 }
+
+// WithMemoizedResults returns the subquery with its results memoized by the values of the columns of the outer row
+// given, which are the only ones it depends on, so that it's only run once for each distinct combination of them
+// during the execution of the query. At most SubqueryMemoMaxEntries combinations are memoized.
+func (s *Subquery) WithMemoizedResults(correlation []int) *Subquery {
+	ns := *s
+	ns.memo = &subqueryMemo{correlation: correlation, results: make(map[string][]interface{})}
+	return &ns
+}
+
+// MemoizedBy returns the indexes of the columns of the outer row the results of the subquery are memoized by, or nil
+// if they aren't.
+func (s *Subquery) MemoizedBy() []int {
+	if s.memo == nil {
+		return nil
+	}
+	return s.memo.correlation
+}
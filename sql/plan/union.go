@@ -9,15 +9,26 @@ import (
 // Union is a node that returns everything in Left and then everything in Right
 type Union struct {
 	BinaryNode
+	// Distinct is whether rows equal to a row already returned are left out, as in UNION, rather than UNION ALL.
+	Distinct bool
 }
 
-// NewUnion creates a new Union node with the given children.
+// NewUnion creates a new Union node with the given children, which returns all their rows, as UNION ALL does.
 func NewUnion(left, right sql.Node) *Union {
 	return &Union{
 		BinaryNode: BinaryNode{Left: left, Right: right},
 	}
 }
 
+// NewDistinctUnion creates a new Union node with the given children, which only returns the first of the rows equal
+// to each other, as UNION and UNION DISTINCT do.
+func NewDistinctUnion(left, right sql.Node) *Union {
+	return &Union{
+		BinaryNode: BinaryNode{Left: left, Right: right},
+		Distinct:   true,
+	}
+}
+
 func (u *Union) Schema() sql.Schema {
 	ls := u.Left.Schema()
 	rs := u.Right.Schema()
@@ -40,12 +51,15 @@ func (u *Union) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 		span.Finish()
 		return nil, err
 	}
-	ui := &unionIter{
+	var ui sql.RowIter = &unionIter{
 		li,
 		func() (sql.RowIter, error) {
 			return u.Right.RowIter(ctx, nil)
 		},
 	}
+	if u.Distinct {
+		ui = newDistinctIter(ctx, ui, u.Schema())
+	}
 	return sql.NewSpanIter(span, ui), nil
 }
 
@@ -54,12 +68,18 @@ func (u *Union) WithChildren(children ...sql.Node) (sql.Node, error) {
 	if len(children) != 2 {
 		return nil, sql.ErrInvalidChildrenNumber.New(u, len(children), 2)
 	}
-	return NewUnion(children[0], children[1]), nil
+	nu := *u
+	nu.BinaryNode = BinaryNode{Left: children[0], Right: children[1]}
+	return &nu, nil
 }
 
 func (u Union) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("Union")
+	if u.Distinct {
+		_ = pr.WriteNode("Union distinct")
+	} else {
+		_ = pr.WriteNode("Union")
+	}
 	_ = pr.WriteChildren(u.Left.String(), u.Right.String())
 	return pr.String()
 }
@@ -4,6 +4,8 @@ import (
 	"io"
 	"testing"
 
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/memory"
@@ -88,3 +90,81 @@ func TestUnion(t *testing.T) {
 		require.Equal(c.expected, results)
 	}
 }
+
+func TestDistinctUnion(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	newTable := func(name string, typ sql.Type, values ...interface{}) sql.Node {
+		table := memory.NewTable(name, sql.Schema{{Name: "v", Type: typ, Source: name, Nullable: true}})
+		for _, v := range values {
+			require.NoError(t, table.Insert(ctx, sql.NewRow(v)))
+		}
+		return NewResolvedTable(table)
+	}
+
+	decimalType := sql.MustCreateDecimalType(10, 2)
+	binType := sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_bin)
+	ciType := sql.MustCreateString(sqltypes.VarChar, 10, sql.Collation_utf8mb4_general_ci)
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected []sql.Row
+	}{
+		{
+			"decimals differing in scale",
+			NewDistinctUnion(
+				newTable("l", decimalType, decimal.RequireFromString("1.5"), decimal.RequireFromString("2")),
+				newTable("r", decimalType, decimal.RequireFromString("1.50"), decimal.RequireFromString("2.5")),
+			),
+			[]sql.Row{
+				{decimal.RequireFromString("1.5")},
+				{decimal.RequireFromString("2")},
+				{decimal.RequireFromString("2.5")},
+			},
+		},
+		{
+			"strings differing in case in a case insensitive collation",
+			NewDistinctUnion(
+				newTable("l", ciType, "abc", "x"),
+				newTable("r", ciType, "ABC", "y"),
+			),
+			[]sql.Row{{"abc"}, {"x"}, {"y"}},
+		},
+		{
+			"strings differing in case in a case sensitive collation",
+			NewDistinctUnion(
+				newTable("l", binType, "abc", "x"),
+				newTable("r", binType, "ABC", "x"),
+			),
+			[]sql.Row{{"abc"}, {"x"}, {"ABC"}},
+		},
+		{
+			"NULL values",
+			NewDistinctUnion(
+				newTable("l", binType, nil, "a"),
+				newTable("r", binType, nil, nil),
+			),
+			[]sql.Row{{nil}, {"a"}},
+		},
+		{
+			"all rows",
+			NewUnion(
+				newTable("l", ciType, "abc", nil),
+				newTable("r", ciType, "ABC", nil),
+			),
+			[]sql.Row{{"abc"}, {nil}, {"ABC"}, {nil}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			iter, err := tt.node.RowIter(ctx, nil)
+			require.NoError(err)
+
+			rows, err := sql.RowIterToRows(iter)
+			require.NoError(err)
+			require.Equal(tt.expected, rows)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrIndexNotUnique is returned when a unique key lookup is created for an index that isn't unique.
+var ErrIndexNotUnique = errors.NewKind("index %s is not unique")
+
+// UniqueKeyLookup is a node that reads the single row of a table, if any, whose columns of a unique index are equal to
+// some keys, by looking them up in the index. It replaces a ResolvedTable filtered by equalities on all the columns of
+// the index, so that the rest of the filter only needs to be checked against that row.
+type UniqueKeyLookup struct {
+	*ResolvedTable
+	Index sql.Index
+	// Keys are the values of the columns of the index, in order.
+	Keys []sql.Expression
+}
+
+var _ sql.Node = (*UniqueKeyLookup)(nil)
+var _ sql.Expressioner = (*UniqueKeyLookup)(nil)
+
+// NewUniqueKeyLookup creates a new UniqueKeyLookup node for the given table, which will look up the unique index given
+// with the keys given.
+func NewUniqueKeyLookup(table *ResolvedTable, index sql.Index, keys []sql.Expression) (*UniqueKeyLookup, error) {
+	if _, ok := table.Table.(sql.IndexAddressableTable); !ok {
+		return nil, sql.ErrInvalidChildType.New(table, table.Table, (*sql.IndexAddressableTable)(nil))
+	}
+
+	if !index.IsUnique() {
+		return nil, ErrIndexNotUnique.New(index.ID())
+	}
+
+	return &UniqueKeyLookup{ResolvedTable: table, Index: index, Keys: keys}, nil
+}
+
+// RowIter implements the Node interface.
+func (u *UniqueKeyLookup) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.UniqueKeyLookup")
+	defer span.Finish()
+
+	keys := make([]interface{}, len(u.Keys))
+	for i, e := range u.Keys {
+		key, err := e.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		// No row is equal to NULL
+		if key == nil {
+			return sql.RowsToRowIter(), nil
+		}
+
+		keys[i] = key
+	}
+
+	lookup, err := u.Index.Get(keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	table := u.Table.(sql.IndexAddressableTable).WithIndexLookup(lookup)
+	partitions, err := table.Partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	iter := sql.NewTableRowIter(ctx, table, partitions)
+	found, err := iter.Next()
+	if err != nil && err != io.EOF {
+		iter.Close()
+		return nil, err
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	if found == nil {
+		return sql.RowsToRowIter(), nil
+	}
+
+	return sql.RowsToRowIter(found), nil
+}
+
+// Expressions implements the Expressioner interface.
+func (u *UniqueKeyLookup) Expressions() []sql.Expression {
+	return u.Keys
+}
+
+// WithExpressions implements the Expressioner interface.
+func (u *UniqueKeyLookup) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(u.Keys) {
+		return nil, sql.ErrInvalidChildrenNumber.New(u, len(exprs), len(u.Keys))
+	}
+
+	return NewUniqueKeyLookup(u.ResolvedTable, u.Index, exprs)
+}
+
+// WithChildren implements the Node interface.
+func (u *UniqueKeyLookup) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(u, len(children), 0)
+	}
+
+	return u, nil
+}
+
+func (u *UniqueKeyLookup) String() string {
+	return fmt.Sprintf("UniqueKeyLookup(%s on %s, keys: %s)", u.Name(), u.Index.ID(), u.keysString(func(e sql.Expression) string { return e.String() }))
+}
+
+func (u *UniqueKeyLookup) DebugString() string {
+	return fmt.Sprintf("UniqueKeyLookup(%s on %s, keys: %s)", u.Name(), u.Index.ID(), u.keysString(func(e sql.Expression) string { return sql.DebugString(e) }))
+}
+
+func (u *UniqueKeyLookup) keysString(str func(sql.Expression) string) string {
+	keys := make([]string, len(u.Keys))
+	for i, k := range u.Keys {
+		keys[i] = str(k)
+	}
+	return strings.Join(keys, ", ")
+}
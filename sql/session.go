@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -23,8 +24,28 @@ const (
 const (
 	CurrentDBSessionVar  = "current_database"
 	AutoCommitSessionVar = "autocommit"
+	// InListHashThresholdSessionVar is the number of elements an IN list of literals must exceed to be looked up in a
+	// hash set of them rather than compared with one by one.
+	InListHashThresholdSessionVar = "in_list_hash_threshold"
+	// EmptyStringIsNullSessionVar is whether empty strings are taken as NULL in comparisons, as Oracle does. It's off
+	// by default.
+	EmptyStringIsNullSessionVar = "empty_string_is_null"
+	// TransformNullEqualsSessionVar is whether comparisons of an expression with a NULL literal using = or <> are
+	// rewritten to IS NULL and IS NOT NULL, as PostgreSQL's variable of the same name does. It's off by default.
+	TransformNullEqualsSessionVar = "transform_null_equals"
+	// LastInsertIDSessionVar is the value LAST_INSERT_ID() returns.
+	LastInsertIDSessionVar = "last_insert_id"
+	// SQLModeSessionVar is the comma-separated list of the SQL modes of the session.
+	SQLModeSessionVar = "sql_mode"
 )
 
+// PadCharToFullLengthSQLMode is the SQL mode that makes the values of CHAR columns keep their trailing spaces, padded
+// to the full length of the column, rather than having them removed.
+const PadCharToFullLengthSQLMode = "PAD_CHAR_TO_FULL_LENGTH"
+
+// DefaultInListHashThreshold is the default value of the in_list_hash_threshold session variable.
+const DefaultInListHashThreshold = 20
+
 // Client holds session user information.
 type Client struct {
 	// User of the session.
@@ -254,6 +275,10 @@ func DefaultSessionConfig() map[string]TypedValue {
 		"character_set_connection": TypedValue{LongText, Collation_Default.CharacterSet().String()},
 		"character_set_results":    TypedValue{LongText, Collation_Default.CharacterSet().String()},
 		"collation_connection":     TypedValue{LongText, Collation_Default.String()},
+		"in_list_hash_threshold":   TypedValue{Int64, int64(DefaultInListHashThreshold)},
+		"empty_string_is_null":     TypedValue{Int8, 0},
+		"transform_null_equals":    TypedValue{Int8, 0},
+		"last_insert_id":           TypedValue{Uint64, uint64(0)},
 	}
 }
 
@@ -266,6 +291,55 @@ func HasDefaultValue(s Session, key string) (bool, interface{}) {
 	return false, val
 }
 
+// EmptyStringIsNull returns whether the session of the context given takes empty strings as NULL in comparisons, which
+// is set with its empty_string_is_null variable. A context without a session doesn't.
+func EmptyStringIsNull(ctx *Context) bool {
+	return boolSessionVar(ctx, EmptyStringIsNullSessionVar)
+}
+
+// TransformNullEquals returns whether the session of the context given rewrites comparisons with a NULL literal using =
+// or <> to IS NULL and IS NOT NULL, which is set with its transform_null_equals variable. A context without a session
+// doesn't.
+func TransformNullEquals(ctx *Context) bool {
+	return boolSessionVar(ctx, TransformNullEqualsSessionVar)
+}
+
+// boolSessionVar returns whether the session variable given of the session of the context given is set to a true value.
+func boolSessionVar(ctx *Context, name string) bool {
+	if ctx == nil || ctx.Session == nil {
+		return false
+	}
+
+	_, v := ctx.Get(name)
+	if v == nil {
+		return false
+	}
+
+	b, err := ConvertToBool(v)
+	return err == nil && b
+}
+
+// HasSQLMode returns whether the SQL mode given is among the SQL modes of the session of the context given. A context
+// without a session has none.
+func HasSQLMode(ctx *Context, mode string) bool {
+	if ctx == nil || ctx.Session == nil {
+		return false
+	}
+
+	_, v := ctx.Get(SQLModeSessionVar)
+	modes, ok := v.(string)
+	if !ok {
+		return false
+	}
+
+	for _, m := range strings.Split(modes, ",") {
+		if strings.EqualFold(strings.TrimSpace(m), mode) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewSession creates a new session with data.
 func NewSession(server, client, user string, id uint32) Session {
 	return &BaseSession{
@@ -289,18 +363,43 @@ func NewBaseSession() Session {
 	return &BaseSession{id: atomic.AddUint32(&autoSessionIDs, 1), config: DefaultSessionConfig(), mu: &sync.RWMutex{}, locks: make(map[string]bool)}
 }
 
+// NullComparisonPolicy is how equality comparisons treat NULL operands.
+type NullComparisonPolicy string
+
+const (
+	// NullComparisonStrict is the SQL semantics, where comparing NULL with any value, even NULL, yields NULL.
+	NullComparisonStrict NullComparisonPolicy = "strict"
+	// NullEqualsNull makes equality comparisons take two NULLs as equal, and NULL as not equal to any other value,
+	// like <=> does in MySQL, for compatibility with dialects that compare NULLs as distinct values.
+	NullEqualsNull NullComparisonPolicy = "null-equals-null"
+)
+
+// NullEqualsNullPolicy returns whether equality comparisons take two NULLs as equal in the context given.
+func NullEqualsNullPolicy(ctx *Context) bool {
+	return ctx != nil && ctx.NullComparisons == NullEqualsNull
+}
+
 // Context of the query execution.
 type Context struct {
 	context.Context
 	Session
 	*IndexRegistry
 	*ViewRegistry
-	Memory    *MemoryManager
-	pid       uint64
-	query     string
-	queryTime time.Time
-	tracer    opentracing.Tracer
-	rootSpan  opentracing.Span
+	Memory      *MemoryManager
+	Diagnostics *Diagnostics
+	// GenericComparisons disables the specialization of comparisons, so that the types their operands are compared
+	// with are always inferred at runtime from the types of the operands.
+	GenericComparisons bool
+	// NullComparisons is how equality comparisons treat NULL operands. The empty policy is NullComparisonStrict.
+	NullComparisons NullComparisonPolicy
+	// DecimalRounding is the rounding mode comparisons with a decimal value coerce the other operand with, instead of
+	// the one of the decimal type, if it's set.
+	DecimalRounding *DecimalRoundingMode
+	pid             uint64
+	query           string
+	queryTime       time.Time
+	tracer          opentracing.Tracer
+	rootSpan        opentracing.Span
 }
 
 // ContextOption is a function to configure the context.
@@ -353,6 +452,38 @@ func WithMemoryManager(m *MemoryManager) ContextOption {
 	}
 }
 
+// WithDiagnostics sets the diagnostics collected while executing the query. Diagnostics are not collected unless
+// this option is used.
+func WithDiagnostics(d *Diagnostics) ContextOption {
+	return func(ctx *Context) {
+		ctx.Diagnostics = d
+	}
+}
+
+// WithGenericComparisons disables the specialization of comparisons, both by the analyzer and at runtime, so that
+// every comparison infers the type to compare its operands with from their types. It's meant for testing that the
+// specialized comparisons give the same results as the generic ones.
+func WithGenericComparisons() ContextOption {
+	return func(ctx *Context) {
+		ctx.GenericComparisons = true
+	}
+}
+
+// WithNullComparisonPolicy sets how equality comparisons treat NULL operands while executing the query.
+func WithNullComparisonPolicy(p NullComparisonPolicy) ContextOption {
+	return func(ctx *Context) {
+		ctx.NullComparisons = p
+	}
+}
+
+// WithDecimalRounding sets the rounding mode comparisons with a decimal value coerce the other operand with while
+// executing the query, instead of the one of the decimal type.
+func WithDecimalRounding(r DecimalRoundingMode) ContextOption {
+	return func(ctx *Context) {
+		ctx.DecimalRounding = &r
+	}
+}
+
 // WithRootSpan sets the root span of the context.
 func WithRootSpan(s opentracing.Span) ContextOption {
 	return func(ctx *Context) {
@@ -385,7 +516,7 @@ func NewContext(
 	ctx context.Context,
 	opts ...ContextOption,
 ) *Context {
-	c := &Context{ctx, NewBaseSession(), nil, nil, nil, 0, "", ctxNowFunc(), opentracing.NoopTracer{}, nil}
+	c := &Context{ctx, NewBaseSession(), nil, nil, nil, nil, false, "", nil, 0, "", ctxNowFunc(), opentracing.NoopTracer{}, nil}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -439,36 +570,14 @@ func (c *Context) Span(
 	span := c.tracer.StartSpan(opName, opts...)
 	ctx := opentracing.ContextWithSpan(c.Context, span)
 
-	return span, &Context{
-		Context:       ctx,
-		Session:       c.Session,
-		IndexRegistry: c.IndexRegistry,
-		ViewRegistry:  c.ViewRegistry,
-		Memory:        c.Memory,
-		pid:           c.Pid(),
-		query:         c.Query(),
-		queryTime:     c.queryTime,
-		tracer:        c.tracer,
-		rootSpan:      c.rootSpan,
-	}
+	return span, c.WithContext(ctx)
 }
 
 // NewSubContext creates a new sub-context with the current context as parent. Returns the resulting context.CancelFunc
 // as well as the new *sql.Context, which be used to cancel the new context before the parent is finished.
 func (c *Context) NewSubContext() (*Context, context.CancelFunc) {
 	ctx, cancelFunc := context.WithCancel(c.Context)
-	return &Context{
-		Context:       ctx,
-		Session:       c.Session,
-		IndexRegistry: c.IndexRegistry,
-		ViewRegistry:  c.ViewRegistry,
-		Memory:        c.Memory,
-		pid:           c.Pid(),
-		query:         c.Query(),
-		queryTime:     c.queryTime,
-		tracer:        c.tracer,
-		rootSpan:      c.rootSpan,
-	}, cancelFunc
+	return c.WithContext(ctx), cancelFunc
 }
 
 func (c *Context) WithCurrentDB(db string) *Context {
@@ -476,20 +585,12 @@ func (c *Context) WithCurrentDB(db string) *Context {
 	return c
 }
 
-// WithContext returns a new context with the given underlying context.
+// WithContext returns a new context with the given underlying context. Every other field is copied from this context,
+// which is how all the contexts derived from it are made.
 func (c *Context) WithContext(ctx context.Context) *Context {
-	return &Context{
-		Context:       ctx,
-		Session:       c.Session,
-		IndexRegistry: c.IndexRegistry,
-		ViewRegistry:  c.ViewRegistry,
-		Memory:        c.Memory,
-		pid:           c.Pid(),
-		query:         c.Query(),
-		queryTime:     c.queryTime,
-		tracer:        c.tracer,
-		rootSpan:      c.rootSpan,
-	}
+	nc := *c
+	nc.Context = ctx
+	return &nc
 }
 
 // RootSpan returns the root span, if any.
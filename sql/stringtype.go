@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
@@ -195,7 +196,7 @@ func (t stringType) Compare(a interface{}, b interface{}) (int, error) {
 		bs = bi.(string)
 	}
 
-	return strings.Compare(as, bs), nil
+	return CompareStrings(t.collation, as, bs), nil
 }
 
 // Convert implements Type interface.
@@ -239,6 +240,29 @@ func (t stringType) Convert(v interface{}) (interface{}, error) {
 	return val, nil
 }
 
+// CharValue returns the value given of an expression of the type given as it's retrieved if the type is CHAR, which is
+// without its trailing spaces, or padded with spaces to the full length of the type if the session of the context given
+// has the PAD_CHAR_TO_FULL_LENGTH SQL mode. Any other value is returned as it is.
+func CharValue(ctx *Context, t Type, v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok || t.Type() != sqltypes.Char {
+		return v
+	}
+
+	st, ok := t.(StringType)
+	if !ok {
+		return v
+	}
+
+	s = strings.TrimRight(s, " ")
+	if HasSQLMode(ctx, PadCharToFullLengthSQLMode) {
+		if n := int(st.MaxCharacterLength()) - utf8.RuneCountInString(s); n > 0 {
+			s += strings.Repeat(" ", n)
+		}
+	}
+	return s
+}
+
 // MustConvert implements the Type interface.
 func (t stringType) MustConvert(v interface{}) interface{} {
 	value, err := t.Convert(v)
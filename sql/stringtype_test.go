@@ -32,7 +32,10 @@ func TestStringCompare(t *testing.T) {
 		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), 1, false, -1},
 		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), 1, 1, 0},
 		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), true, 1, 1},
-		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), "True", true, -1},
+		{MustCreateString(sqltypes.VarChar, 10, Collation_utf8mb4_bin), "True", true, -1},
+		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), "True", true, 0},
+		{MustCreateString(sqltypes.VarChar, 10, Collation_utf8mb4_bin), "a ", "a", 0},
+		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), "a ", "a", 1},
 		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), false, true, -1},
 		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), "0x12345de", "0xed54321", -1},
 		{MustCreateStringWithDefaults(sqltypes.VarChar, 10), "0xed54321", "0x12345de", 1},
@@ -366,3 +369,20 @@ func TestStringString(t *testing.T) {
 		})
 	}
 }
+
+func TestCharValue(t *testing.T) {
+	char := MustCreateStringWithDefaults(sqltypes.Char, 5)
+	varchar := MustCreateStringWithDefaults(sqltypes.VarChar, 5)
+
+	ctx := NewEmptyContext()
+	require.Equal(t, "ab", CharValue(ctx, char, "ab  "))
+	require.Equal(t, " ab", CharValue(ctx, char, " ab"))
+	require.Equal(t, "ab  ", CharValue(ctx, varchar, "ab  "))
+	require.Equal(t, nil, CharValue(ctx, char, nil))
+
+	require.NoError(t, ctx.Set(ctx, SQLModeSessionVar, LongText, "STRICT_TRANS_TABLES,pad_char_to_full_length"))
+	require.True(t, HasSQLMode(ctx, PadCharToFullLengthSQLMode))
+	require.Equal(t, "ab   ", CharValue(ctx, char, "ab"))
+	require.Equal(t, "ñb   ", CharValue(ctx, char, "ñb "))
+	require.Equal(t, "ab", CharValue(ctx, varchar, "ab"))
+}
@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidTimeZone is returned when the time zone of a session isn't a known time zone name or offset.
+var ErrInvalidTimeZone = errors.NewKind("unknown or incorrect time zone: '%s'")
+
+// timeZoneOffsetRegex matches a time zone given as an offset from UTC, such as +05:30.
+var timeZoneOffsetRegex = regexp.MustCompile(`^([+-])(\d{1,2}):(\d{2})$`)
+
+// timeZones caches the locations of named time zones, which are loaded from the system time zone database.
+var timeZones sync.Map
+
+// SessionTimeZone returns the location of the time zone of the session of the context given, which is set with its
+// time_zone variable. It's either SYSTEM, for the time zone in the system_time_zone variable, an offset from UTC such
+// as +05:30, or the name of a time zone such as Europe/Madrid. A context without a session is in UTC.
+func SessionTimeZone(ctx *Context) (*time.Location, error) {
+	if ctx == nil || ctx.Session == nil {
+		return time.UTC, nil
+	}
+
+	_, v := ctx.Get("time_zone")
+	tz, ok := v.(string)
+	if !ok || strings.EqualFold(tz, "SYSTEM") {
+		_, v = ctx.Get("system_time_zone")
+		if tz, ok = v.(string); !ok {
+			return time.UTC, nil
+		}
+	}
+
+	return LoadTimeZone(tz)
+}
+
+// LoadTimeZone returns the location of the time zone given, either as an offset from UTC such as +05:30 or as the name
+// of a time zone such as Europe/Madrid.
+func LoadTimeZone(tz string) (*time.Location, error) {
+	if m := timeZoneOffsetRegex.FindStringSubmatch(tz); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		if minutes >= 60 || hours*60+minutes > 14*60 {
+			return nil, ErrInvalidTimeZone.New(tz)
+		}
+
+		offset := (hours*60 + minutes) * 60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(fmt.Sprintf("%s%02d:%02d", m[1], hours, minutes), offset), nil
+	}
+
+	if loc, ok := timeZones.Load(tz); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "" || strings.EqualFold(tz, "Local") {
+		return nil, ErrInvalidTimeZone.New(tz)
+	}
+
+	timeZones.Store(tz, loc)
+	return loc, nil
+}
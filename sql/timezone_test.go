@@ -0,0 +1,63 @@
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTimeZone(t *testing.T) {
+	testCases := []struct {
+		tz     string
+		offset int
+		err    bool
+	}{
+		{"+00:00", 0, false},
+		{"+05:30", 5*3600 + 30*60, false},
+		{"-8:00", -8 * 3600, false},
+		{"+14:00", 14 * 3600, false},
+		{"+14:01", 0, true},
+		{"+01:60", 0, true},
+		{"UTC", 0, false},
+		{"Asia/Kolkata", 5*3600 + 30*60, false},
+		{"Nowhere/Special", 0, true},
+		{"", 0, true},
+	}
+
+	instant := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range testCases {
+		t.Run(tt.tz, func(t *testing.T) {
+			require := require.New(t)
+
+			loc, err := LoadTimeZone(tt.tz)
+			if tt.err {
+				require.True(ErrInvalidTimeZone.Is(err))
+				return
+			}
+
+			require.NoError(err)
+			_, offset := instant.In(loc).Zone()
+			require.Equal(tt.offset, offset)
+		})
+	}
+}
+
+func TestSessionTimeZone(t *testing.T) {
+	require := require.New(t)
+
+	loc, err := SessionTimeZone(nil)
+	require.NoError(err)
+	require.Equal(time.UTC, loc)
+
+	ctx := NewEmptyContext()
+	require.NoError(ctx.Set(ctx, "system_time_zone", LongText, "+03:00"))
+	loc, err = SessionTimeZone(ctx)
+	require.NoError(err)
+	require.Equal("+03:00", loc.String())
+
+	require.NoError(ctx.Set(ctx, "time_zone", LongText, "-01:00"))
+	loc, err = SessionTimeZone(ctx)
+	require.NoError(err)
+	require.Equal("-01:00", loc.String())
+}
@@ -52,6 +52,16 @@ type Type interface {
 	fmt.Stringer
 }
 
+// OrderableType is a Type that declares whether its values have an order, which types without one, such as those of
+// spatial values, implement to reject the ordering comparisons and sorts of their values. Types that don't implement
+// it are orderable.
+type OrderableType interface {
+	Type
+	// Orderable returns whether the values of the type can be compared with <, >, <= and >=, and sorted. They can be
+	// compared for equality either way.
+	Orderable() bool
+}
+
 // AreComparable returns whether the given types are either the same or similar enough that values can meaningfully be
 // compared across all permutations. Int8 and Int64 are comparable types, where as VarChar and Int64 are not. In the case
 // of the latter example, not all possible values of a VarChar are comparable to an Int64, while this is true for the
@@ -403,6 +413,12 @@ func IsInteger(t Type) bool {
 	return IsSigned(t) || IsUnsigned(t)
 }
 
+// IsJSON checks if t is the JSON type.
+func IsJSON(t Type) bool {
+	_, ok := t.(jsonType)
+	return ok
+}
+
 // IsNull returns true if expression is nil or is Null Type, otherwise false.
 func IsNull(ex Expression) bool {
 	return ex == nil || ex.Type() == Null
@@ -417,6 +433,19 @@ func IsNumber(t Type) bool {
 	return ok
 }
 
+// IsOrderable returns whether the values of the given type can be ordered, which is the case unless the type is an
+// OrderableType that declares they can't.
+func IsOrderable(t Type) bool {
+	ot, ok := t.(OrderableType)
+	return !ok || ot.Orderable()
+}
+
+// IsSet checks if t is a SET type.
+func IsSet(t Type) bool {
+	_, ok := t.(setType)
+	return ok
+}
+
 // IsSigned checks if t is a signed type.
 func IsSigned(t Type) bool {
 	return t == Int8 || t == Int16 || t == Int32 || t == Int64
@@ -477,6 +506,15 @@ func NumColumns(t Type) int {
 	return len(v)
 }
 
+// TupleTypes returns the types of the elements of a tuple type, or just the type given if it isn't a tuple type.
+func TupleTypes(t Type) []Type {
+	v, ok := t.(tupleType)
+	if !ok {
+		return []Type{t}
+	}
+	return v
+}
+
 // UnderlyingType returns the underlying type of an array if the type is an
 // array, or the type itself in any other case.
 func UnderlyingType(t Type) Type {